@@ -0,0 +1,199 @@
+package goread
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TypicalAM/goread/internal/backend"
+	"github.com/TypicalAM/goread/internal/backend/rss"
+	"github.com/charmbracelet/glamour"
+	"github.com/mmcdole/gofeed"
+	"github.com/spf13/cobra"
+)
+
+// catOptions denote the flags of the cat subcommand
+type catOptions struct {
+	format     string
+	width      int
+	dumpUnread bool
+}
+
+// catArticle is an article printed as JSON
+type catArticle struct {
+	Number    int        `json:"number"`
+	Feed      string     `json:"feed"`
+	Title     string     `json:"title"`
+	Link      string     `json:"link"`
+	Author    string     `json:"author,omitempty"`
+	Published *time.Time `json:"published,omitempty"`
+	Read      bool       `json:"read"`
+	Content   string     `json:"content,omitempty"`
+}
+
+var (
+	catOpts = catOptions{}
+
+	catCmd = &cobra.Command{
+		Use:   "cat <feed> [number]",
+		Short: "Print an article of a feed, the articles are listed if the number isn't given",
+		Long: "Print an article of a feed as text, markdown or JSON, the articles are listed if the number isn't given.\n" +
+			"With --dump_unread all the unread articles of the feed (or of all the feeds) are printed.",
+		Args: cobra.RangeArgs(0, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			defer openLog()()
+			return catArticles(args)
+		},
+	}
+)
+
+func init() {
+	catCmd.Flags().StringVarP(&catOpts.format, "format", "f", "text", "The output format: text, markdown or json")
+	catCmd.Flags().IntVarP(&catOpts.width, "width", "w", 80, "The width of the text")
+	catCmd.Flags().BoolVarP(&catOpts.dumpUnread, "dump_unread", "", false, "Print all the unread articles")
+	catCmd.SilenceUsage = true
+	catCmd.SilenceErrors = true
+	rootCmd.AddCommand(catCmd)
+}
+
+// catArticles prints the articles chosen by the arguments
+func catArticles(args []string) error {
+	if catOpts.format != "text" && catOpts.format != "markdown" && catOpts.format != "json" {
+		return fmt.Errorf("unknown format %q, use text, markdown or json", catOpts.format)
+	}
+
+	if !catOpts.dumpUnread && len(args) == 0 {
+		return fmt.Errorf("the name of the feed is missing")
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	b, err := backend.New(cfg, opts.urlsPath, opts.cacheDir, false)
+	if err != nil {
+		return err
+	}
+
+	defer b.Close()
+	feedName := rss.AllFeedsName
+	if len(args) > 0 {
+		feedName = args[0]
+	}
+
+	items, err := b.Articles(feedName)
+	if err != nil {
+		return fmt.Errorf("getting the articles of %s: %w", feedName, err)
+	}
+
+	switch {
+	case catOpts.dumpUnread:
+		var printed int
+		for i := range items {
+			if b.ReadStatus.IsRead(items[i]) {
+				continue
+			}
+
+			if printed > 0 && catOpts.format != "json" {
+				fmt.Println(strings.Repeat("─", catOpts.width))
+			}
+
+			if err = printArticle(b, feedName, i, &items[i]); err != nil {
+				return err
+			}
+
+			printed++
+		}
+
+	case len(args) == 1:
+		listArticles(b, feedName, items)
+
+	default:
+		number, err := strconv.Atoi(args[1])
+		if err != nil || number < 1 || number > len(items) {
+			return fmt.Errorf("the number of the article has to be between 1 and %d", len(items))
+		}
+
+		return printArticle(b, feedName, number-1, &items[number-1])
+	}
+
+	return nil
+}
+
+// listArticles prints the numbers and the titles of the articles, the unread ones are marked with
+// a star. In json the articles are printed one per line without their content
+func listArticles(b *backend.Backend, feedName string, items []gofeed.Item) {
+	for i := range items {
+		read := b.ReadStatus.IsRead(items[i])
+		if catOpts.format == "json" {
+			article := newCatArticle(feedName, i, &items[i], read)
+			article.Content = ""
+			data, _ := json.Marshal(article)
+			fmt.Println(string(data))
+			continue
+		}
+
+		marker := " "
+		if !read {
+			marker = "*"
+		}
+
+		fmt.Printf("%d\t%s %s\n", i+1, marker, items[i].Title)
+	}
+}
+
+// printArticle prints the article in the chosen format, the text is rendered like in the article view
+func printArticle(b *backend.Backend, feedName string, index int, item *gofeed.Item) error {
+	switch catOpts.format {
+	case "markdown":
+		fmt.Print(rss.YassifyItem(item))
+
+	case "json":
+		data, err := json.Marshal(newCatArticle(feedName, index, item, b.ReadStatus.IsRead(*item)))
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(string(data))
+
+	default:
+		renderer, err := glamour.NewTermRenderer(
+			glamour.WithStyles(glamour.NoTTYStyleConfig),
+			glamour.WithWordWrap(catOpts.width),
+		)
+		if err != nil {
+			return err
+		}
+
+		text, err := renderer.Render(rss.YassifyItem(item))
+		if err != nil {
+			return err
+		}
+
+		fmt.Print(text)
+	}
+
+	return nil
+}
+
+// newCatArticle returns the article printed as JSON, the content is the markdown of the article
+func newCatArticle(feedName string, index int, item *gofeed.Item, read bool) catArticle {
+	article := catArticle{
+		Number:    index + 1,
+		Feed:      feedName,
+		Title:     item.Title,
+		Link:      item.Link,
+		Published: item.PublishedParsed,
+		Read:      read,
+		Content:   rss.YassifyItem(item),
+	}
+
+	if len(item.Authors) > 0 && item.Authors[0] != nil {
+		article.Author = item.Authors[0].Name
+	}
+
+	return article
+}
@@ -1,35 +1,59 @@
 package goread
 
 import (
+	"bufio"
 	"fmt"
 	"io"
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/TypicalAM/goread/internal/backend"
 	"github.com/TypicalAM/goread/internal/backend/cache"
+	"github.com/TypicalAM/goread/internal/backend/rss"
+	"github.com/TypicalAM/goread/internal/backend/share"
+	"github.com/TypicalAM/goread/internal/config"
+	"github.com/TypicalAM/goread/internal/ipc"
 	"github.com/TypicalAM/goread/internal/theme"
 	"github.com/TypicalAM/goread/internal/ui/browser"
+	"github.com/TypicalAM/goread/internal/ui/favicon"
+	"github.com/TypicalAM/goread/internal/ui/graphics"
+	"github.com/TypicalAM/goread/internal/ui/hyperlink"
+	"github.com/TypicalAM/goread/internal/ui/simplelist"
+	"github.com/TypicalAM/goread/internal/ui/tab/article"
+	"github.com/TypicalAM/goread/internal/ui/tab/category"
+	"github.com/TypicalAM/goread/internal/ui/tab/feed"
+	"github.com/TypicalAM/goread/internal/ui/tab/overview"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 // options denote the flags that can be given to the program
 type options struct {
-	cacheDir       string
+	cacheDir        string
 	colorschemePath string
+	configPath      string
 	urlsPath        string
+	socketPath      string
 	getColors       string
+	theme           string
 	loadOPMLFrom    string
 	exportOPMLTo    string
+	newsboatURLs    string
+	newsboatCache   string
 	cacheSize       int
 	cacheDuration   int
 	dumpColors      bool
 	testColors      bool
 	resetCache      bool
+	pocketLogin     bool
+	fetch           bool
+	noColor         bool
+	screenReader    bool
 }
 
 var (
@@ -50,17 +74,38 @@ var (
 )
 
 func init() {
-	rootCmd.Flags().StringVarP(&opts.cacheDir, "cache_dir", "", "", "The path to the cache directory")
+	rootCmd.PersistentFlags().StringVarP(&opts.cacheDir, "cache_dir", "", "", "The path to the cache directory")
 	rootCmd.Flags().StringVarP(&opts.colorschemePath, "colorscheme_path", "c", "", "The path to the colorscheme file")
-	rootCmd.Flags().StringVarP(&opts.urlsPath, "urls_path", "u", "", "The path to the urls file")
+	rootCmd.PersistentFlags().StringVarP(&opts.urlsPath, "urls_path", "u", "", "The path to the urls file")
+	rootCmd.PersistentFlags().StringVarP(&opts.configPath, "config_path", "", "", "The path to the config file")
+	rootCmd.PersistentFlags().StringVarP(&opts.socketPath, "socket", "", ipc.DefaultPath(), "The path to the socket for controlling goread remotely")
 	rootCmd.Flags().BoolVarP(&opts.testColors, "test_colors", "", false, "Test the colorscheme")
 	rootCmd.Flags().BoolVarP(&opts.dumpColors, "dump_colors", "", false, "Dump the colors to the colorscheme file")
 	rootCmd.Flags().StringVarP(&opts.getColors, "get_colors", "", "", "Get the colors from pywal and save them to the colorscheme file")
+	rootCmd.Flags().StringVarP(&opts.theme, "theme", "t", "", "The theme to use, e.g. gruvbox, pywal or terminal")
+	rootCmd.Flags().BoolVarP(&opts.noColor, "no_color", "", false, "Disable the colors and the italics, the selected items are shown in reverse")
+	rootCmd.Flags().BoolVarP(&opts.screenReader, "screen_reader", "", false, "Show linear plain text for the screen readers, implies --no_color")
 	rootCmd.Flags().BoolVarP(&opts.resetCache, "reset_cache", "", false, "Reset the cache")
 	rootCmd.Flags().IntVarP(&opts.cacheSize, "cache_size", "", 0, "The size of the cache")
 	rootCmd.Flags().IntVarP(&opts.cacheDuration, "cache_duration", "", 0, "The duration of the cache in hours")
-	rootCmd.Flags().StringVarP(&opts.loadOPMLFrom, "load_opml", "i", "", "Import the feeds from an OPML file")
+	rootCmd.Flags().StringVarP(&opts.loadOPMLFrom, "import_opml", "i", "", "Import the feeds from an OPML file")
 	rootCmd.Flags().StringVarP(&opts.exportOPMLTo, "export_opml", "e", "", "Export the feeds to an OPML file")
+
+	rootCmd.Flags().BoolVarP(&opts.fetch, "fetch", "", false, "Refresh all the feeds and exit without starting the interface")
+	rootCmd.Flags().BoolVarP(&opts.pocketLogin, "pocket_login", "", false, "Authorize goread in Pocket and print the access token for the config")
+	rootCmd.Flags().StringVarP(&opts.newsboatURLs, "import_newsboat", "", "", "Import the feeds from a newsboat urls file")
+	rootCmd.Flags().StringVarP(&opts.newsboatCache, "import_newsboat_cache", "", "", "Import the read articles from a newsboat cache.db file, needs the sqlite3 command")
+	rootCmd.Flags().Lookup("import_newsboat").NoOptDefVal = newsboatPath("urls")
+	rootCmd.Flags().Lookup("import_newsboat_cache").NoOptDefVal = newsboatPath("cache.db")
+
+	// Keep the old name of the import flag working
+	rootCmd.Flags().StringVarP(&opts.loadOPMLFrom, "load_opml", "", "", "Import the feeds from an OPML file")
+	_ = rootCmd.Flags().MarkDeprecated("load_opml", "use --import_opml instead")
+
+	// Allow using dashes instead of underscores, e.g. --import-opml
+	rootCmd.SetGlobalNormalizationFunc(func(_ *pflag.FlagSet, name string) pflag.NormalizedName {
+		return pflag.NormalizedName(strings.ReplaceAll(name, "-", "_"))
+	})
 }
 
 // SetVersion sets the version of the program
@@ -78,13 +123,7 @@ func Execute() {
 
 // Run runs the program
 func Run() error {
-	if f, err := tea.LogToFile(filepath.Join(os.TempDir(), "goread.log"), ""); err == nil {
-		defer f.Close()
-	} else {
-		log.Println("Failed to create log file")
-		log.SetOutput(io.Discard)
-	}
-
+	defer openLog()()
 	log.Println("Starting goread")
 
 	colors, err := theme.New(opts.colorschemePath)
@@ -145,8 +184,68 @@ func Run() error {
 		cache.DefaultCacheDuration = time.Hour * time.Duration(opts.cacheDuration)
 	}
 
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	// Use the named theme instead of the colorscheme file
+	if opts.theme != "" {
+		cfg.Theme = opts.theme
+	}
+
+	if cfg.Theme != "" {
+		if err = colors.SetTheme(cfg.Theme); err != nil {
+			log.Println("Failed to set the theme: ", err)
+		}
+	}
+
+	// Decorate the tabs and the lists with the glyphs of the icon set
+	if err = theme.SetIcons(cfg.Icons); err != nil {
+		log.Println("Failed to set the icons: ", err)
+	}
+
+	// Give the feeds and the categories from the config their own colors and icons
+	for _, decoration := range cfg.Decorations {
+		if decoration.Feed != "" {
+			theme.FeedDecorations[decoration.Feed] = theme.Decoration{Color: lipgloss.Color(decoration.Color), Icon: decoration.Icon}
+		} else {
+			theme.CategoryDecorations[decoration.Category] = theme.Decoration{Color: lipgloss.Color(decoration.Color), Icon: decoration.Icon}
+		}
+	}
+
+	// Show the code blocks in the color of the text
+	if cfg.Accessibility.NoSyntaxHighlighting {
+		log.Println("Disabling the syntax highlighting")
+		theme.DisableSyntaxHighlighting(colors)
+	}
+
+	// Use the high-contrast mode without colors, NO_COLOR is the convention of https://no-color.org
+	switch {
+	case opts.screenReader || cfg.Accessibility.ScreenReader:
+		log.Println("Using the screen reader mode")
+		theme.EnableScreenReader(colors)
+
+	case opts.noColor || cfg.Accessibility.NoColor || os.Getenv("NO_COLOR") != "":
+		log.Println("Disabling the colors")
+		theme.EnableNoColor(colors)
+	}
+
+	// Get the access token of the Pocket account used for sharing
+	if opts.pocketLogin {
+		return loginPocket(cfg.Share.Pocket.ConsumerKey, cfg.HTTP)
+	}
+
+	// Check if the terminal can display images
+	feed.ImageProtocol = graphics.Detect()
+	favicon.Protocol = feed.ImageProtocol
+	log.Println("Detected graphics protocol: ", feed.ImageProtocol)
+	if feed.ImageProtocol == graphics.Sixel {
+		graphics.DetectCellSize(os.Stdout)
+	}
+
 	// Initialize the backend
-	backend, err := backend.New(opts.urlsPath, opts.cacheDir, opts.resetCache)
+	backend, err := backend.New(cfg, opts.urlsPath, opts.cacheDir, opts.resetCache)
 	if err != nil {
 		log.Println("Failed to initialize backend: ", err)
 		return err
@@ -178,16 +277,305 @@ func Run() error {
 		return backend.Close()
 	}
 
-	// Create the browser
+	// Import the feeds and the read articles from newsboat
+	if opts.newsboatURLs != "" || opts.newsboatCache != "" {
+		if err := importNewsboat(backend); err != nil {
+			fmt.Println(errStyle.Render("Importing from newsboat failed: " + err.Error()))
+			return err
+		}
+
+		return backend.Close()
+	}
+
+	// Refresh the feeds without the interface, e.g. from cron
+	if opts.fetch {
+		fetchFeeds(backend)
+		return backend.Close()
+	}
+
+	// Create the browser, it's controlled through the socket unless another instance already is
 	browser := browser.New(colors, backend)
+	server, err := ipc.Listen(opts.socketPath)
+	if err != nil {
+		log.Println("Not listening for remote commands: ", err)
+	} else {
+		defer server.Close()
+		browser = browser.SetRequests(server.Requests())
+	}
+
+	// Start the program, the links are written to the terminal by its output and the images are
+	// transmitted through it
+	var output graphics.File = os.Stdout
+	if hyperlink.Enabled {
+		output = hyperlink.NewOutput(os.Stdout)
+	}
+
+	if feed.ImageProtocol.Inline() {
+		output = graphics.NewOutput(output)
+		graphics.Terminal = output
+	}
 
-	// Start the program
-	if _, err = tea.NewProgram(browser).Run(); err != nil {
+	var program *tea.Program
+	if output != graphics.File(os.Stdout) {
+		program = tea.NewProgram(browser, tea.WithOutput(output))
+		done := make(chan struct{})
+		defer close(done)
+		go hyperlink.WatchSize(program, os.Stdout, done)
+	} else {
+		program = tea.NewProgram(browser)
+	}
+
+	if _, err = program.Run(); err != nil {
 		log.Println("Bubbletea program fail: ", err)
 		return err
 	}
 
+	// Free the images transmitted to the terminal
+	if feed.ImageProtocol == graphics.Kitty {
+		fmt.Print(graphics.DeleteAll())
+	}
+
 	// Clean up the backend
 	log.Println("Closing backend")
 	return backend.Close()
 }
+
+// openLog writes the log to a file in the temporary directory, the returned function closes it
+func openLog() func() {
+	f, err := tea.LogToFile(filepath.Join(os.TempDir(), "goread.log"), "")
+	if err != nil {
+		log.Println("Failed to create log file")
+		log.SetOutput(io.Discard)
+		return func() {}
+	}
+
+	return func() { f.Close() }
+}
+
+// loadConfig loads the config file and applies the settings which are kept in the packages
+func loadConfig() (*config.Config, error) {
+	cfg, err := config.New(opts.configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cfg.Load(); err != nil {
+		log.Println("Failed to load config: ", err)
+		return nil, err
+	}
+
+	// Set the amount of feeds fetched at the same time
+	if cfg.Backend.Workers > 0 {
+		log.Println("Setting worker count to ", cfg.Backend.Workers)
+		cache.DefaultWorkers = cfg.Backend.Workers
+	}
+
+	// Set how many requests are made to a single host at the same time and how they are spread out
+	cache.HostLimit = cfg.Backend.HostLimit
+	cache.HostJitter = cfg.Backend.HostJitter
+
+	// Set the proxies and the other request settings
+	cache.HTTPSettings = cfg.HTTP
+
+	// Set the instance which serves the feeds of the Twitter accounts
+	rss.NitterInstance = cfg.Backend.NitterInstance
+
+	// Set the RSS-Bridge instance which makes feeds out of the sites without them
+	cache.RSSBridge = cfg.Backend.RSSBridge
+
+	// Set how long the articles are kept in the cache
+	cache.Retention = cfg.Retention
+
+	// Set how long the trashed articles can be restored
+	if cfg.Backend.TrashRetention > 0 {
+		cache.TrashRetention = cfg.Backend.TrashRetention
+	}
+
+	// Set the rules which make feeds out of the pages without them
+	cache.Scrapers = cfg.Scrapers
+
+	// Set the cleanup of the links which are opened and copied
+	rss.Links = cfg.Links
+
+	// Set the reading speed used to estimate the reading time of the articles
+	if cfg.Reading.WordsPerMinute > 0 {
+		log.Println("Setting reading speed to ", cfg.Reading.WordsPerMinute)
+		rss.WordsPerMinute = cfg.Reading.WordsPerMinute
+	}
+
+	// Make the links in the articles clickable unless the terminal can't handle it
+	hyperlink.Enabled = !cfg.Accessibility.NoHyperlinks
+
+	// Set the background refresh interval
+	if cfg.Backend.RefreshInterval > 0 {
+		log.Println("Setting refresh interval to ", cfg.Backend.RefreshInterval)
+		browser.DefaultRefreshInterval = cfg.Backend.RefreshInterval
+	}
+
+	// Open a new tab every time instead of focusing the one which is open already
+	browser.AllowDuplicateTabs = cfg.Layout.DuplicateTabs
+
+	// Set the command used to open the articles
+	if cfg.BrowserCommand != "" {
+		log.Println("Setting browser command to ", cfg.BrowserCommand)
+		feed.DefaultBrowserCommand = cfg.BrowserCommand
+	}
+
+	// Set the format of the dates in the article list
+	if cfg.DateFormat != "" {
+		log.Println("Setting date format to ", cfg.DateFormat)
+		feed.DateFormat = cfg.DateFormat
+	}
+
+	// Set the command used to play the podcast episodes
+	if cfg.Podcasts.Player != "" {
+		log.Println("Setting player command to ", cfg.Podcasts.Player)
+		feed.DefaultPlayerCommand = cfg.Podcasts.Player
+	}
+
+	// Set the layout of the feed tabs
+	feed.DefaultLayout.Stacked = cfg.Layout.Stacked
+	if cfg.Layout.ListRatio > 0 {
+		log.Println("Setting list ratio to ", cfg.Layout.ListRatio)
+		feed.DefaultLayout.ListRatio = cfg.Layout.ListRatio
+	}
+
+	feed.ZenWidth = cfg.Layout.ZenWidth
+
+	// Show the icons of the feeds next to their names
+	favicon.Enabled = cfg.Layout.Favicons
+
+	// Remap the keys using the config
+	if err = applyKeymaps(cfg); err != nil {
+		log.Println("Failed to apply keymap: ", err)
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// fetchFeeds refreshes all the feeds and prints the ones which failed
+func fetchFeeds(backend *backend.Backend) {
+	log.Println("Refreshing the feeds without the interface")
+	var failed int
+	urls := backend.Refresh(func(url string, _ int, err error) {
+		if err != nil {
+			failed++
+			url = rss.StripCredentials(url)
+			log.Printf("Error refreshing %s: %v\n", url, err)
+			fmt.Fprintln(os.Stderr, errStyle.Render(fmt.Sprintf("Failed to refresh %s: %v", url, err)))
+		}
+	}, false)
+
+	total := len(backend.Rss.GetAllURLs())
+	fmt.Println(msgStyle.Render(fmt.Sprintf("Refreshed %d of %d feeds", len(urls)-failed, total)))
+}
+
+// loginPocket walks the user through the authorization of goread in Pocket, the access token is
+// printed so that it can be put in the config
+func loginPocket(consumerKey string, settings config.HTTP) error {
+	login, err := share.NewPocketLogin(consumerKey, settings)
+	if err != nil {
+		fmt.Println(errStyle.Render("Starting the Pocket login failed, is share.pocket.consumer_key set in the config?"))
+		return err
+	}
+
+	fmt.Println(msgStyle.Render("Open this page, authorize goread and press enter:"))
+	fmt.Println(login.AuthorizeURL())
+	if _, err = bufio.NewReader(os.Stdin).ReadString('\n'); err != nil {
+		return err
+	}
+
+	token, err := login.AccessToken()
+	if err != nil {
+		fmt.Println(errStyle.Render("Getting the access token failed"))
+		return err
+	}
+
+	log.Println("Got the Pocket access token")
+	fmt.Println(msgStyle.Render("Logged in, add the access token to the share.pocket section of the config:"))
+	fmt.Println("access_token: " + token)
+	return nil
+}
+
+// importNewsboat imports the feeds and the read status from the newsboat files given in the flags
+func importNewsboat(backend *backend.Backend) error {
+	if opts.newsboatURLs != "" {
+		log.Println("Importing newsboat urls file: ", opts.newsboatURLs)
+		skipped, err := backend.Rss.LoadNewsboat(opts.newsboatURLs)
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range skipped {
+			log.Println("Skipped newsboat entry: ", entry)
+			fmt.Println(errStyle.Render("Skipped " + entry))
+		}
+
+		fmt.Println(msgStyle.Render("Imported the newsboat feeds successfully"))
+	}
+
+	if opts.newsboatCache != "" {
+		log.Println("Importing newsboat cache: ", opts.newsboatCache)
+		count, err := backend.ReadStatus.ImportNewsboat(opts.newsboatCache)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(msgStyle.Render(fmt.Sprintf("Imported %d read articles from newsboat", count)))
+	}
+
+	return nil
+}
+
+// newsboatPath returns the path of a newsboat file, the old ~/.newsboat directory is used if it
+// exists and the XDG directories otherwise
+func newsboatPath(name string) string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return name
+	}
+
+	if _, err = os.Stat(filepath.Join(home, ".newsboat")); err == nil {
+		return filepath.Join(home, ".newsboat", name)
+	}
+
+	if name == "urls" {
+		configDir := os.Getenv("XDG_CONFIG_HOME")
+		if configDir == "" {
+			configDir = filepath.Join(home, ".config")
+		}
+
+		return filepath.Join(configDir, "newsboat", name)
+	}
+
+	dataDir := os.Getenv("XDG_DATA_HOME")
+	if dataDir == "" {
+		dataDir = filepath.Join(home, ".local", "share")
+	}
+
+	return filepath.Join(dataDir, "newsboat", name)
+}
+
+// applyKeymaps remaps the default key bindings of the interface using the keymap from the config
+func applyKeymaps(cfg *config.Config) error {
+	keymaps := map[string]any{
+		"browser":  &browser.DefaultKeymap,
+		"overview": &overview.DefaultKeymap,
+		"category": &category.DefaultKeymap,
+		"feed":     &feed.DefaultKeymap,
+		"list":     &simplelist.DefaultKeymap,
+		"article":  &article.DefaultKeymap,
+	}
+
+	sections := make([]string, 0, len(keymaps))
+	for section, keymap := range keymaps {
+		if err := cfg.ApplyKeymap(section, keymap); err != nil {
+			return err
+		}
+
+		sections = append(sections, section)
+	}
+
+	return cfg.CheckKeymap(sections...)
+}
@@ -0,0 +1,41 @@
+package goread
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/TypicalAM/goread/internal/ipc"
+	"github.com/spf13/cobra"
+)
+
+var remoteCmd = &cobra.Command{
+	Use:   "remote <command> [argument]",
+	Short: "Send a command to the running instance of goread",
+	Long: "Send a command to the running instance of goread through its socket and print the reply. The commands are:\n" +
+		"  refresh                 refresh all the feeds\n" +
+		"  unread-count [name]     print the number of unread articles, of all feeds or of a category or a feed\n" +
+		"  open <name>             open the tab of a category or a feed\n" +
+		"  open-url <url>          open the tab of the feed with the url\n" +
+		"  add-feed <url> [name]   subscribe to a feed\n" +
+		"  run <command>           run a command of the command line, e.g. \"run search golang\"",
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		reply, err := ipc.Send(opts.socketPath, strings.Join(args, " "))
+		if err != nil {
+			return err
+		}
+
+		if strings.HasPrefix(reply, "error: ") {
+			return fmt.Errorf("%s", strings.TrimPrefix(reply, "error: "))
+		}
+
+		fmt.Println(reply)
+		return nil
+	},
+}
+
+func init() {
+	remoteCmd.SilenceUsage = true
+	remoteCmd.SilenceErrors = true
+	rootCmd.AddCommand(remoteCmd)
+}
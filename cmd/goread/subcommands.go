@@ -0,0 +1,162 @@
+package goread
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/TypicalAM/goread/internal/backend/cache"
+	"github.com/TypicalAM/goread/internal/backend/rss"
+	"github.com/spf13/cobra"
+)
+
+// subcommandOptions denote the flags of the subcommands
+type subcommandOptions struct {
+	addCategory    string
+	removeCategory string
+	name           string
+}
+
+var (
+	subOpts = subcommandOptions{}
+
+	addCmd = &cobra.Command{
+		Use:   "add <url>",
+		Short: "Subscribe to a feed, the feed is looked for if the url points to a website",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			defer openLog()()
+			return addFeed(args[0])
+		},
+	}
+
+	removeCmd = &cobra.Command{
+		Use:   "remove <name>",
+		Short: "Unsubscribe from a feed",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			defer openLog()()
+			return removeFeed(args[0])
+		},
+	}
+
+	listCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List the subscriptions, one per line with the category, the name and the url separated by tabs",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			defer openLog()()
+			return listFeeds()
+		},
+	}
+)
+
+func init() {
+	addCmd.Flags().StringVarP(&subOpts.addCategory, "category", "", rss.DefaultCategoryName, "The category of the feed, it's created if it doesn't exist")
+	addCmd.Flags().StringVarP(&subOpts.name, "name", "", "", "The name of the feed, the title of the feed is used by default")
+	removeCmd.Flags().StringVarP(&subOpts.removeCategory, "category", "", "", "Only remove the feed from this category")
+
+	for _, cmd := range []*cobra.Command{addCmd, removeCmd, listCmd} {
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		rootCmd.AddCommand(cmd)
+	}
+}
+
+// loadRss loads the urls file
+func loadRss() (*rss.Rss, error) {
+	feeds, err := rss.New(opts.urlsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = feeds.Load(); err != nil {
+		return nil, err
+	}
+
+	return feeds, nil
+}
+
+// addFeed adds the feed to the category, like in the interface the feed is discovered if the url
+// points to a website
+func addFeed(url string) error {
+	if _, err := loadConfig(); err != nil {
+		return err
+	}
+
+	feeds, err := loadRss()
+	if err != nil {
+		return err
+	}
+
+	name := subOpts.name
+	if !strings.HasPrefix(url, rss.QueryPrefix) && !strings.HasPrefix(url, rss.ExecPrefix) {
+		discovered, err := cache.DiscoverFeeds(url)
+		switch {
+		case err != nil:
+			log.Printf("Couldn't discover feeds at %s: %v\n", url, err)
+		case len(discovered) > 0:
+			url = discovered[0].URL
+			if name == "" {
+				name = strings.TrimSpace(discovered[0].Title)
+			}
+		}
+	}
+
+	if name == "" {
+		name = rss.NameFromURL(url)
+	}
+
+	if err = feeds.AddCategory(subOpts.addCategory, ""); err != nil && err != rss.ErrAlreadyExists {
+		return fmt.Errorf("adding the category %s: %w", subOpts.addCategory, err)
+	}
+
+	if err = feeds.AddFeed(subOpts.addCategory, name, url); err != nil {
+		return fmt.Errorf("adding the feed %s: %w", name, err)
+	}
+
+	fmt.Println(msgStyle.Render(fmt.Sprintf("Added %s (%s) to %s", name, url, subOpts.addCategory)))
+	return feeds.Save()
+}
+
+// removeFeed removes the feed from all the categories, or only from the one given in the flags
+func removeFeed(name string) error {
+	feeds, err := loadRss()
+	if err != nil {
+		return err
+	}
+
+	var removed []string
+	for _, cat := range feeds.Categories {
+		if subOpts.removeCategory != "" && cat.Name != subOpts.removeCategory {
+			continue
+		}
+
+		if err = feeds.RemoveFeed(cat.Name, name); err == nil {
+			removed = append(removed, cat.Name)
+		}
+	}
+
+	if len(removed) == 0 {
+		return fmt.Errorf("there is no feed named %s", name)
+	}
+
+	fmt.Println(msgStyle.Render(fmt.Sprintf("Removed %s from %s", name, strings.Join(removed, ", "))))
+	return feeds.Save()
+}
+
+// listFeeds prints the subscriptions separated by tabs, so that the output can be read by scripts
+func listFeeds() error {
+	feeds, err := loadRss()
+	if err != nil {
+		return err
+	}
+
+	for _, cat := range feeds.Categories {
+		for _, feed := range cat.Subscriptions {
+			fmt.Printf("%s\t%s\t%s\n", cat.Name, feed.Name, feed.URL)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package goread
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/TypicalAM/goread/internal/backend"
+	"github.com/spf13/cobra"
+)
+
+// placeholderRegex matches the placeholders of the unread format, e.g. {total} or {feed:Go Blog}
+var placeholderRegex = regexp.MustCompile(`\{(\w+)(?::([^}]*))?\}`)
+
+var (
+	unreadFormat string
+
+	unreadCmd = &cobra.Command{
+		Use:   "unread",
+		Short: "Print the number of unread articles in the cache, e.g. for a status bar",
+		Long: "Print the number of unread articles in the cache without starting the interface or fetching the feeds.\n" +
+			"The format can contain the placeholders:\n" +
+			"  {total}            the number of unread articles\n" +
+			"  {feeds}            the number of feeds with unread articles\n" +
+			"  {categories}       the number of categories with unread articles\n" +
+			"  {feed:<name>}      the number of unread articles in the feed\n" +
+			"  {category:<name>}  the number of unread articles in the category",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			defer openLog()()
+			counts, err := backend.LoadUnreadCounts(opts.urlsPath, opts.cacheDir)
+			if err != nil {
+				return err
+			}
+
+			text, err := formatUnread(unreadFormat, counts)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(text)
+			return nil
+		},
+	}
+)
+
+func init() {
+	unreadCmd.Flags().StringVarP(&unreadFormat, "format", "f", "{total}", "The output format, e.g. '{total} ({feeds})'")
+	unreadCmd.SilenceUsage = true
+	unreadCmd.SilenceErrors = true
+	rootCmd.AddCommand(unreadCmd)
+}
+
+// formatUnread replaces the placeholders in the format with the counts
+func formatUnread(format string, counts backend.UnreadCountMsg) (string, error) {
+	var err error
+	text := placeholderRegex.ReplaceAllStringFunc(format, func(placeholder string) string {
+		match := placeholderRegex.FindStringSubmatch(placeholder)
+		switch match[1] {
+		case "total":
+			return strconv.Itoa(counts.Total)
+		case "feeds":
+			return strconv.Itoa(countNonZero(counts.Feeds))
+		case "categories":
+			return strconv.Itoa(countNonZero(counts.Categories))
+		case "feed":
+			return strconv.Itoa(counts.Feeds[match[2]])
+		case "category":
+			return strconv.Itoa(counts.Categories[match[2]])
+		}
+
+		err = fmt.Errorf("unknown placeholder %s, use {total}, {feeds}, {categories}, {feed:<name>} or {category:<name>}", placeholder)
+		return placeholder
+	})
+
+	return text, err
+}
+
+// countNonZero returns the number of the feeds or the categories which have unread articles
+func countNonZero(counts map[string]int) int {
+	var result int
+	for _, count := range counts {
+		if count > 0 {
+			result++
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,125 @@
+package backend
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/TypicalAM/goread/internal/backend/cache"
+	"github.com/TypicalAM/goread/internal/backend/rss"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/gofeed"
+)
+
+// Annotation returns the tags and the note of an article in a feed tab.
+func (b Backend) Annotation(feedName string, index int) (cache.Annotation, error) {
+	item, err := b.indexToItem(feedName, index)
+	if err != nil {
+		return cache.Annotation{}, err
+	}
+
+	annotation, _ := b.Annotations.Get(*item)
+	return annotation, nil
+}
+
+// SetAnnotation replaces the tags and the note of an article in a feed tab, the new text of the
+// article is sent back so that the tab can show it.
+func (b Backend) SetAnnotation(feedName string, index int, tags []string, note string) tea.Cmd {
+	return func() tea.Msg {
+		item, err := b.indexToItem(feedName, index)
+		if err != nil {
+			return FetchErrorMsg{err, "Error while annotating the article"}
+		}
+
+		for _, tag := range tags {
+			if strings.ContainsAny(tag, " \t\n") {
+				return FetchErrorMsg{rss.ErrInvalidTag, "Error while annotating the article"}
+			}
+		}
+
+		b.Annotations.Set(withoutAlsoIn(*item), tags, strings.TrimSpace(note))
+		return ArticleAnnotatedMsg{feedName, index, b.annotatedContent(item)}
+	}
+}
+
+// Tags returns the sorted tags of the feeds and of the articles.
+func (b Backend) Tags() []string {
+	unique := make(map[string]struct{})
+	for _, tag := range append(b.Rss.Tags(), b.Annotations.Tags()...) {
+		unique[tag] = struct{}{}
+	}
+
+	tags := make([]string, 0, len(unique))
+	for tag := range unique {
+		tags = append(tags, tag)
+	}
+
+	sort.Strings(tags)
+	return tags
+}
+
+// tagArticles adds the articles tagged with the tag to the articles of the tagged feeds, the
+// newest ones come first.
+func (b Backend) tagArticles(tag string, feedArticles cache.SortableArticles) cache.SortableArticles {
+	result := make(cache.SortableArticles, 0, len(feedArticles))
+	seen := make(map[string]bool)
+	for _, item := range append(feedArticles, b.Annotations.Tagged(tag)...) {
+		id := item.Link + "\x00" + item.Title
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, item)
+		}
+	}
+
+	sort.Stable(result)
+	return b.deduplicate(newestFirst(result))
+}
+
+// tagDescription describes what is tagged with a tag in the tags tab.
+func tagDescription(feeds, articles int) string {
+	var parts []string
+	if feeds > 0 {
+		parts = append(parts, plural(feeds, "feed"))
+	}
+
+	if articles > 0 {
+		parts = append(parts, plural(articles, "article"))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// plural returns the count along with the noun, which gets an "s" unless there is one of it.
+func plural(count int, noun string) string {
+	if count == 1 {
+		return "1 " + noun
+	}
+
+	return fmt.Sprintf("%d %ss", count, noun)
+}
+
+// annotatedContent returns the text of the article shown in the article view, the tags and the
+// note of the article and the other feeds which posted it come before it.
+func (b Backend) annotatedContent(item *gofeed.Item) string {
+	content := rss.YassifyItem(item)
+	var quote []string
+	if feeds := alsoIn(*item); feeds != "" {
+		quote = append(quote, "> **Also in:** "+feeds)
+	}
+
+	annotation, _ := b.Annotations.Get(*item)
+	if len(quote) == 0 && len(annotation.Tags) == 0 && annotation.Note == "" {
+		return content
+	}
+
+	if len(annotation.Tags) > 0 {
+		quote = append(quote, "> **Tags:** "+strings.Join(annotation.Tags, ", "))
+	}
+
+	if annotation.Note != "" {
+		quote = append(quote, "> **Note:** "+annotation.Note)
+	}
+
+	// The trailing spaces break the line inside of the quote
+	return strings.Join(quote, "  \n") + "\n\n" + content
+}
@@ -0,0 +1,61 @@
+package backend
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/TypicalAM/goread/internal/backend/cache"
+	"github.com/TypicalAM/goread/internal/backend/rss"
+	"github.com/TypicalAM/goread/internal/ui/simplelist"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ArchiveArticle puts an article away in the state, it's restored if the state is Kept. The
+// archived and the trashed articles are marked as read.
+func (b Backend) ArchiveArticle(feedName string, index int, state cache.ArchiveState) tea.Cmd {
+	return func() tea.Msg {
+		item, err := b.indexToItem(feedName, index)
+		if err != nil {
+			return FetchErrorMsg{err, "Error while getting the article"}
+		}
+
+		log.Printf("Putting the article away in the state %d: %s\n", state, item.Title)
+		b.Archive.Put(withoutAlsoIn(*item), state, time.Now())
+		if state != cache.Kept {
+			b.ReadStatus.MarkAsRead(*item)
+			if b.Remote != nil {
+				if err = b.Remote.SetRead(*item, true); err != nil {
+					return FetchErrorMsg{err, "Error while syncing the read status"}
+				}
+			}
+		}
+
+		return ArticleArchivedMsg{feedName, index, state}
+	}
+}
+
+// FetchArchive gets the archived or the trashed articles, depending on the name of the view.
+func (b Backend) FetchArchive(feedName string, _ bool) tea.Cmd {
+	return func() tea.Msg {
+		return b.articlesToSuccessMsg(feedName, b.Archive.Articles(archiveViewState(feedName)))
+	}
+}
+
+// archiveViewState returns the state of the articles shown in the view with the name
+func archiveViewState(feedName string) cache.ArchiveState {
+	if feedName == rss.TrashName {
+		return cache.Trashed
+	}
+
+	return cache.Archived
+}
+
+// archiveItems returns the entries of the archive and the trash in the welcome tab
+func archiveItems() []simplelist.Item {
+	days := int(cache.TrashRetention.Hours() / 24)
+	return []simplelist.Item{
+		simplelist.NewItem(rss.ArchiveName, "The articles which are kept forever"),
+		simplelist.NewItem(rss.TrashName, fmt.Sprintf("The articles which are deleted after %d days", days)),
+	}
+}
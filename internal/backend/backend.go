@@ -2,10 +2,22 @@ package backend
 
 import (
 	"errors"
+	"fmt"
 	"log"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/TypicalAM/goread/internal/backend/cache"
+	"github.com/TypicalAM/goread/internal/backend/notify"
+	"github.com/TypicalAM/goread/internal/backend/remote"
 	"github.com/TypicalAM/goread/internal/backend/rss"
+	"github.com/TypicalAM/goread/internal/backend/share"
+	"github.com/TypicalAM/goread/internal/backend/speech"
+	"github.com/TypicalAM/goread/internal/backend/summary"
+	"github.com/TypicalAM/goread/internal/backend/translate"
+	"github.com/TypicalAM/goread/internal/config"
 	"github.com/TypicalAM/goread/internal/ui/simplelist"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
@@ -14,13 +26,36 @@ import (
 
 // Backend provides a way of fetching data from the cache and the RSS feed.
 type Backend struct {
-	Rss        *rss.Rss
-	Cache      *cache.Cache
-	ReadStatus *cache.ReadStatus
+	Rss         *rss.Rss
+	Cache       *cache.Cache
+	ReadStatus  *cache.ReadStatus
+	Annotations *cache.Annotations
+	Positions   *cache.Positions
+	Snoozes     *cache.Snoozes
+	Archive     *cache.Archive
+	Images      *cache.ImageStore
+	Remote      remote.Service
+	Downloads   *cache.DownloadQueue
+	Speaker     *speech.Speaker
+	Digests     *cache.Digests
+	rules       []rule
+	dedup       Dedup
+	maxRefresh  time.Duration
+	queries     *queryResults
+	skipped     *skippedMoves
+	source      string
+	export      config.Export
+	share       []share.Service
+	translator  translate.Translator
+	summarizer  summary.Summarizer
+	hooks       config.Hooks
+	notifier    *notify.Notifier
+	digest      config.Digest
+	favicons    bool
 }
 
 // New creates a new backend and its components.
-func New(urlPath, cacheDir string, resetCache bool) (*Backend, error) {
+func New(cfg *config.Config, urlPath, cacheDir string, resetCache bool) (*Backend, error) {
 	log.Println("Creating new backend")
 	store, err := cache.New(cacheDir)
 	if err != nil {
@@ -32,6 +67,73 @@ func New(urlPath, cacheDir string, resetCache bool) (*Backend, error) {
 		return nil, err
 	}
 
+	images, err := cache.NewImageStore(cacheDir)
+	if err != nil {
+		return nil, err
+	}
+
+	responses, err := cache.NewHTTPCache(cacheDir)
+	if err != nil {
+		return nil, err
+	}
+
+	positions, err := cache.NewPositions(cacheDir)
+	if err != nil {
+		return nil, err
+	}
+
+	snoozes, err := cache.NewSnoozes(cacheDir)
+	if err != nil {
+		return nil, err
+	}
+
+	// The annotations are written by the user, they are kept even if the cache is reset
+	annotations, err := cache.NewAnnotations(cacheDir)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = annotations.Load(); err != nil {
+		log.Println("Annotations load failed: ", err)
+	}
+
+	// The archive is put together by the user too, the trash is emptied when it's loaded
+	archive, err := cache.NewArchive(cacheDir)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = archive.Load(); err != nil {
+		log.Println("Archive load failed: ", err)
+	}
+
+	if deleted := archive.EmptyTrash(time.Now()); deleted > 0 {
+		log.Printf("Deleted %d articles from the trash\n", deleted)
+	}
+
+	if resetCache {
+		if err = images.Clear(); err != nil {
+			log.Println("Image store reset failed: ", err)
+		}
+
+		if err = responses.Clear(); err != nil {
+			log.Println("HTTP cache reset failed: ", err)
+		}
+	} else if err = responses.Prune(); err != nil {
+		log.Println("HTTP cache prune failed: ", err)
+	}
+
+	cache.Responses = nil
+	if !cfg.Backend.DisableHTTPCache {
+		cache.Responses = responses
+	}
+
+	// The trusted certificates of the gemini capsules live next to the cache
+	cache.KnownGeminiHosts = ""
+	if cacheDir != "" {
+		cache.KnownGeminiHosts = filepath.Join(cacheDir, "gemini_hosts")
+	}
+
 	if !resetCache {
 		if err = store.Load(); err != nil {
 			log.Println("Cache load failed: ", err)
@@ -40,6 +142,19 @@ func New(urlPath, cacheDir string, resetCache bool) (*Backend, error) {
 		if err = readStatus.Load(); err != nil {
 			log.Println("Read status load failed: ", err)
 		}
+
+		if err = positions.Load(); err != nil {
+			log.Println("Reading positions load failed: ", err)
+		}
+
+		if err = snoozes.Load(); err != nil {
+			log.Println("Snoozed articles load failed: ", err)
+		}
+	}
+
+	downloads, err := cache.NewDownloadQueue(cfg.Podcasts.Directory)
+	if err != nil {
+		return nil, err
 	}
 
 	rss, err := rss.New(urlPath)
@@ -51,15 +166,75 @@ func New(urlPath, cacheDir string, resetCache bool) (*Backend, error) {
 		log.Println("Rss load failed: ", err)
 	}
 
-	return &Backend{rss, store, readStatus}, nil
+	rules, err := newRules(cfg.Rules, cfg.Alerts.Keywords)
+	if err != nil {
+		return nil, err
+	}
+
+	dedup, err := newDedup(cfg.Backend.Dedup)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &Backend{Rss: rss, Cache: store, ReadStatus: readStatus, Annotations: annotations, Positions: positions, Snoozes: snoozes, Archive: archive, Images: images, Downloads: downloads, Speaker: speech.New(cfg.Speech.Command), rules: rules, dedup: dedup, source: "local", export: cfg.Export, share: share.New(cfg.Share, cfg.HTTP), translator: translate.New(cfg.Translation, cfg.HTTP), summarizer: summary.New(cfg.Summary, cfg.HTTP), hooks: cfg.Hooks, favicons: cfg.Layout.Favicons}
+	b.queries = &queryResults{articles: make(map[string]cache.SortableArticles)}
+	b.skipped = &skippedMoves{urls: make(map[string]bool)}
+	if cfg.Backend.AdaptiveRefresh {
+		b.maxRefresh = cache.DefaultCacheDuration
+		if cfg.Backend.MaxRefreshInterval > 0 {
+			b.maxRefresh = cfg.Backend.MaxRefreshInterval
+		}
+	}
+
+	if cfg.Digest.Enabled {
+		b.digest = cfg.Digest
+		if b.Digests, err = cache.NewDigests(cacheDir); err != nil {
+			return nil, err
+		}
+
+		if err = b.Digests.Load(); err != nil {
+			log.Println("Digests load failed: ", err)
+		}
+	}
+
+	store.SetFilter(b.applyRules)
+	store.SetFilterCommands(func(url string) string { return b.Rss.FilterCommand(url) })
+	if cfg.Alerts.Notify {
+		b.notifier = notify.New(cfg.Alerts.Command)
+	}
+	if cfg.Hooks.OnNewArticle != "" || b.notifier != nil {
+		store.SetNewArticles(b.onNewArticles)
+	}
+	if cfg.Sync.Enabled() {
+		if err = b.connectRemote(cfg.Sync); err != nil {
+			log.Println("Sync service connection failed: ", err)
+		}
+	}
+
+	return b, nil
 }
 
 // FetchCategories gets the categories.
 func (b Backend) FetchCategories(_ string) tea.Cmd {
 	return func() tea.Msg {
-		items := make([]list.Item, len(b.Rss.Categories))
-		for i, cat := range b.Rss.Categories {
-			items[i] = simplelist.NewItem(cat.Name, cat.Description)
+		counts := b.unreadCounts()
+
+		// The aggregated feed is always available as the first item
+		items := []list.Item{simplelist.NewItem(rss.AllFeedsName, "Articles from all the feeds, newest first").
+			SetBadge(UnreadBadge(counts.Total))}
+		if b.Digests != nil {
+			items = append(items, b.digestItem())
+		}
+
+		for _, item := range archiveItems() {
+			items = append(items, item)
+		}
+
+		for _, cat := range b.Rss.Categories {
+			if cat.Name != rss.AllFeedsName {
+				items = append(items, simplelist.NewItem(cat.Name, cat.Description).
+					SetBadge(UnreadBadge(counts.Categories[cat.Name])))
+			}
 		}
 
 		return FetchSuccessMsg{Items: items}
@@ -74,9 +249,10 @@ func (b Backend) FetchFeeds(catname string) tea.Cmd {
 			return FetchErrorMsg{err, "Error while trying to get feeds"}
 		}
 
+		counts := b.unreadCounts()
 		items := make([]list.Item, len(feeds))
 		for i, feed := range feeds {
-			items[i] = simplelist.NewItem(feed.Name, feed.URL)
+			items[i] = simplelist.NewItem(feed.Name, rss.StripCredentials(feed.URL)).SetBadge(UnreadBadge(counts.Feeds[feed.Name]))
 		}
 
 		return FetchSuccessMsg{items}
@@ -86,31 +262,161 @@ func (b Backend) FetchFeeds(catname string) tea.Cmd {
 // FetchArticles gets the articles from a feed.
 func (b Backend) FetchArticles(feedname string, refresh bool) tea.Cmd {
 	return func() tea.Msg {
-		url, err := b.Rss.GetFeedURL(feedname)
+		feed, err := b.Rss.GetFeed(feedname)
 		if err != nil {
 			return FetchErrorMsg{err, "Error while trying to get the article url"}
 		}
 
-		items, err := b.Cache.GetArticles(url, refresh)
+		var items cache.SortableArticles
+		switch {
+		case feed.IsQuery():
+			items, err = b.queryArticles(feed, refresh)
+		case feed.FullContent && !b.Cache.OfflineMode:
+			items, err = b.Cache.GetFullArticles(feed.URL, refresh)
+		default:
+			items, err = b.Cache.GetArticles(feed.URL, refresh)
+		}
+
 		if err != nil {
 			return FetchErrorMsg{err, "Error while fetching the article"}
 		}
 
-		return b.articlesToSuccessMsg(items)
+		return b.articlesToSuccessMsg(feedname, items)
 	}
 }
 
 // FetchAllArticles gets all the articles from all the feeds.
-func (b Backend) FetchAllArticles(_ string, refresh bool) tea.Cmd {
+func (b Backend) FetchAllArticles(title string, refresh bool) tea.Cmd {
 	return func() tea.Msg {
-		return b.articlesToSuccessMsg(b.Cache.GetArticlesBulk(b.Rss.GetAllURLs(), refresh))
+		urls := b.Rss.GetAllURLs()
+		messages := make(chan tea.Msg, len(urls)+1)
+		next := waitForMsg(messages)
+
+		go func() {
+			items := b.Cache.GetArticlesBulk(urls, refresh, func(url string, done int, err error) {
+				messages <- FetchProgressMsg{next, err, url, done, len(urls)}
+			})
+
+			messages <- b.articlesToSuccessMsg(title, b.deduplicate(newestFirst(items)))
+		}()
+
+		return next()
+	}
+}
+
+// FetchTagArticles gets the articles from all the feeds tagged with the tag from the title of a tag
+// tab, along with the articles tagged with it.
+func (b Backend) FetchTagArticles(title string, refresh bool) tea.Cmd {
+	return func() tea.Msg {
+		urls := b.Rss.TagURLs(strings.TrimPrefix(title, rss.TagPrefix))
+		messages := make(chan tea.Msg, len(urls)+1)
+		next := waitForMsg(messages)
+
+		go func() {
+			items := b.Cache.GetArticlesBulk(urls, refresh, func(url string, done int, err error) {
+				messages <- FetchProgressMsg{next, err, url, done, len(urls)}
+			})
+
+			messages <- b.articlesToSuccessMsg(title, b.tagArticles(strings.TrimPrefix(title, rss.TagPrefix), items))
+		}()
+
+		return next()
+	}
+}
+
+// SearchArticles gets the cached articles matching the query from the title of a search tab.
+func (b Backend) SearchArticles(title string, _ bool) tea.Cmd {
+	return func() tea.Msg {
+		return b.articlesToSuccessMsg(title, b.deduplicate(b.Cache.Search(strings.TrimPrefix(title, rss.SearchPrefix))))
+	}
+}
+
+// RefreshFeeds fetches the feeds in the background, bypassing the cache. With the adaptive refresh
+// only the feeds which are due are fetched, unless all of them are forced to refresh.
+func (b Backend) RefreshFeeds(all bool) tea.Cmd {
+	return func() tea.Msg {
+		b.Refresh(func(url string, _ int, err error) {
+			if err != nil {
+				log.Printf("Error refreshing %s: %v\n", rss.StripCredentials(url), err)
+			}
+		}, all)
+
+		return RefreshedMsg{time.Now()}
+	}
+}
+
+// Refresh fetches the feeds bypassing the cache and reports the progress, the refresh hooks are
+// run before and after. It returns the urls of the fetched feeds, with the adaptive refresh only
+// the feeds which are due are fetched unless all of them are forced to refresh.
+func (b Backend) Refresh(progress cache.BulkProgress, all bool) []string {
+	urls := b.Rss.GetAllURLs()
+	if b.maxRefresh > 0 && !all {
+		urls = b.Cache.DueURLs(urls, b.maxRefresh)
+		log.Printf("Refreshing %d feeds which are due\n", len(urls))
+	}
+
+	runHook("pre_refresh", b.hooks.PreRefresh, nil)
+	b.Cache.GetArticlesBulk(urls, true, progress)
+	runHook("post_refresh", b.hooks.PostRefresh, nil)
+	b.UpdateDigest(time.Now())
+	if cache.Retention.Enabled() {
+		b.collectGarbage(time.Now())
+	}
+
+	return urls
+}
+
+// DiscoverFeeds looks for the feeds at the url of a new feed.
+func (b Backend) DiscoverFeeds(parent, name, url string) tea.Cmd {
+	return func() tea.Msg {
+		feeds, err := cache.DiscoverFeeds(url)
+		return DiscoveredFeedsMsg{err, parent, name, url, feeds}
+	}
+}
+
+// FetchBridges lists the bridges of the RSS-Bridge instance for a new feed in the category.
+func (b Backend) FetchBridges(parent string) tea.Cmd {
+	return func() tea.Msg {
+		bridges, err := cache.FetchBridges()
+		return BridgesLoadedMsg{err, parent, bridges}
+	}
+}
+
+// FetchImage gets the data of an image used in an article.
+func (b Backend) FetchImage(url string) tea.Cmd {
+	return func() tea.Msg {
+		if b.Cache.OfflineMode {
+			return ImageLoadedMsg{URL: url, Err: errors.New("offline mode")}
+		}
+
+		data, err := b.Images.Get(url)
+		return ImageLoadedMsg{err, url, data}
+	}
+}
+
+// FetchFavicon gets the icon of the site of a feed, the site is the one the articles link to since
+// the feeds are often served from somewhere else.
+func (b Backend) FetchFavicon(feedName string) tea.Cmd {
+	return func() tea.Msg {
+		feed, err := b.Rss.GetFeed(feedName)
+		if err != nil || feed.IsQuery() {
+			return FaviconLoadedMsg{FeedName: feedName, Err: errors.New("not a feed with a site")}
+		}
+
+		site := feed.URL
+		if articles, ok := b.Cache.GetStoredArticles(feed.URL); ok && len(articles) > 0 && articles[0].Link != "" {
+			site = articles[0].Link
+		}
+
+		data, err := b.Images.Favicon(site, !b.Cache.OfflineMode)
+		return FaviconLoadedMsg{err, feedName, data}
 	}
 }
 
 // FetchDownloaded gets the downloaded articles.
-func (b Backend) FetchDownloadedArticles(_ string, _ bool) tea.Cmd {
+func (b Backend) FetchDownloadedArticles(title string, _ bool) tea.Cmd {
 	return func() tea.Msg {
-		return b.articlesToSuccessMsg(b.Cache.GetDownloaded())
+		return b.articlesToSuccessMsg(title, b.Cache.GetDownloaded())
 	}
 }
 
@@ -122,7 +428,177 @@ func (b Backend) DownloadItem(feedName string, index int) tea.Cmd {
 			return FetchErrorMsg{err, "Error while getting the article"}
 		}
 
-		b.Cache.AddToDownloaded(*item)
+		b.Cache.AddToDownloaded(withoutAlsoIn(*item))
+		if b.Remote != nil {
+			if err = b.Remote.SetStarred(*item, true); err != nil {
+				return FetchErrorMsg{err, "Error while syncing the starred status"}
+			}
+		}
+
+		return nil
+	}
+}
+
+// RemoveDownloaded removes articles from the downloaded articles. The highest indexes are
+// removed first so that the removals don't shift the remaining ones.
+func (b Backend) RemoveDownloaded(indexes ...int) tea.Cmd {
+	return func() tea.Msg {
+		downloaded := b.Cache.GetDownloaded()
+		sorted := make([]int, 0, len(indexes))
+		seen := make(map[int]bool)
+		for _, index := range indexes {
+			if index < 0 || index >= len(downloaded) {
+				return FetchErrorMsg{errors.New("index out of range"), "Error while deleting the article"}
+			}
+
+			if !seen[index] {
+				seen[index] = true
+				sorted = append(sorted, index)
+			}
+		}
+
+		sort.Sort(sort.Reverse(sort.IntSlice(sorted)))
+		for _, index := range sorted {
+			item := downloaded[index]
+			if err := b.Cache.RemoveFromDownloaded(index); err != nil {
+				return FetchErrorMsg{err, "Error while deleting the article"}
+			}
+
+			if b.Remote != nil {
+				if err := b.Remote.SetStarred(item, false); err != nil {
+					return FetchErrorMsg{err, "Error while syncing the starred status"}
+				}
+			}
+		}
+
+		return nil
+	}
+}
+
+// DownloadEpisode queues the download of the podcast episode attached to an article.
+func (b Backend) DownloadEpisode(feedName string, index int) tea.Cmd {
+	return func() tea.Msg {
+		item, err := b.indexToItem(feedName, index)
+		if err != nil {
+			return FetchErrorMsg{err, "Error while getting the article"}
+		}
+
+		episode := rss.Episode(item)
+		if episode == nil {
+			return FetchErrorMsg{errors.New("no episode attached"), "Error while downloading the episode"}
+		}
+
+		log.Println("Queueing episode download:", episode.URL)
+		download := b.Downloads.Add(item.Title, episode.URL, episode.Type)
+		return EpisodeQueuedMsg{download.Title, download.State == cache.DownloadDone}
+	}
+}
+
+// ReadAloud starts reading the text of an article aloud, the title is read first.
+func (b Backend) ReadAloud(feedName string, index int) tea.Cmd {
+	return func() tea.Msg {
+		item, err := b.indexToItem(feedName, index)
+		if err != nil {
+			return FetchErrorMsg{err, "Error while getting the article"}
+		}
+
+		log.Println("Reading aloud:", item.Title)
+		done, err := b.Speaker.Speak(item.Title, item.Title+".\n"+rss.Text(item))
+		if err != nil {
+			return FetchErrorMsg{err, "Error while reading the article aloud"}
+		}
+
+		return SpeechStartedMsg{item.Title, done}
+	}
+}
+
+// FetchDownloads gets the episode downloads along with their progress.
+func (b Backend) FetchDownloads(_ string) tea.Cmd {
+	return func() tea.Msg {
+		downloads := b.Downloads.Downloads()
+		items := make([]list.Item, len(downloads))
+		for i := range downloads {
+			// The newest downloads come first
+			d := downloads[len(downloads)-1-i]
+			items[i] = simplelist.NewItem(d.Title, downloadStatus(d))
+		}
+
+		return FetchSuccessMsg{items}
+	}
+}
+
+// FetchHealth gets the health of all the feeds, the failing feeds come first, then the ones whose
+// invalid xml had to be repaired and then the ones which haven't posted anything for the longest time.
+func (b Backend) FetchHealth(_ string) tea.Cmd {
+	return func() tea.Msg {
+		type feedHealth struct {
+			name    string
+			health  cache.Health
+			fetched bool
+		}
+
+		var feeds []feedHealth
+		seen := make(map[string]bool)
+		for _, cat := range b.Rss.Categories {
+			for _, feed := range cat.Subscriptions {
+				if feed.IsQuery() || seen[feed.URL] {
+					continue
+				}
+
+				seen[feed.URL] = true
+				health, fetched := b.Cache.GetHealth(feed.URL)
+				feeds = append(feeds, feedHealth{feed.Name, health, fetched})
+			}
+		}
+
+		sort.SliceStable(feeds, func(i, j int) bool {
+			a, b := feeds[i], feeds[j]
+			switch {
+			case a.health.Failed() != b.health.Failed():
+				return a.health.Failed()
+			case (a.health.Repaired != "") != (b.health.Repaired != ""):
+				return a.health.Repaired != ""
+			case a.fetched != b.fetched:
+				return !a.fetched
+			default:
+				return a.health.LastPost.Before(b.health.LastPost)
+			}
+		})
+
+		now := time.Now()
+		items := make([]list.Item, len(feeds))
+		for i, feed := range feeds {
+			items[i] = simplelist.NewItem(feed.name, healthStatus(feed.health, feed.fetched, now))
+		}
+
+		return FetchSuccessMsg{items}
+	}
+}
+
+// FetchTags gets the tags of the feeds and the articles along with the number of the things tagged with them.
+func (b Backend) FetchTags(_ string) tea.Cmd {
+	return func() tea.Msg {
+		counts := b.unreadCounts()
+		tags := b.Tags()
+		items := make([]list.Item, len(tags))
+		for i, tag := range tags {
+			desc := tagDescription(len(b.Rss.TagURLs(tag)), len(b.Annotations.Tagged(tag)))
+			items[i] = simplelist.NewItem(tag, desc).SetBadge(UnreadBadge(counts.Tags[tag]))
+		}
+
+		return FetchSuccessMsg{items}
+	}
+}
+
+// SetPosition remembers the line at which the reading of an article stopped.
+func (b Backend) SetPosition(feedName string, index, line int) tea.Cmd {
+	return func() tea.Msg {
+		item, err := b.indexToItem(feedName, index)
+		if err != nil {
+			return FetchErrorMsg{err, "Error while getting the article"}
+		}
+
+		b.Positions.Set(*item, line)
 		return nil
 	}
 }
@@ -135,9 +611,20 @@ func (b Backend) MarkAsRead(feedName string, index int) tea.Cmd {
 			return FetchErrorMsg{err, "Error while getting the article"}
 		}
 
+		// The article is marked whenever it's shown, the server only hears about the changes
+		if b.ReadStatus.IsRead(*item) {
+			return nil
+		}
+
 		log.Println("Marking as read:", item.Title)
 		b.ReadStatus.MarkAsRead(*item)
-		return nil
+		if b.Remote != nil {
+			if err = b.Remote.SetRead(*item, true); err != nil {
+				return FetchErrorMsg{err, "Error while syncing the read status"}
+			}
+		}
+
+		return ReadStatusChangedMsg{}
 	}
 }
 
@@ -149,14 +636,211 @@ func (b Backend) MarkAsUnread(feedName string, index int) tea.Cmd {
 			return FetchErrorMsg{err, "Error while getting the article"}
 		}
 
+		if !b.ReadStatus.IsRead(*item) {
+			return nil
+		}
+
 		log.Println("Marking as unread:", item.Title)
 		b.ReadStatus.MarkAsUnread(*item)
-		return nil
+		if b.Remote != nil {
+			if err = b.Remote.SetRead(*item, false); err != nil {
+				return FetchErrorMsg{err, "Error while syncing the read status"}
+			}
+		}
+
+		return ReadStatusChangedMsg{}
+	}
+}
+
+// MarkAllAsRead marks all the articles of a feed tab as read.
+func (b Backend) MarkAllAsRead(feedName string) tea.Cmd {
+	return func() tea.Msg {
+		items, err := b.Articles(feedName)
+		if err != nil {
+			return FetchErrorMsg{err, "Error while getting the articles"}
+		}
+
+		return b.markAllAsRead(items, func(gofeed.Item) bool { return true })
+	}
+}
+
+// MarkCategoryAsRead marks all the articles of the feeds in a category as read, the query feeds are skipped.
+func (b Backend) MarkCategoryAsRead(category string) tea.Cmd {
+	return func() tea.Msg {
+		feeds, err := b.Rss.GetFeeds(category)
+		if err != nil {
+			return FetchErrorMsg{err, "Error while getting the feeds"}
+		}
+
+		urls := make([]string, 0, len(feeds))
+		for _, feed := range feeds {
+			if !feed.IsQuery() {
+				urls = append(urls, feed.URL)
+			}
+		}
+
+		items := b.Cache.GetArticlesBulk(urls, false, nil)
+		return b.markAllAsRead(items, func(gofeed.Item) bool { return true })
+	}
+}
+
+// MarkOlderAsRead marks the articles of all the feeds which were published more than the given number of days ago as read.
+func (b Backend) MarkOlderAsRead(days int) tea.Cmd {
+	return func() tea.Msg {
+		cutoff := time.Now().AddDate(0, 0, -days)
+		items := b.Cache.GetArticlesBulk(b.Rss.GetAllURLs(), false, nil)
+		return b.markAllAsRead(items, func(item gofeed.Item) bool {
+			return item.PublishedParsed != nil && item.PublishedParsed.Before(cutoff)
+		})
+	}
+}
+
+// markAllAsRead marks the unread articles accepted by the filter as read and syncs them.
+func (b Backend) markAllAsRead(items cache.SortableArticles, filter func(gofeed.Item) bool) tea.Msg {
+	var count int
+	for _, item := range items {
+		if b.ReadStatus.IsRead(item) || !filter(item) {
+			continue
+		}
+
+		b.ReadStatus.MarkAsRead(item)
+		count++
+		if b.Remote != nil {
+			if err := b.Remote.SetRead(item, true); err != nil {
+				return FetchErrorMsg{err, "Error while syncing the read status"}
+			}
+		}
+	}
+
+	log.Printf("Marked %d articles as read\n", count)
+	return MarkedAllAsReadMsg{count}
+}
+
+// Source returns where the articles come from, the name of the sync service or local.
+func (b Backend) Source() string {
+	return b.source
+}
+
+// CountUnread counts the unread articles of all the feeds which are in the cache, nothing is fetched.
+func (b Backend) CountUnread() tea.Cmd {
+	return func() tea.Msg {
+		b.wakeSnoozed(time.Now())
+		return b.unreadCounts()
+	}
+}
+
+// UnreadBadge returns the badge shown next to the name of a feed or a category, it's empty if
+// everything was read.
+func UnreadBadge(unread int) string {
+	if unread == 0 {
+		return ""
 	}
+
+	return fmt.Sprintf("(%d)", unread)
+}
+
+// LoadUnreadCounts counts the unread articles in the cache without creating the whole backend, so
+// it's cheap enough to be called from a status bar. Nothing is fetched or written
+func LoadUnreadCounts(urlPath, cacheDir string) (UnreadCountMsg, error) {
+	store, err := cache.New(cacheDir)
+	if err != nil {
+		return UnreadCountMsg{}, err
+	}
+
+	readStatus, err := cache.NewReadStatus(cacheDir)
+	if err != nil {
+		return UnreadCountMsg{}, err
+	}
+
+	feeds, err := rss.New(urlPath)
+	if err != nil {
+		return UnreadCountMsg{}, err
+	}
+
+	if err = store.Load(); err != nil {
+		log.Println("Cache load failed: ", err)
+	}
+
+	if err = readStatus.Load(); err != nil {
+		log.Println("Read status load failed: ", err)
+	}
+
+	annotations, err := cache.NewAnnotations(cacheDir)
+	if err != nil {
+		return UnreadCountMsg{}, err
+	}
+
+	if err = annotations.Load(); err != nil {
+		log.Println("Annotations load failed: ", err)
+	}
+
+	if err = feeds.Load(); err != nil {
+		return UnreadCountMsg{}, err
+	}
+
+	b := Backend{Rss: feeds, Cache: store, ReadStatus: readStatus, Annotations: annotations}
+	return b.unreadCounts(), nil
+}
+
+// unreadCounts counts the unread articles which are in the cache, the feeds shared by several
+// categories are counted once in the total. The categories include the feeds of the categories
+// nested in them and the tags include the tagged articles. The query feeds aren't counted.
+func (b Backend) unreadCounts() UnreadCountMsg {
+	result := UnreadCountMsg{Feeds: make(map[string]int), Categories: make(map[string]int), Tags: make(map[string]int)}
+	byURL := make(map[string]int)
+	counted := make(map[string]map[string]bool)
+	for _, cat := range b.Rss.Categories {
+		for _, feed := range cat.Subscriptions {
+			if feed.IsQuery() {
+				continue
+			}
+
+			unread, ok := byURL[feed.URL]
+			if !ok {
+				if articles, stored := b.Cache.GetStoredArticles(feed.URL); stored {
+					unread = b.ReadStatus.CountUnread(articles)
+				}
+
+				byURL[feed.URL] = unread
+				result.Total += unread
+			}
+
+			result.Feeds[feed.Name] = unread
+			for name := cat.Name; name != ""; name = rss.ParentName(name) {
+				if counted[name] == nil {
+					counted[name] = make(map[string]bool)
+				}
+
+				if !counted[name][feed.URL] {
+					counted[name][feed.URL] = true
+					result.Categories[name] += unread
+				}
+			}
+		}
+	}
+
+	for _, tag := range b.Tags() {
+		var articles cache.SortableArticles
+		for _, url := range b.Rss.TagURLs(tag) {
+			stored, _ := b.Cache.GetStoredArticles(url)
+			articles = append(articles, stored...)
+		}
+
+		result.Tags[tag] = b.ReadStatus.CountUnread(b.tagArticles(tag, articles))
+	}
+
+	return result
 }
 
 // Close closes the backend and saves its components.
 func (b Backend) Close() error {
+	waitHooks()
+	if b.Speaker != nil {
+		if err := b.Speaker.Stop(); err != nil {
+			log.Println("Stopping the reading failed: ", err)
+		}
+	}
+
 	if err := b.Rss.Save(); err != nil {
 		return err
 	}
@@ -165,46 +849,261 @@ func (b Backend) Close() error {
 		return err
 	}
 
+	if err := b.Annotations.Save(); err != nil {
+		return err
+	}
+
+	if err := b.Positions.Save(); err != nil {
+		return err
+	}
+
+	if err := b.Snoozes.Save(); err != nil {
+		return err
+	}
+
+	if err := b.Archive.Save(); err != nil {
+		return err
+	}
+
+	if b.Digests != nil {
+		if err := b.Digests.Save(); err != nil {
+			return err
+		}
+	}
+
 	return b.ReadStatus.Save()
 }
 
-// articlesToSuccessMsg converts a list of items to a FetchArticleSuccessMsg.
-func (b Backend) articlesToSuccessMsg(items cache.SortableArticles) FetchArticleSuccessMsg {
+// connectRemote logs in to the sync service, merges its subscriptions and uses it as the article source.
+func (b *Backend) connectRemote(cfg config.Sync) error {
+	service, err := remote.New(cfg)
+	if err != nil {
+		return err
+	}
+
+	if err = service.Login(); err != nil {
+		return err
+	}
+
+	categories, err := service.Categories()
+	if err != nil {
+		return err
+	}
+
+	if err = b.Rss.MergeCategories(categories); err != nil {
+		return err
+	}
+
+	b.Remote = service
+	b.source = cfg.Service
+	b.Cache.SetSource(b.fetchRemote)
+	log.Printf("Connected to the sync service with %d categories\n", len(categories))
+	return nil
+}
+
+// fetchRemote fetches the articles using the sync service, the server read status takes precedence.
+func (b Backend) fetchRemote(url string) (cache.SortableArticles, error) {
+	articles, err := b.Remote.Articles(url)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range articles {
+		read, _, ok := remote.State(articles[i])
+		switch {
+		case !ok:
+			continue
+		case read:
+			b.ReadStatus.MarkAsRead(articles[i])
+		default:
+			b.ReadStatus.MarkAsUnread(articles[i])
+		}
+	}
+
+	return articles, nil
+}
+
+// articlesToSuccessMsg converts a list of items to a FetchArticleSuccessMsg for the tab of the feed.
+func (b Backend) articlesToSuccessMsg(feedName string, items cache.SortableArticles) FetchArticleSuccessMsg {
 	result := make([]list.Item, len(items))
 	contents := make([]string, len(items))
+	b.wakeSnoozed(time.Now())
+
+	// The feeds of the articles are only needed to show their icons and to clean their links
+	var feeds map[string]string
+	if b.favicons || rss.HasFeedRules() {
+		feeds = b.articleFeeds()
+	}
 
 	for i, item := range items {
-		if b.ReadStatus.IsRead(item) {
-			item.Title = "✓ " + item.Title
+		article := NewArticleItem(rss.StripControl(item.Title), betterDesc(item.Description), item.Link, b.ReadStatus.IsRead(item)).
+			SetHighlighted(item.Custom[highlightKey] == "true").
+			SetPublished(item.PublishedParsed).
+			SetWords(rss.WordCount(&items[i])).
+			SetPosition(b.Positions.Get(item)).
+			SetSnoozedUntil(b.Snoozes.Until(item)).
+			SetArchiveState(b.Archive.State(item)).
+			SetSaved(b.Cache.IsDownloaded(item)).
+			SetFeed(feeds[item.Link+"\x00"+item.Title])
+		if episode := rss.Episode(&items[i]); episode != nil {
+			article = article.SetEpisode(episode.URL)
 		}
 
-		result[i] = simplelist.NewItem(item.Title, betterDesc(item.Description))
-		contents[i] = rss.YassifyItem(&items[i])
+		result[i] = article
+		contents[i] = b.annotatedContent(&items[i])
 	}
 
-	return FetchArticleSuccessMsg{result, contents}
+	return FetchArticleSuccessMsg{feedName, result, contents}
 }
 
 // indexToItem resolves an index to an item.
 func (b Backend) indexToItem(feedName string, index int) (*gofeed.Item, error) {
-	switch feedName {
-	case rss.AllFeedsName:
-		return &b.Cache.GetArticlesBulk(rss.Default.GetAllURLs(), false)[index], nil
-	case rss.DownloadedFeedsName:
-		return &b.Cache.GetDownloaded()[index], nil
-	default:
-		url, err := b.Rss.GetFeedURL(feedName)
-		if err != nil {
-			return nil, errors.New("getting the article url")
+	items, err := b.Articles(feedName)
+	if err != nil {
+		return nil, err
+	}
+
+	if index < 0 || index >= len(items) {
+		return nil, errors.New("index out of range")
+	}
+
+	return &items[index], nil
+}
+
+// Articles returns the articles of a feed tab in the order in which they are shown.
+func (b Backend) Articles(feedName string) (cache.SortableArticles, error) {
+	switch {
+	case feedName == rss.AllFeedsName:
+		return b.deduplicate(newestFirst(b.Cache.GetArticlesBulk(b.Rss.GetAllURLs(), false, nil))), nil
+	case feedName == rss.DownloadedFeedsName:
+		return b.Cache.GetDownloaded(), nil
+	case feedName == rss.DigestName && b.Digests != nil:
+		return b.Digests.Get(), nil
+	case feedName == rss.ArchiveName || feedName == rss.TrashName:
+		return b.Archive.Articles(archiveViewState(feedName)), nil
+	case strings.HasPrefix(feedName, rss.SearchPrefix):
+		return b.deduplicate(b.Cache.Search(strings.TrimPrefix(feedName, rss.SearchPrefix))), nil
+	case strings.HasPrefix(feedName, rss.TagPrefix):
+		tag := strings.TrimPrefix(feedName, rss.TagPrefix)
+		return b.tagArticles(tag, b.Cache.GetArticlesBulk(b.Rss.TagURLs(tag), false, nil)), nil
+	}
+
+	feed, err := b.Rss.GetFeed(feedName)
+	if err != nil {
+		return nil, errors.New("getting the article url")
+	}
+
+	// The query feeds use the articles which are shown in their tab
+	var items cache.SortableArticles
+	if feed.IsQuery() {
+		var ok bool
+		if items, ok = b.queries.get(feed.Name); !ok {
+			items, err = b.queryArticles(feed, false)
 		}
+	} else {
+		items, err = b.Cache.GetArticles(feed.URL, false)
+	}
 
-		items, err := b.Cache.GetArticles(url, false)
-		if err != nil {
-			return nil, errors.New("fetching the article")
+	if err != nil {
+		return nil, errors.New("fetching the article")
+	}
+
+	return items, nil
+}
+
+// newestFirst returns a copy of the sorted articles in the reverse order, so that the newest ones come first.
+func newestFirst(items cache.SortableArticles) cache.SortableArticles {
+	result := make(cache.SortableArticles, len(items))
+	for i := range items {
+		result[len(items)-1-i] = items[i]
+	}
+
+	return result
+}
+
+// waitForMsg returns a command which waits for the next message on the channel.
+func waitForMsg(messages <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg { return <-messages }
+}
+
+// downloadStatus describes the progress of a download.
+func downloadStatus(d cache.Download) string {
+	switch d.State {
+	case cache.DownloadQueued:
+		return "Queued"
+	case cache.DownloadRunning:
+		if d.Total <= 0 {
+			return fmt.Sprintf("Downloading, %s", formatSize(d.Done))
 		}
 
-		return &items[index], nil
+		return fmt.Sprintf("Downloading %d%%, %s of %s", d.Done*100/d.Total, formatSize(d.Done), formatSize(d.Total))
+	case cache.DownloadFailed:
+		return fmt.Sprintf("Failed: %v", d.Err)
+	default:
+		return "Saved to " + d.Path
+	}
+}
+
+// healthStatus describes the health of a feed.
+func healthStatus(h cache.Health, fetched bool, now time.Time) string {
+	if !fetched {
+		return "Not fetched yet"
+	}
+
+	parts := []string{"Fetched " + h.LastFetch.Format("2006-01-02 15:04")}
+	switch {
+	case h.Failed():
+		parts = append(parts, "Error: "+h.Err)
+	case h.MovedTo != "":
+		parts = append(parts, "Moved to "+h.MovedTo)
+	case h.Status != 0:
+		parts = append(parts, fmt.Sprintf("HTTP %d", h.Status))
+	}
+
+	if h.Repaired != "" && !h.Failed() {
+		parts = append(parts, "Repaired invalid XML ("+h.Repaired+")")
+	}
+
+	parts = append(parts, fmt.Sprintf("%v on average", h.AverageLatency().Round(time.Millisecond)))
+	if h.LastPost.IsZero() {
+		parts = append(parts, "no posts")
+	} else {
+		parts = append(parts, fmt.Sprintf("last post %d days ago", int(now.Sub(h.LastPost).Hours()/24)))
+	}
+
+	if h.PostInterval > 0 {
+		parts = append(parts, "posts every "+roughDuration(h.PostInterval))
 	}
+
+	return strings.Join(parts, " · ")
+}
+
+// roughDuration returns the duration in the largest unit which fits, e.g. "3 days" or "1 hour".
+func roughDuration(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour:
+		return plural(int(d/(24*time.Hour)), "day")
+	case d >= time.Hour:
+		return plural(int(d/time.Hour), "hour")
+	default:
+		return plural(int(d/time.Minute), "minute")
+	}
+}
+
+// formatSize returns the size in a human readable form.
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
 }
 
 // betterDesc returns a styled item description.
@@ -215,5 +1114,5 @@ func betterDesc(rawDesc string) string {
 		desc = text
 	}
 
-	return desc
+	return rss.StripControl(desc)
 }
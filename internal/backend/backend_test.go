@@ -1,14 +1,27 @@
 package backend
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/TypicalAM/goread/internal/backend/cache"
+	"github.com/TypicalAM/goread/internal/backend/remote"
 	"github.com/TypicalAM/goread/internal/backend/rss"
+	"github.com/TypicalAM/goread/internal/config"
+	"github.com/TypicalAM/goread/internal/ui/simplelist"
+	"github.com/mmcdole/gofeed"
 )
 
 // getBackend creates a fake backend
 func getBackend() (*Backend, error) {
-	b, err := New("../test/data/urls.yml", "", false)
+	b, err := New(&config.Default, "../test/data/urls.yml", "", false)
 	if err != nil {
 		return nil, err
 	}
@@ -41,8 +54,13 @@ func TestBackendGetCategories(t *testing.T) {
 	// Try to fetch the categories
 	result := b.FetchCategories("")()
 	if msg, ok := result.(FetchSuccessMsg); ok {
-		if len(msg.Items) != 2 {
-			t.Errorf("expected 2 items, got %d", len(msg.Items))
+		// The archive and the trash follow the aggregated feed
+		if len(msg.Items) != 5 {
+			t.Errorf("expected 5 items, got %d", len(msg.Items))
+		}
+
+		if msg.Items[0].FilterValue() != rss.AllFeedsName {
+			t.Errorf("expected the first item to be %s, got %s", rss.AllFeedsName, msg.Items[0].FilterValue())
 		}
 	} else {
 		t.Errorf("expected FetchSuccessMessage, got %T", msg)
@@ -130,3 +148,853 @@ func TestBackendGetArticles(t *testing.T) {
 		t.Errorf("expected FetchErrorMessage, got %T", msg)
 	}
 }
+
+// TestBackendRules if we get an error then the filter rules aren't applied correctly
+func TestBackendRules(t *testing.T) {
+	cfg := config.Default
+	cfg.Rules = []config.Rule{
+		{Title: "(?i)sponsored", Action: "hide"},
+		{Feed: "Primordial soup", Author: "Hoppe", Action: "read"},
+		{Category: "News", Title: "Strauss", Action: "highlight"},
+		{Category: "Technology", Title: "Strauss", Action: "hide"},
+		{Title: "Aristotle", Action: "star"},
+	}
+
+	b, err := New(&cfg, "../test/data/urls.yml", t.TempDir(), false)
+	if err != nil {
+		t.Fatalf("couldn't create the backend: %v", err)
+	}
+
+	articles := cache.SortableArticles{
+		{Title: "[Sponsored] Buy this"},
+		{Title: "Monarchy & Democracy", Authors: []*gofeed.Person{{Name: "Hans Hermann Hoppe"}}},
+		{Title: "Natural Rights - Leo Strauss"},
+		{Title: "Outline of Aristotle's Political Philosophy", Link: "https://example.com/aristotle"},
+	}
+
+	result := b.applyRules("https://primordialsoup.info/feed", articles)
+	if len(result) != 3 {
+		t.Fatalf("expected the sponsored article to be hidden, got %d articles", len(result))
+	}
+
+	if !b.ReadStatus.IsRead(result[0]) {
+		t.Error("expected the article by Hoppe to be marked as read")
+	}
+
+	if result[1].Custom[highlightKey] != "true" || articles[2].Custom != nil {
+		t.Error("expected only the returned article to be highlighted")
+	}
+
+	if len(b.Cache.Downloaded) != 1 || b.Cache.Downloaded[0].Link != "https://example.com/aristotle" {
+		t.Errorf("expected the article about Aristotle to be saved, got %d saved", len(b.Cache.Downloaded))
+	}
+
+	b.applyRules("https://primordialsoup.info/feed", articles)
+	if len(b.Cache.Downloaded) != 1 {
+		t.Errorf("expected the article to be saved once, got %d saved", len(b.Cache.Downloaded))
+	}
+
+	if result := b.applyRules("https://christitus.com/categories/virtualization/index.xml", articles[2:3]); len(result) != 0 {
+		t.Error("expected the category rule to hide the article")
+	}
+
+	for _, rules := range [][]config.Rule{{{Action: "delete"}}, {{Title: "(", Action: "hide"}}} {
+		cfg.Rules = rules
+		if _, err = New(&cfg, "../test/data/urls.yml", t.TempDir(), false); err == nil {
+			t.Errorf("expected an error for the rules %+v", rules)
+		}
+	}
+}
+
+// TestBackendAlerts if we get an error then the articles mentioning the watch keywords aren't found
+func TestBackendAlerts(t *testing.T) {
+	cfg := config.Default
+	cfg.Alerts = config.Alerts{Keywords: []string{"CVE", "Wrocław", "go"}}
+	b, err := New(&cfg, "../test/data/urls.yml", t.TempDir(), false)
+	if err != nil {
+		t.Fatalf("couldn't create the backend: %v", err)
+	}
+
+	articles := cache.SortableArticles{
+		{Title: "Patch now", Description: "<p>Details of cve-2024-1234</p>", Link: "https://example.com/cve"},
+		{Title: "Meetup in Wrocław", Link: "https://example.com/meetup"},
+		{Title: "Google announces things", Link: "https://example.com/google"},
+	}
+
+	result := b.applyRules("https://primordialsoup.info/feed", articles)
+	for i, keyword := range []string{"CVE", "Wrocław"} {
+		if result[i].Custom[alertKey] != keyword || result[i].Custom[highlightKey] != "true" {
+			t.Errorf("expected %q to be alerted about %s, got %v", result[i].Title, keyword, result[i].Custom)
+		}
+	}
+
+	if _, ok := result[2].Custom[alertKey]; ok {
+		t.Error("expected the keyword not to match a part of a word")
+	}
+
+	if len(b.Cache.Downloaded) != 2 {
+		t.Errorf("expected the alerted articles to be saved, got %d saved", len(b.Cache.Downloaded))
+	}
+
+	cfg.Alerts.Keywords = []string{" "}
+	if _, err = New(&cfg, "../test/data/urls.yml", t.TempDir(), false); err == nil {
+		t.Error("expected an error with an empty keyword")
+	}
+}
+
+// TestBackendDigest if we get an error then the digest doesn't list the new articles or isn't sent
+func TestBackendDigest(t *testing.T) {
+	cfg := config.Default
+	cfg.Digest = config.Digest{Enabled: true, Hour: 7, Top: 1, Email: "me@example.com"}
+	cfg.Digest.Sendmail = "cat > " + filepath.Join(t.TempDir(), "mail")
+	b, err := New(&cfg, "../test/data/urls.yml", "../test/data", false)
+	if err != nil {
+		t.Fatalf("couldn't create the backend: %v", err)
+	}
+
+	b.Cache.OfflineMode = true
+	since := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	digest := b.makeDigest(since, time.Now())
+	if !strings.Contains(digest.Content, "<h2>News (") || !strings.Contains(digest.Content, "more</li>") {
+		t.Errorf("expected the new articles of the category to be listed, got %q", digest.Content)
+	}
+
+	if strings.Count(digest.Content, "<a href=") != strings.Count(digest.Content, "<h2>") {
+		t.Errorf("expected only the top title of every category, got %q", digest.Content)
+	}
+
+	b.Digests = nil
+	if b.UpdateDigest(time.Now()) {
+		t.Error("expected no digest when they are disabled")
+	}
+
+	if b.Digests, err = cache.NewDigests(t.TempDir()); err != nil {
+		t.Fatalf("couldn't create the digests: %v", err)
+	}
+
+	morning := time.Date(2030, 5, 6, 8, 0, 0, 0, time.Local)
+	made := make(chan bool, 4)
+	for i := 0; i < cap(made); i++ {
+		go func() { made <- b.UpdateDigest(morning) }()
+	}
+
+	var count int
+	for i := 0; i < cap(made); i++ {
+		if <-made {
+			count++
+		}
+	}
+
+	if count != 1 || b.UpdateDigest(morning.Add(time.Hour)) {
+		t.Errorf("expected a single digest in the morning, got %d", count)
+	}
+
+	mail, err := os.ReadFile(strings.TrimPrefix(cfg.Digest.Sendmail, "cat > "))
+	if err != nil || !strings.Contains(string(mail), "To: me@example.com\r\nSubject: Digest of Monday, 6 May 2030") {
+		t.Errorf("expected the digest to be mailed, got %q (%v)", mail, err)
+	}
+}
+
+// TestBackendParseSnooze if we get an error then the articles are snoozed until the wrong time
+func TestBackendParseSnooze(t *testing.T) {
+	// It's a Wednesday afternoon
+	now := time.Date(2023, 3, 15, 14, 30, 0, 0, time.UTC)
+	expected := map[string]time.Time{
+		"tonight":          time.Date(2023, 3, 15, 20, 0, 0, 0, time.UTC),
+		"Tomorrow":         time.Date(2023, 3, 16, 8, 0, 0, 0, time.UTC),
+		"next week":        time.Date(2023, 3, 20, 8, 0, 0, 0, time.UTC),
+		"90m":              now.Add(90 * time.Minute),
+		"2d":               time.Date(2023, 3, 17, 14, 30, 0, 0, time.UTC),
+		"1w":               time.Date(2023, 3, 22, 14, 30, 0, 0, time.UTC),
+		"2023-04-01":       time.Date(2023, 4, 1, 8, 0, 0, 0, time.UTC),
+		"2023-04-01 18:45": time.Date(2023, 4, 1, 18, 45, 0, 0, time.UTC),
+		"9:15":             time.Date(2023, 3, 16, 9, 15, 0, 0, time.UTC),
+	}
+
+	for when, want := range expected {
+		if until, err := ParseSnooze(when, now); err != nil || !until.Equal(want) {
+			t.Errorf("expected %q to be %v, got %v (%v)", when, want, until, err)
+		}
+	}
+
+	for _, when := range []string{"", "someday", "-3h", "2023-03-01"} {
+		if _, err := ParseSnooze(when, now); err == nil {
+			t.Errorf("expected an error for %q", when)
+		}
+	}
+
+	// Late in the evening tonight is already tomorrow
+	late := time.Date(2023, 3, 19, 22, 0, 0, 0, time.UTC)
+	if until, _ := ParseSnooze("tonight", late); !until.Equal(time.Date(2023, 3, 20, 20, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected tonight to be the next evening, got %v", until)
+	}
+
+	if until, _ := ParseSnooze("next week", late); !until.Equal(time.Date(2023, 3, 27, 8, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected next week to skip the Monday tomorrow, got %v", until)
+	}
+}
+
+// TestBackendSnooze if we get an error then the snoozed articles don't come back unread
+func TestBackendSnooze(t *testing.T) {
+	b, err := New(&config.Default, "../test/data/urls.yml", "../test/data", false)
+	if err != nil {
+		t.Fatalf("couldn't create the backend: %v", err)
+	}
+
+	b.Cache.OfflineMode = true
+	if b.Snoozes, err = cache.NewSnoozes(t.TempDir()); err != nil {
+		t.Fatalf("couldn't create the snoozes: %v", err)
+	}
+
+	item, err := b.indexToItem("Primordial soup", 0)
+	if err != nil {
+		t.Fatalf("couldn't get the article: %v", err)
+	}
+
+	defer b.ReadStatus.MarkAsUnread(*item)
+	until := time.Now().Add(time.Hour)
+	msg, ok := b.SnoozeArticle("Primordial soup", 0, until)().(ArticleSnoozedMsg)
+	if !ok || !msg.Until.Equal(until) || !b.ReadStatus.IsRead(*item) {
+		t.Fatalf("expected the article to be snoozed and read, got %+v", msg)
+	}
+
+	fetched := b.articlesToSuccessMsg("", cache.SortableArticles{*item}).Items[0].(ArticleItem)
+	if !fetched.IsSnoozed() {
+		t.Error("expected the article to be hidden")
+	}
+
+	b.wakeSnoozed(until)
+	if b.ReadStatus.IsRead(*item) || !b.Snoozes.Until(*item).IsZero() {
+		t.Error("expected the article to come back unread")
+	}
+}
+
+// TestBackendArchive if we get an error then the articles can't be put away and restored from their views
+func TestBackendArchive(t *testing.T) {
+	b, err := New(&config.Default, "../test/data/urls.yml", "../test/data", false)
+	if err != nil {
+		t.Fatalf("couldn't create the backend: %v", err)
+	}
+
+	b.Cache.OfflineMode = true
+	if b.Archive, err = cache.NewArchive(t.TempDir()); err != nil {
+		t.Fatalf("couldn't create the archive: %v", err)
+	}
+
+	item, err := b.indexToItem("Primordial soup", 0)
+	if err != nil {
+		t.Fatalf("couldn't get the article: %v", err)
+	}
+
+	defer b.ReadStatus.MarkAsUnread(*item)
+	msg, ok := b.ArchiveArticle("Primordial soup", 0, cache.Trashed)().(ArticleArchivedMsg)
+	if !ok || msg.State != cache.Trashed || !b.ReadStatus.IsRead(*item) {
+		t.Fatalf("expected the article to be trashed and read, got %+v", msg)
+	}
+
+	fetched := b.articlesToSuccessMsg("", cache.SortableArticles{*item}).Items[0].(ArticleItem)
+	if !fetched.IsPutAway() {
+		t.Error("expected the article to be hidden from its feed")
+	}
+
+	trash, err := b.Articles(rss.TrashName)
+	if err != nil || len(trash) != 1 || trash[0].Title != item.Title {
+		t.Fatalf("expected the article in the trash, got %d articles", len(trash))
+	}
+
+	// The article is restored from the trash using the index in the trash view
+	b.ArchiveArticle(rss.TrashName, 0, cache.Kept)()
+	if b.Archive.State(*item) != cache.Kept || !b.ReadStatus.IsRead(*item) {
+		t.Error("expected the article to be restored without changing its read status")
+	}
+
+	if trash, _ = b.Articles(rss.TrashName); len(trash) != 0 {
+		t.Errorf("expected the trash to be empty, got %d articles", len(trash))
+	}
+}
+
+// TestBackendFavicons if we get an error then the articles don't know the feeds whose icons are shown
+func TestBackendFavicons(t *testing.T) {
+	b, err := New(&config.Default, "../test/data/urls.yml", "../test/data", false)
+	if err != nil {
+		t.Fatalf("couldn't create the backend: %v", err)
+	}
+
+	b.Cache.OfflineMode = true
+	b.favicons = true
+	msg, ok := b.FetchArticles("Primordial soup", false)().(FetchArticleSuccessMsg)
+	if !ok || len(msg.Items) == 0 {
+		t.Fatalf("expected the articles, got %T", msg)
+	}
+
+	if msg.FeedName != "Primordial soup" {
+		t.Errorf("expected the message to go to the tab of the feed, got %q", msg.FeedName)
+	}
+
+	if feed := msg.Items[0].(ArticleItem).Feed(); feed != "Primordial soup" {
+		t.Errorf("expected the article to know its feed, got %q", feed)
+	}
+
+	// The link rules of a feed apply to its articles in every tab, so they need to know it too
+	oldLinks := rss.Links
+	defer func() { rss.Links = oldLinks }()
+	rss.Links = config.Links{Rules: []config.LinkRule{{Feed: "Primordial soup", StripParams: []string{"ref"}}}}
+	b.favicons = false
+	msg, ok = b.FetchArticles("Primordial soup", false)().(FetchArticleSuccessMsg)
+	if !ok || len(msg.Items) == 0 || msg.Items[0].(ArticleItem).Feed() != "Primordial soup" {
+		t.Error("expected the articles to know their feed for the link rules")
+	}
+
+	if b.Images, err = cache.NewImageStore(t.TempDir()); err != nil {
+		t.Fatalf("couldn't create the image store: %v", err)
+	}
+
+	// Nothing is fetched in the offline mode, only the stored icons are used
+	if loaded := b.FetchFavicon("Primordial soup")().(FaviconLoadedMsg); loaded.Err == nil {
+		t.Error("expected no icon in the offline mode")
+	}
+}
+
+// TestBackendQueryFeed if we get an error then the query feeds don't aggregate the matching articles
+func TestBackendQueryFeed(t *testing.T) {
+	b, err := New(&config.Default, "../test/data/urls.yml", "../test/data", false)
+	if err != nil {
+		t.Fatalf("couldn't create the backend: %v", err)
+	}
+
+	b.Cache.OfflineMode = true
+	if err = b.Rss.AddFeed("News", "Strauss", `query: title # "leo strauss" and unread = yes`); err != nil {
+		t.Fatalf("couldn't add the query feed: %v", err)
+	}
+
+	msg, ok := b.FetchArticles("Strauss", false)().(FetchArticleSuccessMsg)
+	if !ok {
+		t.Fatalf("expected FetchArticleSuccessMsg, got %T", msg)
+	}
+
+	if len(msg.Items) != 2 {
+		t.Fatalf("expected 2 articles, got %d", len(msg.Items))
+	}
+
+	// The indices should refer to the shown articles even after they stop matching the query
+	first := msg.Items[0].FilterValue()
+	b.MarkAsRead("Strauss", 0)()
+	item, err := b.indexToItem("Strauss", 0)
+	if err != nil || item.Title != first {
+		t.Errorf("expected the index to refer to %q, got %v", first, err)
+	}
+
+	b.ReadStatus.MarkAsUnread(*item)
+}
+
+// TestBackendSavedSearch if we get an error then the saved searches don't find the same articles as the search
+func TestBackendSavedSearch(t *testing.T) {
+	b, err := New(&config.Default, "../test/data/urls.yml", "../test/data", false)
+	if err != nil {
+		t.Fatalf("couldn't create the backend: %v", err)
+	}
+
+	b.Cache.OfflineMode = true
+	if err = b.Rss.SaveSearch("Strauss", "Leo strauss", false); err != nil {
+		t.Fatalf("couldn't save the search: %v", err)
+	}
+
+	msg, ok := b.FetchArticles("Strauss", false)().(FetchArticleSuccessMsg)
+	if !ok {
+		t.Fatalf("expected FetchArticleSuccessMsg, got %T", msg)
+	}
+
+	if len(msg.Items) == 0 {
+		t.Fatal("expected the saved search to find the articles")
+	}
+
+	for i := range msg.Items {
+		item, err := b.indexToItem("Strauss", i)
+		if err != nil {
+			t.Fatalf("couldn't get the article: %v", err)
+		}
+
+		if text := cache.SearchableText(*item); !strings.Contains(text, "leo") || !strings.Contains(text, "strauss") {
+			t.Errorf("expected %q to contain all the words", item.Title)
+		}
+	}
+}
+
+// TestBackendMarkAllAsRead if we get an error then the bulk operations don't mark the right articles as read
+func TestBackendMarkAllAsRead(t *testing.T) {
+	b, err := New(&config.Default, "../test/data/urls.yml", "../test/data", false)
+	if err != nil {
+		t.Fatalf("couldn't create the backend: %v", err)
+	}
+
+	b.Cache.OfflineMode = true
+	articles, ok := b.Cache.GetCachedArticles("https://primordialsoup.info/feed")
+	if !ok {
+		t.Fatal("expected the cached articles")
+	}
+
+	cutoff := time.Date(2022, 9, 1, 0, 0, 0, 0, time.UTC)
+	var old int
+	for _, item := range articles {
+		if item.PublishedParsed != nil && item.PublishedParsed.Before(cutoff) {
+			old++
+		}
+	}
+
+	if old == 0 || old == len(articles) {
+		t.Fatalf("expected the test data to contain both old and new articles, got %d old", old)
+	}
+
+	defer func() {
+		for _, item := range articles {
+			b.ReadStatus.MarkAsUnread(item)
+		}
+	}()
+
+	days := int(time.Since(cutoff).Hours() / 24)
+	if msg, ok := b.MarkOlderAsRead(days)().(MarkedAllAsReadMsg); !ok || msg.Count != old {
+		t.Fatalf("expected %d old articles to be marked as read, got %v", old, msg)
+	}
+
+	if msg, ok := b.MarkCategoryAsRead("News")().(MarkedAllAsReadMsg); !ok || msg.Count != len(articles)-old {
+		t.Fatalf("expected %d articles to be marked as read, got %v", len(articles)-old, msg)
+	}
+
+	if unread := b.ReadStatus.CountUnread(articles); unread != 0 {
+		t.Fatalf("expected all the articles to be read, got %d unread", unread)
+	}
+
+	if msg, ok := b.MarkAllAsRead("Primordial soup")().(MarkedAllAsReadMsg); !ok || msg.Count != 0 {
+		t.Fatalf("expected no more articles to be marked as read, got %v", msg)
+	}
+}
+
+// countingRemote is a sync service which counts the read status changes sent to it
+type countingRemote struct {
+	remote.Service
+	changes []bool
+}
+
+// SetRead records the change
+func (r *countingRemote) SetRead(_ gofeed.Item, read bool) error {
+	r.changes = append(r.changes, read)
+	return nil
+}
+
+// TestBackendMarkAsRead if we get an error then the articles which are already read are sent to the server again
+func TestBackendMarkAsRead(t *testing.T) {
+	b, err := New(&config.Default, "../test/data/urls.yml", "../test/data", false)
+	if err != nil {
+		t.Fatalf("couldn't create the backend: %v", err)
+	}
+
+	b.Cache.OfflineMode = true
+	service := &countingRemote{}
+	b.Remote = service
+	item, err := b.indexToItem("Primordial soup", 0)
+	if err != nil {
+		t.Fatalf("couldn't get the article: %v", err)
+	}
+	defer b.ReadStatus.MarkAsUnread(*item)
+
+	for i := 0; i < 3; i++ {
+		b.MarkAsRead("Primordial soup", 0)()
+	}
+
+	for i := 0; i < 2; i++ {
+		b.MarkAsUnread("Primordial soup", 0)()
+	}
+
+	if !reflect.DeepEqual(service.changes, []bool{true, false}) {
+		t.Errorf("expected only the changes to be synced, got %v", service.changes)
+	}
+}
+
+// TestBackendRemoveDownloaded if we get an error then removing several saved articles removes the wrong ones
+func TestBackendRemoveDownloaded(t *testing.T) {
+	b, err := New(&config.Default, "../test/data/urls.yml", "../test/data", false)
+	if err != nil {
+		t.Fatalf("couldn't create the backend: %v", err)
+	}
+
+	b.Cache.Downloaded = nil
+	for i := 0; i < 4; i++ {
+		published := time.Date(2023, 1, i+1, 0, 0, 0, 0, time.UTC)
+		b.Cache.AddToDownloaded(gofeed.Item{Title: fmt.Sprint(i), PublishedParsed: &published})
+	}
+
+	if msg := b.RemoveDownloaded(0, 2, 2)(); msg != nil {
+		t.Fatalf("expected no error, got %v", msg)
+	}
+
+	downloaded := b.Cache.GetDownloaded()
+	if len(downloaded) != 2 || downloaded[0].Title != "1" || downloaded[1].Title != "3" {
+		t.Fatalf("expected articles 1 and 3 to be left, got %v", downloaded)
+	}
+
+	if _, ok := b.RemoveDownloaded(1, 5)().(FetchErrorMsg); !ok {
+		t.Fatal("expected an error for an index out of range")
+	}
+
+	if len(b.Cache.GetDownloaded()) != 2 {
+		t.Fatal("expected nothing to be removed when an index is out of range")
+	}
+}
+
+// TestBackendAnnotations if we get an error then the tags and the notes of the articles are not shown
+func TestBackendAnnotations(t *testing.T) {
+	b, err := New(&config.Default, "../test/data/urls.yml", t.TempDir(), false)
+	if err != nil {
+		t.Fatalf("couldn't create the backend: %v", err)
+	}
+
+	published := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	b.Cache.AddToDownloaded(gofeed.Item{Title: "Saved", Link: "https://example.com/saved", PublishedParsed: &published})
+
+	msg, ok := b.SetAnnotation(rss.DownloadedFeedsName, 0, []string{"research"}, " Worth citing ")().(ArticleAnnotatedMsg)
+	if !ok {
+		t.Fatalf("expected the article to be annotated, got %v", msg)
+	}
+
+	if !strings.Contains(msg.Content, "**Tags:** research") || !strings.Contains(msg.Content, "**Note:** Worth citing") {
+		t.Fatalf("expected the tags and the note in the article, got %q", msg.Content)
+	}
+
+	if annotation, err := b.Annotation(rss.DownloadedFeedsName, 0); err != nil || annotation.Note != "Worth citing" {
+		t.Fatalf("expected the note of the article, got %v (%v)", annotation, err)
+	}
+
+	if tags := b.Tags(); len(tags) != 1 || tags[0] != "research" {
+		t.Fatalf("expected the research tag, got %v", tags)
+	}
+
+	tagged, err := b.Articles(rss.TagPrefix + "research")
+	if err != nil || len(tagged) != 1 || tagged[0].Title != "Saved" {
+		t.Fatalf("expected the tagged article, got %v (%v)", tagged, err)
+	}
+
+	if counts := b.unreadCounts(); counts.Tags["research"] != 1 {
+		t.Fatalf("expected the tagged article to be unread, got %v", counts.Tags)
+	}
+
+	if _, ok := b.SetAnnotation(rss.DownloadedFeedsName, 0, []string{"two words"}, "")().(FetchErrorMsg); !ok {
+		t.Fatal("expected an error for a tag with whitespace")
+	}
+
+	if _, ok := b.SetAnnotation(rss.DownloadedFeedsName, 5, nil, "")().(FetchErrorMsg); !ok {
+		t.Fatal("expected an error for an index out of range")
+	}
+
+	// The tags of the feeds and of the articles overlap, every one of them is listed once
+	if err = b.Rss.SetTags("Primordial soup", []string{"research"}); err != nil {
+		t.Fatalf("couldn't tag the feed: %v", err)
+	}
+
+	if _, ok := b.SetAnnotation(rss.DownloadedFeedsName, 0, []string{"research", "alpha"}, "")().(ArticleAnnotatedMsg); !ok {
+		t.Fatal("expected the article to be annotated")
+	}
+
+	if tags := b.Tags(); !reflect.DeepEqual(tags, []string{"alpha", "research"}) {
+		t.Errorf("expected every tag once, got %v", tags)
+	}
+}
+
+// TestBackendDedup if we get an error then the duplicate articles aren't collapsed in the aggregated views
+func TestBackendDedup(t *testing.T) {
+	cfg := config.Default
+	cfg.Backend.Dedup = "title"
+	b, err := New(&cfg, "../test/data/urls.yml", t.TempDir(), false)
+	if err != nil {
+		t.Fatalf("couldn't create the backend: %v", err)
+	}
+
+	soup := cache.SortableArticles{
+		{Title: "Rust in the kernel", Link: "https://www.example.com/rust/?utm_source=soup"},
+		{Title: "Apple releases the new iPhone today", Link: "https://soup.example/iphone"},
+		{Title: "News", Link: "https://soup.example/news"},
+	}
+
+	ars := cache.SortableArticles{
+		{Title: "Linux gets Rust support", Link: "http://example.com/rust#comments"},
+		{Title: "Apple releases new iPhone today!", Link: "https://ars.example/iphone"},
+		{Title: "News", Link: "https://ars.example/news"},
+		{Title: "Weekly news", Link: "https://ars.example/weekly"},
+	}
+
+	b.Cache.Content["https://primordialsoup.info/feed"] = cache.Entry{Articles: soup}
+	b.Cache.Content["http://feeds.arstechnica.com/arstechnica/technology-lab"] = cache.Entry{Articles: ars}
+
+	result := b.deduplicate(append(append(cache.SortableArticles{}, soup...), ars...))
+	if len(result) != 4 {
+		t.Fatalf("expected 4 articles after collapsing the duplicates, got %d", len(result))
+	}
+
+	for i := 0; i < 3; i++ {
+		if alsoIn(result[i]) != "Ars Technica" {
+			t.Errorf("expected %q to be also in Ars Technica, got %q", result[i].Title, alsoIn(result[i]))
+		}
+	}
+
+	if alsoIn(result[3]) != "" || soup[0].Custom != nil {
+		t.Error("expected only the collapsed articles to be annotated")
+	}
+
+	if content := b.annotatedContent(&result[0]); !strings.Contains(content, "**Also in:** Ars Technica") {
+		t.Errorf("expected the article to mention the other feed, got %q", content)
+	}
+
+	b.dedup = DedupURL
+	if result = b.deduplicate(append(append(cache.SortableArticles{}, soup...), ars...)); len(result) != 6 {
+		t.Errorf("expected only the same links to be collapsed, got %d articles", len(result))
+	}
+
+	cfg.Backend.Dedup = "fuzzy"
+	if _, err = New(&cfg, "../test/data/urls.yml", t.TempDir(), false); err == nil {
+		t.Error("expected an error for an unknown dedup mode")
+	}
+}
+
+// TestBackendCountUnread if we get an error then the unread articles are not counted
+func TestBackendCountUnread(t *testing.T) {
+	b, err := New(&config.Default, "../test/data/urls.yml", "../test/data", false)
+	if err != nil {
+		t.Fatalf("couldn't create the backend: %v", err)
+	}
+
+	articles, ok := b.Cache.GetStoredArticles("https://primordialsoup.info/feed")
+	if !ok || len(articles) == 0 {
+		t.Fatal("expected the cached articles")
+	}
+
+	msg, ok := b.CountUnread()().(UnreadCountMsg)
+	if !ok || msg.Total < len(articles) {
+		t.Fatalf("expected at least %d unread articles, got %v", len(articles), msg)
+	}
+
+	b.ReadStatus.MarkAsRead(articles[0])
+	defer b.ReadStatus.MarkAsUnread(articles[0])
+	if after, ok := b.CountUnread()().(UnreadCountMsg); !ok || after.Total != msg.Total-1 {
+		t.Fatalf("expected %d unread articles, got %v", msg.Total-1, after)
+	}
+
+	if after, _ := b.CountUnread()().(UnreadCountMsg); after.Feeds["Primordial soup"] != len(articles)-1 || after.Categories["News"] < len(articles)-1 {
+		t.Fatalf("expected %d unread articles in the feed and its category, got %v", len(articles)-1, after)
+	}
+
+	// The nested categories are counted in their parents, the shared feeds only once
+	before, _ := b.CountUnread()().(UnreadCountMsg)
+	if err = b.Rss.AddCategory("News/Soup", ""); err != nil {
+		t.Fatalf("couldn't add a nested category: %v", err)
+	}
+
+	if err = b.Rss.AddFeed("News/Soup", "Soup", "https://primordialsoup.info/feed"); err != nil {
+		t.Fatalf("couldn't add a feed to the nested category: %v", err)
+	}
+
+	if nested, _ := b.CountUnread()().(UnreadCountMsg); nested.Categories["News/Soup"] != len(articles)-1 || nested.Categories["News"] != before.Categories["News"] {
+		t.Fatalf("expected %d unread articles in the nested category and the parent unchanged, got %v", len(articles)-1, nested)
+	}
+
+	// The tagged feeds are counted once in every tag
+	if err = b.Rss.SetTags("Soup", []string{"soup"}); err != nil {
+		t.Fatalf("couldn't tag the feed: %v", err)
+	}
+
+	if err = b.Rss.SetTags("Primordial soup", []string{"soup"}); err != nil {
+		t.Fatalf("couldn't tag the feed: %v", err)
+	}
+
+	if tagged, _ := b.CountUnread()().(UnreadCountMsg); tagged.Tags["soup"] != len(articles)-1 {
+		t.Fatalf("expected %d unread articles in the tag, got %v", len(articles)-1, tagged)
+	}
+
+	if tags, ok := b.FetchTags("")().(FetchSuccessMsg); !ok || len(tags.Items) != 1 || tags.Items[0].FilterValue() != "soup" {
+		t.Fatalf("expected the soup tag, got %v", tags)
+	}
+
+	if tagArticles, err := b.Articles(rss.TagPrefix + "soup"); err != nil || len(tagArticles) != len(articles) {
+		t.Fatalf("expected %d articles in the tag, got %d (%v)", len(articles), len(tagArticles), err)
+	}
+
+	if err = b.Rss.RemoveCategory("News/Soup"); err != nil {
+		t.Fatalf("couldn't remove the nested category: %v", err)
+	}
+
+	if err = b.Rss.SetTags("Primordial soup", nil); err != nil {
+		t.Fatalf("couldn't remove the tags: %v", err)
+	}
+
+	if b.Source() != "local" {
+		t.Fatalf("expected the local source, got %q", b.Source())
+	}
+
+	loaded, err := LoadUnreadCounts("../test/data/urls.yml", "../test/data")
+	if err != nil {
+		t.Fatalf("couldn't load the unread counts: %v", err)
+	}
+
+	if loaded.Total != msg.Total || loaded.Feeds["Primordial soup"] != len(articles) {
+		t.Fatalf("expected the same counts without the backend, got %v", loaded)
+	}
+}
+
+// TestBackendFetchHealth if we get an error then the broken feeds aren't listed first
+func TestBackendFetchHealth(t *testing.T) {
+	b, err := New(&config.Default, "../test/data/urls.yml", "../test/data", false)
+	if err != nil {
+		t.Fatalf("couldn't create the backend: %v", err)
+	}
+
+	b.Cache.Health = map[string]cache.Health{
+		"https://primordialsoup.info/feed":                        {LastFetch: time.Now(), Status: 200, Fetches: 1, LastPost: time.Now()},
+		"http://feeds.arstechnica.com/arstechnica/technology-lab": {LastFetch: time.Now(), Status: 404, Err: "404 Not Found", Fetches: 1},
+	}
+
+	msg, ok := b.FetchHealth("")().(FetchSuccessMsg)
+	if !ok || len(msg.Items) == 0 {
+		t.Fatalf("expected the health of the feeds, got %v", msg)
+	}
+
+	first := msg.Items[0].(simplelist.Item)
+	if !strings.Contains(first.Description(), "404 Not Found") {
+		t.Fatalf("expected the broken feed to come first, got %q", first.Description())
+	}
+
+	last := msg.Items[len(msg.Items)-1].(simplelist.Item)
+	if last.Title() != "Primordial soup" || !strings.Contains(last.Description(), "HTTP 200") {
+		t.Fatalf("expected the healthy feed to come last, got %q: %q", last.Title(), last.Description())
+	}
+}
+
+// TestBackendExportArticle if we get an error then the articles aren't written to the files
+func TestBackendExportArticle(t *testing.T) {
+	b, err := New(&config.Default, "../test/data/urls.yml", "../test/data", false)
+	if err != nil {
+		t.Fatalf("couldn't create the backend: %v", err)
+	}
+
+	b.export = config.Export{Directory: t.TempDir(), Filename: "%f/%d-%t"}
+	published := time.Date(2023, 5, 4, 0, 0, 0, 0, time.UTC)
+	b.Cache.Downloaded = nil
+	b.Cache.AddToDownloaded(gofeed.Item{
+		Title:           "Hello, World! Ünïcode",
+		Link:            "https://example.com/hello",
+		Description:     "<p>Some <b>text</b></p>",
+		PublishedParsed: &published,
+	})
+
+	msg, ok := b.ExportArticle(rss.DownloadedFeedsName, 0, false)().(ArticleExportedMsg)
+	if !ok {
+		t.Fatal("expected the article to be exported as markdown")
+	}
+
+	if filepath.Base(msg.Path) != "saved-2023-05-04-hello-world-ünïcode.md" {
+		t.Errorf("incorrect file name, got %s", filepath.Base(msg.Path))
+	}
+
+	data, err := os.ReadFile(msg.Path)
+	if err != nil || !strings.Contains(string(data), "# Hello, World! Ünïcode") || !strings.Contains(string(data), "**text**") {
+		t.Errorf("incorrect markdown, got %s (%v)", data, err)
+	}
+
+	msg, ok = b.ExportArticle(rss.DownloadedFeedsName, 0, true)().(ArticleExportedMsg)
+	if !ok {
+		t.Fatal("expected the article to be exported as html")
+	}
+
+	data, err = os.ReadFile(msg.Path)
+	if err != nil || !strings.HasSuffix(msg.Path, ".html") || !strings.Contains(string(data), "<p>Some <b>text</b></p>") {
+		t.Errorf("incorrect html in %s, got %s (%v)", msg.Path, data, err)
+	}
+
+	if _, ok = b.ExportArticle(rss.DownloadedFeedsName, 3, false)().(FetchErrorMsg); !ok {
+		t.Error("expected an error for an index out of range")
+	}
+}
+
+func TestBackendComments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"children": [{"author": "alice", "text": "<p>Top</p>", "children": [
+			{"author": "bob", "text": "<p>Reply</p>", "children": [{"author": "carol", "text": "<p>Deep</p>", "children": []}]}
+		]}]}`)
+	}))
+	defer server.Close()
+
+	oldAPI := cache.HackerNewsAPI
+	cache.HackerNewsAPI = server.URL + "/"
+	defer func() { cache.HackerNewsAPI = oldAPI }()
+
+	b, err := New(&config.Default, "../test/data/urls.yml", "../test/data", false)
+	if err != nil {
+		t.Fatalf("couldn't create the backend: %v", err)
+	}
+
+	b.Cache.Downloaded = nil
+	b.Cache.AddToDownloaded(gofeed.Item{Title: "Plain", Link: "https://example.com/plain"})
+	b.Cache.AddToDownloaded(gofeed.Item{
+		Title:       "Story",
+		Link:        "https://example.com/story",
+		Description: `<a href="https://news.ycombinator.com/item?id=1">Comments</a>`,
+	})
+
+	if msg, ok := b.FetchComments(rss.DownloadedFeedsName, 0)().(FetchErrorMsg); !ok || msg.Err != ErrNoComments {
+		t.Fatal("expected an error for an article without comments")
+	}
+
+	msg, ok := b.FetchComments(rss.DownloadedFeedsName, 1)().(CommentsLoadedMsg)
+	if !ok || msg.Title != "Story" || len(msg.Comments) != 1 {
+		t.Fatalf("expected the comments of the story, got %+v", msg)
+	}
+
+	blocks := CommentBlocks(msg.Title, msg.Comments, 0)
+	if len(blocks) != 4 || blocks[0].Markdown != "# Comments on Story" || blocks[3].Level != 2 || !strings.HasSuffix(blocks[3].Markdown, "Deep") {
+		t.Errorf("expected the whole thread, got %+v", blocks)
+	}
+
+	blocks = CommentBlocks(msg.Title, msg.Comments, 1)
+	if len(blocks) != 3 || blocks[2].Level != 1 || blocks[2].Markdown != "*▸ 2 replies folded*" {
+		t.Errorf("expected the replies to be folded, got %+v", blocks)
+	}
+}
+
+// TestBackendStripControl if we get an error then the escape sequences of a feed reach the list
+func TestBackendStripControl(t *testing.T) {
+	b, err := New(&config.Default, "../test/data/urls.yml", t.TempDir(), false)
+	if err != nil {
+		t.Fatalf("couldn't create the backend: %v", err)
+	}
+
+	msg := b.articlesToSuccessMsg("feed", cache.SortableArticles{{
+		Title:       "Title\x1b[2J",
+		Description: "<p>Text\x1b]0;pwned\x07</p>",
+	}})
+
+	item := msg.Items[0].(ArticleItem)
+	if item.Title() != "Title[2J" || item.Description() != "Text]0;pwned" {
+		t.Errorf("expected the control characters to be removed, got %q and %q", item.Title(), item.Description())
+	}
+
+	blocks := CommentBlocks("Story", []cache.Comment{{Author: "eve\x1b[31m", Text: "<p>Hi</p>"}}, 0)
+	if strings.Contains(blocks[1].Markdown, "\x1b") {
+		t.Errorf("expected the control characters to be removed from the author, got %q", blocks[1].Markdown)
+	}
+}
+
+// TestBackendNewArticleHook if we get an error then a slow on_new_article hook holds up the refresh
+func TestBackendNewArticleHook(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "hook")
+	b := Backend{Rss: &rss.Rss{}, hooks: config.Hooks{OnNewArticle: "sleep 0.3; cat >> " + out}}
+	articles := cache.SortableArticles{{Title: "First"}, {Title: "Second"}}
+
+	start := time.Now()
+	b.onNewArticles("https://example.com/feed.xml", articles)
+	if elapsed := time.Since(start); elapsed > 200*time.Millisecond {
+		t.Errorf("expected the hooks to run in the background, waited %v", elapsed)
+	}
+
+	waitHooks()
+	data, err := os.ReadFile(out)
+	if err != nil || !strings.Contains(string(data), `"title":"First"`) || !strings.Contains(string(data), `"title":"Second"`) {
+		t.Errorf("expected the hook to get every article, got %q (%v)", data, err)
+	}
+}
@@ -0,0 +1,89 @@
+package cache
+
+import (
+	"sort"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// Annotation contains the tags and the note attached to an article. The article is kept along
+// with them, so it can be shown after it drops out of its feed
+type Annotation struct {
+	Tags    []string    `json:"tags,omitempty"`
+	Note    string      `json:"note,omitempty"`
+	Article gofeed.Item `json:"article"`
+	Updated time.Time   `json:"updated"`
+}
+
+// Annotations stores the tags and the notes attached to the articles
+type Annotations struct {
+	*jsonStore[map[uint32]Annotation]
+}
+
+// NewAnnotations creates a new annotation store.
+func NewAnnotations(dir string) (*Annotations, error) {
+	store, err := newJSONStore(dir, "annotations.json", "annotation", make(map[uint32]Annotation))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Annotations{store}, nil
+}
+
+// Get returns the annotation of an article, if it has one.
+func (a *Annotations) Get(item gofeed.Item) (Annotation, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	annotation, ok := a.data[hashArticle(item)]
+	return annotation, ok
+}
+
+// Set replaces the tags and the note of an article, the annotation is removed if both are empty.
+func (a *Annotations) Set(item gofeed.Item, tags []string, note string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if len(tags) == 0 && note == "" {
+		delete(a.data, hashArticle(item))
+		return
+	}
+
+	a.data[hashArticle(item)] = Annotation{Tags: tags, Note: note, Article: item, Updated: time.Now()}
+}
+
+// Tags returns the sorted list of the tags used by the articles.
+func (a *Annotations) Tags() []string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	seen := make(map[string]bool)
+	var tags []string
+	for _, annotation := range a.data {
+		for _, tag := range annotation.Tags {
+			if !seen[tag] {
+				seen[tag] = true
+				tags = append(tags, tag)
+			}
+		}
+	}
+
+	sort.Strings(tags)
+	return tags
+}
+
+// Tagged returns the sorted articles tagged with the tag.
+func (a *Annotations) Tagged(tag string) SortableArticles {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	var result SortableArticles
+	for _, annotation := range a.data {
+		for _, t := range annotation.Tags {
+			if t == tag {
+				result = append(result, annotation.Article)
+				break
+			}
+		}
+	}
+
+	result.Sort()
+	return result
+}
@@ -0,0 +1,111 @@
+package cache
+
+import (
+	"sort"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// TrashRetention is how long the articles stay in the trash, they can be restored until then
+var TrashRetention = 30 * 24 * time.Hour
+
+// ArchiveState is where an article was put away, the articles which weren't put away are Kept
+type ArchiveState int
+
+const (
+	// Kept means that the article is shown in its feed like usual
+	Kept ArchiveState = iota
+	// Archived means that the article is kept forever, but only shown in the archive
+	Archived
+	// Trashed means that the article is only shown in the trash, it's deleted after a while
+	Trashed
+	// Deleted means that the article was emptied from the trash, it's never shown again
+	Deleted
+)
+
+// ArchivedArticle is an article which was put away, a copy of it is kept so that it outlives its feed
+type ArchivedArticle struct {
+	State ArchiveState `json:"state"`
+	Since time.Time    `json:"since"`
+	Item  gofeed.Item  `json:"item"`
+}
+
+// Archive stores the archived, the trashed and the deleted articles
+type Archive struct {
+	*jsonStore[map[uint32]ArchivedArticle]
+}
+
+// NewArchive creates a new archive store.
+func NewArchive(dir string) (*Archive, error) {
+	store, err := newJSONStore(dir, "archive.json", "archive", make(map[uint32]ArchivedArticle))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Archive{store}, nil
+}
+
+// State returns where the article was put away
+func (a *Archive) State(item gofeed.Item) ArchiveState {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.data[hashArticle(item)].State
+}
+
+// Put puts the article away, it's restored if the state is Kept
+func (a *Archive) Put(item gofeed.Item, state ArchiveState, now time.Time) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if state == Kept {
+		delete(a.data, hashArticle(item))
+		return
+	}
+
+	a.data[hashArticle(item)] = ArchivedArticle{State: state, Since: now, Item: item}
+}
+
+// Articles returns the articles in the state, the ones which were put away last come first
+func (a *Archive) Articles(state ArchiveState) SortableArticles {
+	a.mu.RLock()
+	entries := make([]ArchivedArticle, 0, len(a.data))
+	for _, entry := range a.data {
+		if entry.State == state {
+			entries = append(entries, entry)
+		}
+	}
+	a.mu.RUnlock()
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if !entries[i].Since.Equal(entries[j].Since) {
+			return entries[i].Since.After(entries[j].Since)
+		}
+
+		return entries[i].Item.Title < entries[j].Item.Title
+	})
+
+	articles := make(SortableArticles, len(entries))
+	for i, entry := range entries {
+		articles[i] = entry.Item
+	}
+
+	return articles
+}
+
+// EmptyTrash deletes the articles which were trashed longer than the trash retention ago, only
+// the fields which identify them are kept so that they aren't shown again. It returns how many
+// articles were deleted
+func (a *Archive) EmptyTrash(now time.Time) int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	var deleted int
+	for hash, entry := range a.data {
+		if entry.State == Trashed && now.Sub(entry.Since) >= TrashRetention {
+			item := gofeed.Item{GUID: entry.Item.GUID, Title: entry.Item.Title, Link: entry.Item.Link}
+			a.data[hash] = ArchivedArticle{State: Deleted, Since: now, Item: item}
+			deleted++
+		}
+	}
+
+	return deleted
+}
@@ -1,7 +1,6 @@
 package cache
 
 import (
-	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -9,8 +8,11 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/TypicalAM/goread/internal/backend/rss"
 	"github.com/mmcdole/gofeed"
 )
 
@@ -20,6 +22,9 @@ var DefaultCacheDuration = 24 * time.Hour
 // DefaultCacheSize is the default size of the cache
 var DefaultCacheSize = 100
 
+// DefaultWorkers is the default amount of feeds fetched at the same time
+var DefaultWorkers = 8
+
 // SortableArticles is a sortable list of articles
 type SortableArticles []gofeed.Item
 
@@ -28,8 +33,13 @@ func (sa SortableArticles) Len() int {
 	return len(sa)
 }
 
-// Less returns true if the item at index i is less than the item at index j, needed for sorting
+// Less returns true if the item at index i is less than the item at index j, needed for sorting.
+// Items without a date are treated as the oldest ones
 func (sa SortableArticles) Less(a, b int) bool {
+	if sa[a].PublishedParsed == nil || sa[b].PublishedParsed == nil {
+		return sa[a].PublishedParsed == nil && sa[b].PublishedParsed != nil
+	}
+
 	return sa[a].PublishedParsed.Before(
 		*sa[b].PublishedParsed,
 	)
@@ -40,9 +50,55 @@ func (sa SortableArticles) Swap(a, b int) {
 	sa[a], sa[b] = sa[b], sa[a]
 }
 
+// Sort sorts the articles from the oldest to the newest. The tabs map their indexes back to the
+// articles by building the list again, so the articles with the same date are ordered by their guid
+// and link to get the same order every time
+func (sa SortableArticles) Sort() {
+	sort.SliceStable(sa, func(a, b int) bool { return sa.before(a, b) })
+}
+
+// SortNewestFirst sorts the articles from the newest to the oldest, in the same stable way as Sort
+func (sa SortableArticles) SortNewestFirst() {
+	sort.SliceStable(sa, func(a, b int) bool { return sa.before(b, a) })
+}
+
+// before reports if the article at index a comes before the one at index b, the date decides
+// first and the guid and the link break the ties
+func (sa SortableArticles) before(a, b int) bool {
+	if sa.Less(a, b) || sa.Less(b, a) {
+		return sa.Less(a, b)
+	}
+
+	if sa[a].GUID != sa[b].GUID {
+		return sa[a].GUID < sa[b].GUID
+	}
+
+	return sa[a].Link < sa[b].Link
+}
+
+// Source retrieves the articles of a feed, when no source is set the feed itself is fetched using
+// conditional requests
+type Source func(url string) (SortableArticles, error)
+
+// Filter processes the articles of a feed after they're fetched, before they're stored in the cache
+type Filter func(url string, articles SortableArticles) SortableArticles
+
+// NewArticles is called with the articles which appeared in a feed since it was last fetched
+type NewArticles func(url string, articles SortableArticles)
+
+// FilterCommands returns the command which the fetched feed is piped through before it's parsed,
+// it's empty if the feed is parsed as it is
+type FilterCommands func(url string) string
+
 // Cache handles the caching of feeds and storing downloaded articles
 type Cache struct {
-	Content     map[string]Entry `json:"content"`
+	Content     map[string]Entry  `json:"content"`
+	Health      map[string]Health `json:"health"`
+	mu          sync.Mutex
+	source      Source
+	filter      Filter
+	commands    FilterCommands
+	onNew       NewArticles
 	filePath    string
 	Downloaded  SortableArticles `json:"downloaded"`
 	OfflineMode bool             `json:"-"`
@@ -50,8 +106,16 @@ type Cache struct {
 
 // Entry is a cache entry
 type Entry struct {
-	Expire   time.Time        `json:"expire"`
-	Articles SortableArticles `json:"articles"`
+	Expire       time.Time        `json:"expire"`
+	ETag         string           `json:"etag,omitempty"`
+	LastModified string           `json:"last_modified,omitempty"`
+	Articles     SortableArticles `json:"articles"`
+
+	// Repaired lists the problems of the invalid xml of the feed which were repaired to parse it
+	Repaired string `json:"repaired,omitempty"`
+
+	// MovedTo is the url the feed permanently redirected to, it's kept in the health of the feed
+	MovedTo string `json:"-"`
 }
 
 // New creates a new cache store.
@@ -69,6 +133,7 @@ func New(dir string) (*Cache, error) {
 	return &Cache{
 		filePath:   filepath.Join(dir, "cache.json"),
 		Content:    make(map[string]Entry),
+		Health:     make(map[string]Health),
 		Downloaded: make(SortableArticles, 0),
 	}, nil
 }
@@ -95,9 +160,10 @@ func (c *Cache) Load() error {
 
 	log.Println("Loaded initial cache entries: ", len(c.Content))
 
-	// Iterate over the cache and remove any expired items
+	// Iterate over the cache and remove any expired items, unless they can be revalidated or the
+	// articles are kept after they drop out of their feeds
 	for key, value := range c.Content {
-		if value.Expire.Before(time.Now()) {
+		if value.Expire.Before(time.Now()) && value.ETag == "" && value.LastModified == "" && !Retention.Enabled() {
 			delete(c.Content, key)
 		}
 	}
@@ -108,7 +174,9 @@ func (c *Cache) Load() error {
 
 // Save writes the cache to disk
 func (c *Cache) Save() error {
+	c.mu.Lock()
 	cacheData, err := json.Marshal(c)
+	c.mu.Unlock()
 	if err != nil {
 		return err
 	}
@@ -127,30 +195,74 @@ func (c *Cache) Save() error {
 	return nil
 }
 
+// BulkProgress is called after each feed is fetched in a bulk fetch
+type BulkProgress func(url string, done int, err error)
+
+// bulkResult is the result of fetching a single feed in a bulk fetch
+type bulkResult struct {
+	err      error
+	index    int
+	articles SortableArticles
+}
+
 // GetArticles returns an article list using the cache if possible
 func (c *Cache) GetArticles(url string, ignoreCache bool) (SortableArticles, error) {
-	log.Println("Getting articles for", url, " from cache: ", !ignoreCache)
+	log.Println("Getting articles for", rss.StripCredentials(url), " from cache: ", !ignoreCache)
+	c.mu.Lock()
+
+	// Keep the previous entry around, its validators are used in the request
+	prev, ok := c.Content[url]
+	if ok && !ignoreCache && prev.Expire.After(time.Now()) {
+		c.mu.Unlock()
+		return prev.Articles, nil
+	}
+
+	c.mu.Unlock()
+	if c.OfflineMode {
+		return nil, fmt.Errorf("offline mode")
+	}
 
-	// Delete entry if expired
-	if item, ok := c.Content[url]; ok && !ignoreCache {
-		if item.Expire.After(time.Now()) {
-			return item.Articles, nil
+	var entry Entry
+	start := time.Now()
+	// The exec, the mailbox and the local feeds are private and the scrapers and the gemini client
+	// are local, they can't come from the sync service
+	if c.source != nil && !isExec(url) && !isMailbox(url) && !isFile(url) && !isScraped(url) && !isGemini(url) {
+		articles, err := c.source(url)
+		c.recordFetch(url, start, Entry{Articles: articles}, err)
+		if err != nil {
+			return nil, err
 		}
 
-		delete(c.Content, url)
+		entry = Entry{Expire: time.Now().Add(DefaultCacheDuration), Articles: articles}
+	} else {
+		var err error
+		entry, err = fetchEntry(url, c.filterCommand(url), prev, ignoreCache)
+		c.recordFetch(url, start, entry, err)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	if c.OfflineMode {
-		return nil, fmt.Errorf("offline mode")
+	if c.filter != nil {
+		entry.Articles = c.filter(url, entry.Articles)
 	}
 
-	articles, err := fetchArticles(url)
-	if err != nil {
-		return nil, err
+	if c.onNew != nil && ok {
+		if added := newArticles(prev.Articles, entry.Articles); len(added) > 0 {
+			c.onNew(url, added)
+		}
 	}
 
-	// Delete oldest item if cache is full
-	if len(c.Content) >= DefaultCacheSize {
+	if Retention.Enabled() {
+		entry.Articles = keepOld(prev.Articles, entry.Articles)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Delete oldest item if cache is full, the retention policies collect the unsubscribed feeds
+	// instead so that the kept articles of the other feeds aren't thrown away with them
+	if _, exists := c.Content[url]; !exists && len(c.Content) >= DefaultCacheSize && !Retention.Enabled() {
 		var oldestKey string
 		var oldestTime time.Time
 		for key, value := range c.Content {
@@ -163,40 +275,156 @@ func (c *Cache) GetArticles(url string, ignoreCache bool) (SortableArticles, err
 		delete(c.Content, oldestKey)
 	}
 
-	entry := Entry{
-		Expire:   time.Now().Add(DefaultCacheDuration),
-		Articles: articles,
-	}
-
 	c.Content[url] = entry
 	return entry.Articles, nil
 }
 
-// GetArticlesBulk returns a sorted list of articles from all the given urls, ignoring any errors
-func (c *Cache) GetArticlesBulk(urls []string, ignoreCache bool) SortableArticles {
-	var result SortableArticles
+// newArticles returns the fetched articles which weren't there before
+func newArticles(prev, fetched SortableArticles) SortableArticles {
+	seen := make(map[uint32]bool, len(prev))
+	for _, item := range prev {
+		seen[hashArticle(item)] = true
+	}
+
+	var added SortableArticles
+	for _, item := range fetched {
+		if !seen[hashArticle(item)] {
+			added = append(added, item)
+		}
+	}
+
+	return added
+}
+
+// SetSource changes where the articles are retrieved from, by default they are fetched from the feed itself
+func (c *Cache) SetSource(source Source) {
+	c.source = source
+}
+
+// SetNewArticles sets the function which is called with the new articles of the refreshed feeds, the
+// articles of the feeds fetched for the first time aren't new
+func (c *Cache) SetNewArticles(onNew NewArticles) {
+	c.onNew = onNew
+}
+
+// SetFilterCommands sets the lookup of the commands which process the fetched feeds
+func (c *Cache) SetFilterCommands(commands FilterCommands) {
+	c.commands = commands
+}
+
+// filterCommand returns the command which processes the feed, it's empty if there is none
+func (c *Cache) filterCommand(url string) string {
+	if c.commands == nil {
+		return ""
+	}
+
+	return c.commands(url)
+}
+
+// SetFilter sets the filter which processes the fetched articles
+func (c *Cache) SetFilter(filter Filter) {
+	c.filter = filter
+}
+
+// GetCachedArticles returns the cached articles of a feed without fetching them
+func (c *Cache) GetCachedArticles(url string) (SortableArticles, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	item, ok := c.Content[url]
+	if !ok || item.Expire.Before(time.Now()) {
+		return nil, false
+	}
+
+	return item.Articles, true
+}
+
+// GetStoredArticles returns the articles of a feed which are in the cache, even if they expired
+func (c *Cache) GetStoredArticles(url string) (SortableArticles, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	item, ok := c.Content[url]
+	return item.Articles, ok
+}
+
+// GetArticlesBulk returns a sorted list of articles from all the given urls, ignoring any errors. The feeds
+// are fetched by a pool of workers, the progress function (if not nil) is called after each feed is done
+func (c *Cache) GetArticlesBulk(urls []string, ignoreCache bool, progress BulkProgress) SortableArticles {
+	jobs := make(chan int)
+	results := make(chan bulkResult)
+
+	workers := DefaultWorkers
+	if workers < 1 {
+		workers = 1
+	}
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			for i := range jobs {
+				articles, err := c.GetArticles(urls[i], ignoreCache)
+				results <- bulkResult{err, i, articles}
+			}
+		}()
+	}
+
+	go func() {
+		for i := range urls {
+			jobs <- i
+		}
+
+		close(jobs)
+	}()
+
+	// The articles are put together in the order of the urls, not in the order the fetches finish
+	slots := make([]SortableArticles, len(urls))
+	for done := 1; done <= len(urls); done++ {
+		res := <-results
+		if res.err == nil {
+			slots[res.index] = res.articles
+		}
 
-	for _, url := range urls {
-		if items, err := c.GetArticles(url, ignoreCache); err == nil {
-			result = append(result, items...)
+		if progress != nil {
+			progress(urls[res.index], done, res.err)
 		}
 	}
 
-	sort.Sort(result)
+	var result SortableArticles
+	for _, articles := range slots {
+		result = append(result, articles...)
+	}
+
+	result.Sort()
 	return result
 }
 
 // GetDownloaded returns a list of downloaded items
 func (c *Cache) GetDownloaded() SortableArticles {
-	sort.Sort(c.Downloaded)
+	c.Downloaded.Sort()
 	return c.Downloaded
 }
 
 // AddToDownloaded adds an item to the downloaded list
 func (c *Cache) AddToDownloaded(item gofeed.Item) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.Downloaded = append(c.Downloaded, item)
 }
 
+// IsDownloaded reports if an item with the same link and title is in the downloaded list
+func (c *Cache) IsDownloaded(item gofeed.Item) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, saved := range c.Downloaded {
+		if saved.Link == item.Link && saved.Title == item.Title {
+			return true
+		}
+	}
+
+	return false
+}
+
 // RemoveFromDownloaded removes an item from the downloaded list
 func (c *Cache) RemoveFromDownloaded(index int) error {
 	if index < 0 || index >= len(c.Downloaded) {
@@ -207,60 +435,197 @@ func (c *Cache) RemoveFromDownloaded(index int) error {
 	return nil
 }
 
-// fetchArticles fetches articles from the internet and returns them
-func fetchArticles(url string) (SortableArticles, error) {
-	log.Println("Fetching articles from", url)
-	feed, err := parseFeed(url)
+// FetchArticles fetches articles from the internet and returns them
+func FetchArticles(url string) (SortableArticles, error) {
+	entry, err := fetchEntry(url, "", Entry{}, false)
 	if err != nil {
 		return nil, err
 	}
 
-	items := make(SortableArticles, len(feed.Items))
+	return entry.Articles, nil
+}
+
+// fetchEntry fetches a feed and returns a fresh cache entry. The validators of the previous entry
+// are sent with the request, if the feed didn't change the previous articles are reused. The feed is
+// piped through the filter command if it's given, with revalidate the stored http response is only
+// used if the server confirms it
+func fetchEntry(url, filterCommand string, prev Entry, revalidate bool) (Entry, error) {
+	log.Println("Fetching articles from", rss.StripCredentials(url))
+	var feed *gofeed.Feed
+	var header http.Header
+	var moved string
+	var err error
+	scraper, scraped := scraperFor(url)
+	switch {
+	case isExec(url):
+		feed, err = parseExec(url, filterCommand)
+	case isMailbox(url):
+		feed, err = parseMailbox(url)
+	case scraped:
+		feed, err = scrapePage(url, scraper)
+	case isGemini(url):
+		feed, err = parseGemini(url, filterCommand, prev.Articles)
+	case isFile(url):
+		feed, err = parseFile(url, filterCommand)
+	default:
+		feed, header, moved, err = parseFeed(url, filterCommand, prev.ETag, prev.LastModified, revalidate)
+	}
+
+	if err != nil {
+		return Entry{}, err
+	}
+
+	entry := Entry{
+		Expire:       time.Now().Add(DefaultCacheDuration),
+		ETag:         header.Get("ETag"),
+		LastModified: header.Get("Last-Modified"),
+		MovedTo:      moved,
+	}
+
+	if feed == nil {
+		log.Println("Feed not modified", rss.StripCredentials(url))
+		entry.Articles, entry.Repaired = prev.Articles, prev.Repaired
+		if entry.ETag == "" {
+			entry.ETag = prev.ETag
+		}
+
+		if entry.LastModified == "" {
+			entry.LastModified = prev.LastModified
+		}
+
+		return entry, nil
+	}
+
+	entry.Repaired = feed.Custom[repairedKey]
+	entry.Articles = make(SortableArticles, len(feed.Items))
 	for i, item := range feed.Items {
-		items[i] = *item
+		entry.Articles[i] = normalizeItem(*item)
+
+		// The authors of the feed are the authors of the items which don't specify them
+		if len(entry.Articles[i].Authors) == 0 {
+			entry.Articles[i].Authors = feed.Authors
+		}
 	}
 
-	return items, nil
+	return entry, nil
 }
 
-// parseFeed parses a url and attempts to return a parsed feed
+// maxUntitledLength is the length of the title made from the text of an article without one
+const maxUntitledLength = 80
+
+// normalizeItem fills in the fields which the feed formats name differently, atom entries for example
+// often carry only the content and the updated timestamp
+func normalizeItem(item gofeed.Item) gofeed.Item {
+	if item.Description == "" {
+		item.Description = item.Content
+	}
+
+	if item.PublishedParsed == nil {
+		item.PublishedParsed = item.UpdatedParsed
+	}
+
+	if item.Link == "" && len(item.Links) > 0 {
+		item.Link = item.Links[0]
+	}
+
+	// The posts of Mastodon and the other microblogs have no titles, the beginning of the text is used
+	if strings.TrimSpace(item.Title) == "" {
+		item.Title = untitledTitle(item.Description)
+	}
+
+	// The escape sequences in the titles could take over the terminal in the list of the articles
+	item.Title = rss.StripControl(item.Title)
+
+	return item
+}
+
+// untitledTitle returns the title of an article without one, it's the beginning of its text
+func untitledTitle(description string) string {
+	text, err := rss.HTMLToText(description)
+	if err != nil {
+		text = description
+	}
+
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return "Untitled"
+	}
+
+	title := words[0]
+	for _, word := range words[1:] {
+		if len([]rune(title))+1+len([]rune(word)) > maxUntitledLength {
+			return title + "…"
+		}
+
+		title += " " + word
+	}
+
+	return title
+}
+
+// parseFeed parses a url and attempts to return a parsed feed, the feed is nil if the server
+// reports that it wasn't modified since the etag or the last modification date. If the feed
+// permanently redirects, the url it moved to is returned as well
 // authors note: this is was because the gofeed parser did not support reddit
-func parseFeed(url string) (*gofeed.Feed, error) {
-	req, err := http.NewRequest("GET", url, nil)
+func parseFeed(url, filterCommand, etag, lastModified string, revalidate bool) (*gofeed.Feed, http.Header, string, error) {
+	req, err := newRequest(url)
 	if err != nil {
-		return nil, err
+		return nil, nil, "", err
+	}
+
+	if revalidate {
+		req.Header.Set("Cache-Control", "no-cache")
+	}
+
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
 	}
-	req.Header.Set("User-Agent", "goread (by /u/TypicalAM)")
 
-	client := http.Client{
-		Transport: &http.Transport{
-			Proxy:        http.ProxyFromEnvironment,
-			TLSNextProto: map[string]func(authority string, c *tls.Conn) http.RoundTripper{},
-		},
+	if lastModified != "" {
+		req.Header.Set("If-Modified-Since", lastModified)
+	}
+
+	// The feed moved only if all the redirects were permanent
+	moved, permanent := "", true
+	client := newPageClient(url)
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) >= maxRedirects {
+			return fmt.Errorf("stopped after %d redirects", maxRedirects)
+		}
+
+		status := req.Response.StatusCode
+		permanent = permanent && (status == http.StatusMovedPermanently || status == http.StatusPermanentRedirect)
+		moved = req.URL.String()
+		return nil
 	}
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, nil, "", err
+	}
+	defer resp.Body.Close()
+
+	if !permanent {
+		moved = ""
+	}
+
+	if resp.StatusCode == http.StatusNotModified && (etag != "" || lastModified != "") {
+		return nil, resp.Header, moved, nil
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, gofeed.HTTPError{
+		return nil, nil, "", gofeed.HTTPError{
 			StatusCode: resp.StatusCode,
 			Status:     resp.Status,
 		}
 	}
 
-	feed, err := gofeed.NewParser().Parse(resp.Body)
+	feed, err := parse(resp.Body, resp.Header.Get("Content-Type"), filterCommand)
 	if err != nil {
-		return nil, err
-	}
-
-	if err := resp.Body.Close(); err != nil {
-		return nil, err
+		return nil, nil, "", err
 	}
 
-	return feed, nil
+	return feed, resp.Header, moved, nil
 }
 
 // getDefaultDir returns the default cache directory
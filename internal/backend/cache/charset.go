@@ -0,0 +1,49 @@
+package cache
+
+import (
+	"log"
+	"mime"
+	"regexp"
+	"unicode/utf8"
+
+	"golang.org/x/net/html/charset"
+)
+
+// xmlEncoding matches the encoding in the xml declaration of a feed
+var xmlEncoding = regexp.MustCompile(`^(\s*<\?xml[^>]*?encoding\s*=\s*["'])([^"']*)(["'])`)
+
+// toUTF8 transcodes the feed to utf-8. The encoding is declared in the xml declaration or in the
+// Content-Type header, the feeds which declare none or aren't valid in the declared one are sniffed
+// like the html pages, using the byte order mark and falling back to windows-1252
+func toUTF8(data []byte, contentType string) []byte {
+	label := ""
+	if match := xmlEncoding.FindSubmatch(data); match != nil {
+		label = string(match[2])
+	}
+
+	if _, params, err := mime.ParseMediaType(contentType); label == "" && err == nil {
+		label = params["charset"]
+	}
+
+	enc, name := charset.Lookup(label)
+	if enc == nil || name == "utf-8" {
+		if utf8.Valid(data) {
+			return data
+		}
+
+		enc, name, _ = charset.DetermineEncoding(data, "")
+	}
+
+	if name == "utf-8" {
+		return data
+	}
+
+	decoded, err := enc.NewDecoder().Bytes(data)
+	if err != nil {
+		log.Printf("Couldn't decode the feed from %s: %v\n", name, err)
+		return data
+	}
+
+	// The parser would decode the feed again if the declaration was kept
+	return xmlEncoding.ReplaceAll(decoded, []byte("${1}UTF-8${3}"))
+}
@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// MaxDigests is the number of the daily digests which are kept, the older ones are removed
+var MaxDigests = 14
+
+// digestList is the saved state of the digests
+type digestList struct {
+	Articles SortableArticles `json:"articles"`
+	Last     time.Time        `json:"last"`
+}
+
+// Digests stores the daily digests, the articles which list the new articles of every category
+type Digests struct {
+	*jsonStore[digestList]
+}
+
+// NewDigests creates a new digest store.
+func NewDigests(dir string) (*Digests, error) {
+	store, err := newJSONStore(dir, "digests.json", "digest", digestList{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Digests{store}, nil
+}
+
+// Get returns the digests, the newest one comes first
+func (d *Digests) Get() SortableArticles {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return append(SortableArticles(nil), d.data.Articles...)
+}
+
+// LastMade returns the time when the last digest was made, it's zero if there wasn't one yet
+func (d *Digests) LastMade() time.Time {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.data.Last
+}
+
+// Due reports if the digest of the day should be made, which is once a day after the hour
+func (d *Digests) Due(now time.Time, hour int) bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return isDigestDue(d.data.Last, now, hour)
+}
+
+// Claim marks the digest of the day as made if it's due, so only one of the callers asking at the
+// same time makes it. It returns the time of the previous digest
+func (d *Digests) Claim(now time.Time, hour int) (time.Time, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !isDigestDue(d.data.Last, now, hour) {
+		return time.Time{}, false
+	}
+
+	last := d.data.Last
+	d.data.Last = now
+	return last, true
+}
+
+// Add puts a new digest made at the time in front of the others, the oldest ones are removed
+func (d *Digests) Add(item gofeed.Item, made time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.data.Articles = append(SortableArticles{item}, d.data.Articles...)
+	if len(d.data.Articles) > MaxDigests {
+		d.data.Articles = d.data.Articles[:MaxDigests]
+	}
+
+	d.data.Last = made
+}
+
+// isDigestDue reports if a digest should be made at the time, given the time of the last one
+func isDigestDue(last, now time.Time, hour int) bool {
+	if now.Hour() < hour {
+		return false
+	}
+
+	last = last.In(now.Location())
+	return last.Year() != now.Year() || last.YearDay() != now.YearDay()
+}
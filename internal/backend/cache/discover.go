@@ -0,0 +1,136 @@
+package cache
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"log"
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/TypicalAM/goread/internal/backend/rss"
+	"github.com/mmcdole/gofeed"
+)
+
+// ErrNoFeeds is returned when no feeds could be discovered on a page
+var ErrNoFeeds = errors.New("no feeds found")
+
+// feedTypes are the link types which point to feeds
+var feedTypes = []string{
+	"application/rss+xml",
+	"application/atom+xml",
+	"application/feed+json",
+	"application/json",
+}
+
+// DiscoveredFeed is a feed found by the autodiscovery
+type DiscoveredFeed struct {
+	Title string
+	URL   string
+}
+
+// DiscoverFeeds looks for feeds at the url. If the url is a feed itself it's returned as is,
+// otherwise the page is searched for alternate links which point to feeds
+func DiscoverFeeds(pageURL string) ([]DiscoveredFeed, error) {
+	log.Println("Discovering feeds at", pageURL)
+	// The well-known sites don't need to be searched
+	if feedURL, title, ok := rss.ExpandShorthand(pageURL); ok {
+		return []DiscoveredFeed{{title, feedURL}}, nil
+	}
+
+	// The pages with a scraper are feeds already
+	if isScraped(pageURL) {
+		return []DiscoveredFeed{{URL: pageURL}}, nil
+	}
+
+	// The fediverse profiles usually advertise their feeds, the Mastodon feed is tried if they don't
+	profileURL, feedURL, title, isProfile := rss.FediverseProfile(pageURL)
+	if !isProfile {
+		return discoverPage(pageURL)
+	}
+
+	feeds, err := discoverPage(profileURL)
+	if err != nil {
+		log.Println("Nothing discovered on the profile, guessing its feed:", err)
+		return []DiscoveredFeed{{title, feedURL}}, nil
+	}
+
+	return feeds, nil
+}
+
+// discoverPage downloads the page and looks for the feeds it links to
+func discoverPage(pageURL string) ([]DiscoveredFeed, error) {
+	req, err := newRequest(pageURL)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := newPageClient(pageURL).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, gofeed.HTTPError{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+		}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if gofeed.DetectFeedType(bytes.NewReader(body)) != gofeed.FeedTypeUnknown {
+		return []DiscoveredFeed{{URL: pageURL}}, nil
+	}
+
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	var feeds []DiscoveredFeed
+	seen := make(map[string]bool)
+	doc.Find("link[rel~='alternate']").Each(func(_ int, link *goquery.Selection) {
+		linkType := strings.ToLower(strings.TrimSpace(link.AttrOr("type", "")))
+		href := strings.TrimSpace(link.AttrOr("href", ""))
+		if href == "" || !isFeedType(linkType) {
+			return
+		}
+
+		ref, err := url.Parse(href)
+		if err != nil {
+			return
+		}
+
+		// The page only gets to point to the feeds on the web, an exec feed would run its command
+		feedURL := resp.Request.URL.ResolveReference(ref).String()
+		if !rss.IsDiscoverable(feedURL) || seen[feedURL] {
+			return
+		}
+
+		seen[feedURL] = true
+		feeds = append(feeds, DiscoveredFeed{link.AttrOr("title", ""), feedURL})
+	})
+
+	if len(feeds) == 0 {
+		return nil, ErrNoFeeds
+	}
+
+	return feeds, nil
+}
+
+// isFeedType reports if the link type points to a feed
+func isFeedType(linkType string) bool {
+	for _, feedType := range feedTypes {
+		if linkType == feedType {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,236 @@
+package cache
+
+import (
+	"io"
+	"log"
+	"mime"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// DownloadState is the state of a download in the queue
+type DownloadState int
+
+const (
+	// DownloadQueued means that the download waits for the previous ones to finish
+	DownloadQueued DownloadState = iota
+	// DownloadRunning means that the file is being downloaded
+	DownloadRunning
+	// DownloadDone means that the file is saved
+	DownloadDone
+	// DownloadFailed means that the download failed, the error is kept in the download
+	DownloadFailed
+)
+
+// Download is a single file in the download queue
+type Download struct {
+	Title string
+	URL   string
+	Path  string
+	State DownloadState
+	Err   error
+	Done  int64
+	Total int64
+}
+
+// DownloadQueue downloads the episodes of the podcasts one by one and saves them in a directory
+type DownloadQueue struct {
+	dir       string
+	mu        sync.Mutex
+	downloads []*Download
+	wake      chan struct{}
+}
+
+// NewDownloadQueue creates a new download queue which saves the files in the directory, a leading
+// ~ is expanded to the home directory. The files are saved in ~/Podcasts if the directory is empty
+func NewDownloadQueue(dir string) (*DownloadQueue, error) {
+	log.Println("Creating new download queue")
+	home, err := os.UserHomeDir()
+	switch {
+	case dir == "" && err != nil:
+		return nil, err
+	case dir == "":
+		dir = filepath.Join(home, "Podcasts")
+	case strings.HasPrefix(dir, "~/") && err == nil:
+		dir = filepath.Join(home, dir[2:])
+	}
+
+	q := &DownloadQueue{dir: dir, wake: make(chan struct{}, 1)}
+	go q.work()
+	return q, nil
+}
+
+// Add queues the download of a file, the title and the type are used to name it. If the url
+// is already in the queue the existing download is returned
+func (q *DownloadQueue) Add(title, fileURL, mimeType string) Download {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for _, d := range q.downloads {
+		if d.URL == fileURL && d.State != DownloadFailed {
+			return *d
+		}
+	}
+
+	d := &Download{Title: title, URL: fileURL, Path: filepath.Join(q.dir, fileName(title, fileURL, mimeType))}
+	if _, err := os.Stat(d.Path); err == nil {
+		log.Println("Episode already downloaded:", d.Path)
+		d.State = DownloadDone
+	}
+
+	q.downloads = append(q.downloads, d)
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+
+	return *d
+}
+
+// Downloads returns a copy of all the downloads, the oldest ones come first
+func (q *DownloadQueue) Downloads() []Download {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	result := make([]Download, len(q.downloads))
+	for i, d := range q.downloads {
+		result[i] = *d
+	}
+
+	return result
+}
+
+// work downloads the queued files, it runs for the lifetime of the queue
+func (q *DownloadQueue) work() {
+	for {
+		d := q.next()
+		if d == nil {
+			<-q.wake
+			continue
+		}
+
+		err := q.download(d)
+		q.mu.Lock()
+		if err != nil {
+			log.Printf("Downloading %s failed: %v\n", d.URL, err)
+			d.State, d.Err = DownloadFailed, err
+		} else {
+			log.Println("Downloaded episode to", d.Path)
+			d.State = DownloadDone
+		}
+		q.mu.Unlock()
+	}
+}
+
+// next marks the first queued download as running and returns it, nil if there is nothing to do
+func (q *DownloadQueue) next() *Download {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for _, d := range q.downloads {
+		if d.State == DownloadQueued {
+			d.State = DownloadRunning
+			return d
+		}
+	}
+
+	return nil
+}
+
+// download saves the file, it's written to a temporary file first so that a broken download
+// doesn't look like a finished one
+func (q *DownloadQueue) download(d *Download) error {
+	req, err := newRequest(d.URL)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "audio/*, video/*, */*;q=0.8")
+	resp, err := newClient(d.URL).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return gofeed.HTTPError{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+		}
+	}
+
+	q.mu.Lock()
+	d.Total = resp.ContentLength
+	q.mu.Unlock()
+
+	if err = os.MkdirAll(filepath.Dir(d.Path), 0755); err != nil {
+		return err
+	}
+
+	partPath := d.Path + ".part"
+	file, err := os.Create(partPath)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(file, io.TeeReader(resp.Body, progressWriter{q, d}))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		_ = os.Remove(partPath)
+		return err
+	}
+
+	return os.Rename(partPath, d.Path)
+}
+
+// progressWriter counts the downloaded bytes of a download
+type progressWriter struct {
+	queue    *DownloadQueue
+	download *Download
+}
+
+// Write adds the length of the data to the downloaded bytes
+func (pw progressWriter) Write(data []byte) (int, error) {
+	pw.queue.mu.Lock()
+	pw.download.Done += int64(len(data))
+	pw.queue.mu.Unlock()
+	return len(data), nil
+}
+
+// fileName returns the name of the file in which the episode is saved, the extension is taken
+// from the url or the type of the file
+func fileName(title, fileURL, mimeType string) string {
+	var ext string
+	if parsed, err := url.Parse(fileURL); err == nil {
+		ext = path.Ext(parsed.Path)
+	}
+
+	if ext == "" && mimeType != "" {
+		if exts, err := mime.ExtensionsByType(mimeType); err == nil && len(exts) > 0 {
+			ext = exts[0]
+		}
+	}
+
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`/\:*?"<>|`, r) || r < ' ' {
+			return '_'
+		}
+
+		return r
+	}, strings.TrimSpace(title))
+
+	if name == "" || name == "." || name == ".." {
+		name = "episode"
+	}
+
+	return name + ext
+}
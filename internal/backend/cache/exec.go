@@ -0,0 +1,91 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/TypicalAM/goread/internal/backend/rss"
+	"github.com/mmcdole/gofeed"
+)
+
+// ExecTimeout is how long the commands of the exec feeds, the filter commands and the hooks can run
+var ExecTimeout = time.Minute
+
+// isExec reports if the url belongs to an exec feed
+func isExec(url string) bool {
+	return rss.IsExec(url)
+}
+
+// parseExec runs the command of an exec feed and parses its output, it can be any of the formats
+// which the feeds use
+func parseExec(url, filterCommand string) (*gofeed.Feed, error) {
+	command := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(url), rss.ExecPrefix))
+	if command == "" {
+		return nil, fmt.Errorf("the exec feed has no command")
+	}
+
+	out, err := RunCommand(command, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return parse(bytes.NewReader(out), "", filterCommand)
+}
+
+// parse parses the feed, it's transcoded to utf-8 using its content type and piped through the
+// filter command first if there is one
+func parse(data io.Reader, contentType, filterCommand string) (*gofeed.Feed, error) {
+	raw, err := io.ReadAll(data)
+	if err != nil {
+		return nil, err
+	}
+
+	decoded := toUTF8(raw, contentType)
+	if filterCommand != "" {
+		if decoded, err = RunCommand(filterCommand, bytes.NewReader(decoded)); err != nil {
+			return nil, err
+		}
+	}
+
+	// The invalid xml is repaired instead of failing the whole feed
+	feed, err := gofeed.NewParser().Parse(bytes.NewReader(decoded))
+	if err != nil && isXML(decoded) {
+		return parseRepaired(decoded, err)
+	}
+
+	return feed, err
+}
+
+// RunCommand runs the command using the shell and returns its output, the input is passed on the
+// standard input. The error output is included in the error if the command fails
+func RunCommand(command string, stdin io.Reader) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), ExecTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command) //nolint:gosec
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command) //nolint:gosec
+	}
+
+	var stderr bytes.Buffer
+	cmd.Stdin = stdin
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("command %q failed: %w: %s", command, err, msg)
+		}
+
+		return nil, fmt.Errorf("command %q failed: %w", command, err)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,163 @@
+package cache
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"io"
+	"log"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/mmcdole/gofeed"
+
+	// Register the decoders of the formats the icons come in
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+)
+
+// ErrNoFavicon is returned when a site doesn't have an icon which can be displayed
+var ErrNoFavicon = errors.New("the site has no usable icon")
+
+// pngSignature starts every png file
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// Favicon returns the icon of the site at the url, it's looked up on the home page of the site and
+// stored like the images. The sites without a usable icon are remembered so they aren't asked again.
+// Only the stored icons are returned if the icon can't be fetched
+func (is *ImageStore) Favicon(siteURL string, fetch bool) ([]byte, error) {
+	site, err := url.Parse(siteURL)
+	if err != nil || site.Host == "" {
+		return nil, ErrNoFavicon
+	}
+
+	home := &url.URL{Scheme: site.Scheme, Host: site.Host, Path: "/"}
+	path := is.path("favicon:" + home.String())
+	if data, err := os.ReadFile(path); err == nil {
+		if len(data) == 0 {
+			return nil, ErrNoFavicon
+		}
+
+		return data, nil
+	}
+
+	if !fetch {
+		return nil, errors.New("offline mode")
+	}
+
+	log.Println("Looking up the favicon of", home)
+	candidates, err := iconLinks(home)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, candidate := range candidates {
+		data, err := download(candidate)
+		var httpErr gofeed.HTTPError
+		switch {
+		case errors.As(err, &httpErr):
+			continue
+		case err != nil:
+			// The site can't be reached right now, it's not remembered as one without an icon
+			return nil, err
+		}
+
+		if data = usableIcon(data); data != nil {
+			return data, is.store(path, data)
+		}
+	}
+
+	if err = is.store(path, nil); err != nil {
+		return nil, err
+	}
+
+	return nil, ErrNoFavicon
+}
+
+// iconLinks returns the urls of the icons listed on the home page, the ones which are likely to
+// be png files come first and the well-known favicon.ico is the last resort
+func iconLinks(home *url.URL) ([]string, error) {
+	fallback := home.ResolveReference(&url.URL{Path: "/favicon.ico"}).String()
+	req, err := newRequest(home.String())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := newPageClient(home.String()).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return []string{fallback}, nil
+	}
+
+	doc, err := goquery.NewDocumentFromReader(io.LimitReader(resp.Body, MaxImageSize))
+	if err != nil {
+		return []string{fallback}, nil
+	}
+
+	var preferred, others []string
+	doc.Find("link[rel~='icon'], link[rel='apple-touch-icon']").Each(func(_ int, link *goquery.Selection) {
+		ref, err := url.Parse(strings.TrimSpace(link.AttrOr("href", "")))
+		if err != nil || ref.String() == "" {
+			return
+		}
+
+		icon := resp.Request.URL.ResolveReference(ref).String()
+		linkType := strings.ToLower(link.AttrOr("type", ""))
+		if link.AttrOr("rel", "") == "apple-touch-icon" || strings.HasSuffix(ref.Path, ".png") || linkType == "image/png" {
+			preferred = append(preferred, icon)
+		} else {
+			others = append(others, icon)
+		}
+	})
+
+	return append(append(preferred, others...), fallback), nil
+}
+
+// usableIcon returns the icon if it can be decoded, the png images embedded in ico files are
+// taken out of them. It returns nil if the icon can't be used
+func usableIcon(data []byte) []byte {
+	if _, _, err := image.DecodeConfig(bytes.NewReader(data)); err == nil {
+		return data
+	}
+
+	return icoPNG(data)
+}
+
+// icoPNG returns the largest png image in an ico file, the older bitmap images aren't supported.
+// It returns nil if there is no png image
+func icoPNG(data []byte) []byte {
+	// The header is followed by a 16 byte directory entry for every image
+	if len(data) < 6 || binary.LittleEndian.Uint16(data[0:]) != 0 || binary.LittleEndian.Uint16(data[2:]) != 1 {
+		return nil
+	}
+
+	var best []byte
+	count := int(binary.LittleEndian.Uint16(data[4:]))
+	for i := 0; i < count; i++ {
+		entry := 6 + 16*i
+		if entry+16 > len(data) {
+			break
+		}
+
+		size := int(binary.LittleEndian.Uint32(data[entry+8:]))
+		offset := int(binary.LittleEndian.Uint32(data[entry+12:]))
+		if size <= 0 || offset < 0 || offset+size > len(data) {
+			continue
+		}
+
+		img := data[offset : offset+size]
+		if bytes.HasPrefix(img, pngSignature) && len(img) > len(best) {
+			best = img
+		}
+	}
+
+	return best
+}
@@ -0,0 +1,191 @@
+package cache
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/TypicalAM/goread/internal/backend/rss"
+	"github.com/mmcdole/gofeed"
+	"github.com/yuin/goldmark"
+	"gopkg.in/yaml.v3"
+)
+
+// markdownExtensions are the extensions of the files which are the articles of a local directory feed
+var markdownExtensions = map[string]bool{".md": true, ".markdown": true}
+
+// frontMatterDelimiter starts and ends the yaml front matter of a markdown file
+const frontMatterDelimiter = "---"
+
+// frontMatter is the metadata of a markdown article, all of it is optional
+type frontMatter struct {
+	Title       string    `yaml:"title"`
+	Date        time.Time `yaml:"date"`
+	Author      string    `yaml:"author"`
+	Description string    `yaml:"description"`
+}
+
+// isFile reports if the url belongs to a local feed
+func isFile(url string) bool {
+	return rss.IsFile(url)
+}
+
+// localPath returns the path of a local feed, a leading ~ is expanded to the home directory
+func localPath(fileURL string) (string, error) {
+	path := fileURL[len(rss.FileScheme):]
+	if unescaped, err := url.PathUnescape(path); err == nil {
+		path = unescaped
+	}
+
+	path = strings.TrimPrefix(path, "localhost")
+	if strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+
+		path = filepath.Join(home, path[2:])
+	}
+
+	if !filepath.IsAbs(path) {
+		return "", fmt.Errorf("the path of the local feed %q isn't absolute", path)
+	}
+
+	return path, nil
+}
+
+// parseFile reads a local feed, a file is parsed like a downloaded feed and every markdown file in
+// a directory is an article
+func parseFile(fileURL, filterCommand string) (*gofeed.Feed, error) {
+	path, err := localPath(fileURL)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.IsDir() {
+		return parseDirectory(path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return parse(bytes.NewReader(data), "", filterCommand)
+}
+
+// parseDirectory makes a feed out of the markdown files in the directory, the newest file first
+func parseDirectory(dir string) (*gofeed.Feed, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	feed := &gofeed.Feed{Title: filepath.Base(dir), Link: rss.FileScheme + dir}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".") || !markdownExtensions[strings.ToLower(filepath.Ext(name))] {
+			continue
+		}
+
+		item, err := markdownItem(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+
+		feed.Items = append(feed.Items, item)
+	}
+
+	sort.SliceStable(feed.Items, func(i, j int) bool {
+		return feed.Items[i].PublishedParsed.After(*feed.Items[j].PublishedParsed)
+	})
+
+	return feed, nil
+}
+
+// markdownItem makes an article out of a markdown file. The title and the date come from its front
+// matter, the title falls back to the first heading or the file name and the date to the time of
+// the last change
+func markdownItem(path string) (*gofeed.Item, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	meta, body, err := splitFrontMatter(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid front matter in %s: %w", path, err)
+	}
+
+	title := meta.Title
+	if title == "" {
+		title = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		for _, line := range strings.Split(string(body), "\n") {
+			if strings.HasPrefix(line, "# ") {
+				title = strings.TrimSpace(line[2:])
+				break
+			}
+		}
+	}
+
+	date := meta.Date
+	if date.IsZero() {
+		date = info.ModTime()
+	}
+
+	var content bytes.Buffer
+	if err = goldmark.Convert(body, &content); err != nil {
+		return nil, err
+	}
+
+	item := &gofeed.Item{
+		Title:           title,
+		Description:     meta.Description,
+		Content:         content.String(),
+		Link:            rss.FileScheme + path,
+		GUID:            rss.FileScheme + path,
+		Published:       date.Format(time.RFC1123Z),
+		PublishedParsed: &date,
+	}
+
+	if meta.Author != "" {
+		item.Author = &gofeed.Person{Name: meta.Author}
+	}
+
+	return item, nil
+}
+
+// splitFrontMatter returns the yaml front matter of a markdown file and the rest of it
+func splitFrontMatter(data []byte) (frontMatter, []byte, error) {
+	var meta frontMatter
+	text := string(bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n")))
+	if !strings.HasPrefix(text, frontMatterDelimiter+"\n") {
+		return meta, data, nil
+	}
+
+	header, body, found := strings.Cut(text[len(frontMatterDelimiter)+1:], "\n"+frontMatterDelimiter)
+	if !found {
+		return meta, data, nil
+	}
+
+	if err := yaml.Unmarshal([]byte(header), &meta); err != nil {
+		return meta, nil, err
+	}
+
+	return meta, []byte(strings.TrimPrefix(strings.TrimLeft(body, "-"), "\n")), nil
+}
@@ -0,0 +1,481 @@
+package cache
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"log"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TypicalAM/goread/internal/backend/rss"
+	"github.com/mmcdole/gofeed"
+)
+
+// GeminiTimeout is how long a request to a gemini capsule can take
+var GeminiTimeout = 30 * time.Second
+
+// KnownGeminiHosts is the file with the certificates of the capsules trusted on the first use, it's
+// in the cache directory if empty
+var KnownGeminiHosts string
+
+// ErrCertificateChanged is returned when a capsule presents a different certificate than the one
+// trusted before, while the old one is still valid
+var ErrCertificateChanged = errors.New("the certificate of the capsule changed")
+
+// maxGeminiRedirects is the amount of the redirects followed by a gemini request
+const maxGeminiRedirects = 5
+
+// maxGeminiSize is the size of the largest gemini response which is read
+const maxGeminiSize = 10 << 20
+
+// maxGeminiArticles is the amount of the newest gemfeed entries whose pages are downloaded
+const maxGeminiArticles = 20
+
+// gemfeedEntry matches the label of a link in a gemfeed, it starts with the date of the post
+var gemfeedEntry = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})\s*[-–—:]?\s*(.*)$`)
+
+// knownHosts guards the file with the trusted certificates
+var knownHosts sync.Mutex
+
+// GeminiError is a response of a capsule which isn't a success or a redirect
+type GeminiError struct {
+	Status int
+	Meta   string
+}
+
+// Error returns the status and the message of the capsule
+func (e GeminiError) Error() string {
+	if e.Meta == "" {
+		return fmt.Sprintf("gemini status %d", e.Status)
+	}
+
+	return fmt.Sprintf("gemini status %d: %s", e.Status, e.Meta)
+}
+
+// isGemini reports if the url belongs to a feed on the Gemini protocol
+func isGemini(url string) bool {
+	return rss.IsGemini(url)
+}
+
+// parseGemini downloads a feed from a capsule. The Atom and RSS feeds are parsed like on the web,
+// a gemtext page is a gemfeed whose entries are the dated links. The entries without the content
+// get the gemtext of their pages, the ones in the previous articles aren't downloaded again
+func parseGemini(feedURL, filterCommand string, prev SortableArticles) (*gofeed.Feed, error) {
+	body, mime, final, err := fetchGemini(feedURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var feed *gofeed.Feed
+	if strings.HasPrefix(mime, "text/gemini") {
+		feed, err = parseGemfeed(string(body), final)
+	} else {
+		feed, err = parse(bytes.NewReader(body), mime, filterCommand)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]string, len(prev))
+	for _, item := range prev {
+		if item.Content != "" {
+			known[item.Link] = item.Content
+		}
+	}
+
+	fetched := 0
+	for _, item := range feed.Items {
+		if item.Content != "" || item.Description != "" || !isGemini(item.Link) {
+			continue
+		}
+
+		if content, ok := known[item.Link]; ok {
+			item.Content = content
+			continue
+		}
+
+		if fetched++; fetched > maxGeminiArticles {
+			continue
+		}
+
+		if item.Content, err = ExtractContent(item.Link); err != nil {
+			log.Printf("Error downloading the gemini page %s: %v\n", item.Link, err)
+		}
+	}
+
+	return feed, nil
+}
+
+// parseGemfeed makes a feed out of a gemtext page, the first heading is its title and the links
+// starting with a date are its entries
+func parseGemfeed(text string, base *url.URL) (*gofeed.Feed, error) {
+	feed := &gofeed.Feed{Link: base.String()}
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimRight(line, "\r")
+		switch {
+		case strings.HasPrefix(line, "# ") && feed.Title == "":
+			feed.Title = strings.TrimSpace(line[2:])
+
+		case strings.HasPrefix(line, "## ") && feed.Description == "" && len(feed.Items) == 0:
+			feed.Description = strings.TrimSpace(line[3:])
+
+		case strings.HasPrefix(line, "=>"):
+			link, label := geminiLink(line, base)
+			match := gemfeedEntry.FindStringSubmatch(label)
+			if match == nil {
+				continue
+			}
+
+			date, err := time.Parse("2006-01-02", match[1])
+			if err != nil {
+				continue
+			}
+
+			title := match[2]
+			if title == "" {
+				title = match[1]
+			}
+
+			feed.Items = append(feed.Items, &gofeed.Item{
+				Title:           title,
+				Link:            link,
+				GUID:            link,
+				Published:       date.Format(time.RFC1123Z),
+				PublishedParsed: &date,
+			})
+		}
+	}
+
+	if len(feed.Items) == 0 {
+		return nil, errors.New("the page has no gemfeed entries")
+	}
+
+	return feed, nil
+}
+
+// geminiLink returns the resolved address and the label of a link line, the label is the address if
+// it's missing
+func geminiLink(line string, base *url.URL) (string, string) {
+	fields := strings.Fields(strings.TrimPrefix(line, "=>"))
+	if len(fields) == 0 {
+		return "", ""
+	}
+
+	link := fields[0]
+	if ref, err := base.Parse(link); err == nil {
+		link = ref.String()
+	}
+
+	label := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(strings.TrimPrefix(line, "=>")), fields[0]))
+	if label == "" {
+		label = link
+	}
+
+	return link, label
+}
+
+// gemtextToHTML converts a gemtext page to html, the links are resolved against the address of the page
+func gemtextToHTML(text string, base *url.URL) string {
+	var b strings.Builder
+	preformatted, list := false, false
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimRight(line, "\r")
+		// The list ends at the first line which isn't an item
+		if isItem := strings.HasPrefix(line, "* "); !preformatted && isItem != list {
+			if isItem {
+				b.WriteString("<ul>")
+			} else {
+				b.WriteString("</ul>")
+			}
+
+			list = isItem
+		}
+
+		if strings.HasPrefix(line, "```") {
+			if preformatted {
+				b.WriteString("</code></pre>")
+			} else {
+				b.WriteString("<pre><code>")
+			}
+
+			preformatted = !preformatted
+			continue
+		}
+
+		if preformatted {
+			b.WriteString(html.EscapeString(line) + "\n")
+			continue
+		}
+
+		switch {
+		case strings.TrimSpace(line) == "":
+		case strings.HasPrefix(line, "=>"):
+			link, label := geminiLink(line, base)
+			fmt.Fprintf(&b, `<p><a href="%s">%s</a></p>`, html.EscapeString(link), html.EscapeString(label))
+		case strings.HasPrefix(line, "###"):
+			b.WriteString("<h3>" + html.EscapeString(strings.TrimSpace(line[3:])) + "</h3>")
+		case strings.HasPrefix(line, "##"):
+			b.WriteString("<h2>" + html.EscapeString(strings.TrimSpace(line[2:])) + "</h2>")
+		case strings.HasPrefix(line, "#"):
+			b.WriteString("<h1>" + html.EscapeString(strings.TrimSpace(line[1:])) + "</h1>")
+		case list:
+			b.WriteString("<li>" + html.EscapeString(strings.TrimSpace(line[2:])) + "</li>")
+		case strings.HasPrefix(line, ">"):
+			b.WriteString("<blockquote><p>" + html.EscapeString(strings.TrimSpace(line[1:])) + "</p></blockquote>")
+		default:
+			b.WriteString("<p>" + html.EscapeString(line) + "</p>")
+		}
+	}
+
+	if preformatted {
+		b.WriteString("</code></pre>")
+	}
+
+	if list {
+		b.WriteString("</ul>")
+	}
+
+	return b.String()
+}
+
+// extractGemini downloads a gemini page and returns its html, the pages which aren't gemtext or
+// plain text have no content
+func extractGemini(pageURL string) (string, error) {
+	body, mime, final, err := fetchGemini(pageURL)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case strings.HasPrefix(mime, "text/gemini"):
+		return gemtextToHTML(string(body), final), nil
+	case strings.HasPrefix(mime, "text/"):
+		return "<pre><code>" + html.EscapeString(string(body)) + "</code></pre>", nil
+	}
+
+	return "", ErrNoContent
+}
+
+// fetchGemini requests the url from its capsule and returns the body of the response, its mime
+// type and the address it was found at after the redirects
+func fetchGemini(rawURL string) ([]byte, string, *url.URL, error) {
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	for redirects := 0; ; redirects++ {
+		status, meta, body, err := geminiRequest(target)
+		if err != nil {
+			return nil, "", nil, err
+		}
+
+		switch status / 10 {
+		case 2:
+			if meta == "" {
+				meta = "text/gemini"
+			}
+
+			return body, strings.ToLower(meta), target, nil
+
+		case 3:
+			if redirects >= maxGeminiRedirects {
+				return nil, "", nil, errors.New("too many redirects")
+			}
+
+			if target, err = target.Parse(meta); err != nil {
+				return nil, "", nil, err
+			}
+
+			log.Println("Following the gemini redirect to", target)
+
+		default:
+			return nil, "", nil, GeminiError{status, meta}
+		}
+	}
+}
+
+// geminiRequest makes a single request and returns the status, the meta line and the body of the response
+func geminiRequest(target *url.URL) (int, string, []byte, error) {
+	if target.Scheme != "gemini" {
+		return 0, "", nil, fmt.Errorf("unsupported scheme %q", target.Scheme)
+	}
+
+	host := target.Host
+	if target.Port() == "" {
+		host = net.JoinHostPort(target.Hostname(), "1965")
+	}
+
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: target.Hostname(),
+		// The capsules use self-signed certificates, they're trusted on the first use instead
+		InsecureSkipVerify: true,
+		VerifyConnection: func(state tls.ConnectionState) error {
+			return trustCertificate(target.Host, state.PeerCertificates)
+		},
+	}
+
+	conn, err := tls.DialWithDialer(&net.Dialer{Timeout: GeminiTimeout}, "tcp", host, config)
+	if err != nil {
+		return 0, "", nil, err
+	}
+	defer conn.Close()
+
+	if err = conn.SetDeadline(time.Now().Add(GeminiTimeout)); err != nil {
+		return 0, "", nil, err
+	}
+
+	if _, err = fmt.Fprintf(conn, "%s\r\n", target); err != nil {
+		return 0, "", nil, err
+	}
+
+	reader := bufio.NewReader(io.LimitReader(conn, maxGeminiSize))
+	header, err := reader.ReadString('\n')
+	if err != nil {
+		return 0, "", nil, fmt.Errorf("invalid gemini response: %w", err)
+	}
+
+	code, meta, _ := strings.Cut(strings.TrimRight(header, "\r\n"), " ")
+	status, err := strconv.Atoi(code)
+	if err != nil || len(code) != 2 {
+		return 0, "", nil, fmt.Errorf("invalid gemini status %q", code)
+	}
+
+	if status/10 != 2 {
+		return status, strings.TrimSpace(meta), nil, nil
+	}
+
+	body, err := io.ReadAll(reader)
+	return status, strings.TrimSpace(meta), body, err
+}
+
+// trustCertificate checks the certificate of the capsule against the one trusted before, the first
+// certificate of a capsule is remembered and so is a new one after the old one expired
+func trustCertificate(host string, certs []*x509.Certificate) error {
+	if len(certs) == 0 {
+		return errors.New("the capsule has no certificate")
+	}
+
+	cert := certs[0]
+	if time.Now().After(cert.NotAfter) {
+		return fmt.Errorf("the certificate of %s expired", host)
+	}
+
+	sum := sha256.Sum256(cert.Raw)
+	fingerprint := hex.EncodeToString(sum[:])
+
+	knownHosts.Lock()
+	defer knownHosts.Unlock()
+
+	path, err := knownHostsPath()
+	if err != nil {
+		return err
+	}
+
+	hosts, err := readKnownHosts(path)
+	if err != nil {
+		return err
+	}
+
+	known, ok := hosts[host]
+	if ok && known.fingerprint == fingerprint {
+		return nil
+	}
+
+	if ok && time.Now().Before(known.expires) {
+		return fmt.Errorf("%w, remove %s from %s if you trust the new one", ErrCertificateChanged, host, path)
+	}
+
+	log.Println("Trusting the certificate of", host)
+	hosts[host] = knownHost{fingerprint, cert.NotAfter}
+	return writeKnownHosts(path, hosts)
+}
+
+// knownHost is a certificate trusted for a capsule
+type knownHost struct {
+	fingerprint string
+	expires     time.Time
+}
+
+// knownHostsPath returns the path of the file with the trusted certificates
+func knownHostsPath() (string, error) {
+	if KnownGeminiHosts != "" {
+		return KnownGeminiHosts, nil
+	}
+
+	dir, err := getDefaultDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "gemini_hosts"), nil
+}
+
+// readKnownHosts reads the trusted certificates, every line has the host, the sha256 fingerprint
+// of the certificate and the date when it expires
+func readKnownHosts(path string) (map[string]knownHost, error) {
+	hosts := make(map[string]knownHost)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return hosts, nil
+		}
+
+		return nil, err
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			continue
+		}
+
+		expires, err := time.Parse(time.RFC3339, fields[2])
+		if err != nil {
+			continue
+		}
+
+		hosts[fields[0]] = knownHost{fields[1], expires}
+	}
+
+	return hosts, nil
+}
+
+// writeKnownHosts saves the trusted certificates
+func writeKnownHosts(path string, hosts map[string]knownHost) error {
+	names := make([]string, 0, len(hosts))
+	for host := range hosts {
+		names = append(names, host)
+	}
+
+	sort.Strings(names)
+	var b strings.Builder
+	for _, host := range names {
+		known := hosts[host]
+		fmt.Fprintf(&b, "%s %s %s\n", host, known.fingerprint, known.expires.UTC().Format(time.RFC3339))
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, []byte(b.String()), 0600)
+}
@@ -0,0 +1,91 @@
+package cache
+
+import (
+	"encoding/json"
+	"log"
+	"regexp"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// HackerNewsAPI is the address of the api which returns a story with its whole comment thread
+var HackerNewsAPI = "https://hn.algolia.com/api/v1/items/"
+
+// hackerNewsItem matches the address of a discussion on Hacker News
+var hackerNewsItem = regexp.MustCompile(`https?://news\.ycombinator\.com/item\?id=(\d+)`)
+
+// Comment is a comment in a Hacker News thread, the text is html
+type Comment struct {
+	Author  string
+	Text    string
+	Created time.Time
+	Replies []Comment
+}
+
+// hackerNewsItemJSON is an item returned by the api, the deleted comments have no author
+type hackerNewsItemJSON struct {
+	Author    string               `json:"author"`
+	Text      string               `json:"text"`
+	CreatedAt time.Time            `json:"created_at"`
+	Children  []hackerNewsItemJSON `json:"children"`
+}
+
+// HackerNewsID returns the id of the Hacker News discussion linked in the texts, the link of an
+// article, its guid or its description which contains the link to the comments
+func HackerNewsID(texts ...string) (string, bool) {
+	for _, text := range texts {
+		if match := hackerNewsItem.FindStringSubmatch(text); match != nil {
+			return match[1], true
+		}
+	}
+
+	return "", false
+}
+
+// FetchComments downloads the comment thread of the Hacker News story with the id
+func FetchComments(id string) ([]Comment, error) {
+	log.Println("Fetching the comments of", id)
+	url := HackerNewsAPI + id
+	req, err := newRequest(url)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	resp, err := newPageClient(url).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, gofeed.HTTPError{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+		}
+	}
+
+	var story hackerNewsItemJSON
+	if err = json.NewDecoder(resp.Body).Decode(&story); err != nil {
+		return nil, err
+	}
+
+	return toComments(story.Children), nil
+}
+
+// toComments converts the items of the api to comments, the deleted comments are kept only if
+// somebody replied to them
+func toComments(items []hackerNewsItemJSON) []Comment {
+	var comments []Comment
+	for _, item := range items {
+		replies := toComments(item.Children)
+		if item.Author == "" && len(replies) == 0 {
+			continue
+		}
+
+		comments = append(comments, Comment{item.Author, item.Text, item.CreatedAt, replies})
+	}
+
+	return comments
+}
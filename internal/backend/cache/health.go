@@ -0,0 +1,172 @@
+package cache
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// Health describes how the fetching of a feed went, it's used to find the broken and the dead feeds
+type Health struct {
+	LastFetch    time.Time     `json:"last_fetch"`
+	Status       int           `json:"status,omitempty"`
+	Err          string        `json:"error,omitempty"`
+	Fetches      int           `json:"fetches"`
+	TotalLatency time.Duration `json:"total_latency"`
+	LastPost     time.Time     `json:"last_post,omitempty"`
+	MovedTo      string        `json:"moved_to,omitempty"`
+	Repaired     string        `json:"repaired,omitempty"`
+	PostInterval time.Duration `json:"post_interval,omitempty"`
+}
+
+// postingSample is the number of the newest articles used to measure how often a feed posts
+const postingSample = 10
+
+// AverageLatency returns the average time it took to fetch the feed
+func (h Health) AverageLatency() time.Duration {
+	if h.Fetches == 0 {
+		return 0
+	}
+
+	return h.TotalLatency / time.Duration(h.Fetches)
+}
+
+// Failed reports if the last fetch of the feed failed
+func (h Health) Failed() bool {
+	return h.Err != ""
+}
+
+// RefreshInterval returns how long to wait between the refreshes of the feed, the feeds which post
+// often are refreshed often and the ones which went quiet more and more rarely. The interval is
+// capped by the maximum
+func (h Health) RefreshInterval(now time.Time, max time.Duration) time.Duration {
+	interval := h.PostInterval
+	if !h.LastPost.IsZero() {
+		if quiet := now.Sub(h.LastPost) / 2; quiet > interval {
+			interval = quiet
+		}
+	}
+
+	if interval > max {
+		interval = max
+	}
+
+	return interval
+}
+
+// Due reports if the feed should be refreshed, the failed feeds are always retried
+func (h Health) Due(now time.Time, max time.Duration) bool {
+	return h.Failed() || now.Sub(h.LastFetch) >= h.RefreshInterval(now, max)
+}
+
+// DueURLs returns the feeds which should be refreshed, the ones which were never fetched are due
+func (c *Cache) DueURLs(urls []string, max time.Duration) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	var due []string
+	for _, url := range urls {
+		if health, ok := c.Health[url]; !ok || health.Due(now, max) {
+			due = append(due, url)
+		}
+	}
+
+	return due
+}
+
+// GetHealth returns the health of a feed, ok is false if it was never fetched
+func (c *Cache) GetHealth(url string) (Health, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	health, ok := c.Health[url]
+	return health, ok
+}
+
+// recordFetch updates the health of a feed after it was fetched. The status is the http status of
+// the response, it's zero if there was no response or the articles came from a sync service. The
+// moved url and the repaired problems of the feed come from the fetched entry
+func (c *Cache) recordFetch(url string, start time.Time, entry Entry, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Health == nil {
+		c.Health = make(map[string]Health)
+	}
+
+	health := c.Health[url]
+	health.LastFetch = time.Now()
+	health.Fetches++
+	health.TotalLatency += time.Since(start)
+	health.Status, health.Err = 0, ""
+	health.MovedTo, health.Repaired = entry.MovedTo, entry.Repaired
+	if c.source == nil {
+		health.Status = http.StatusOK
+	}
+
+	var httpErr gofeed.HTTPError
+	switch {
+	case errors.As(err, &httpErr):
+		health.Status, health.Err = httpErr.StatusCode, httpErr.Status
+	case err != nil:
+		health.Status, health.Err = 0, err.Error()
+	}
+
+	for _, item := range entry.Articles {
+		if item.PublishedParsed != nil && item.PublishedParsed.After(health.LastPost) {
+			health.LastPost = *item.PublishedParsed
+		}
+	}
+
+	if interval := postInterval(entry.Articles); interval > 0 {
+		health.PostInterval = interval
+	}
+
+	c.Health[url] = health
+}
+
+// postInterval returns the average time between the newest articles, it's zero if there aren't
+// enough dated articles to tell
+func postInterval(articles SortableArticles) time.Duration {
+	var dates []time.Time
+	for _, item := range articles {
+		if item.PublishedParsed != nil {
+			dates = append(dates, *item.PublishedParsed)
+		}
+	}
+
+	sort.Slice(dates, func(i, j int) bool { return dates[i].After(dates[j]) })
+	if len(dates) > postingSample {
+		dates = dates[:postingSample]
+	}
+
+	if len(dates) < 2 {
+		return 0
+	}
+
+	return dates[0].Sub(dates[len(dates)-1]) / time.Duration(len(dates)-1)
+}
+
+// MoveFeed keeps the articles and the health of a feed under its new url, after the feed moved
+func (c *Cache) MoveFeed(from, to string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry, ok := c.Content[from]; ok {
+		if _, exists := c.Content[to]; !exists {
+			c.Content[to] = entry
+		}
+
+		delete(c.Content, from)
+	}
+
+	if health, ok := c.Health[from]; ok {
+		health.MovedTo = ""
+		c.Health[to] = health
+		delete(c.Health, from)
+	}
+}
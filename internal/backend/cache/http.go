@@ -0,0 +1,96 @@
+package cache
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/TypicalAM/goread/internal/config"
+)
+
+// HTTPSettings are the settings of the requests made to the feeds and the websites
+var HTTPSettings config.HTTP
+
+// DefaultUserAgent is the user agent sent with the requests when the settings don't change it
+var DefaultUserAgent = "goread (by /u/TypicalAM)"
+
+// acceptHeader lists the feed formats which can be parsed
+const acceptHeader = "application/rss+xml, application/atom+xml, application/feed+json, application/json;q=0.9, application/xml;q=0.9, text/xml;q=0.9, */*;q=0.8"
+
+// maxRedirects is the number of the redirects followed when fetching a feed, like in the default client
+const maxRedirects = 10
+
+// secrets remembers the output of the password commands, so they are run only once
+var secrets = struct {
+	mu     sync.Mutex
+	values map[string]string
+}{values: make(map[string]string)}
+
+// newRequest creates a request for a feed, the user agent, the credentials and the headers from the
+// settings are added
+func newRequest(url string) (*http.Request, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := HTTPSettings.For(url)
+	userAgent := DefaultUserAgent
+	if settings.UserAgent != "" {
+		userAgent = settings.UserAgent
+	}
+
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", acceptHeader)
+	if settings.Username != "" {
+		password, err := secret(settings)
+		if err != nil {
+			return nil, err
+		}
+
+		req.SetBasicAuth(settings.Username, password)
+	}
+
+	for name, value := range settings.Headers {
+		req.Header.Set(name, value)
+	}
+
+	return req, nil
+}
+
+// secret returns the password from the settings, the output of the password command is cached
+func secret(settings config.Request) (string, error) {
+	if settings.PasswordCommand == "" {
+		return settings.Password, nil
+	}
+
+	secrets.mu.Lock()
+	defer secrets.mu.Unlock()
+	if password, ok := secrets.values[settings.PasswordCommand]; ok {
+		return password, nil
+	}
+
+	password, err := settings.Secret()
+	if err != nil {
+		return "", err
+	}
+
+	secrets.values[settings.PasswordCommand] = password
+	return password, nil
+}
+
+// newClient creates the http client used to fetch the url with the settings
+func newClient(target string) *http.Client {
+	return HTTPSettings.Client(target, 0)
+}
+
+// newPageClient creates the http client used to fetch the feeds and the pages, the requests are
+// limited per host and the responses go through the http cache if it's enabled
+func newPageClient(target string) *http.Client {
+	client := newClient(target)
+	client.Transport = limitingTransport{client.Transport}
+	if Responses != nil {
+		client.Transport = Responses.Transport(client.Transport)
+	}
+
+	return client
+}
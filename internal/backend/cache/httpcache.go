@@ -0,0 +1,306 @@
+package cache
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spaolacci/murmur3"
+)
+
+// MaxHTTPCacheAge caps how long a response is considered fresh, whatever the server says
+var MaxHTTPCacheAge = time.Hour
+
+// Responses keeps the responses of the feeds and the pages on disk, it's nil if the http cache is disabled
+var Responses *HTTPCache
+
+// maxCachedBody is the size of the largest response body which is stored
+const maxCachedBody = 10 << 20
+
+// unusedResponseAge is how long a response is kept on disk after it was last stored
+const unusedResponseAge = 30 * 24 * time.Hour
+
+// HTTPCache stores the http responses on disk, so the unchanged feeds aren't downloaded again after
+// a restart. The responses are fresh for as long as their Cache-Control or Expires headers allow,
+// the stale ones are revalidated using their ETag and Last-Modified headers
+type HTTPCache struct {
+	dir string
+}
+
+// cachedResponse is a response stored on disk
+type cachedResponse struct {
+	Status  int         `json:"status"`
+	Header  http.Header `json:"header"`
+	Body    []byte      `json:"body"`
+	Expires time.Time   `json:"expires"`
+}
+
+// NewHTTPCache creates a new http cache.
+func NewHTTPCache(dir string) (*HTTPCache, error) {
+	log.Println("Creating new http cache")
+	if dir == "" {
+		defaultDir, err := getDefaultDir()
+		if err != nil {
+			return nil, err
+		}
+
+		dir = defaultDir
+	}
+
+	return &HTTPCache{dir: filepath.Join(dir, "http")}, nil
+}
+
+// Clear removes all the stored responses
+func (hc *HTTPCache) Clear() error {
+	return os.RemoveAll(hc.dir)
+}
+
+// Prune removes the responses which weren't stored for a long time
+func (hc *HTTPCache) Prune() error {
+	entries, err := os.ReadDir(hc.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err == nil && time.Since(info.ModTime()) > unusedResponseAge {
+			_ = os.Remove(filepath.Join(hc.dir, entry.Name()))
+		}
+	}
+
+	return nil
+}
+
+// Transport returns a round tripper which answers the requests from the cache when it can and
+// stores the responses of the other ones
+func (hc *HTTPCache) Transport(next http.RoundTripper) http.RoundTripper {
+	return cachingTransport{hc, next}
+}
+
+// load returns the stored response to the request
+func (hc *HTTPCache) load(url string) (cachedResponse, bool) {
+	data, err := os.ReadFile(hc.path(url))
+	if err != nil {
+		return cachedResponse{}, false
+	}
+
+	var cached cachedResponse
+	if err = json.Unmarshal(data, &cached); err != nil {
+		return cachedResponse{}, false
+	}
+
+	return cached, true
+}
+
+// store saves the response to the request, it's written to a temporary file first so that the
+// concurrent requests never read half of it
+func (hc *HTTPCache) store(url string, cached cachedResponse) {
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return
+	}
+
+	if err = os.MkdirAll(hc.dir, 0755); err != nil {
+		log.Println("Couldn't create the http cache:", err)
+		return
+	}
+
+	path := hc.path(url)
+	tmp, err := os.CreateTemp(hc.dir, ".tmp-*")
+	if err != nil {
+		log.Println("Couldn't store the response:", err)
+		return
+	}
+
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+
+	if err != nil {
+		log.Println("Couldn't store the response:", err)
+		_ = os.Remove(tmp.Name())
+	}
+}
+
+// path returns the path of the file in which the response is stored
+func (hc *HTTPCache) path(url string) string {
+	h := murmur3.New64()
+	_, _ = h.Write([]byte(url))
+	return filepath.Join(hc.dir, fmt.Sprintf("%016x.json", h.Sum64()))
+}
+
+// cachingTransport answers the requests using the http cache
+type cachingTransport struct {
+	cache *HTTPCache
+	next  http.RoundTripper
+}
+
+// RoundTrip answers the request from the cache if the stored response is fresh, otherwise the
+// request is sent and its response is stored. The conditional requests of the caller get a 304
+// response if the stored response matches them. The requests with "Cache-Control: no-cache",
+// like the refreshes, are always revalidated with the server
+func (t cachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// The responses to the requests with credentials are private, they aren't stored
+	if req.Method != http.MethodGet || req.Header.Get("Authorization") != "" {
+		return t.next.RoundTrip(req)
+	}
+
+	url := req.URL.String()
+	cached, ok := t.cache.load(url)
+	conditional := req.Header.Get("If-None-Match") != "" || req.Header.Get("If-Modified-Since") != ""
+	revalidate := strings.Contains(strings.ToLower(req.Header.Get("Cache-Control")), "no-cache")
+	if ok && !revalidate && time.Now().Before(cached.Expires) {
+		log.Println("Using the cached response of", url)
+		if conditional && cached.matches(req) {
+			return cached.response(req, http.StatusNotModified), nil
+		}
+
+		return cached.response(req, cached.Status), nil
+	}
+
+	// The stored response is revalidated, unless the caller has its own copy
+	if ok && !conditional {
+		req = req.Clone(req.Context())
+		if etag := cached.Header.Get("ETag"); etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+
+		if lastModified := cached.Header.Get("Last-Modified"); lastModified != "" {
+			req.Header.Set("If-Modified-Since", lastModified)
+		}
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && ok:
+		expires, storable := freshUntil(resp.Header, time.Now())
+		if storable {
+			cached.Expires = expires
+			t.cache.store(url, cached)
+		}
+
+		if conditional {
+			return resp, nil
+		}
+
+		resp.Body.Close()
+		return cached.response(req, cached.Status), nil
+
+	case resp.StatusCode == http.StatusOK:
+		expires, storable := freshUntil(resp.Header, time.Now())
+		if !storable {
+			return resp, nil
+		}
+
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxCachedBody+1))
+		if err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+
+		// The rest of a large body is read by the caller
+		if len(body) > maxCachedBody {
+			resp.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+			return resp, nil
+		}
+
+		resp.Body.Close()
+		stored := cachedResponse{resp.StatusCode, resp.Header, body, expires}
+		if expires.After(time.Now()) || stored.Header.Get("ETag") != "" || stored.Header.Get("Last-Modified") != "" {
+			t.cache.store(url, stored)
+		}
+
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		return resp, nil
+	}
+
+	return resp, nil
+}
+
+// matches reports if the validators of the conditional request match the stored response
+func (c cachedResponse) matches(req *http.Request) bool {
+	if etag := req.Header.Get("If-None-Match"); etag != "" {
+		return etag == c.Header.Get("ETag")
+	}
+
+	return req.Header.Get("If-Modified-Since") == c.Header.Get("Last-Modified")
+}
+
+// response returns the stored response with the status
+func (c cachedResponse) response(req *http.Request, status int) *http.Response {
+	body := c.Body
+	if status == http.StatusNotModified {
+		body = nil
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        c.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// freshUntil returns the time until which the response is fresh according to its headers, capped
+// by the maximum age. The responses which mustn't be stored aren't storable
+func freshUntil(header http.Header, now time.Time) (time.Time, bool) {
+	maxAge, hasMaxAge := time.Duration(0), false
+	for _, directive := range strings.Split(header.Get("Cache-Control"), ",") {
+		name, value, _ := strings.Cut(strings.ToLower(strings.TrimSpace(directive)), "=")
+		switch name {
+		case "no-store":
+			return time.Time{}, false
+		case "no-cache":
+			return now, true
+		case "max-age":
+			if seconds, err := strconv.Atoi(strings.Trim(value, `"`)); err == nil {
+				maxAge, hasMaxAge = time.Duration(seconds)*time.Second, true
+			}
+		}
+	}
+
+	if !hasMaxAge {
+		expires, err := http.ParseTime(header.Get("Expires"))
+		if err != nil {
+			return now, true
+		}
+
+		maxAge = expires.Sub(now)
+	}
+
+	if maxAge > MaxHTTPCacheAge {
+		maxAge = MaxHTTPCacheAge
+	}
+
+	return now.Add(maxAge), true
+}
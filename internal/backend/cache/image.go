@@ -0,0 +1,109 @@
+package cache
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/spaolacci/murmur3"
+)
+
+// MaxImageSize is the maximum size of an image which is downloaded
+var MaxImageSize int64 = 10 << 20
+
+// ImageStore downloads the images of the articles and keeps them on disk
+type ImageStore struct {
+	dir string
+}
+
+// NewImageStore creates a new image store.
+func NewImageStore(dir string) (*ImageStore, error) {
+	log.Println("Creating new image store")
+	if dir == "" {
+		defaultDir, err := getDefaultDir()
+		if err != nil {
+			return nil, err
+		}
+
+		dir = defaultDir
+	}
+
+	return &ImageStore{dir: filepath.Join(dir, "images")}, nil
+}
+
+// Get returns the image data, it's downloaded if it's not stored yet
+func (is *ImageStore) Get(url string) ([]byte, error) {
+	path := is.path(url)
+	if data, err := os.ReadFile(path); err == nil {
+		return data, nil
+	}
+
+	log.Println("Downloading image", url)
+	data, err := download(url)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = is.store(path, data); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// download downloads an image, it's refused if it's larger than the maximum size
+func download(url string) ([]byte, error) {
+	req, err := newRequest(url)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "image/png, image/jpeg, image/gif, image/*;q=0.8")
+	resp, err := newClient(url).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, gofeed.HTTPError{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+		}
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, MaxImageSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(data)) > MaxImageSize {
+		return nil, fmt.Errorf("image larger than %d bytes", MaxImageSize)
+	}
+
+	return data, nil
+}
+
+// store writes the image data to the path in the store
+func (is *ImageStore) store(path string, data []byte) error {
+	if err := os.MkdirAll(is.dir, 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0600)
+}
+
+// Clear removes all the stored images
+func (is *ImageStore) Clear() error {
+	return os.RemoveAll(is.dir)
+}
+
+// path returns the path of the file in which the image is stored
+func (is *ImageStore) path(url string) string {
+	h := murmur3.New64()
+	_, _ = h.Write([]byte(url))
+	return filepath.Join(is.dir, fmt.Sprintf("%016x", h.Sum64()))
+}
@@ -0,0 +1,504 @@
+package cache
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"log"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/TypicalAM/goread/internal/backend/rss"
+	"github.com/mmcdole/gofeed"
+	"golang.org/x/net/html/atom"
+	"golang.org/x/net/html/charset"
+)
+
+// MailTimeout is how long the conversation with a mail server can take
+var MailTimeout = time.Minute
+
+// maxMailboxArticles is the number of the newest messages fetched from a mailbox
+const maxMailboxArticles = 50
+
+// maxMessageSize is the size of the largest message which is downloaded
+const maxMessageSize = 20 << 20
+
+var (
+	literalSize = regexp.MustCompile(`\{(\d+)\}$`)
+	fetchedUID  = regexp.MustCompile(`\bUID (\d+)`)
+)
+
+// browserLinkTexts are the texts of the links to the web version of a newsletter
+var browserLinkTexts = []string{
+	"view in browser", "view in your browser", "view online", "view this email", "view it in your browser",
+	"read online", "read in browser", "open in browser", "view on the web", "web version",
+}
+
+// isMailbox reports if the url belongs to a mailbox feed
+func isMailbox(url string) bool {
+	return rss.IsMailbox(url)
+}
+
+// parseMailbox downloads the newest messages of the IMAP mailbox and turns them into a feed. The
+// username and the password come from the url or from the settings of the private feeds, the
+// from parameter only keeps the messages from the matching senders
+func parseMailbox(mailboxURL string) (*gofeed.Feed, error) {
+	target, err := url.Parse(mailboxURL)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := HTTPSettings.For(mailboxURL)
+	username := target.User.Username()
+	password, hasPassword := target.User.Password()
+	if username == "" {
+		username = settings.Username
+	}
+
+	if !hasPassword {
+		if password, err = secret(settings); err != nil {
+			return nil, err
+		}
+	}
+
+	if username == "" {
+		return nil, errors.New("the mailbox needs a username")
+	}
+
+	mailbox := strings.Trim(target.Path, "/")
+	if mailbox == "" {
+		mailbox = "INBOX"
+	}
+
+	from := target.Query().Get("from")
+	for _, value := range []string{username, password, mailbox, from} {
+		if strings.ContainsAny(value, "\r\n\x00") {
+			return nil, errors.New("the mailbox url can't contain line breaks or null characters")
+		}
+	}
+
+	conn, err := dialIMAP(target)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.close()
+
+	if !conn.preauth {
+		if _, err = conn.command("LOGIN " + imapQuote(username) + " " + imapQuote(password)); err != nil {
+			return nil, err
+		}
+	}
+
+	// The mailbox is opened read-only, so that the messages aren't marked as seen
+	if _, err = conn.command("EXAMINE " + imapQuote(mailbox)); err != nil {
+		return nil, err
+	}
+
+	criteria := "ALL"
+	if from != "" {
+		criteria = "FROM " + imapQuote(from)
+	}
+
+	responses, err := conn.command("UID SEARCH " + criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	var uids []string
+	for _, resp := range responses {
+		if fields := strings.Fields(resp.text); len(fields) > 1 && strings.EqualFold(fields[1], "SEARCH") {
+			uids = append(uids, fields[2:]...)
+		}
+	}
+
+	if len(uids) > maxMailboxArticles {
+		uids = uids[len(uids)-maxMailboxArticles:]
+	}
+
+	feed := &gofeed.Feed{Title: mailbox}
+	if len(uids) == 0 {
+		return feed, nil
+	}
+
+	if responses, err = conn.command("UID FETCH " + strings.Join(uids, ",") + " (UID BODY.PEEK[])"); err != nil {
+		return nil, err
+	}
+
+	for _, resp := range responses {
+		if len(resp.literals) == 0 {
+			continue
+		}
+
+		item, err := parseMessage(resp.literals[0])
+		if err != nil {
+			log.Println("Couldn't parse a message:", err)
+			continue
+		}
+
+		if item.GUID == "" {
+			if match := fetchedUID.FindStringSubmatch(resp.text); match != nil {
+				item.GUID = rss.StripCredentials(mailboxURL) + ";UID=" + match[1]
+			}
+		}
+
+		feed.Items = append(feed.Items, &item)
+	}
+
+	// The newest messages come first, like in the other feeds
+	sort.SliceStable(feed.Items, func(i, j int) bool {
+		first, second := feed.Items[i].PublishedParsed, feed.Items[j].PublishedParsed
+		return first != nil && second != nil && first.After(*second)
+	})
+
+	_, _ = conn.command("LOGOUT")
+	return feed, nil
+}
+
+// imapConn is a connection to an IMAP server which sends the commands one at a time
+type imapConn struct {
+	conn    net.Conn
+	reader  *bufio.Reader
+	tag     int
+	preauth bool
+}
+
+// imapResponse is an untagged response of the server, the literals are the raw data sent with it
+type imapResponse struct {
+	text     string
+	literals [][]byte
+}
+
+// dialIMAP connects to the server of the mailbox, imaps uses tls from the start and imap is upgraded
+// with STARTTLS. The password is only sent without tls to a server on this machine, like net/smtp does
+func dialIMAP(target *url.URL) (*imapConn, error) {
+	dialer := &net.Dialer{Timeout: MailTimeout}
+	var conn net.Conn
+	var err error
+	if target.Scheme == "imaps" {
+		address := target.Host
+		if target.Port() == "" {
+			address = net.JoinHostPort(target.Hostname(), "993")
+		}
+
+		conn, err = tls.DialWithDialer(dialer, "tcp", address, &tls.Config{ServerName: target.Hostname(), MinVersion: tls.VersionTLS12})
+	} else {
+		address := target.Host
+		if target.Port() == "" {
+			address = net.JoinHostPort(target.Hostname(), "143")
+		}
+
+		conn, err = dialer.Dial("tcp", address)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	_ = conn.SetDeadline(time.Now().Add(MailTimeout))
+	c := &imapConn{conn: conn, reader: bufio.NewReader(conn)}
+	greeting, err := c.readResponse()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if !strings.HasPrefix(greeting.text, "* OK") && !strings.HasPrefix(greeting.text, "* PREAUTH") {
+		conn.Close()
+		return nil, fmt.Errorf("imap: unexpected greeting %q", greeting.text)
+	}
+
+	c.preauth = strings.HasPrefix(greeting.text, "* PREAUTH")
+	if target.Scheme == "imaps" || c.preauth {
+		return c, nil
+	}
+
+	if _, err = c.command("STARTTLS"); err != nil {
+		if isLoopback(target.Hostname()) {
+			return c, nil
+		}
+
+		conn.Close()
+		return nil, fmt.Errorf("imap: the server doesn't support STARTTLS, refusing to send the password without tls (%w)", err)
+	}
+
+	tlsConn := tls.Client(conn, &tls.Config{ServerName: target.Hostname(), MinVersion: tls.VersionTLS12})
+	if err = tlsConn.Handshake(); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("imap: starting tls: %w", err)
+	}
+
+	c.conn, c.reader = tlsConn, bufio.NewReader(tlsConn)
+	return c, nil
+}
+
+// isLoopback reports if the host is this machine
+func isLoopback(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// close closes the connection
+func (c *imapConn) close() {
+	c.conn.Close()
+}
+
+// command sends the command and returns the untagged responses to it, an error is returned
+// unless the server completes it
+func (c *imapConn) command(command string) ([]imapResponse, error) {
+	c.tag++
+	tag := fmt.Sprintf("g%d", c.tag)
+	if _, err := fmt.Fprintf(c.conn, "%s %s\r\n", tag, command); err != nil {
+		return nil, err
+	}
+
+	var responses []imapResponse
+	for {
+		resp, err := c.readResponse()
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case strings.HasPrefix(resp.text, tag+" OK"):
+			return responses, nil
+		case strings.HasPrefix(resp.text, tag+" "):
+			return nil, fmt.Errorf("imap: %s", strings.TrimPrefix(resp.text, tag+" "))
+		case strings.HasPrefix(resp.text, "* "):
+			responses = append(responses, resp)
+		}
+	}
+}
+
+// readResponse reads a line of the server, the literals in it are read as well
+func (c *imapConn) readResponse() (imapResponse, error) {
+	var resp imapResponse
+	for {
+		line, err := c.reader.ReadString('\n')
+		if err != nil {
+			return resp, err
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+		resp.text += line
+		match := literalSize.FindStringSubmatch(line)
+		if match == nil {
+			return resp, nil
+		}
+
+		size, err := strconv.Atoi(match[1])
+		if err != nil || size > maxMessageSize {
+			return resp, fmt.Errorf("imap: the message is too large")
+		}
+
+		literal := make([]byte, size)
+		if _, err = io.ReadFull(c.reader, literal); err != nil {
+			return resp, err
+		}
+
+		resp.literals = append(resp.literals, literal)
+	}
+}
+
+// imapQuote quotes the string for a command. A line break would end the command and start another
+// one, so they're dropped along with the null characters even though parseMailbox refuses them
+func imapQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\r", "", "\n", "", "\x00", "").Replace(s) + `"`
+}
+
+// parseMessage turns an email into an article, the html body is preferred over the plain text
+func parseMessage(data []byte) (gofeed.Item, error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		return gofeed.Item{}, err
+	}
+
+	decoder := &mime.WordDecoder{CharsetReader: charset.NewReaderLabel}
+	subject, err := decoder.DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil {
+		subject = msg.Header.Get("Subject")
+	}
+
+	item := gofeed.Item{
+		Title: subject,
+		GUID:  strings.Trim(msg.Header.Get("Message-Id"), "<> "),
+	}
+
+	parser := mail.AddressParser{WordDecoder: decoder}
+	if from, err := parser.Parse(msg.Header.Get("From")); err == nil {
+		name := from.Name
+		if name == "" {
+			name = from.Address
+		}
+
+		item.Author = &gofeed.Person{Name: name, Email: from.Address}
+		item.Authors = []*gofeed.Person{item.Author}
+	}
+
+	if date, err := msg.Header.Date(); err == nil {
+		item.PublishedParsed = &date
+		item.Published = date.Format(time.RFC1123Z)
+	}
+
+	htmlBody, textBody := messageBodies(textproto.MIMEHeader(msg.Header), msg.Body)
+	switch {
+	case htmlBody != "":
+		item.Description, item.Link = cleanEmail(htmlBody)
+	case textBody != "":
+		item.Description = textToHTML(textBody)
+	}
+
+	return item, nil
+}
+
+// messageBodies walks the parts of the message and returns its first html and plain text bodies,
+// the attachments are skipped
+func messageBodies(header textproto.MIMEHeader, body io.Reader) (htmlBody, textBody string) {
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		mediaType, params = "text/plain", nil
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextPart()
+			if err != nil {
+				return htmlBody, textBody
+			}
+
+			if strings.HasPrefix(strings.ToLower(part.Header.Get("Content-Disposition")), "attachment") {
+				continue
+			}
+
+			partHTML, partText := messageBodies(part.Header, part)
+			if htmlBody == "" {
+				htmlBody = partHTML
+			}
+
+			if textBody == "" {
+				textBody = partText
+			}
+		}
+	}
+
+	if mediaType != "text/html" && mediaType != "text/plain" {
+		return "", ""
+	}
+
+	// The multipart reader decodes the quoted-printable parts itself and removes the header
+	var reader io.Reader = body
+	switch strings.ToLower(header.Get("Content-Transfer-Encoding")) {
+	case "base64":
+		reader = base64.NewDecoder(base64.StdEncoding, body)
+	case "quoted-printable":
+		reader = quotedprintable.NewReader(body)
+	}
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return "", ""
+	}
+
+	text := decodeCharset(params["charset"], data)
+	if mediaType == "text/html" {
+		return text, ""
+	}
+
+	return "", text
+}
+
+// cleanEmail prepares the html of a newsletter for the article view, the layout tables are turned
+// into blocks and the hidden parts are removed. The link to the web version is returned as well
+func cleanEmail(body string) (string, string) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(body))
+	if err != nil {
+		return body, ""
+	}
+
+	doc.Find("head, style, script, title").Remove()
+	doc.Find(`img[src^="cid:"], img[width="1"], img[height="1"]`).Remove()
+	doc.Find("[style]").Each(func(_ int, s *goquery.Selection) {
+		style := strings.ReplaceAll(strings.ToLower(s.AttrOr("style", "")), " ", "")
+		if strings.Contains(style, "display:none") {
+			s.Remove()
+		}
+	})
+
+	doc.Find("table, thead, tbody, tfoot, tr, td, th").Each(func(_ int, s *goquery.Selection) {
+		for _, node := range s.Nodes {
+			node.Data, node.DataAtom = "div", atom.Div
+		}
+	})
+
+	var link string
+	doc.Find("a[href]").EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		text := strings.ToLower(strings.Join(strings.Fields(s.Text()), " "))
+		for _, linkText := range browserLinkTexts {
+			if strings.Contains(text, linkText) && strings.HasPrefix(s.AttrOr("href", ""), "http") {
+				link = s.AttrOr("href", "")
+				return false
+			}
+		}
+
+		return true
+	})
+
+	cleaned, err := doc.Find("body").Html()
+	if err != nil {
+		return body, link
+	}
+
+	return cleaned, link
+}
+
+// textToHTML turns a plain text email into paragraphs
+func textToHTML(text string) string {
+	var sb strings.Builder
+	for _, paragraph := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n\n") {
+		if paragraph = strings.TrimSpace(paragraph); paragraph != "" {
+			sb.WriteString("<p>" + strings.ReplaceAll(html.EscapeString(paragraph), "\n", "<br>") + "</p>")
+		}
+	}
+
+	return sb.String()
+}
+
+// decodeCharset converts the text in the charset to utf-8, the text is left as it is if the charset
+// isn't known
+func decodeCharset(label string, data []byte) string {
+	if label == "" {
+		return string(data)
+	}
+
+	reader, err := charset.NewReaderLabel(label, bytes.NewReader(data))
+	if err != nil {
+		return string(data)
+	}
+
+	decoded, err := io.ReadAll(reader)
+	if err != nil {
+		return string(data)
+	}
+
+	return string(decoded)
+}
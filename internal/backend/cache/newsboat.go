@@ -0,0 +1,56 @@
+package cache
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os/exec"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// ErrNoSqlite is returned when the newsboat cache is imported without the sqlite3 command
+var ErrNoSqlite = errors.New("sqlite3 not found, install it to import the newsboat cache")
+
+// newsboatReadQuery selects the read articles from the newsboat cache
+const newsboatReadQuery = "SELECT guid, title, url FROM rss_item WHERE unread = 0 AND deleted = 0"
+
+// ImportNewsboat marks the articles which were read in newsboat as read, the cache.db file is read
+// using the sqlite3 command. It returns the number of the imported articles
+func (rs *ReadStatus) ImportNewsboat(dbPath string) (int, error) {
+	if _, err := exec.LookPath("sqlite3"); err != nil {
+		return 0, ErrNoSqlite
+	}
+
+	out, err := exec.Command("sqlite3", "-readonly", "-json", dbPath, newsboatReadQuery).Output() //nolint:gosec
+	if err != nil {
+		return 0, fmt.Errorf("reading the newsboat cache failed: %w", err)
+	}
+
+	// There is no output at all if nothing was read
+	var rows []struct {
+		GUID  string `json:"guid"`
+		Title string `json:"title"`
+		URL   string `json:"url"`
+	}
+
+	if out = bytes.TrimSpace(out); len(out) > 0 {
+		if err = json.Unmarshal(out, &rows); err != nil {
+			return 0, fmt.Errorf("parsing the newsboat cache failed: %w", err)
+		}
+	}
+
+	for _, row := range rows {
+		// Newsboat makes up a guid if the feed doesn't have one, goread uses the title and the link then
+		if row.GUID != "" {
+			rs.MarkAsRead(gofeed.Item{GUID: row.GUID})
+		}
+
+		rs.MarkAsRead(gofeed.Item{Title: row.Title, Link: row.URL})
+	}
+
+	log.Printf("Imported %d read articles from newsboat\n", len(rows))
+	return len(rows), nil
+}
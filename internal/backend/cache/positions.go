@@ -0,0 +1,48 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// Position is the line of an article at which the reading stopped
+type Position struct {
+	Line    int       `json:"line"`
+	Updated time.Time `json:"updated"`
+}
+
+// Positions stores the lines at which the reading of the articles stopped
+type Positions struct {
+	*jsonStore[map[uint32]Position]
+}
+
+// NewPositions creates a new reading position store.
+func NewPositions(dir string) (*Positions, error) {
+	store, err := newJSONStore(dir, "positions.json", "reading position", make(map[uint32]Position))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Positions{store}, nil
+}
+
+// Get returns the line at which the reading of an article stopped, it's zero if it wasn't started.
+func (p *Positions) Get(item gofeed.Item) int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.data[hashArticle(item)].Line
+}
+
+// Set remembers the line at which the reading of an article stopped, the position is forgotten if
+// the line is zero.
+func (p *Positions) Set(item gofeed.Item, line int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if line <= 0 {
+		delete(p.data, hashArticle(item))
+		return
+	}
+
+	p.data[hashArticle(item)] = Position{Line: line, Updated: time.Now()}
+}
@@ -0,0 +1,103 @@
+package cache
+
+import (
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HostLimit is the number of the requests made to a single host at the same time, zero means no limit
+var HostLimit = 2
+
+// HostJitter is the longest random delay before a request to a host which was requested recently
+var HostJitter = 500 * time.Millisecond
+
+// recentRequest is how long after a request to a host the next ones get the jitter
+const recentRequest = time.Minute
+
+// hosts throttles the requests made to the hosts, many feeds often share one (e.g. subreddits)
+var hosts = hostLimiter{
+	slots: make(map[string]chan struct{}),
+	last:  make(map[string]time.Time),
+}
+
+// hostLimiter limits the number of the requests made to every host and spreads them out
+type hostLimiter struct {
+	mu    sync.Mutex
+	slots map[string]chan struct{}
+	last  map[string]time.Time
+}
+
+// wait blocks until a request to the host of the url can be made, the returned function has to be
+// called after the request is done
+func (l *hostLimiter) wait(target string) func() {
+	parsed, err := url.Parse(target)
+	if err != nil || parsed.Host == "" {
+		return func() {}
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+	l.mu.Lock()
+	slot, ok := l.slots[host]
+	if !ok && HostLimit > 0 {
+		slot = make(chan struct{}, HostLimit)
+		l.slots[host] = slot
+	}
+	l.mu.Unlock()
+
+	if slot != nil {
+		slot <- struct{}{}
+	}
+
+	l.mu.Lock()
+	recent := time.Since(l.last[host]) < recentRequest
+	l.last[host] = time.Now()
+	l.mu.Unlock()
+
+	if recent && HostJitter > 0 {
+		time.Sleep(time.Duration(rand.Int63n(int64(HostJitter))))
+	}
+
+	return func() {
+		if slot != nil {
+			<-slot
+		}
+	}
+}
+
+// limitingTransport sends the requests once the limits of their hosts allow it
+type limitingTransport struct {
+	next http.RoundTripper
+}
+
+// RoundTrip waits for the host of the request and sends it, the host is freed once the body of
+// the response is closed
+func (t limitingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	release := hosts.wait(req.URL.String())
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+// releasingBody frees the host of the request when it's closed
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+	once    sync.Once
+}
+
+// Close closes the body and frees the host, only once
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
@@ -6,16 +6,18 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"sync"
 
 	"github.com/mmcdole/gofeed"
 	"github.com/spaolacci/murmur3"
 )
 
 // ReadStatus is a set containing the hashes of the already read articles. We use a struct{} here
-// because it takes up no space in memory. To hash the article, we use the URL of the feed and the title
-// of the article.
+// because it takes up no space in memory. To hash the article, we use its GUID, falling back to the
+// link and the title of the article if the feed doesn't provide one.
 type ReadStatus struct {
 	set      map[uint32]struct{}
+	mu       sync.RWMutex
 	filePath string
 }
 
@@ -53,13 +55,17 @@ func (rs *ReadStatus) Load() error {
 		return err
 	}
 
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
 	rs.set, err = unmarshal(data)
 	return err
 }
 
 // Save writes the cache to disk
-func (rs ReadStatus) Save() error {
+func (rs *ReadStatus) Save() error {
+	rs.mu.RLock()
 	data := marshal(rs.set)
+	rs.mu.RUnlock()
 	log.Println("Marshalling the data yielded a size of", len(data))
 
 	// Try to write the data to the file
@@ -79,18 +85,36 @@ func (rs ReadStatus) Save() error {
 
 // MarkAsRead adds an article to the set.
 func (rs *ReadStatus) MarkAsRead(item gofeed.Item) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
 	rs.set[hashArticle(item)] = struct{}{}
 }
 
-// IsRead checks if an article is already in the set.
-func (rs ReadStatus) IsRead(item gofeed.Item) bool {
-	_, ok := rs.set[hashArticle(item)]
-	return ok
+// IsRead checks if an article is already in the set. The articles marked as read before the GUIDs
+// were used are found by their legacy hash and moved to the new one.
+func (rs *ReadStatus) IsRead(item gofeed.Item) bool {
+	hash, legacy := hashArticle(item), legacyHashArticle(item)
+	rs.mu.RLock()
+	_, ok := rs.set[hash]
+	_, legacyOk := rs.set[legacy]
+	rs.mu.RUnlock()
+	if ok || !legacyOk {
+		return ok
+	}
+
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	rs.set[hash] = struct{}{}
+	delete(rs.set, legacy)
+	return true
 }
 
 // MarkAsUnread removes an article from the set.
 func (rs *ReadStatus) MarkAsUnread(item gofeed.Item) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
 	delete(rs.set, hashArticle(item))
+	delete(rs.set, legacyHashArticle(item))
 }
 
 // marshal converts the set to bytes.
@@ -116,8 +140,34 @@ func unmarshal(data []byte) (map[uint32]struct{}, error) {
 	return set, nil
 }
 
+// CountUnread returns the number of articles which are not in the set.
+func (rs *ReadStatus) CountUnread(items SortableArticles) int {
+	var count int
+	for i := range items {
+		if !rs.IsRead(items[i]) {
+			count++
+		}
+	}
+
+	return count
+}
+
 // hashArticle hashes the gofeed.Item to a uint32.
 func hashArticle(item gofeed.Item) uint32 {
+	h := murmur3.New32()
+	if item.GUID != "" {
+		h.Write([]byte(item.GUID))
+		return h.Sum32()
+	}
+
+	h.Write([]byte(item.Title))
+	h.Write([]byte(item.Link))
+	return h.Sum32()
+}
+
+// legacyHashArticle hashes the gofeed.Item the way the read status did before the GUIDs were used,
+// it's the same as hashArticle for the articles without a GUID.
+func legacyHashArticle(item gofeed.Item) uint32 {
 	h := murmur3.New32()
 	h.Write([]byte(item.Title))
 	h.Write([]byte(item.Link))
@@ -0,0 +1,229 @@
+package cache
+
+import (
+	"errors"
+	"log"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/mmcdole/gofeed"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/charset"
+)
+
+// ErrNoContent is returned when the main content of a page couldn't be found
+var ErrNoContent = errors.New("no content found")
+
+// fullContentKey marks the articles which already have their full content extracted
+const fullContentKey = "goread_full_content"
+
+// minContentLength is the minimum length of the text which is considered to be an article
+const minContentLength = 250
+
+var (
+	positiveHint = regexp.MustCompile(`(?i)article|body|content|entry|main|page|post|text|blog|story`)
+	negativeHint = regexp.MustCompile(`(?i)comment|meta|footer|footnote|sidebar|sponsor|promo|related|share|social|nav|menu|widget|banner|ad-`)
+)
+
+// GetFullArticles returns an article list like GetArticles, but the content of the articles is
+// replaced with the main text of their pages. The extracted content is kept in the cache
+func (c *Cache) GetFullArticles(url string, ignoreCache bool) (SortableArticles, error) {
+	articles, err := c.GetArticles(url, ignoreCache)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(SortableArticles, len(articles))
+	copy(result, articles)
+
+	workers := DefaultWorkers
+	if workers < 1 {
+		workers = 1
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				content, err := ExtractContent(result[i].Link)
+				if errors.Is(err, ErrNoContent) {
+					// There is no point in trying again
+					result[i].Custom = withCustom(result[i].Custom, fullContentKey, "false")
+				}
+
+				if err != nil {
+					log.Printf("Error extracting the content of %s: %v\n", result[i].Link, err)
+					continue
+				}
+
+				result[i].Content = content
+				result[i].Custom = withCustom(result[i].Custom, fullContentKey, "true")
+			}
+		}()
+	}
+
+	for i := range result {
+		if result[i].Link != "" && result[i].Custom[fullContentKey] == "" {
+			jobs <- i
+		}
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	c.mu.Lock()
+	if entry, ok := c.Content[url]; ok {
+		entry.Articles = result
+		c.Content[url] = entry
+	}
+	c.mu.Unlock()
+
+	return result, nil
+}
+
+// ExtractContent downloads a page and returns the html of its main content
+func ExtractContent(pageURL string) (string, error) {
+	if isGemini(pageURL) {
+		return extractGemini(pageURL)
+	}
+
+	req, err := newRequest(pageURL)
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Accept", "text/html")
+	resp, err := newPageClient(pageURL).Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", gofeed.HTTPError{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+		}
+	}
+
+	// The pages in the legacy encodings are transcoded to utf-8
+	body, err := charset.NewReader(resp.Body, resp.Header.Get("Content-Type"))
+	if err != nil {
+		return "", err
+	}
+
+	doc, err := goquery.NewDocumentFromReader(body)
+	if err != nil {
+		return "", err
+	}
+
+	return extractMainContent(doc)
+}
+
+// extractMainContent finds the element which most likely contains the article, it's scored
+// by the amount of the text in its paragraphs, its class/id names and the density of its links
+func extractMainContent(doc *goquery.Document) (string, error) {
+	doc.Find("script, style, noscript, iframe, form, nav, header, footer, aside, svg, button").Remove()
+
+	scores := make(map[*html.Node]float64)
+	doc.Find("p, pre, td").Each(func(_ int, p *goquery.Selection) {
+		text := strings.TrimSpace(p.Text())
+		if len(text) < 25 {
+			return
+		}
+
+		score := 1 + float64(strings.Count(text, ",")) + minFloat(float64(len(text))/100, 3)
+		if parent := p.Parent(); parent.Length() > 0 {
+			scores[parent.Get(0)] += score
+			if grandparent := parent.Parent(); grandparent.Length() > 0 {
+				scores[grandparent.Get(0)] += score / 2
+			}
+		}
+	})
+
+	var best *goquery.Selection
+	var bestScore float64
+	for node, score := range scores {
+		sel := doc.FindNodes(node)
+		score = (score + classWeight(sel)) * (1 - linkDensity(sel))
+		if best == nil || score > bestScore {
+			best, bestScore = sel, score
+		}
+	}
+
+	// Fall back to the semantic elements if there are no paragraphs
+	if best == nil {
+		best = doc.Find("article, main, [role='main']").First()
+	}
+
+	if best.Length() == 0 || len(strings.TrimSpace(best.Text())) < minContentLength {
+		return "", ErrNoContent
+	}
+
+	return best.Html()
+}
+
+// classWeight scores an element by the hints in its class and id names
+func classWeight(sel *goquery.Selection) float64 {
+	var weight float64
+	for _, attr := range []string{"class", "id"} {
+		value := sel.AttrOr(attr, "")
+		if value == "" {
+			continue
+		}
+
+		if negativeHint.MatchString(value) {
+			weight -= 25
+		}
+
+		if positiveHint.MatchString(value) {
+			weight += 25
+		}
+	}
+
+	if goquery.NodeName(sel) == "article" || goquery.NodeName(sel) == "main" {
+		weight += 25
+	}
+
+	return weight
+}
+
+// linkDensity returns the fraction of the text of an element which is inside links
+func linkDensity(sel *goquery.Selection) float64 {
+	textLength := len(sel.Text())
+	if textLength == 0 {
+		return 0
+	}
+
+	var linkLength int
+	sel.Find("a").Each(func(_ int, a *goquery.Selection) {
+		linkLength += len(a.Text())
+	})
+
+	return float64(linkLength) / float64(textLength)
+}
+
+// withCustom returns the custom fields of an item with the key set, the original map is not modified
+func withCustom(custom map[string]string, key, value string) map[string]string {
+	result := make(map[string]string, len(custom)+1)
+	for k, v := range custom {
+		result[k] = v
+	}
+
+	result[key] = value
+	return result
+}
+
+// minFloat returns the smaller of the two numbers
+func minFloat(a, b float64) float64 {
+	if a < b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,194 @@
+package cache
+
+import (
+	"bytes"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// repairedKey marks the feeds which could be parsed only after their xml was repaired, the value
+// lists the problems which were found
+const repairedKey = "goread_repaired"
+
+var (
+	namedEntity   = regexp.MustCompile(`^&[A-Za-z][A-Za-z0-9]*;`)
+	numericEntity = regexp.MustCompile(`^&#([0-9]+|[xX][0-9a-fA-F]+);`)
+)
+
+// xmlSections are the parts of a document which are copied as they are, the markup inside of them
+// isn't parsed
+var xmlSections = [][2]string{{"<![CDATA[", "]]>"}, {"<!--", "-->"}, {"<?", "?>"}}
+
+// isXML reports if the document looks like xml, the json feeds aren't repaired
+func isXML(data []byte) bool {
+	return bytes.HasPrefix(bytes.TrimLeft(data, " \t\r\n\ufeff"), []byte("<"))
+}
+
+// parseRepaired parses a feed whose xml is invalid after repairing it, the error of the original
+// parse is returned if it can't be repaired. The problems are noted in the custom fields of the feed
+func parseRepaired(data []byte, parseErr error) (*gofeed.Feed, error) {
+	repaired, issues := repairXML(data)
+	feed, err := gofeed.NewParser().Parse(bytes.NewReader(repaired))
+	if err != nil {
+		if closed, ok := closeTruncated(repaired); ok {
+			feed, err = gofeed.NewParser().Parse(bytes.NewReader(closed))
+			issues = append(issues, "truncated feed")
+		}
+	}
+
+	if err != nil || len(issues) == 0 {
+		return nil, parseErr
+	}
+
+	note := strings.Join(issues, ", ")
+	log.Printf("Repaired the invalid xml of a feed (%s): %v\n", note, parseErr)
+	if feed.Custom == nil {
+		feed.Custom = make(map[string]string)
+	}
+
+	feed.Custom[repairedKey] = note
+	return feed, nil
+}
+
+// repairXML fixes the common mistakes which make a feed invalid: the control characters, the
+// entities of the characters which xml doesn't allow, the ampersands which don't start an entity
+// and the less-than signs which don't start a tag. The problems which were found are returned too
+func repairXML(data []byte) ([]byte, []string) {
+	var issues []string
+	found := make(map[string]bool)
+	note := func(issue string) {
+		if !found[issue] {
+			found[issue] = true
+			issues = append(issues, issue)
+		}
+	}
+
+	// The invalid characters aren't allowed anywhere, not even in the CDATA sections
+	var clean bytes.Buffer
+	for i := 0; i < len(data); {
+		r, size := utf8.DecodeRune(data[i:])
+		switch {
+		case r == utf8.RuneError && size == 1:
+			note("invalid characters")
+			clean.WriteRune(utf8.RuneError)
+		case !isXMLChar(r):
+			note("control characters")
+		default:
+			clean.Write(data[i : i+size])
+		}
+
+		i += size
+	}
+
+	data = clean.Bytes()
+	var b bytes.Buffer
+	for i := 0; i < len(data); i++ {
+		rest := data[i:]
+		if end, ok := sectionEnd(rest); ok {
+			b.Write(rest[:end])
+			i += end - 1
+			continue
+		}
+
+		switch c := data[i]; {
+		case c == '<' && (len(rest) == 1 || !isTagStart(rest[1])):
+			note("unescaped less-than signs")
+			b.WriteString("&lt;")
+
+		case c == '&':
+			if match := numericEntity.FindSubmatch(rest); match != nil {
+				if isValidReference(string(match[1])) {
+					b.Write(match[0])
+				} else {
+					note("invalid entities")
+				}
+
+				i += len(match[0]) - 1
+				continue
+			}
+
+			if namedEntity.Match(rest) {
+				b.WriteByte(c)
+				continue
+			}
+
+			note("unescaped ampersands")
+			b.WriteString("&amp;")
+
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return b.Bytes(), issues
+}
+
+// sectionEnd returns the length of the section which starts the data, it reaches the end of the
+// data if the section isn't closed
+func sectionEnd(data []byte) (int, bool) {
+	for _, section := range xmlSections {
+		if !bytes.HasPrefix(data, []byte(section[0])) {
+			continue
+		}
+
+		if end := bytes.Index(data[len(section[0]):], []byte(section[1])); end >= 0 {
+			return len(section[0]) + end + len(section[1]), true
+		}
+
+		return len(data), true
+	}
+
+	return 0, false
+}
+
+// closeTruncated cuts the feed after its last complete article and closes the elements around it
+func closeTruncated(data []byte) ([]byte, bool) {
+	closing := map[string]string{
+		"</entry>": "</feed>",
+		"</item>":  "</channel></rss>",
+	}
+
+	// The items of the RSS 1.0 feeds are outside of the channel
+	if bytes.Contains(data, []byte("<rdf:RDF")) {
+		closing["</item>"] = "</rdf:RDF>"
+	}
+
+	for end, root := range closing {
+		if i := bytes.LastIndex(data, []byte(end)); i >= 0 {
+			closed := append(append([]byte{}, data[:i+len(end)]...), root...)
+			return closed, true
+		}
+	}
+
+	return nil, false
+}
+
+// isTagStart reports if the character after a less-than sign starts a tag, a closing tag, a
+// comment or a processing instruction
+func isTagStart(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_' || c == ':' || c == '/' || c == '!' || c == '?' || c >= utf8.RuneSelf
+}
+
+// isValidReference reports if the number of a character reference is a character allowed in xml
+func isValidReference(number string) bool {
+	base := 10
+	if strings.HasPrefix(number, "x") || strings.HasPrefix(number, "X") {
+		number, base = number[1:], 16
+	}
+
+	code, err := strconv.ParseInt(number, base, 32)
+	return err == nil && isXMLChar(rune(code))
+}
+
+// isXMLChar reports if the character is allowed in an xml document
+func isXMLChar(r rune) bool {
+	return r == '\t' || r == '\n' || r == '\r' ||
+		r >= 0x20 && r <= 0xd7ff ||
+		r >= 0xe000 && r <= 0xfffd ||
+		r >= 0x10000 && r <= 0x10ffff
+}
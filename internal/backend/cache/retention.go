@@ -0,0 +1,169 @@
+package cache
+
+import (
+	"encoding/json"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/TypicalAM/goread/internal/config"
+	"github.com/mmcdole/gofeed"
+)
+
+// Retention are the policies which decide how long the articles are kept in the cache
+var Retention config.Retention
+
+// Collected is the result of a garbage collection pass over the cache
+type Collected struct {
+	Articles int
+	Feeds    int
+}
+
+// retained is an article which is a candidate for the removal when the cache is over its size
+type retained struct {
+	url  string
+	hash uint32
+	date time.Time
+	size int
+}
+
+// keepOld returns the fetched articles followed by the previous ones which dropped out of the feed
+func keepOld(prev, fetched SortableArticles) SortableArticles {
+	if len(prev) == 0 {
+		return fetched
+	}
+
+	seen := make(map[uint32]bool, len(fetched))
+	for _, item := range fetched {
+		seen[hashArticle(item)] = true
+	}
+
+	articles := append(SortableArticles{}, fetched...)
+	for _, item := range prev {
+		if !seen[hashArticle(item)] {
+			articles = append(articles, item)
+		}
+	}
+
+	return articles
+}
+
+// Collect removes the articles which the retention policies don't keep and the feeds which
+// aren't subscribed to anymore. The subscribed feeds are the urls, the articles for which isRead
+// reports false are unread and the ones for which isKept reports true are never removed
+func (c *Cache) Collect(urls []string, isRead, isKept func(gofeed.Item) bool, now time.Time) Collected {
+	subscribed := make(map[string]bool, len(urls))
+	for _, url := range urls {
+		subscribed[url] = true
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var result Collected
+	for url, entry := range c.Content {
+		if !subscribed[url] {
+			delete(c.Content, url)
+			delete(c.Health, url)
+			result.Feeds++
+			result.Articles += len(entry.Articles)
+			continue
+		}
+
+		kept := collectFeed(entry.Articles, isRead, isKept, now)
+		result.Articles += len(entry.Articles) - len(kept)
+		entry.Articles = kept
+		c.Content[url] = entry
+	}
+
+	result.Articles += c.collectSize(isRead, isKept)
+	if result.Articles > 0 || result.Feeds > 0 {
+		log.Printf("Collected %d articles and %d feeds from the cache\n", result.Articles, result.Feeds)
+	}
+
+	return result
+}
+
+// isProtected reports if the article stays in the cache whatever the limits are, these are the
+// unread articles when they're kept and the ones the user kept something of
+func isProtected(item gofeed.Item, isRead, isKept func(gofeed.Item) bool) bool {
+	return Retention.KeepUnread && !isRead(item) || isKept(item)
+}
+
+// collectFeed returns the articles of a feed which the retention policies keep, the articles of
+// the feed come first so they are the newest
+func collectFeed(articles SortableArticles, isRead, isKept func(gofeed.Item) bool, now time.Time) SortableArticles {
+	var kept SortableArticles
+	for i, item := range articles {
+		protected := isProtected(item, isRead, isKept)
+		tooOld := Retention.PurgeReadDays > 0 && isRead(item) && item.PublishedParsed != nil &&
+			now.Sub(*item.PublishedParsed) > time.Duration(Retention.PurgeReadDays)*24*time.Hour
+		tooMany := Retention.KeepItems > 0 && i >= Retention.KeepItems
+		if protected || !tooOld && !tooMany {
+			kept = append(kept, item)
+		}
+	}
+
+	return kept
+}
+
+// collectSize removes the oldest articles until the cache fits in its size, the caller holds the
+// lock. It returns how many articles were removed
+func (c *Cache) collectSize(isRead, isKept func(gofeed.Item) bool) int {
+	if Retention.MaxSizeMB <= 0 {
+		return 0
+	}
+
+	var total int
+	var candidates []retained
+	for url, entry := range c.Content {
+		for _, item := range entry.Articles {
+			data, err := json.Marshal(item)
+			if err != nil {
+				continue
+			}
+
+			total += len(data)
+			if !isProtected(item, isRead, isKept) {
+				candidate := retained{url: url, hash: hashArticle(item), size: len(data)}
+				if item.PublishedParsed != nil {
+					candidate.date = *item.PublishedParsed
+				}
+
+				candidates = append(candidates, candidate)
+			}
+		}
+	}
+
+	// The articles without a date are treated as the oldest ones
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].date.Before(candidates[j].date) })
+	removed := make(map[string]map[uint32]bool)
+	var count int
+	for _, candidate := range candidates {
+		if total <= Retention.MaxSizeMB<<20 {
+			break
+		}
+
+		if removed[candidate.url] == nil {
+			removed[candidate.url] = make(map[uint32]bool)
+		}
+
+		removed[candidate.url][candidate.hash] = true
+		total -= candidate.size
+		count++
+	}
+
+	for url, hashes := range removed {
+		entry := c.Content[url]
+		var kept SortableArticles
+		for _, item := range entry.Articles {
+			if !hashes[hashArticle(item)] {
+				kept = append(kept, item)
+			}
+		}
+
+		entry.Articles = kept
+		c.Content[url] = entry
+	}
+
+	return count
+}
@@ -0,0 +1,337 @@
+package cache
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// RSSBridge is the address of the RSS-Bridge instance which makes feeds out of the sites without them
+var RSSBridge string
+
+// ErrNoRSSBridge is returned when the bridges are listed but there is no RSS-Bridge instance
+var ErrNoRSSBridge = errors.New("no rss_bridge instance is set in the config")
+
+// globalContext holds the parameters which are shared by all the contexts of a bridge
+const globalContext = "global"
+
+// Bridge is a bridge of RSS-Bridge, every context is a different kind of feed it can make
+type Bridge struct {
+	ID          string
+	Name        string
+	Description string
+	URI         string
+	Contexts    []BridgeContext
+}
+
+// BridgeContext is a set of parameters which make a feed together, a bridge without the contexts
+// has a single one without a name
+type BridgeContext struct {
+	Name   string
+	Params []BridgeParam
+}
+
+// BridgeParam is a parameter of a bridge, the type is text, number, list or checkbox
+type BridgeParam struct {
+	ID       string
+	Name     string
+	Type     string
+	Required bool
+	Example  string
+	Default  string
+	Values   []BridgeValue
+}
+
+// BridgeValue is one of the values of a list parameter
+type BridgeValue struct {
+	Title string
+	Value string
+}
+
+// bridgeJSON is a bridge in the list returned by RSS-Bridge, the parameters are an empty array if
+// the bridge has none
+type bridgeJSON struct {
+	Status      string          `json:"status"`
+	URI         string          `json:"uri"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Parameters  json.RawMessage `json:"parameters"`
+}
+
+// bridgeParamJSON is a parameter of a bridge returned by RSS-Bridge
+type bridgeParamJSON struct {
+	Name         string          `json:"name"`
+	Type         string          `json:"type"`
+	Required     bool            `json:"required"`
+	ExampleValue json.RawMessage `json:"exampleValue"`
+	DefaultValue json.RawMessage `json:"defaultValue"`
+	Values       json.RawMessage `json:"values"`
+}
+
+// bridgeURL returns the address of the RSS-Bridge api with the query
+func bridgeURL(query url.Values) (string, error) {
+	if RSSBridge == "" {
+		return "", ErrNoRSSBridge
+	}
+
+	instance := RSSBridge
+	if !strings.Contains(instance, "://") {
+		instance = "https://" + instance
+	}
+
+	parsed, err := url.Parse(instance)
+	if err != nil {
+		return "", err
+	}
+
+	if parsed.Path == "" {
+		parsed.Path = "/"
+	}
+
+	parsed.RawQuery = query.Encode()
+	return parsed.String(), nil
+}
+
+// FetchBridges lists the active bridges of the RSS-Bridge instance sorted by their names
+func FetchBridges() ([]Bridge, error) {
+	listURL, err := bridgeURL(url.Values{"action": {"list"}})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Println("Listing the bridges at", listURL)
+	req, err := newRequest(listURL)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	resp, err := newPageClient(listURL).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, gofeed.HTTPError{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+		}
+	}
+
+	var list struct {
+		Bridges map[string]bridgeJSON `json:"bridges"`
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, err
+	}
+
+	bridges := make([]Bridge, 0, len(list.Bridges))
+	for id, bridge := range list.Bridges {
+		if bridge.Status != "active" {
+			continue
+		}
+
+		contexts, err := bridgeContexts(bridge.Parameters)
+		if err != nil {
+			return nil, fmt.Errorf("invalid parameters of %s: %w", id, err)
+		}
+
+		name := bridge.Name
+		if name == "" {
+			name = strings.TrimSuffix(id, "Bridge")
+		}
+
+		bridges = append(bridges, Bridge{id, name, bridge.Description, bridge.URI, contexts})
+	}
+
+	sort.Slice(bridges, func(i, j int) bool {
+		return strings.ToLower(bridges[i].Name) < strings.ToLower(bridges[j].Name)
+	})
+
+	return bridges, nil
+}
+
+// bridgeContexts reads the contexts of a bridge in their order, the global parameters are added
+// to every context
+func bridgeContexts(data json.RawMessage) ([]BridgeContext, error) {
+	if len(bytes.TrimSpace(data)) == 0 || data[0] != '{' {
+		return []BridgeContext{{}}, nil
+	}
+
+	var contexts []BridgeContext
+	var global []BridgeParam
+	err := eachField(data, func(name string, value json.RawMessage) error {
+		params, err := bridgeParams(value)
+		if name == globalContext {
+			global = params
+		} else {
+			contexts = append(contexts, BridgeContext{name, params})
+		}
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(contexts) == 0 {
+		return []BridgeContext{{Params: global}}, nil
+	}
+
+	for i := range contexts {
+		contexts[i].Params = append(contexts[i].Params, global...)
+	}
+
+	return contexts, nil
+}
+
+// bridgeParams reads the parameters of a context in their order
+func bridgeParams(data json.RawMessage) ([]BridgeParam, error) {
+	if len(bytes.TrimSpace(data)) == 0 || data[0] != '{' {
+		return nil, nil
+	}
+
+	var params []BridgeParam
+	err := eachField(data, func(id string, value json.RawMessage) error {
+		var param bridgeParamJSON
+		if err := json.Unmarshal(value, &param); err != nil {
+			return err
+		}
+
+		if param.Type == "" {
+			param.Type = "text"
+		}
+
+		if param.Name == "" {
+			param.Name = id
+		}
+
+		values, err := bridgeValues(param.Values)
+		params = append(params, BridgeParam{
+			ID:       id,
+			Name:     param.Name,
+			Type:     param.Type,
+			Required: param.Required,
+			Example:  jsonText(param.ExampleValue),
+			Default:  jsonText(param.DefaultValue),
+			Values:   values,
+		})
+
+		return err
+	})
+
+	return params, err
+}
+
+// bridgeValues reads the values of a list parameter, the grouped values are flattened
+func bridgeValues(data json.RawMessage) ([]BridgeValue, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || data[0] != '{' {
+		return nil, nil
+	}
+
+	var values []BridgeValue
+	err := eachField(data, func(title string, value json.RawMessage) error {
+		value = bytes.TrimSpace(value)
+		if len(value) > 0 && value[0] == '{' {
+			group, err := bridgeValues(value)
+			values = append(values, group...)
+			return err
+		}
+
+		values = append(values, BridgeValue{title, jsonText(value)})
+		return nil
+	})
+
+	return values, err
+}
+
+// eachField calls the function for every field of the json object in their order, the maps of
+// encoding/json forget it
+func eachField(data json.RawMessage, fn func(string, json.RawMessage) error) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		var value json.RawMessage
+		if err = dec.Decode(&value); err != nil {
+			return err
+		}
+
+		if err = fn(fmt.Sprint(tok), value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// jsonText returns the text of a json string, number or boolean, the bridges use all of them for
+// the values
+func jsonText(data json.RawMessage) string {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return ""
+	}
+
+	switch value := value.(type) {
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case bool:
+		if value {
+			return "on"
+		}
+	}
+
+	return ""
+}
+
+// BridgeFeedURL returns the address of the Atom feed which the bridge makes out of the values of
+// the context, the empty values are left out
+func BridgeFeedURL(bridge Bridge, context BridgeContext, values map[string]string) (string, error) {
+	query := url.Values{"action": {"display"}, "bridge": {bridge.ID}, "format": {"Atom"}}
+	if context.Name != "" {
+		query.Set("context", context.Name)
+	}
+
+	for _, param := range context.Params {
+		value := strings.TrimSpace(values[param.ID])
+		if value == "" {
+			if param.Required {
+				return "", fmt.Errorf("%s is required", param.Name)
+			}
+
+			continue
+		}
+
+		if param.Type == "number" {
+			if _, err := strconv.ParseFloat(value, 64); err != nil {
+				return "", fmt.Errorf("%s must be a number", param.Name)
+			}
+		}
+
+		query.Set(param.ID, value)
+	}
+
+	return bridgeURL(query)
+}
@@ -0,0 +1,188 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/TypicalAM/goread/internal/config"
+	"github.com/mmcdole/gofeed"
+	"golang.org/x/net/html/charset"
+)
+
+// Scrapers are the rules which turn the pages without feeds into feeds
+var Scrapers []config.Scraper
+
+// ErrNoScrapedItems is returned when the item selector of a scraper matches nothing on the page
+var ErrNoScrapedItems = errors.New("the item selector matched nothing")
+
+// scrapedDateLayouts are the date formats which are tried if the scraper has no date format
+var scrapedDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	time.RFC1123Z,
+	time.RFC1123,
+	"January 2, 2006",
+	"Jan 2, 2006",
+	"2 January 2006",
+	"2 Jan 2006",
+	"02/01/2006",
+	"01/02/2006",
+}
+
+// scraperFor returns the scraper of the page
+func scraperFor(pageURL string) (config.Scraper, bool) {
+	for _, scraper := range Scrapers {
+		if scraper.URL == pageURL {
+			return scraper, true
+		}
+	}
+
+	return config.Scraper{}, false
+}
+
+// isScraped reports if the url belongs to a page which is scraped instead of a feed
+func isScraped(pageURL string) bool {
+	_, ok := scraperFor(pageURL)
+	return ok
+}
+
+// scrapePage downloads the page and makes a feed out of the elements matched by the scraper
+func scrapePage(pageURL string, scraper config.Scraper) (*gofeed.Feed, error) {
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := newRequest(pageURL)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := newPageClient(pageURL).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, gofeed.HTTPError{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+		}
+	}
+
+	// The pages in the legacy encodings are transcoded to utf-8
+	body, err := charset.NewReader(resp.Body, resp.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := goquery.NewDocumentFromReader(body)
+	if err != nil {
+		return nil, err
+	}
+
+	items := doc.Find(scraper.Item)
+	if items.Length() == 0 {
+		return nil, ErrNoScrapedItems
+	}
+
+	feed := &gofeed.Feed{
+		Title: strings.TrimSpace(doc.Find("title").First().Text()),
+		Link:  pageURL,
+	}
+
+	items.Each(func(i int, sel *goquery.Selection) {
+		item := scrapeItem(sel, scraper, base)
+		if item.GUID == "" {
+			item.GUID = fmt.Sprintf("%s#%d", pageURL, i)
+		}
+
+		feed.Items = append(feed.Items, item)
+	})
+
+	log.Printf("Scraped %d articles from %s", len(feed.Items), pageURL)
+	return feed, nil
+}
+
+// scrapeItem makes an article out of an element matched by the item selector, the empty selectors
+// fall back to the element itself
+func scrapeItem(sel *goquery.Selection, scraper config.Scraper, base *url.URL) *gofeed.Item {
+	item := &gofeed.Item{}
+
+	linkSel := findIn(sel, scraper.Link)
+	href, ok := linkSel.Attr("href")
+	if !ok {
+		linkSel = linkSel.Find("a[href]").First()
+		href, ok = linkSel.Attr("href")
+	}
+
+	if ok {
+		if ref, err := base.Parse(strings.TrimSpace(href)); err == nil {
+			item.Link = ref.String()
+			item.GUID = item.Link
+		}
+	}
+
+	if scraper.Title != "" {
+		item.Title = collapseSpaces(findIn(sel, scraper.Title).Text())
+	} else if ok {
+		item.Title = collapseSpaces(linkSel.Text())
+	}
+
+	if scraper.Date != "" {
+		dateSel := findIn(sel, scraper.Date)
+		date := dateSel.AttrOr("datetime", dateSel.AttrOr("content", dateSel.Text()))
+		if parsed, ok := parseScrapedDate(date, scraper.DateFormat); ok {
+			item.PublishedParsed = &parsed
+			item.Published = parsed.Format(time.RFC1123Z)
+		}
+	}
+
+	if scraper.Description != "" {
+		if html, err := findIn(sel, scraper.Description).Html(); err == nil {
+			item.Description = strings.TrimSpace(html)
+		}
+	}
+
+	return item
+}
+
+// findIn returns the first element matched by the selector inside of the item, the item itself if
+// the selector is empty
+func findIn(sel *goquery.Selection, selector string) *goquery.Selection {
+	if selector == "" {
+		return sel
+	}
+
+	return sel.Find(selector).First()
+}
+
+// parseScrapedDate parses the date using the format of the scraper or the common formats
+func parseScrapedDate(date, format string) (time.Time, bool) {
+	date = collapseSpaces(date)
+	layouts := scrapedDateLayouts
+	if format != "" {
+		layouts = []string{format}
+	}
+
+	for _, layout := range layouts {
+		if parsed, err := time.Parse(layout, date); err == nil {
+			return parsed, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// collapseSpaces joins the words of the text with single spaces
+func collapseSpaces(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
@@ -0,0 +1,73 @@
+package cache
+
+import (
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// tagPattern matches the html tags, they are skipped when searching the articles
+var tagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// Search returns the cached and downloaded articles which contain all the words of the query
+// in their title or text, the newest articles come first. Expired entries are searched too, the
+// search doesn't fetch anything
+func (c *Cache) Search(query string) SortableArticles {
+	terms := strings.Fields(strings.ToLower(query))
+	if len(terms) == 0 {
+		return nil
+	}
+
+	c.mu.Lock()
+	candidates := make(SortableArticles, 0, len(c.Downloaded))
+	for _, entry := range c.Content {
+		candidates = append(candidates, entry.Articles...)
+	}
+	c.mu.Unlock()
+
+	candidates = append(candidates, c.Downloaded...)
+
+	var result SortableArticles
+	seen := make(map[string]bool)
+	for _, item := range candidates {
+		id := item.Link + "\x00" + item.Title
+		if seen[id] || !matchesAll(SearchableText(item), terms) {
+			continue
+		}
+
+		seen[id] = true
+		result = append(result, item)
+	}
+
+	result.SortNewestFirst()
+	return result
+}
+
+// SearchableText returns the lowercase text of the article which is matched against the query
+func SearchableText(item gofeed.Item) string {
+	var sb strings.Builder
+	sb.WriteString(item.Title)
+	for _, author := range item.Authors {
+		if author != nil {
+			sb.WriteString("\n" + author.Name)
+		}
+	}
+
+	sb.WriteString("\n" + item.Description)
+	sb.WriteString("\n" + item.Content)
+	text := html.UnescapeString(tagPattern.ReplaceAllString(sb.String(), " "))
+	return strings.ToLower(text)
+}
+
+// matchesAll reports if the text contains all the terms
+func matchesAll(text string, terms []string) bool {
+	for _, term := range terms {
+		if !strings.Contains(text, term) {
+			return false
+		}
+	}
+
+	return true
+}
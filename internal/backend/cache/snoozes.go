@@ -0,0 +1,60 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// Snooze is an article hidden until a time, only the fields which identify the article are kept
+type Snooze struct {
+	Until time.Time   `json:"until"`
+	Item  gofeed.Item `json:"item"`
+}
+
+// Snoozes stores the articles which are hidden until a time
+type Snoozes struct {
+	*jsonStore[map[uint32]Snooze]
+}
+
+// NewSnoozes creates a new snooze store.
+func NewSnoozes(dir string) (*Snoozes, error) {
+	store, err := newJSONStore(dir, "snoozes.json", "snooze", make(map[uint32]Snooze))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Snoozes{store}, nil
+}
+
+// Until returns the time until which the article is snoozed, it's zero if it isn't snoozed
+func (s *Snoozes) Until(item gofeed.Item) time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.data[hashArticle(item)].Until
+}
+
+// Snooze hides the article until the time
+func (s *Snoozes) Snooze(item gofeed.Item, until time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[hashArticle(item)] = Snooze{
+		Until: until,
+		Item:  gofeed.Item{GUID: item.GUID, Title: item.Title, Link: item.Link},
+	}
+}
+
+// Wake forgets the articles snoozed until the time or earlier and returns them
+func (s *Snoozes) Wake(now time.Time) []gofeed.Item {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var woken []gofeed.Item
+	for hash, snooze := range s.data {
+		if !snooze.Until.After(now) {
+			woken = append(woken, snooze.Item)
+			delete(s.data, hash)
+		}
+	}
+
+	return woken
+}
@@ -0,0 +1,72 @@
+package cache
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// jsonStore keeps a value in a JSON file in the cache directory. The stores of the articles use a
+// map keyed by hashArticle, so they recognize the articles the same way the read status does
+type jsonStore[T any] struct {
+	data     T
+	mu       sync.RWMutex
+	filePath string
+	name     string
+}
+
+// newJSONStore creates a store of the value in the file, the name describes it in the logs
+func newJSONStore[T any](dir, file, name string, data T) (*jsonStore[T], error) {
+	log.Println("Creating new", name, "store")
+	if dir == "" {
+		defaultDir, err := getDefaultDir()
+		if err != nil {
+			return nil, err
+		}
+
+		dir = defaultDir
+	}
+
+	return &jsonStore[T]{data: data, filePath: filepath.Join(dir, file), name: name}, nil
+}
+
+// Load reads the store from disk, a missing file leaves it empty
+func (s *jsonStore[T]) Load() error {
+	log.Println("Loading the", s.name, "store from", s.filePath)
+	data, err := os.ReadFile(s.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return json.Unmarshal(data, &s.data)
+}
+
+// Save writes the store to disk
+func (s *jsonStore[T]) Save() error {
+	s.mu.RLock()
+	data, err := json.Marshal(s.data)
+	s.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	if err = os.WriteFile(s.filePath, data, 0600); err != nil {
+		if err = os.MkdirAll(filepath.Dir(s.filePath), 0755); err != nil {
+			return err
+		}
+
+		if err = os.WriteFile(s.filePath, data, 0600); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
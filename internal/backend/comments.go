@@ -0,0 +1,111 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/TypicalAM/goread/internal/backend/cache"
+	"github.com/TypicalAM/goread/internal/backend/rss"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ErrNoComments is returned when an article doesn't link to a Hacker News discussion
+var ErrNoComments = errors.New("the article has no hacker news comments")
+
+// FetchComments downloads the Hacker News discussion of an article in a feed tab, the article can
+// link to it directly or mention it in its description like the Hacker News feeds do.
+func (b Backend) FetchComments(feedName string, index int) tea.Cmd {
+	return func() tea.Msg {
+		item, err := b.indexToItem(feedName, index)
+		if err != nil {
+			return FetchErrorMsg{err, "Error while getting the article"}
+		}
+
+		id, ok := cache.HackerNewsID(item.Link, item.GUID, item.Description, item.Content)
+		if !ok {
+			return FetchErrorMsg{ErrNoComments, "Error while loading the comments"}
+		}
+
+		if b.Cache.OfflineMode {
+			return FetchErrorMsg{errors.New("offline mode"), "Error while loading the comments"}
+		}
+
+		comments, err := cache.FetchComments(id)
+		if err != nil {
+			return FetchErrorMsg{err, "Error while loading the comments"}
+		}
+
+		return CommentsLoadedMsg{feedName, index, item.Title, comments}
+	}
+}
+
+// CommentBlock is a comment of a thread or a note about the folded replies, the level tells how
+// deep it's nested.
+type CommentBlock struct {
+	Level    int
+	Markdown string
+}
+
+// CommentBlocks flattens the comment thread in the reading order, the title comes first. The
+// replies deeper than the depth are folded into a count, all of them are shown if it's zero.
+func CommentBlocks(title string, comments []cache.Comment, depth int) []CommentBlock {
+	blocks := []CommentBlock{{0, "# Comments on " + title}}
+	if len(comments) == 0 {
+		return append(blocks, CommentBlock{0, "*Nobody commented yet.*"})
+	}
+
+	return appendComments(blocks, comments, 0, depth, time.Now())
+}
+
+// appendComments adds the comments on the level and their replies to the blocks
+func appendComments(blocks []CommentBlock, comments []cache.Comment, level, depth int, now time.Time) []CommentBlock {
+	for _, comment := range comments {
+		author := rss.StripControl(comment.Author)
+		if author == "" {
+			author = "[deleted]"
+		}
+
+		text, err := rss.HTMLToMarkdown(comment.Text)
+		if err != nil {
+			text = comment.Text
+		}
+
+		header := fmt.Sprintf("**%s** · %s", author, commentAge(now.Sub(comment.Created)))
+		blocks = append(blocks, CommentBlock{level, header + "\n\n" + strings.TrimSpace(text)})
+		switch {
+		case len(comment.Replies) == 0:
+		case depth > 0 && level+1 >= depth:
+			folded := "*▸ 1 reply folded*"
+			if count := countComments(comment.Replies); count > 1 {
+				folded = fmt.Sprintf("*▸ %d replies folded*", count)
+			}
+
+			blocks = append(blocks, CommentBlock{level + 1, folded})
+		default:
+			blocks = appendComments(blocks, comment.Replies, level+1, depth, now)
+		}
+	}
+
+	return blocks
+}
+
+// commentAge describes how long ago the comment was written
+func commentAge(age time.Duration) string {
+	if age < time.Minute {
+		return "just now"
+	}
+
+	return roughDuration(age) + " ago"
+}
+
+// countComments returns the number of the comments in the thread
+func countComments(comments []cache.Comment) int {
+	count := len(comments)
+	for _, comment := range comments {
+		count += countComments(comment.Replies)
+	}
+
+	return count
+}
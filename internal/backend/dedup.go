@@ -0,0 +1,233 @@
+package backend
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/TypicalAM/goread/internal/backend/cache"
+	"github.com/TypicalAM/goread/internal/backend/rss"
+	"github.com/mmcdole/gofeed"
+)
+
+// alsoInKey keeps the names of the other feeds which posted a collapsed duplicate article
+const alsoInKey = "goread_also_in"
+
+// Dedup is the way the duplicate articles are found in the views which aggregate many feeds
+type Dedup string
+
+const (
+	// DedupOff shows all the articles
+	DedupOff Dedup = ""
+	// DedupURL collapses the articles with the same link, once the tracking parameters are removed
+	DedupURL Dedup = "url"
+	// DedupTitle collapses the articles with the same link or a similar title
+	DedupTitle Dedup = "title"
+)
+
+const (
+	// minSimilarWords is the length of the shortest title which is compared by its words, the
+	// shorter titles have to be the same
+	minSimilarWords = 4
+
+	// minSimilarity is the share of the words two titles have to have in common
+	minSimilarity = 0.75
+)
+
+// newDedup checks the deduplication mode from the config
+func newDedup(mode string) (Dedup, error) {
+	switch dedup := Dedup(mode); dedup {
+	case DedupOff, DedupURL, DedupTitle:
+		return dedup, nil
+	default:
+		return DedupOff, fmt.Errorf("unknown dedup mode %q, expected url or title", mode)
+	}
+}
+
+// canonicalURL returns the link of an article without the parts which don't change the page, so
+// the same story posted by many feeds has the same canonical url
+func canonicalURL(link string) string {
+	parsed, err := url.Parse(strings.TrimSpace(link))
+	if err != nil || parsed.Host == "" {
+		return link
+	}
+
+	query := parsed.Query()
+	for key := range query {
+		if rss.IsTrackingParam(key) {
+			query.Del(key)
+		}
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(parsed.Host), "www.")
+	path := strings.TrimSuffix(parsed.EscapedPath(), "/")
+	if encoded := query.Encode(); encoded != "" {
+		path += "?" + encoded
+	}
+
+	return host + path
+}
+
+// titleWords returns the distinct lowercase words of a title, the punctuation is dropped
+func titleWords(title string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	seen := make(map[string]bool)
+	words := make([]string, 0, len(fields))
+	for _, word := range fields {
+		if !seen[word] {
+			seen[word] = true
+			words = append(words, word)
+		}
+	}
+
+	return words
+}
+
+// deduplicate collapses the duplicate articles, the first one is kept and the names of the other
+// feeds which posted it are attached to it
+func (b Backend) deduplicate(items cache.SortableArticles) cache.SortableArticles {
+	if b.dedup == DedupOff || len(items) < 2 {
+		return items
+	}
+
+	feeds := b.articleFeeds()
+	result := make(cache.SortableArticles, 0, len(items))
+	alsoIn := make([][]string, 0, len(items))
+	byURL := make(map[string]int)
+	byTitle := make(map[string]int)
+	byWord := make(map[string][]int)
+
+	for _, item := range items {
+		id := item.Link + "\x00" + item.Title
+		words := titleWords(item.Title)
+		kept, found := byURL[canonicalURL(item.Link)]
+		if !found && b.dedup == DedupTitle {
+			kept, found = similarTitle(result, words, byTitle, byWord)
+		}
+
+		if found {
+			if name := feeds[id]; name != "" && name != feeds[result[kept].Link+"\x00"+result[kept].Title] {
+				alsoIn[kept] = appendUnique(alsoIn[kept], name)
+			}
+
+			continue
+		}
+
+		index := len(result)
+		result = append(result, item)
+		alsoIn = append(alsoIn, nil)
+		if item.Link != "" {
+			byURL[canonicalURL(item.Link)] = index
+		}
+
+		byTitle[strings.Join(words, " ")] = index
+		for _, word := range words {
+			byWord[word] = append(byWord[word], index)
+		}
+	}
+
+	for i := range result {
+		if len(alsoIn[i]) > 0 {
+			result[i].Custom = withCustom(result[i].Custom, alsoInKey, strings.Join(alsoIn[i], ", "))
+		}
+	}
+
+	return result
+}
+
+// similarTitle looks for a kept article with a similar title, the short titles have to be the same
+func similarTitle(kept cache.SortableArticles, words []string, byTitle map[string]int, byWord map[string][]int) (int, bool) {
+	if len(words) == 0 {
+		return 0, false
+	}
+
+	if index, ok := byTitle[strings.Join(words, " ")]; ok {
+		return index, true
+	}
+
+	if len(words) < minSimilarWords {
+		return 0, false
+	}
+
+	shared := make(map[int]int)
+	for _, word := range words {
+		for _, index := range byWord[word] {
+			shared[index]++
+		}
+	}
+
+	// The candidates are checked in order, so the result doesn't depend on the map order
+	candidates := make([]int, 0, len(shared))
+	for index := range shared {
+		candidates = append(candidates, index)
+	}
+
+	sort.Ints(candidates)
+	for _, index := range candidates {
+		other := len(titleWords(kept[index].Title))
+		if other < minSimilarWords {
+			continue
+		}
+
+		similarity := float64(shared[index]) / float64(len(words)+other-shared[index])
+		if similarity >= minSimilarity {
+			return index, true
+		}
+	}
+
+	return 0, false
+}
+
+// articleFeeds maps the stored articles to the names of the feeds they come from
+func (b Backend) articleFeeds() map[string]string {
+	result := make(map[string]string)
+	for _, cat := range b.Rss.Categories {
+		for _, sub := range cat.Subscriptions {
+			if sub.IsQuery() {
+				continue
+			}
+
+			articles, _ := b.Cache.GetStoredArticles(sub.URL)
+			for _, item := range articles {
+				id := item.Link + "\x00" + item.Title
+				if _, ok := result[id]; !ok {
+					result[id] = sub.Name
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+// appendUnique adds the name to the names unless it's already there
+func appendUnique(names []string, name string) []string {
+	for _, n := range names {
+		if n == name {
+			return names
+		}
+	}
+
+	return append(names, name)
+}
+
+// alsoIn returns the names of the other feeds which posted the article, it's empty if the article
+// wasn't collapsed
+func alsoIn(item gofeed.Item) string {
+	return item.Custom[alsoInKey]
+}
+
+// withoutAlsoIn returns the article without the other feeds which posted it, they depend on the
+// view so they aren't stored along with the article
+func withoutAlsoIn(item gofeed.Item) gofeed.Item {
+	if alsoIn(item) != "" {
+		item.Custom = withoutCustom(item.Custom, alsoInKey)
+	}
+
+	return item
+}
@@ -0,0 +1,173 @@
+package backend
+
+import (
+	"fmt"
+	"html"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/TypicalAM/goread/internal/backend/cache"
+	"github.com/TypicalAM/goread/internal/backend/rss"
+	"github.com/TypicalAM/goread/internal/ui/simplelist"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/gofeed"
+)
+
+// digestCategory is a category in the digest with its new articles, the newest come first
+type digestCategory struct {
+	name     string
+	articles cache.SortableArticles
+	feeds    map[string]string
+}
+
+// UpdateDigest makes the digest of the day if it's due, it's sent by email if the address is set.
+// It reports if a new digest was made
+func (b Backend) UpdateDigest(now time.Time) bool {
+	if b.Digests == nil {
+		return false
+	}
+
+	since, ok := b.Digests.Claim(now, b.digest.Hour)
+	if !ok {
+		return false
+	}
+
+	if since.IsZero() || now.Sub(since) > 7*24*time.Hour {
+		since = now.Add(-24 * time.Hour)
+	}
+
+	item := b.makeDigest(since, now)
+	b.Digests.Add(item, now)
+	log.Println("Made the digest:", item.Title)
+
+	if b.digest.Email != "" {
+		if err := b.mailDigest(item); err != nil {
+			log.Println("Couldn't send the digest:", err)
+		}
+	}
+
+	return true
+}
+
+// FetchDigests gets the daily digests, the digest of the day is made first if it's due.
+func (b Backend) FetchDigests(title string, _ bool) tea.Cmd {
+	return func() tea.Msg {
+		b.UpdateDigest(time.Now())
+		return b.articlesToSuccessMsg(title, b.Digests.Get())
+	}
+}
+
+// makeDigest makes the article listing the articles of every category published between the times
+func (b Backend) makeDigest(since, now time.Time) gofeed.Item {
+	var categories []digestCategory
+	var total int
+	for _, cat := range b.Rss.Categories {
+		dc := digestCategory{name: cat.Name, feeds: make(map[string]string)}
+		seen := make(map[string]bool)
+		for _, sub := range cat.Subscriptions {
+			if sub.IsQuery() {
+				continue
+			}
+
+			articles, ok := b.Cache.GetCachedArticles(sub.URL)
+			if !ok {
+				continue
+			}
+
+			for _, item := range articles {
+				published := item.PublishedParsed
+				if published == nil || published.Before(since) || published.After(now) {
+					continue
+				}
+
+				id := item.Link + "\x00" + item.Title
+				if seen[id] {
+					continue
+				}
+
+				seen[id] = true
+				dc.articles = append(dc.articles, item)
+				dc.feeds[id] = sub.Name
+			}
+		}
+
+		if len(dc.articles) > 0 {
+			dc.articles.SortNewestFirst()
+			categories = append(categories, dc)
+			total += len(dc.articles)
+		}
+	}
+
+	var sb strings.Builder
+	from := since.Format("Monday, 2 January 15:04")
+	switch total {
+	case 0:
+		fmt.Fprintf(&sb, "<p>There are no new articles since %s.</p>", from)
+	case 1:
+		fmt.Fprintf(&sb, "<p>There is 1 new article since %s.</p>", from)
+	default:
+		fmt.Fprintf(&sb, "<p>There are %d new articles in %d categories since %s.</p>", total, len(categories), from)
+	}
+
+	for _, dc := range categories {
+		fmt.Fprintf(&sb, "<h2>%s (%d)</h2><ul>", html.EscapeString(dc.name), len(dc.articles))
+		for i, item := range dc.articles {
+			if i == b.digest.Top {
+				fmt.Fprintf(&sb, "<li>and %d more</li>", len(dc.articles)-i)
+				break
+			}
+
+			feed := html.EscapeString(dc.feeds[item.Link+"\x00"+item.Title])
+			title := html.EscapeString(item.Title)
+			if item.Link != "" {
+				title = fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(item.Link), title)
+			}
+
+			fmt.Fprintf(&sb, "<li>%s - %s</li>", title, feed)
+		}
+
+		sb.WriteString("</ul>")
+	}
+
+	return gofeed.Item{
+		Title:           "Digest of " + now.Format("Monday, 2 January 2006"),
+		Description:     fmt.Sprintf("%d new articles", total),
+		Content:         sb.String(),
+		GUID:            "goread-digest-" + now.Format("2006-01-02"),
+		Authors:         []*gofeed.Person{{Name: "goread"}},
+		Published:       now.Format(time.RFC1123Z),
+		PublishedParsed: &now,
+	}
+}
+
+// mailDigest sends the digest to the email address using the sendmail command
+func (b Backend) mailDigest(item gofeed.Item) error {
+	command := b.digest.Sendmail
+	if command == "" {
+		command = "sendmail -t"
+	}
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "To: %s\r\n", b.digest.Email)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", item.Title)
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/html; charset=utf-8\r\n\r\n")
+	fmt.Fprintf(&msg, "<html><body><h1>%s</h1>%s</body></html>\r\n", html.EscapeString(item.Title), item.Content)
+
+	log.Println("Sending the digest to", b.digest.Email)
+	_, err := cache.RunCommand(command, strings.NewReader(msg.String()))
+	return err
+}
+
+// digestItem returns the entry of the digests in the welcome tab
+func (b Backend) digestItem() simplelist.Item {
+	var unread int
+	for _, item := range b.Digests.Get() {
+		if !b.ReadStatus.IsRead(item) {
+			unread++
+		}
+	}
+
+	return simplelist.NewItem(rss.DigestName, "The new articles of every category, once a day").SetBadge(UnreadBadge(unread))
+}
@@ -0,0 +1,139 @@
+package backend
+
+import (
+	"fmt"
+	"html"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/gofeed"
+)
+
+// DefaultExportFilename is the template of the names of the exported articles
+const DefaultExportFilename = "%d-%t"
+
+// maxSlugLength is the maximum length of the title in the name of an exported article
+const maxSlugLength = 60
+
+// ExportArticle writes the article to a file in the export directory, either as the markdown
+// shown in the article view or as the html from the feed
+func (b Backend) ExportArticle(feedName string, index int, asHTML bool) tea.Cmd {
+	return func() tea.Msg {
+		item, err := b.indexToItem(feedName, index)
+		if err != nil {
+			return FetchErrorMsg{err, "Error while getting the article"}
+		}
+
+		dir, err := exportDirectory(b.export.Directory)
+		if err != nil {
+			return FetchErrorMsg{err, "Error while exporting the article"}
+		}
+
+		content, ext := b.annotatedContent(item), ".md"
+		if asHTML {
+			content, ext = articleHTML(item), ".html"
+		}
+
+		path := filepath.Join(dir, exportFilename(b.export.Filename, feedName, item)+ext)
+		if err = os.MkdirAll(dir, 0755); err != nil {
+			return FetchErrorMsg{err, "Error while exporting the article"}
+		}
+
+		if err = os.WriteFile(path, []byte(content), 0600); err != nil {
+			return FetchErrorMsg{err, "Error while exporting the article"}
+		}
+
+		log.Println("Exported article to", path)
+		return ArticleExportedMsg{Path: path}
+	}
+}
+
+// exportDirectory returns the directory of the exported articles, a leading ~ is expanded to the
+// home directory. The articles are saved in ~/Articles if the directory is empty
+func exportDirectory(dir string) (string, error) {
+	home, err := os.UserHomeDir()
+	switch {
+	case dir == "" && err != nil:
+		return "", err
+	case dir == "":
+		return filepath.Join(home, "Articles"), nil
+	case strings.HasPrefix(dir, "~/") && err == nil:
+		return filepath.Join(home, dir[2:]), nil
+	}
+
+	return dir, nil
+}
+
+// exportFilename fills the template with the date and the title of the article and the name of
+// the feed, the file extension isn't included
+func exportFilename(template, feedName string, item *gofeed.Item) string {
+	if template == "" {
+		template = DefaultExportFilename
+	}
+
+	date := time.Now()
+	if item.PublishedParsed != nil {
+		date = *item.PublishedParsed
+	}
+
+	name := strings.NewReplacer(
+		"%d", date.Format("2006-01-02"),
+		"%t", slugify(item.Title),
+		"%f", slugify(feedName),
+	).Replace(template)
+
+	// The template shouldn't be able to escape the directory
+	name = strings.Trim(strings.ReplaceAll(name, string(filepath.Separator), "-"), ". ")
+	if name == "" {
+		return "article"
+	}
+
+	return name
+}
+
+// slugify returns the lowercase words of the text separated by dashes
+func slugify(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	slug := strings.Join(words, "-")
+	if runes := []rune(slug); len(runes) > maxSlugLength {
+		slug = strings.TrimRight(string(runes[:maxSlugLength]), "-")
+	}
+
+	return slug
+}
+
+// articleHTML returns a html document with the title and the content of the article, the content
+// is left as it is in the feed
+func articleHTML(item *gofeed.Item) string {
+	content := item.Description
+	if len(item.Content) > len(content) {
+		content = item.Content
+	}
+
+	title := html.EscapeString(item.Title)
+	heading := title
+	if item.Link != "" {
+		heading = fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(item.Link), title)
+	}
+
+	return fmt.Sprintf(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>%s</title>
+</head>
+<body>
+<h1>%s</h1>
+%s
+</body>
+</html>
+`, title, heading, content)
+}
@@ -0,0 +1,138 @@
+package backend
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/TypicalAM/goread/internal/backend/cache"
+)
+
+// hookArticle is the article passed to the on_new_article hook
+type hookArticle struct {
+	Feed        string     `json:"feed"`
+	FeedURL     string     `json:"feed_url"`
+	Category    string     `json:"category"`
+	Title       string     `json:"title"`
+	Link        string     `json:"link"`
+	Author      string     `json:"author,omitempty"`
+	Published   *time.Time `json:"published,omitempty"`
+	Description string     `json:"description"`
+}
+
+// maxHookWorkers is the number of the feeds whose on_new_article hooks can run at the same time
+const maxHookWorkers = 4
+
+// newArticleHooks are the running on_new_article hooks, they run in the background so that a slow
+// hook doesn't hold up the refresh. Every command is stopped after the exec timeout
+var newArticleHooks = struct {
+	slots chan struct{}
+	wg    sync.WaitGroup
+}{slots: make(chan struct{}, maxHookWorkers)}
+
+// waitHooks waits for the on_new_article hooks which are still running
+func waitHooks() {
+	newArticleHooks.wg.Wait()
+}
+
+// onNewArticles runs the on_new_article hook and shows the notifications of the alerts for the
+// new articles of the feed
+func (b Backend) onNewArticles(url string, articles cache.SortableArticles) {
+	if b.hooks.OnNewArticle != "" {
+		inputs, command := b.hookInputs(url, articles), b.hooks.OnNewArticle
+		newArticleHooks.wg.Add(1)
+		go func() {
+			defer newArticleHooks.wg.Done()
+			newArticleHooks.slots <- struct{}{}
+			defer func() { <-newArticleHooks.slots }()
+			for _, input := range inputs {
+				runHook("on_new_article", command, input)
+			}
+		}()
+	}
+
+	if b.notifier != nil {
+		b.notifyAlerts(articles)
+	}
+}
+
+// notifyAlerts shows a notification for every unread article which triggered an alert
+func (b Backend) notifyAlerts(articles cache.SortableArticles) {
+	for _, item := range articles {
+		keyword, ok := item.Custom[alertKey]
+		if !ok || b.ReadStatus.IsRead(item) {
+			continue
+		}
+
+		summary := "goread alert"
+		if keyword != "" {
+			summary = fmt.Sprintf("goread: %q mentioned", keyword)
+		}
+
+		log.Println("Showing the notification of an alert:", item.Title)
+		if err := b.notifier.Send(summary, item.Title); err != nil {
+			log.Println("Couldn't show the notification:", err)
+		}
+	}
+}
+
+// hookInputs encodes the new articles of the feed for the on_new_article hook, one per article
+func (b Backend) hookInputs(url string, articles cache.SortableArticles) [][]byte {
+	var feedName, category string
+	for _, cat := range b.Rss.Categories {
+		for _, sub := range cat.Subscriptions {
+			if sub.URL == url && feedName == "" {
+				feedName, category = sub.Name, cat.Name
+			}
+		}
+	}
+
+	inputs := make([][]byte, 0, len(articles))
+	for _, item := range articles {
+		article := hookArticle{
+			Feed:        feedName,
+			FeedURL:     url,
+			Category:    category,
+			Title:       item.Title,
+			Link:        item.Link,
+			Published:   item.PublishedParsed,
+			Description: betterDesc(item.Description),
+		}
+
+		if len(item.Authors) > 0 && item.Authors[0] != nil {
+			article.Author = item.Authors[0].Name
+		}
+
+		data, err := json.Marshal(article)
+		if err != nil {
+			log.Println("Couldn't encode the article for the hook:", err)
+			continue
+		}
+
+		inputs = append(inputs, data)
+	}
+
+	return inputs
+}
+
+// runHook runs the command of the hook with the input on the standard input, the failures are only
+// logged since the hooks shouldn't stop the refresh
+func runHook(name, command string, input []byte) {
+	if command == "" {
+		return
+	}
+
+	var stdin io.Reader
+	if input != nil {
+		stdin = bytes.NewReader(input)
+	}
+
+	log.Printf("Running the %s hook\n", name)
+	if _, err := cache.RunCommand(command, stdin); err != nil {
+		log.Printf("The %s hook failed: %v\n", name, err)
+	}
+}
@@ -0,0 +1,202 @@
+package backend
+
+import (
+	"time"
+
+	"github.com/TypicalAM/goread/internal/backend/cache"
+)
+
+// ArticleItem is an item in the article list of the feed tab.
+type ArticleItem struct {
+	title string
+	desc  string
+	link  string
+	read  bool
+	hl    bool
+	ep    string
+	mark  bool
+	saved bool
+	date  time.Time
+	words int
+	pos   int
+	until time.Time
+	state cache.ArchiveState
+	feed  string
+}
+
+// NewArticleItem creates a new article item.
+func NewArticleItem(title, desc, link string, read bool) ArticleItem {
+	return ArticleItem{
+		title: title,
+		desc:  desc,
+		link:  link,
+		read:  read,
+	}
+}
+
+// Title returns the title of the article.
+func (i ArticleItem) Title() string {
+	return i.title
+}
+
+// Description returns the description of the article.
+func (i ArticleItem) Description() string {
+	return i.desc
+}
+
+// Link returns the url of the article.
+func (i ArticleItem) Link() string {
+	return i.link
+}
+
+// FilterValue returns the title of the article.
+func (i ArticleItem) FilterValue() string {
+	return i.title
+}
+
+// IsRead returns true if the article was read.
+func (i ArticleItem) IsRead() bool {
+	return i.read
+}
+
+// IsHighlighted returns true if the article was highlighted by a rule.
+func (i ArticleItem) IsHighlighted() bool {
+	return i.hl
+}
+
+// SetHighlighted returns a copy of the item with the highlight changed.
+func (i ArticleItem) SetHighlighted(highlighted bool) ArticleItem {
+	i.hl = highlighted
+	return i
+}
+
+// IsEpisode returns true if the article has a podcast episode attached.
+func (i ArticleItem) IsEpisode() bool {
+	return i.ep != ""
+}
+
+// Episode returns the url of the podcast episode attached to the article.
+func (i ArticleItem) Episode() string {
+	return i.ep
+}
+
+// SetEpisode returns a copy of the item with the url of its podcast episode.
+func (i ArticleItem) SetEpisode(url string) ArticleItem {
+	i.ep = url
+	return i
+}
+
+// Published returns the publication date of the article, it's zero if the feed didn't have one.
+func (i ArticleItem) Published() time.Time {
+	return i.date
+}
+
+// SetPublished returns a copy of the item with the publication date changed.
+func (i ArticleItem) SetPublished(published *time.Time) ArticleItem {
+	i.date = time.Time{}
+	if published != nil {
+		i.date = *published
+	}
+
+	return i
+}
+
+// IsMarked returns true if the article was marked for a bulk action.
+func (i ArticleItem) IsMarked() bool {
+	return i.mark
+}
+
+// SetMarked returns a copy of the item with the mark changed.
+func (i ArticleItem) SetMarked(marked bool) ArticleItem {
+	i.mark = marked
+	return i
+}
+
+// SetRead returns a copy of the item with the read status changed.
+func (i ArticleItem) SetRead(read bool) ArticleItem {
+	i.read = read
+	return i
+}
+
+// SetDescription returns a copy of the item with the description changed.
+func (i ArticleItem) SetDescription(desc string) ArticleItem {
+	i.desc = desc
+	return i
+}
+
+// Words returns the number of words in the article.
+func (i ArticleItem) Words() int {
+	return i.words
+}
+
+// SetWords returns a copy of the item with the number of its words changed.
+func (i ArticleItem) SetWords(words int) ArticleItem {
+	i.words = words
+	return i
+}
+
+// Position returns the line at which the reading of the article stopped.
+func (i ArticleItem) Position() int {
+	return i.pos
+}
+
+// SetPosition returns a copy of the item with the reading position changed.
+func (i ArticleItem) SetPosition(line int) ArticleItem {
+	i.pos = line
+	return i
+}
+
+// SnoozedUntil returns the time until which the article is hidden, it's zero if it isn't snoozed.
+func (i ArticleItem) SnoozedUntil() time.Time {
+	return i.until
+}
+
+// SetSnoozedUntil returns a copy of the item snoozed until the time.
+func (i ArticleItem) SetSnoozedUntil(until time.Time) ArticleItem {
+	i.until = until
+	return i
+}
+
+// IsSnoozed reports if the article is hidden at the moment.
+func (i ArticleItem) IsSnoozed() bool {
+	return i.until.After(time.Now())
+}
+
+// ArchiveState returns where the article was put away.
+func (i ArticleItem) ArchiveState() cache.ArchiveState {
+	return i.state
+}
+
+// SetArchiveState returns a copy of the item put away in the state.
+func (i ArticleItem) SetArchiveState(state cache.ArchiveState) ArticleItem {
+	i.state = state
+	return i
+}
+
+// IsPutAway reports if the article was archived or trashed, it's only shown in the archive or the trash.
+func (i ArticleItem) IsPutAway() bool {
+	return i.state != cache.Kept
+}
+
+// Feed returns the name of the feed the article comes from, it's only known if the icons of the feeds
+// are shown or some link rules apply to a single feed.
+func (i ArticleItem) Feed() string {
+	return i.feed
+}
+
+// SetFeed returns a copy of the item coming from the feed.
+func (i ArticleItem) SetFeed(name string) ArticleItem {
+	i.feed = name
+	return i
+}
+
+// IsSaved returns whether the article is saved in the downloads.
+func (i ArticleItem) IsSaved() bool {
+	return i.saved
+}
+
+// SetSaved returns a copy of the item with the saved status set.
+func (i ArticleItem) SetSaved(saved bool) ArticleItem {
+	i.saved = saved
+	return i
+}
@@ -1,6 +1,9 @@
 package backend
 
 import (
+	"time"
+
+	"github.com/TypicalAM/goread/internal/backend/cache"
 	"github.com/TypicalAM/goread/internal/ui/tab"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
@@ -15,12 +18,51 @@ type ArticleFetcher func(feedname string, refresh bool) tea.Cmd
 // FetchSuccessMsg is sent on fetch success.
 type FetchSuccessMsg struct{ Items []list.Item }
 
-// FetchArticleSuccessMsg is sent on article fetch success.
+// FetchArticleSuccessMsg is sent on article fetch success, it goes to the tab of the feed.
 type FetchArticleSuccessMsg struct {
+	FeedName        string
 	Items           []list.Item
 	ArticleContents []string
 }
 
+// FetchProgressMsg is sent after every feed fetched during a bulk fetch, Next waits for the next message.
+type FetchProgressMsg struct {
+	Next  tea.Cmd
+	Err   error
+	URL   string
+	Done  int
+	Total int
+}
+
+// RefreshedMsg is sent after all the feeds were refreshed in the background.
+type RefreshedMsg struct{ Time time.Time }
+
+// VacuumedMsg is sent after the garbage was collected from the cache.
+type VacuumedMsg struct{ Collected cache.Collected }
+
+// FeedMovedMsg is sent when a feed permanently redirects to a new url.
+type FeedMovedMsg struct {
+	Name   string
+	URL    string
+	NewURL string
+}
+
+// DiscoveredFeedsMsg is sent after looking for feeds at the url of a new feed.
+type DiscoveredFeedsMsg struct {
+	Err    error
+	Parent string
+	Name   string
+	URL    string
+	Feeds  []cache.DiscoveredFeed
+}
+
+// ImageLoadedMsg is sent after an image used in an article is loaded.
+type ImageLoadedMsg struct {
+	Err  error
+	URL  string
+	Data []byte
+}
+
 // FetchErrorMsg is sent on fetch error.
 type FetchErrorMsg struct {
 	Err         error
@@ -69,6 +111,57 @@ func DownloadItem(feedName string, index int) tea.Cmd {
 	return func() tea.Msg { return DownloadItemMsg{feedName, index} }
 }
 
+// DownloadEpisodeMsg contains info the browser needs to know to download the episode of an item.
+type DownloadEpisodeMsg struct {
+	FeedName string
+	Index    int
+}
+
+// DownloadEpisode is called from a tab to tell the browser that the episode of an item needs to be downloaded.
+func DownloadEpisode(feedName string, index int) tea.Cmd {
+	return func() tea.Msg { return DownloadEpisodeMsg{feedName, index} }
+}
+
+// EpisodeQueuedMsg is sent after an episode was added to the download queue.
+type EpisodeQueuedMsg struct {
+	Title      string
+	Downloaded bool
+}
+
+// ExportArticleMsg is sent when an article should be written to a file.
+type ExportArticleMsg struct {
+	FeedName string
+	Index    int
+	HTML     bool
+}
+
+// ExportArticle is called from a tab to export an article as markdown or as html.
+func ExportArticle(feedName string, index int, asHTML bool) tea.Cmd {
+	return func() tea.Msg { return ExportArticleMsg{feedName, index, asHTML} }
+}
+
+// ArticleExportedMsg is sent when an article was written to a file.
+type ArticleExportedMsg struct{ Path string }
+
+// ShareArticlesMsg is sent when articles should be shared to a read-later service, the share
+// menu is shown if the service isn't chosen yet.
+type ShareArticlesMsg struct {
+	FeedName string
+	Indexes  []int
+	Service  string
+}
+
+// ShareArticles is called from a tab to share articles to a read-later service.
+func ShareArticles(feedName string, indexes []int) tea.Cmd {
+	return func() tea.Msg { return ShareArticlesMsg{FeedName: feedName, Indexes: indexes} }
+}
+
+// ArticleSharedMsg is sent when an article was shared to a read-later service.
+type ArticleSharedMsg struct {
+	Title   string
+	Service string
+}
+
 // MakeChoiceMsg contains info needed to create a binary choice prompt.
 type MakeChoiceMsg struct {
 	Question string
@@ -80,6 +173,38 @@ func MakeChoice(question string, defaultChoice bool) tea.Cmd {
 	return func() tea.Msg { return MakeChoiceMsg{question, defaultChoice} }
 }
 
+// ReadAloudMsg contains info needed to read an item aloud.
+type ReadAloudMsg struct {
+	FeedName string
+	Index    int
+}
+
+// ReadAloud is called from a tab to tell the browser that an item needs to be read aloud.
+func ReadAloud(feedName string, index int) tea.Cmd {
+	return func() tea.Msg { return ReadAloudMsg{feedName, index} }
+}
+
+// SpeechStartedMsg is sent when an article is read aloud, the channel is closed when the reading ends.
+type SpeechStartedMsg struct {
+	Title string
+	Done  <-chan struct{}
+}
+
+// SpeechEndedMsg is sent when the reading of an article ends.
+type SpeechEndedMsg struct{}
+
+// SavePositionMsg contains info needed to remember where the reading of an item stopped.
+type SavePositionMsg struct {
+	FeedName string
+	Index    int
+	Line     int
+}
+
+// SavePosition is called from a tab to tell the browser that the reading position of an item changed.
+func SavePosition(feedName string, index, line int) tea.Cmd {
+	return func() tea.Msg { return SavePositionMsg{feedName, index, line} }
+}
+
 // MarkAsReadMsg contains info needed to mark an item as read.
 type MarkAsReadMsg struct {
 	FeedName string
@@ -102,6 +227,239 @@ func MarkAsUnread(feedName string, index int) tea.Cmd {
 	return func() tea.Msg { return MarkAsUnreadMsg{feedName, index} }
 }
 
+// MarkAllAsReadMsg contains info needed to mark all the articles of a feed tab as read.
+type MarkAllAsReadMsg struct{ FeedName string }
+
+// MarkAllAsRead is called from a tab to tell the browser that all the articles of a feed tab need to be marked as read.
+func MarkAllAsRead(feedName string) tea.Cmd {
+	return func() tea.Msg { return MarkAllAsReadMsg{feedName} }
+}
+
+// MarkCategoryAsReadMsg contains info needed to mark all the articles in a category as read.
+type MarkCategoryAsReadMsg struct{ Category string }
+
+// MarkCategoryAsRead is called from a tab to tell the browser that all the articles in a category need to be marked as read.
+func MarkCategoryAsRead(category string) tea.Cmd {
+	return func() tea.Msg { return MarkCategoryAsReadMsg{category} }
+}
+
+// MarkOldAsReadMsg contains info needed to show the prompt for the age of the articles which should be marked as read.
+type MarkOldAsReadMsg struct{}
+
+// MarkOldAsRead is called from a tab to tell the browser that the prompt for marking the old articles as read needs to be created.
+func MarkOldAsRead() tea.Cmd {
+	return func() tea.Msg { return MarkOldAsReadMsg{} }
+}
+
+// ReadStatusChangedMsg is sent after an article was marked as read or unread.
+type ReadStatusChangedMsg struct{}
+
+// UnreadCountMsg contains the number of the unread articles in all the feeds, and in every feed,
+// category and tag by its name.
+type UnreadCountMsg struct {
+	Total      int
+	Feeds      map[string]int
+	Categories map[string]int
+	Tags       map[string]int
+}
+
+// MarkedAllAsReadMsg is sent after many articles were marked as read at once.
+type MarkedAllAsReadMsg struct{ Count int }
+
+// ToggleFullContentMsg contains info needed to toggle the full content extraction of a feed.
+type ToggleFullContentMsg struct {
+	Category string
+	FeedName string
+}
+
+// ToggleFullContent is called from a tab to tell the browser that the full content extraction of a feed needs to be toggled.
+func ToggleFullContent(category, feedName string) tea.Cmd {
+	return func() tea.Msg { return ToggleFullContentMsg{category, feedName} }
+}
+
+// AnnotateMsg is sent when the tags and the note of an article should be edited.
+type AnnotateMsg struct {
+	FeedName string
+	Index    int
+}
+
+// Annotate is called from a tab to tell the browser that the user wants to change the tags and the note of an article.
+func Annotate(feedName string, index int) tea.Cmd {
+	return func() tea.Msg { return AnnotateMsg{feedName, index} }
+}
+
+// ArticleAnnotatedMsg is sent after the tags and the note of an article were changed, it contains the new text of the article.
+type ArticleAnnotatedMsg struct {
+	FeedName string
+	Index    int
+	Content  string
+}
+
+// SnoozeMsg is sent when an article should be snoozed.
+type SnoozeMsg struct {
+	FeedName string
+	Index    int
+}
+
+// Snooze is called from a tab to tell the browser that the user wants to choose until when an article is snoozed.
+func Snooze(feedName string, index int) tea.Cmd {
+	return func() tea.Msg { return SnoozeMsg{feedName, index} }
+}
+
+// ArticleSnoozedMsg is sent after an article was snoozed, it's hidden until the time.
+type ArticleSnoozedMsg struct {
+	FeedName string
+	Index    int
+	Until    time.Time
+}
+
+// ArchiveMsg is sent when an article should be archived, trashed or restored.
+type ArchiveMsg struct {
+	FeedName string
+	Index    int
+	State    cache.ArchiveState
+}
+
+// Archive is called from a tab to tell the browser that the user wants to put an article away in the state.
+func Archive(feedName string, index int, state cache.ArchiveState) tea.Cmd {
+	return func() tea.Msg { return ArchiveMsg{feedName, index, state} }
+}
+
+// ArticleArchivedMsg is sent after an article was put away in the state, Kept means that it was restored.
+type ArticleArchivedMsg struct {
+	FeedName string
+	Index    int
+	State    cache.ArchiveState
+}
+
+// ShowCommentsMsg is sent when the Hacker News comments of an article should be shown.
+type ShowCommentsMsg struct {
+	FeedName string
+	Index    int
+}
+
+// ShowComments is called from a tab to tell the browser that the user wants to read the comments of an article.
+func ShowComments(feedName string, index int) tea.Cmd {
+	return func() tea.Msg { return ShowCommentsMsg{feedName, index} }
+}
+
+// TranslateMsg is sent when the translation of an article should be shown.
+type TranslateMsg struct {
+	FeedName string
+	Index    int
+}
+
+// Translate is called from a tab to tell the browser that the user wants to read an article translated.
+func Translate(feedName string, index int) tea.Cmd {
+	return func() tea.Msg { return TranslateMsg{feedName, index} }
+}
+
+// ArticleTranslatedMsg is sent after an article was translated, the markdown replaces its text.
+type ArticleTranslatedMsg struct {
+	FeedName string
+	Index    int
+	Markdown string
+}
+
+// SummarizeMsg is sent when the summary of an article should be shown.
+type SummarizeMsg struct {
+	FeedName string
+	Index    int
+}
+
+// Summarize is called from a tab to tell the browser that the user wants the summary of an article.
+func Summarize(feedName string, index int) tea.Cmd {
+	return func() tea.Msg { return SummarizeMsg{feedName, index} }
+}
+
+// ArticleSummarizedMsg is sent after an article was summarized, the markdown is shown above its text.
+type ArticleSummarizedMsg struct {
+	FeedName string
+	Index    int
+	Markdown string
+}
+
+// CommentsLoadedMsg is sent after the comment thread of an article was downloaded.
+type CommentsLoadedMsg struct {
+	FeedName string
+	Index    int
+	Title    string
+	Comments []cache.Comment
+}
+
+// NewBridgeFeedMsg is sent when a feed from RSS-Bridge should be added to a category.
+type NewBridgeFeedMsg struct{ Parent string }
+
+// NewBridgeFeed is called from a tab to tell the browser that the user wants to pick a feed from RSS-Bridge.
+func NewBridgeFeed(parent string) tea.Cmd {
+	return func() tea.Msg { return NewBridgeFeedMsg{parent} }
+}
+
+// BridgesLoadedMsg is sent after the bridges of the RSS-Bridge instance were listed.
+type BridgesLoadedMsg struct {
+	Err     error
+	Parent  string
+	Bridges []cache.Bridge
+}
+
+// EditTagsMsg is sent when the tags of a feed should be edited.
+type EditTagsMsg struct{ FeedName string }
+
+// EditTags is called from a tab to tell the browser that the user wants to change the tags of a feed.
+func EditTags(feedName string) tea.Cmd {
+	return func() tea.Msg { return EditTagsMsg{feedName} }
+}
+
+// SetSortOrderMsg contains info needed to remember the order of the articles in a feed.
+type SetSortOrderMsg struct {
+	FeedName string
+	Order    string
+}
+
+// SetSortOrder is called from a tab to tell the browser that the sort order of a feed changed.
+func SetSortOrder(feedName, order string) tea.Cmd {
+	return func() tea.Msg { return SetSortOrderMsg{feedName, order} }
+}
+
+// LoadImageMsg contains info needed to load an image used in an article.
+type LoadImageMsg struct{ URL string }
+
+// LoadImage is called from a tab to tell the browser that an image needs to be loaded.
+func LoadImage(url string) tea.Cmd {
+	return func() tea.Msg { return LoadImageMsg{url} }
+}
+
+// LoadFaviconMsg contains info needed to load the icon of a feed.
+type LoadFaviconMsg struct{ FeedName string }
+
+// LoadFavicon is called from a tab to tell the browser that the icon of a feed needs to be loaded.
+func LoadFavicon(feedName string) tea.Cmd {
+	return func() tea.Msg { return LoadFaviconMsg{feedName} }
+}
+
+// FaviconLoadedMsg is sent after the icon of a feed is loaded.
+type FaviconLoadedMsg struct {
+	Err      error
+	FeedName string
+	Data     []byte
+}
+
+// ManageOPMLMsg contains info needed to show the OPML import/export prompt.
+type ManageOPMLMsg struct{ Export bool }
+
+// ManageOPML is called from a tab to tell the browser that an OPML import/export prompt needs to be created.
+func ManageOPML(export bool) tea.Cmd {
+	return func() tea.Msg { return ManageOPMLMsg{export} }
+}
+
+// SearchMsg contains info needed to show the search prompt.
+type SearchMsg struct{}
+
+// Search is called from a tab to tell the browser that a search prompt needs to be created.
+func Search() tea.Cmd {
+	return func() tea.Msg { return SearchMsg{} }
+}
+
 // SetEnableKeybindMsg contains the desired state of the keybinds.
 type SetEnableKeybindMsg bool
 
@@ -115,3 +473,11 @@ type StartQuittingMsg struct{}
 
 // StartQuitting is called from a tab to tell the browser to start quitting.
 func StartQuitting() tea.Cmd { return func() tea.Msg { return StartQuittingMsg{} } }
+
+// ShowMessageMsg contains a message which should be shown in the status bar.
+type ShowMessageMsg string
+
+// ShowMessage is called from a tab to tell the browser what just happened.
+func ShowMessage(text string) tea.Cmd {
+	return func() tea.Msg { return ShowMessageMsg(text) }
+}
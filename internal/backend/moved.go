@@ -0,0 +1,60 @@
+package backend
+
+import (
+	"log"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// skippedMoves holds the urls of the feeds whose move the user declined, the check runs in its own
+// goroutine while the browser adds to the set
+type skippedMoves struct {
+	mu   sync.Mutex
+	urls map[string]bool
+}
+
+// has reports whether the move of the feed was declined
+func (sm *skippedMoves) has(url string) bool {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	return sm.urls[url]
+}
+
+// SkipMove stops the move of the feed from being brought up again until the next start
+func (b Backend) SkipMove(url string) {
+	b.skipped.mu.Lock()
+	defer b.skipped.mu.Unlock()
+	b.skipped.urls[url] = true
+}
+
+// CheckMoved looks for a feed which permanently redirected to a new url on its last fetch, the
+// feeds with the skipped moves are ignored. Nothing is sent if no feed moved.
+func (b Backend) CheckMoved() tea.Cmd {
+	return func() tea.Msg {
+		for _, cat := range b.Rss.Categories {
+			for _, feed := range cat.Subscriptions {
+				if feed.IsQuery() || b.skipped.has(feed.URL) {
+					continue
+				}
+
+				if health, ok := b.Cache.GetHealth(feed.URL); ok && health.MovedTo != "" && health.MovedTo != feed.URL {
+					return FeedMovedMsg{feed.Name, feed.URL, health.MovedTo}
+				}
+			}
+		}
+
+		return nil
+	}
+}
+
+// MoveFeed changes the url of a feed which moved, the cached articles are kept.
+func (b Backend) MoveFeed(from, to string) error {
+	if err := b.Rss.UpdateURL(from, to); err != nil {
+		return err
+	}
+
+	b.Cache.MoveFeed(from, to)
+	log.Printf("Feed moved from %s to %s\n", from, to)
+	return nil
+}
@@ -0,0 +1,58 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/TypicalAM/goread/internal/backend/cache"
+)
+
+// ErrUnsupported is returned when there is no command to show the notifications with
+var ErrUnsupported = errors.New("no notification command for this system")
+
+// timeout is how long the notification command can take, it should return right away
+const timeout = 10 * time.Second
+
+// Notifier shows the desktop notifications, using notify-send on linux and the BSDs and osascript
+// on macOS unless a shell command is given
+type Notifier struct {
+	command string
+}
+
+// New creates a notifier using the shell command, which gets the summary on the first line of the
+// standard input and the body on the rest. The command of the system is used if it's empty
+func New(command string) *Notifier {
+	return &Notifier{command: strings.TrimSpace(command)}
+}
+
+// Send shows a notification with the summary and the body
+func (n *Notifier) Send(summary, body string) error {
+	if n.command != "" {
+		_, err := cache.RunCommand(n.command, strings.NewReader(summary+"\n"+body))
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// The texts are passed as arguments so that they can't be run as a part of the command
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.CommandContext(ctx, "osascript",
+			"-e", "on run argv",
+			"-e", "display notification (item 2 of argv) with title (item 1 of argv)",
+			"-e", "end run",
+			summary, body)
+	case "windows":
+		return ErrUnsupported
+	default:
+		cmd = exec.CommandContext(ctx, "notify-send", "--app-name=goread", summary, body)
+	}
+
+	return cmd.Run()
+}
@@ -0,0 +1,28 @@
+package notify
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// TestNotifyCommand if we get an error then the notification isn't passed to the command
+func TestNotifyCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test uses a unix shell")
+	}
+
+	out := filepath.Join(t.TempDir(), "notification")
+	if err := New("cat > "+out).Send("CVE", "A new CVE in $(whoami)"); err != nil {
+		t.Fatalf("couldn't send the notification: %v", err)
+	}
+
+	if data, err := os.ReadFile(out); err != nil || string(data) != "CVE\nA new CVE in $(whoami)" {
+		t.Errorf("expected the summary and the body on the standard input, got %q (%v)", data, err)
+	}
+
+	if err := New("exit 1").Send("CVE", "A new CVE"); err == nil {
+		t.Error("expected an error when the command fails")
+	}
+}
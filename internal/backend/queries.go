@@ -0,0 +1,112 @@
+package backend
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TypicalAM/goread/internal/backend/cache"
+	"github.com/TypicalAM/goread/internal/backend/query"
+	"github.com/TypicalAM/goread/internal/backend/rss"
+	"github.com/mmcdole/gofeed"
+)
+
+// queryResults keeps the articles last shown in the query feeds, the read status of the articles
+// changes while the tab is open so the indices must refer to the shown list
+type queryResults struct {
+	mu       sync.Mutex
+	articles map[string]cache.SortableArticles
+}
+
+// get returns the articles last shown in the query feed
+func (qr *queryResults) get(name string) (cache.SortableArticles, bool) {
+	qr.mu.Lock()
+	defer qr.mu.Unlock()
+	articles, ok := qr.articles[name]
+	return articles, ok
+}
+
+// set remembers the articles shown in the query feed
+func (qr *queryResults) set(name string, articles cache.SortableArticles) {
+	qr.mu.Lock()
+	defer qr.mu.Unlock()
+	qr.articles[name] = articles
+}
+
+// queryArticles returns the articles of all the feeds which match the expression of a query feed
+func (b Backend) queryArticles(feed rss.Feed, refresh bool) (cache.SortableArticles, error) {
+	q, err := query.Parse(feed.Query())
+	if err != nil {
+		return nil, err
+	}
+
+	// Make sure that all the feeds are fetched, then go through them one by one to know their names
+	b.Cache.GetArticlesBulk(b.Rss.GetAllURLs(), refresh, nil)
+
+	var result cache.SortableArticles
+	seen := make(map[string]bool)
+	for _, cat := range b.Rss.Categories {
+		for _, sub := range cat.Subscriptions {
+			if sub.IsQuery() {
+				continue
+			}
+
+			articles, err := b.Cache.GetArticles(sub.URL, false)
+			if err != nil {
+				continue
+			}
+
+			for _, item := range articles {
+				id := item.Link + "\x00" + item.Title
+				if seen[id] || !q.Match(b.queryAttributes(item, sub, cat.Name)) {
+					continue
+				}
+
+				seen[id] = true
+				result = append(result, item)
+			}
+		}
+	}
+
+	result.SortNewestFirst()
+	result = b.deduplicate(result)
+	b.queries.set(feed.Name, result)
+	return result, nil
+}
+
+// queryAttributes returns the attributes of an article which can be used in the queries
+func (b Backend) queryAttributes(item gofeed.Item, feed rss.Feed, category string) query.Article {
+	unread := "yes"
+	if b.ReadStatus.IsRead(item) {
+		unread = "no"
+	}
+
+	var author string
+	if len(item.Authors) > 0 && item.Authors[0] != nil {
+		author = item.Authors[0].Name
+	}
+
+	content := item.Content
+	if content == "" {
+		content = item.Description
+	}
+
+	age := ""
+	if item.PublishedParsed != nil {
+		age = strconv.Itoa(int(time.Since(*item.PublishedParsed).Hours() / 24))
+	}
+
+	return query.Article{
+		"title":    item.Title,
+		"author":   author,
+		"content":  content,
+		"text":     cache.SearchableText(item),
+		"link":     item.Link,
+		"feed":     feed.Name,
+		"category": category,
+		"tags":     strings.Join(feed.Tags, " "),
+		"unread":   unread,
+		"age":      age,
+	}
+}
@@ -0,0 +1,369 @@
+// Package query implements the filter expressions of the query feeds, e.g.
+// `unread = yes and (title =~ "(?i)golang" or feed = "Go Blog")`.
+package query
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Attributes lists the attributes of an article which can be used in the expressions
+var Attributes = []string{"title", "author", "content", "text", "link", "feed", "category", "unread", "age"}
+
+// ErrEmpty is returned when the expression is empty
+var ErrEmpty = errors.New("empty query")
+
+// Article contains the attributes of an article, the values are compared as text or as numbers
+type Article map[string]string
+
+// Query is a parsed filter expression
+type Query struct {
+	root node
+}
+
+// node is a part of the expression which can be evaluated
+type node interface {
+	match(a Article) bool
+}
+
+// Parse parses a filter expression
+func Parse(expr string) (*Query, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tokens) == 0 {
+		return nil, ErrEmpty
+	}
+
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q", p.tokens[p.pos].text)
+	}
+
+	return &Query{root}, nil
+}
+
+// Match reports if the article matches the expression
+func (q *Query) Match(a Article) bool {
+	return q.root.match(a)
+}
+
+// tokenKind is the kind of a token
+type tokenKind int
+
+const (
+	wordToken tokenKind = iota
+	stringToken
+	operatorToken
+	openToken
+	closeToken
+)
+
+// token is a single token of the expression
+type token struct {
+	kind tokenKind
+	text string
+}
+
+// operators are the comparison operators, the longer ones come first
+var operators = []string{"!=", "=~", "!~", "!#", "<=", ">=", "=", "#", "<", ">"}
+
+// tokenize splits the expression into tokens
+func tokenize(expr string) ([]token, error) {
+	var tokens []token
+	runes := []rune(expr)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case r == '(':
+			tokens = append(tokens, token{openToken, "("})
+			i++
+
+		case r == ')':
+			tokens = append(tokens, token{closeToken, ")"})
+			i++
+
+		case r == '"':
+			var sb strings.Builder
+			i++
+			for ; i < len(runes) && runes[i] != '"'; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+
+				sb.WriteRune(runes[i])
+			}
+
+			if i >= len(runes) {
+				return nil, errors.New("unterminated string")
+			}
+
+			tokens = append(tokens, token{stringToken, sb.String()})
+			i++
+
+		default:
+			if op := operatorAt(runes[i:]); op != "" {
+				tokens = append(tokens, token{operatorToken, op})
+				i += len(op)
+				continue
+			}
+
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && !strings.ContainsRune(`()"`, runes[i]) &&
+				operatorAt(runes[i:]) == "" {
+				i++
+			}
+
+			tokens = append(tokens, token{wordToken, string(runes[start:i])})
+		}
+	}
+
+	return tokens, nil
+}
+
+// operatorAt returns the operator at the start of the text, if there is one
+func operatorAt(runes []rune) string {
+	for _, op := range operators {
+		if strings.HasPrefix(string(runes[:minInt(len(runes), 2)]), op) {
+			return op
+		}
+	}
+
+	return ""
+}
+
+// parser is a recursive descent parser of the expressions
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// next returns the next token without consuming it
+func (p *parser) next() (token, bool) {
+	if p.pos >= len(p.tokens) {
+		return token{}, false
+	}
+
+	return p.tokens[p.pos], true
+}
+
+// keyword consumes the next token if it's the given keyword
+func (p *parser) keyword(word string) bool {
+	if t, ok := p.next(); ok && t.kind == wordToken && strings.EqualFold(t.text, word) {
+		p.pos++
+		return true
+	}
+
+	return false
+}
+
+// parseOr parses a list of expressions joined with "or"
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.keyword("or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		left = orNode{left, right}
+	}
+
+	return left, nil
+}
+
+// parseAnd parses a list of expressions joined with "and"
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.keyword("and") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		left = andNode{left, right}
+	}
+
+	return left, nil
+}
+
+// parseUnary parses a negation, an expression in parentheses or a comparison
+func (p *parser) parseUnary() (node, error) {
+	if p.keyword("not") {
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return notNode{inner}, nil
+	}
+
+	t, ok := p.next()
+	if !ok {
+		return nil, errors.New("unexpected end of the query")
+	}
+
+	if t.kind == openToken {
+		p.pos++
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if t, ok := p.next(); !ok || t.kind != closeToken {
+			return nil, errors.New("missing closing parenthesis")
+		}
+
+		p.pos++
+		return inner, nil
+	}
+
+	return p.parseComparison()
+}
+
+// parseComparison parses a comparison of an attribute with a value
+func (p *parser) parseComparison() (node, error) {
+	if p.pos+3 > len(p.tokens) {
+		return nil, errors.New("incomplete comparison")
+	}
+
+	attr, op, value := p.tokens[p.pos], p.tokens[p.pos+1], p.tokens[p.pos+2]
+	if attr.kind != wordToken || !isAttribute(attr.text) {
+		return nil, fmt.Errorf("unknown attribute %q, expected one of: %s", attr.text, strings.Join(Attributes, ", "))
+	}
+
+	if op.kind != operatorToken {
+		return nil, fmt.Errorf("expected an operator after %q, got %q", attr.text, op.text)
+	}
+
+	if value.kind != wordToken && value.kind != stringToken {
+		return nil, fmt.Errorf("expected a value after %q", op.text)
+	}
+
+	p.pos += 3
+	cmp := comparison{attr: attr.text, op: op.text, value: value.text}
+	switch op.text {
+	case "=~", "!~":
+		re, err := regexp.Compile(value.text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", value.text, err)
+		}
+
+		cmp.re = re
+
+	case "<", ">", "<=", ">=":
+		number, err := strconv.ParseFloat(value.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("expected a number after %q, got %q", op.text, value.text)
+		}
+
+		cmp.number = number
+	}
+
+	return cmp, nil
+}
+
+// isAttribute reports if the name is a known attribute
+func isAttribute(name string) bool {
+	for _, attr := range Attributes {
+		if attr == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// andNode matches if both of the expressions match
+type andNode struct{ left, right node }
+
+func (n andNode) match(a Article) bool { return n.left.match(a) && n.right.match(a) }
+
+// orNode matches if any of the expressions match
+type orNode struct{ left, right node }
+
+func (n orNode) match(a Article) bool { return n.left.match(a) || n.right.match(a) }
+
+// notNode matches if the expression doesn't match
+type notNode struct{ inner node }
+
+func (n notNode) match(a Article) bool { return !n.inner.match(a) }
+
+// comparison compares an attribute of the article with a value
+type comparison struct {
+	re     *regexp.Regexp
+	attr   string
+	op     string
+	value  string
+	number float64
+}
+
+func (c comparison) match(a Article) bool {
+	actual := a[c.attr]
+	switch c.op {
+	case "=":
+		return strings.EqualFold(actual, c.value)
+	case "!=":
+		return !strings.EqualFold(actual, c.value)
+	case "=~":
+		return c.re.MatchString(actual)
+	case "!~":
+		return !c.re.MatchString(actual)
+	case "#":
+		return contains(actual, c.value)
+	case "!#":
+		return !contains(actual, c.value)
+	}
+
+	number, err := strconv.ParseFloat(actual, 64)
+	if err != nil {
+		return false
+	}
+
+	switch c.op {
+	case "<":
+		return number < c.number
+	case ">":
+		return number > c.number
+	case "<=":
+		return number <= c.number
+	default:
+		return number >= c.number
+	}
+}
+
+// contains reports if the text contains the value, ignoring the case
+func contains(text, value string) bool {
+	return strings.Contains(strings.ToLower(text), strings.ToLower(value))
+}
+
+// minInt returns the smaller of the two numbers
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,70 @@
+package query
+
+import "testing"
+
+// article is the article used to test the queries
+var article = Article{
+	"title":    "Go 1.20 is released",
+	"author":   "The Go Team",
+	"content":  "<p>Today the Go team is happy to release Go 1.20</p>",
+	"link":     "https://go.dev/blog/go1.20",
+	"feed":     "Go Blog",
+	"category": "Programming",
+	"unread":   "yes",
+	"age":      "3",
+}
+
+// TestQueryMatch if we get an error then the expressions are evaluated incorrectly
+func TestQueryMatch(t *testing.T) {
+	cases := map[string]bool{
+		`unread = yes`:              true,
+		`unread = no`:               false,
+		`feed = "go blog"`:          true,
+		`feed != "Go Blog"`:         false,
+		`title =~ "^Go [0-9.]+ is"`: true,
+		`title !~ "released"`:       false,
+		`content # "happy"`:         true,
+		`content !# "happy"`:        false,
+		`age < 7`:                   true,
+		`age >= 7`:                  false,
+		`unread = yes and age > 5`:  false,
+		`unread = yes and (age > 5 or category = Programming)`: true,
+		`not unread = no`:                       true,
+		`unread = no or not (feed = "Go Blog")`: false,
+		`author # go AND title # released`:      true,
+	}
+
+	for expr, expected := range cases {
+		q, err := Parse(expr)
+		if err != nil {
+			t.Errorf("couldn't parse %q: %v", expr, err)
+			continue
+		}
+
+		if q.Match(article) != expected {
+			t.Errorf("expected %q to match: %v", expr, expected)
+		}
+	}
+}
+
+// TestQueryParseErrors if we get an error then invalid expressions are accepted
+func TestQueryParseErrors(t *testing.T) {
+	invalid := []string{
+		``,
+		`unread`,
+		`unread =`,
+		`tittle = x`,
+		`title = "unterminated`,
+		`(unread = yes`,
+		`title =~ "("`,
+		`age < many`,
+		`unread = yes and`,
+		`unread = yes feed = x`,
+	}
+
+	for _, expr := range invalid {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("expected an error for %q", expr)
+		}
+	}
+}
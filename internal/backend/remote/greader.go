@@ -0,0 +1,277 @@
+package remote
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/TypicalAM/goread/internal/backend/cache"
+	"github.com/TypicalAM/goread/internal/backend/rss"
+	"github.com/TypicalAM/goread/internal/config"
+	"github.com/mmcdole/gofeed"
+)
+
+// The states used by the Google Reader API
+const (
+	greaderRead    = "user/-/state/com.google/read"
+	greaderStarred = "user/-/state/com.google/starred"
+	greaderItemID  = "tag:google.com,2005:reader/item/"
+)
+
+// greaderArticleCount is the amount of articles fetched per feed
+var greaderArticleCount = 100
+
+// GReader is a sync service using the Google Reader API, used by FreshRSS, Miniflux, Inoreader and others.
+type GReader struct {
+	client   *http.Client
+	streams  map[string]string
+	baseURL  string
+	username string
+	password string
+	auth     string
+	token    string
+}
+
+// greaderSubscriptions is the response of the subscription list endpoint.
+type greaderSubscriptions struct {
+	Subscriptions []struct {
+		ID         string `json:"id"`
+		Title      string `json:"title"`
+		URL        string `json:"url"`
+		Categories []struct {
+			ID    string `json:"id"`
+			Label string `json:"label"`
+		} `json:"categories"`
+	} `json:"subscriptions"`
+}
+
+// greaderStream is the response of the stream contents endpoint.
+type greaderStream struct {
+	Items []struct {
+		ID         string   `json:"id"`
+		Title      string   `json:"title"`
+		Author     string   `json:"author"`
+		Published  int64    `json:"published"`
+		Updated    int64    `json:"updated"`
+		Categories []string `json:"categories"`
+		Canonical  []struct {
+			Href string `json:"href"`
+		} `json:"canonical"`
+		Alternate []struct {
+			Href string `json:"href"`
+		} `json:"alternate"`
+		Summary struct {
+			Content string `json:"content"`
+		} `json:"summary"`
+		Content struct {
+			Content string `json:"content"`
+		} `json:"content"`
+	} `json:"items"`
+}
+
+// newGReader creates a new Google Reader API client.
+func newGReader(cfg config.Sync) *GReader {
+	return &GReader{
+		client:   &http.Client{Timeout: 30 * time.Second},
+		streams:  make(map[string]string),
+		baseURL:  strings.TrimSuffix(cfg.URL, "/"),
+		username: cfg.Username,
+		password: cfg.Password,
+	}
+}
+
+// Login authenticates using the ClientLogin endpoint and retrieves the token needed for edits.
+func (g *GReader) Login() error {
+	resp, err := g.client.PostForm(g.baseURL+"/accounts/ClientLogin", url.Values{
+		"Email":  {g.username},
+		"Passwd": {g.password},
+	})
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("login failed: %s", resp.Status)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		if strings.HasPrefix(scanner.Text(), "Auth=") {
+			g.auth = strings.TrimPrefix(scanner.Text(), "Auth=")
+		}
+	}
+
+	if g.auth == "" {
+		return fmt.Errorf("login failed: no auth token in the response")
+	}
+
+	token, err := g.request(http.MethodGet, "/reader/api/0/token", nil)
+	if err != nil {
+		return err
+	}
+
+	g.token = strings.TrimSpace(string(token))
+	return nil
+}
+
+// Categories returns the subscriptions grouped by their labels.
+func (g *GReader) Categories() ([]rss.Category, error) {
+	data, err := g.request(http.MethodGet, "/reader/api/0/subscription/list?output=json", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var subs greaderSubscriptions
+	if err = json.Unmarshal(data, &subs); err != nil {
+		return nil, err
+	}
+
+	var categories []rss.Category
+	index := make(map[string]int)
+
+	for _, sub := range subs.Subscriptions {
+		g.streams[sub.URL] = sub.ID
+
+		catName := rss.DefaultCategoryName
+		if len(sub.Categories) > 0 {
+			catName = sub.Categories[0].Label
+		}
+
+		i, ok := index[catName]
+		if !ok {
+			categories = append(categories, rss.Category{Name: catName})
+			i = len(categories) - 1
+			index[catName] = i
+		}
+
+		categories[i].Subscriptions = append(categories[i].Subscriptions, rss.Feed{
+			Name: sub.Title,
+			URL:  sub.URL,
+		})
+	}
+
+	return categories, nil
+}
+
+// Articles returns the articles of a feed from the server, unknown feeds are fetched directly.
+func (g *GReader) Articles(feedURL string) (cache.SortableArticles, error) {
+	stream, ok := g.streams[feedURL]
+	if !ok {
+		return cache.FetchArticles(feedURL)
+	}
+
+	path := fmt.Sprintf("/reader/api/0/stream/contents/%s?output=json&n=%d", url.PathEscape(stream), greaderArticleCount)
+	data, err := g.request(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var contents greaderStream
+	if err = json.Unmarshal(data, &contents); err != nil {
+		return nil, err
+	}
+
+	articles := make(cache.SortableArticles, len(contents.Items))
+	for i, item := range contents.Items {
+		published := time.Unix(item.Published, 0)
+		updated := time.Unix(item.Updated, 0)
+
+		articles[i] = gofeed.Item{
+			Title:           item.Title,
+			GUID:            item.ID,
+			Description:     item.Summary.Content,
+			Content:         item.Content.Content,
+			PublishedParsed: &published,
+			UpdatedParsed:   &updated,
+		}
+
+		if item.Content.Content != "" && item.Summary.Content == "" {
+			articles[i].Description = item.Content.Content
+		}
+
+		if item.Author != "" {
+			articles[i].Authors = []*gofeed.Person{{Name: item.Author}}
+		}
+
+		for _, link := range append(item.Canonical, item.Alternate...) {
+			articles[i].Link = link.Href
+			articles[i].Links = append(articles[i].Links, link.Href)
+		}
+
+		var read, starred bool
+		for _, cat := range item.Categories {
+			read = read || cat == greaderRead
+			starred = starred || cat == greaderStarred
+		}
+
+		setState(&articles[i], read, starred)
+	}
+
+	return articles, nil
+}
+
+// SetRead marks the article as read or unread on the server.
+func (g *GReader) SetRead(item gofeed.Item, read bool) error {
+	return g.editTag(item, greaderRead, read)
+}
+
+// SetStarred stars or unstars the article on the server.
+func (g *GReader) SetStarred(item gofeed.Item, starred bool) error {
+	return g.editTag(item, greaderStarred, starred)
+}
+
+// editTag adds or removes a tag from an article, articles which didn't come from the server are ignored.
+func (g *GReader) editTag(item gofeed.Item, tag string, add bool) error {
+	if !strings.HasPrefix(item.GUID, greaderItemID) {
+		return nil
+	}
+
+	action := "r"
+	if add {
+		action = "a"
+	}
+
+	form := url.Values{"i": {item.GUID}, action: {tag}, "T": {g.token}}
+	_, err := g.request(http.MethodPost, "/reader/api/0/edit-tag", form)
+	return err
+}
+
+// request sends an authenticated request to the API and returns the body of the response.
+func (g *GReader) request(method, path string, form url.Values) ([]byte, error) {
+	if g.auth == "" {
+		return nil, ErrNotLoggedIn
+	}
+
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+
+	req, err := http.NewRequest(method, g.baseURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "GoogleLogin auth="+g.auth)
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("request to %s failed: %s", path, resp.Status)
+	}
+
+	return io.ReadAll(resp.Body)
+}
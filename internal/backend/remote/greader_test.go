@@ -0,0 +1,130 @@
+package remote
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/TypicalAM/goread/internal/config"
+)
+
+// newFakeGReader creates a fake Google Reader API server and returns the edits made to the articles
+func newFakeGReader(t *testing.T) (*httptest.Server, *[]string) {
+	t.Helper()
+	edits := make([]string, 0)
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/accounts/ClientLogin", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("Email") != "alice" || r.FormValue("Passwd") != "hunter2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		fmt.Fprint(w, "SID=alice/1\nLSID=null\nAuth=alice/1\n")
+	})
+
+	mux.HandleFunc("/reader/api/0/token", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "token\n")
+	})
+
+	mux.HandleFunc("/reader/api/0/subscription/list", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"subscriptions":[
+			{"id":"feed/1","title":"Go blog","url":"https://go.dev/blog/feed.atom","categories":[{"id":"user/-/label/Tech","label":"Tech"}]},
+			{"id":"feed/2","title":"Uncategorized","url":"https://example.com/rss","categories":[]}
+		]}`)
+	})
+
+	mux.HandleFunc("/reader/api/0/stream/contents/feed/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"items":[
+			{"id":"tag:google.com,2005:reader/item/0001","title":"Read one","published":1680000000,
+			 "categories":["user/-/state/com.google/read"],"alternate":[{"href":"https://go.dev/blog/1"}],"summary":{"content":"<p>Hi</p>"}},
+			{"id":"tag:google.com,2005:reader/item/0002","title":"Unread one","published":1680000001,
+			 "categories":["user/-/state/com.google/starred"],"alternate":[{"href":"https://go.dev/blog/2"}],"content":{"content":"<p>Hello</p>"}}
+		]}`)
+	})
+
+	mux.HandleFunc("/reader/api/0/edit-tag", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("T") != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		edits = append(edits, r.FormValue("i")+" a="+r.FormValue("a")+" r="+r.FormValue("r"))
+		fmt.Fprint(w, "OK")
+	})
+
+	return httptest.NewServer(mux), &edits
+}
+
+// TestGReaderSync if we get an error then the Google Reader API isn't used correctly
+func TestGReaderSync(t *testing.T) {
+	server, edits := newFakeGReader(t)
+	defer server.Close()
+
+	service, err := New(config.Sync{Service: "greader", URL: server.URL, Username: "alice", Password: "hunter2"})
+	if err != nil {
+		t.Fatalf("couldn't create the service: %v", err)
+	}
+
+	if err = service.Login(); err != nil {
+		t.Fatalf("couldn't log in: %v", err)
+	}
+
+	categories, err := service.Categories()
+	if err != nil {
+		t.Fatalf("couldn't get the categories: %v", err)
+	}
+
+	if len(categories) != 2 || categories[0].Name != "Tech" || categories[0].Subscriptions[0].Name != "Go blog" {
+		t.Fatalf("incorrect categories, got %+v", categories)
+	}
+
+	articles, err := service.Articles("https://go.dev/blog/feed.atom")
+	if err != nil {
+		t.Fatalf("couldn't get the articles: %v", err)
+	}
+
+	if len(articles) != 2 {
+		t.Fatalf("expected 2 articles, got %d", len(articles))
+	}
+
+	if read, starred, ok := State(articles[0]); !ok || !read || starred {
+		t.Fatalf("expected the first article to be read and not starred")
+	}
+
+	if read, starred, _ := State(articles[1]); read || !starred {
+		t.Fatalf("expected the second article to be unread and starred")
+	}
+
+	if articles[1].Description != "<p>Hello</p>" {
+		t.Fatalf("expected the content to be used as the description, got %s", articles[1].Description)
+	}
+
+	if err = service.SetRead(articles[1], true); err != nil {
+		t.Fatalf("couldn't mark the article as read: %v", err)
+	}
+
+	if len(*edits) != 1 || (*edits)[0] != "tag:google.com,2005:reader/item/0002 a=user/-/state/com.google/read r=" {
+		t.Fatalf("incorrect edits, got %v", *edits)
+	}
+}
+
+// TestGReaderLoginFailed if we get an error then the wrong credentials are accepted
+func TestGReaderLoginFailed(t *testing.T) {
+	server, _ := newFakeGReader(t)
+	defer server.Close()
+
+	service, err := New(config.Sync{Service: "freshrss", URL: server.URL, Username: "alice", Password: "wrong"})
+	if err != nil {
+		t.Fatalf("couldn't create the service: %v", err)
+	}
+
+	if err = service.Login(); err == nil {
+		t.Fatal("expected the login to fail")
+	}
+
+	if _, err = service.Categories(); err != ErrNotLoggedIn {
+		t.Fatalf("expected ErrNotLoggedIn, got %v", err)
+	}
+}
@@ -0,0 +1,209 @@
+package remote
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TypicalAM/goread/internal/backend/cache"
+	"github.com/TypicalAM/goread/internal/backend/rss"
+	"github.com/TypicalAM/goread/internal/config"
+	"github.com/mmcdole/gofeed"
+)
+
+// nextcloudItemID is the prefix of the GUIDs of articles coming from Nextcloud News
+const nextcloudItemID = "nextcloud:"
+
+// nextcloudArticleCount is the amount of articles fetched per feed
+var nextcloudArticleCount = 200
+
+// Nextcloud is a sync service using the Nextcloud News API.
+type Nextcloud struct {
+	client   *http.Client
+	feeds    map[string]int
+	apiURL   string
+	username string
+	password string
+	loggedIn bool
+}
+
+// newNextcloud creates a new Nextcloud News API client.
+func newNextcloud(cfg config.Sync) *Nextcloud {
+	return &Nextcloud{
+		client:   &http.Client{Timeout: 30 * time.Second},
+		feeds:    make(map[string]int),
+		apiURL:   strings.TrimSuffix(cfg.URL, "/") + "/index.php/apps/news/api/v1-3",
+		username: cfg.Username,
+		password: cfg.Password,
+	}
+}
+
+// Login checks the credentials, the API uses basic auth so there is no session.
+func (n *Nextcloud) Login() error {
+	n.loggedIn = true
+	if err := n.request(http.MethodGet, "/folders", nil); err != nil {
+		n.loggedIn = false
+		return err
+	}
+
+	return nil
+}
+
+// Categories returns the feeds grouped by their folders.
+func (n *Nextcloud) Categories() ([]rss.Category, error) {
+	var folders struct {
+		Folders []struct {
+			ID   int    `json:"id"`
+			Name string `json:"name"`
+		} `json:"folders"`
+	}
+
+	if err := n.request(http.MethodGet, "/folders", &folders); err != nil {
+		return nil, err
+	}
+
+	var feeds struct {
+		Feeds []struct {
+			ID       int    `json:"id"`
+			URL      string `json:"url"`
+			Title    string `json:"title"`
+			FolderID int    `json:"folderId"`
+		} `json:"feeds"`
+	}
+
+	if err := n.request(http.MethodGet, "/feeds", &feeds); err != nil {
+		return nil, err
+	}
+
+	// Feeds without a folder go to the default category
+	categories := []rss.Category{{Name: rss.DefaultCategoryName, Description: rss.DefaultCategoryDescription}}
+	index := map[int]int{0: 0}
+	for _, folder := range folders.Folders {
+		categories = append(categories, rss.Category{Name: folder.Name})
+		index[folder.ID] = len(categories) - 1
+	}
+
+	for _, feed := range feeds.Feeds {
+		n.feeds[feed.URL] = feed.ID
+		i, ok := index[feed.FolderID]
+		if !ok {
+			i = 0
+		}
+
+		categories[i].Subscriptions = append(categories[i].Subscriptions, rss.Feed{Name: feed.Title, URL: feed.URL})
+	}
+
+	if len(categories[0].Subscriptions) == 0 {
+		categories = categories[1:]
+	}
+
+	return categories, nil
+}
+
+// Articles returns the articles of a feed from the server, unknown feeds are fetched directly.
+func (n *Nextcloud) Articles(url string) (cache.SortableArticles, error) {
+	feedID, ok := n.feeds[url]
+	if !ok {
+		return cache.FetchArticles(url)
+	}
+
+	var items struct {
+		Items []struct {
+			ID      int    `json:"id"`
+			URL     string `json:"url"`
+			Title   string `json:"title"`
+			Author  string `json:"author"`
+			PubDate int64  `json:"pubDate"`
+			Body    string `json:"body"`
+			Unread  bool   `json:"unread"`
+			Starred bool   `json:"starred"`
+		} `json:"items"`
+	}
+
+	path := fmt.Sprintf("/items?type=0&id=%d&batchSize=%d&getRead=true", feedID, nextcloudArticleCount)
+	if err := n.request(http.MethodGet, path, &items); err != nil {
+		return nil, err
+	}
+
+	articles := make(cache.SortableArticles, len(items.Items))
+	for i, item := range items.Items {
+		published := time.Unix(item.PubDate, 0)
+		articles[i] = gofeed.Item{
+			Title:           item.Title,
+			GUID:            nextcloudItemID + strconv.Itoa(item.ID),
+			Link:            item.URL,
+			Links:           []string{item.URL},
+			Description:     item.Body,
+			PublishedParsed: &published,
+		}
+
+		if item.Author != "" {
+			articles[i].Authors = []*gofeed.Person{{Name: item.Author}}
+		}
+
+		setState(&articles[i], !item.Unread, item.Starred)
+	}
+
+	return articles, nil
+}
+
+// SetRead marks the article as read or unread on the server.
+func (n *Nextcloud) SetRead(item gofeed.Item, read bool) error {
+	action := "unread"
+	if read {
+		action = "read"
+	}
+
+	return n.updateItem(item, action)
+}
+
+// SetStarred stars or unstars the article on the server.
+func (n *Nextcloud) SetStarred(item gofeed.Item, starred bool) error {
+	action := "unstar"
+	if starred {
+		action = "star"
+	}
+
+	return n.updateItem(item, action)
+}
+
+// updateItem performs an action on an article, articles which didn't come from the server are ignored.
+func (n *Nextcloud) updateItem(item gofeed.Item, action string) error {
+	if !strings.HasPrefix(item.GUID, nextcloudItemID) {
+		return nil
+	}
+
+	return n.request(http.MethodPut, fmt.Sprintf("/items/%s/%s", strings.TrimPrefix(item.GUID, nextcloudItemID), action), nil)
+}
+
+// request sends an authenticated request to the API and decodes the response into the result.
+func (n *Nextcloud) request(method, path string, result interface{}) error {
+	if !n.loggedIn {
+		return ErrNotLoggedIn
+	}
+
+	req, err := http.NewRequest(method, n.apiURL+path, nil)
+	if err != nil {
+		return err
+	}
+
+	req.SetBasicAuth(n.username, n.password)
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("request to %s failed: %s", path, resp.Status)
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
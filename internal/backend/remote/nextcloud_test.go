@@ -0,0 +1,117 @@
+package remote
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/TypicalAM/goread/internal/config"
+)
+
+// newFakeNextcloud creates a fake Nextcloud News API server and returns the actions made on the articles
+func newFakeNextcloud(t *testing.T) (*httptest.Server, *[]string) {
+	t.Helper()
+	actions := make([]string, 0)
+	mux := http.NewServeMux()
+	api := "/index.php/apps/news/api/v1-3"
+
+	mux.HandleFunc(api+"/folders", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"folders":[{"id":4,"name":"Comics"}]}`)
+	})
+
+	mux.HandleFunc(api+"/feeds", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"feeds":[
+			{"id":39,"url":"https://theoatmeal.com/feed/rss","title":"The Oatmeal","folderId":4},
+			{"id":40,"url":"https://xkcd.com/rss.xml","title":"xkcd","folderId":null}
+		]}`)
+	})
+
+	mux.HandleFunc(api+"/items", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("id") != "39" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		fmt.Fprint(w, `{"items":[{"id":3443,"url":"https://theoatmeal.com/comics/1","title":"A comic","pubDate":1680000000,"body":"<p>Comic</p>","unread":true,"starred":false}]}`)
+	})
+
+	mux.HandleFunc(api+"/items/", func(w http.ResponseWriter, r *http.Request) {
+		actions = append(actions, r.Method+" "+r.URL.Path[len(api):])
+	})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "alice" || pass != "hunter2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		mux.ServeHTTP(w, r)
+	})
+
+	return httptest.NewServer(handler), &actions
+}
+
+// TestNextcloudSync if we get an error then the Nextcloud News API isn't used correctly
+func TestNextcloudSync(t *testing.T) {
+	server, actions := newFakeNextcloud(t)
+	defer server.Close()
+
+	service, err := New(config.Sync{Service: "nextcloud", URL: server.URL, Username: "alice", Password: "hunter2"})
+	if err != nil {
+		t.Fatalf("couldn't create the service: %v", err)
+	}
+
+	if err = service.Login(); err != nil {
+		t.Fatalf("couldn't log in: %v", err)
+	}
+
+	categories, err := service.Categories()
+	if err != nil {
+		t.Fatalf("couldn't get the categories: %v", err)
+	}
+
+	if len(categories) != 2 || categories[1].Name != "Comics" || categories[0].Subscriptions[0].Name != "xkcd" {
+		t.Fatalf("incorrect categories, got %+v", categories)
+	}
+
+	articles, err := service.Articles("https://theoatmeal.com/feed/rss")
+	if err != nil {
+		t.Fatalf("couldn't get the articles: %v", err)
+	}
+
+	if len(articles) != 1 {
+		t.Fatalf("expected 1 article, got %d", len(articles))
+	}
+
+	if read, _, ok := State(articles[0]); !ok || read {
+		t.Fatal("expected the article to be unread")
+	}
+
+	if err = service.SetRead(articles[0], true); err != nil {
+		t.Fatalf("couldn't mark the article as read: %v", err)
+	}
+
+	if err = service.SetStarred(articles[0], true); err != nil {
+		t.Fatalf("couldn't star the article: %v", err)
+	}
+
+	if len(*actions) != 2 || (*actions)[0] != "PUT /items/3443/read" || (*actions)[1] != "PUT /items/3443/star" {
+		t.Fatalf("incorrect actions, got %v", *actions)
+	}
+}
+
+// TestNextcloudLoginFailed if we get an error then the wrong credentials are accepted
+func TestNextcloudLoginFailed(t *testing.T) {
+	server, _ := newFakeNextcloud(t)
+	defer server.Close()
+
+	service, err := New(config.Sync{Service: "nextcloud", URL: server.URL, Username: "alice", Password: "wrong"})
+	if err != nil {
+		t.Fatalf("couldn't create the service: %v", err)
+	}
+
+	if err = service.Login(); err == nil {
+		t.Fatal("expected the login to fail")
+	}
+}
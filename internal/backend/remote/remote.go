@@ -0,0 +1,69 @@
+package remote
+
+import (
+	"errors"
+	"log"
+	"strconv"
+
+	"github.com/TypicalAM/goread/internal/backend/cache"
+	"github.com/TypicalAM/goread/internal/backend/rss"
+	"github.com/TypicalAM/goread/internal/config"
+	"github.com/mmcdole/gofeed"
+)
+
+// ErrUnknownService is returned when the configured sync service is not supported
+var ErrUnknownService = errors.New("unknown sync service")
+
+// ErrNotLoggedIn is returned when the service is used before logging in
+var ErrNotLoggedIn = errors.New("not logged in")
+
+// The keys of the article state stored in gofeed.Item.Custom by the services
+const (
+	readKey    = "goread_read"
+	starredKey = "goread_starred"
+)
+
+// Service is a sync service which keeps the subscriptions and the state of the articles.
+type Service interface {
+	// Login authenticates the user with the service.
+	Login() error
+	// Categories returns the subscriptions of the user grouped in categories.
+	Categories() ([]rss.Category, error)
+	// Articles returns the articles of a feed, the server state of an article is available using State.
+	Articles(url string) (cache.SortableArticles, error)
+	// SetRead marks the article as read or unread on the server.
+	SetRead(item gofeed.Item, read bool) error
+	// SetStarred stars or unstars the article on the server.
+	SetStarred(item gofeed.Item, starred bool) error
+}
+
+// New creates the sync service described by the config.
+func New(cfg config.Sync) (Service, error) {
+	log.Println("Creating new sync service", cfg.Service)
+	switch cfg.Service {
+	case "greader", "freshrss":
+		return newGReader(cfg), nil
+	case "ttrss":
+		return newTTRss(cfg), nil
+	case "nextcloud":
+		return newNextcloud(cfg), nil
+	default:
+		return nil, ErrUnknownService
+	}
+}
+
+// State returns the server state of the article, ok is false if the article didn't come from a sync service.
+func State(item gofeed.Item) (read, starred, ok bool) {
+	readValue, ok := item.Custom[readKey]
+	return readValue == "true", item.Custom[starredKey] == "true", ok
+}
+
+// setState stores the server state of the article in the item.
+func setState(item *gofeed.Item, read, starred bool) {
+	if item.Custom == nil {
+		item.Custom = make(map[string]string)
+	}
+
+	item.Custom[readKey] = strconv.FormatBool(read)
+	item.Custom[starredKey] = strconv.FormatBool(starred)
+}
@@ -0,0 +1,252 @@
+package remote
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TypicalAM/goread/internal/backend/cache"
+	"github.com/TypicalAM/goread/internal/backend/rss"
+	"github.com/TypicalAM/goread/internal/config"
+	"github.com/mmcdole/gofeed"
+)
+
+// The fields which can be changed by the updateArticle operation
+const (
+	ttrssFieldStarred = 0
+	ttrssFieldUnread  = 2
+)
+
+// ttrssItemID is the prefix of the GUIDs of articles coming from TT-RSS
+const ttrssItemID = "ttrss:"
+
+// ttrssPageSize is the amount of headlines fetched per request and ttrssMaxArticles is the maximum amount fetched per feed
+var (
+	ttrssPageSize    = 60
+	ttrssMaxArticles = 300
+)
+
+// TTRss is a sync service using the Tiny Tiny RSS JSON API.
+type TTRss struct {
+	client   *http.Client
+	feeds    map[string]int
+	apiURL   string
+	username string
+	password string
+	session  string
+}
+
+// ttrssResponse is the envelope of every TT-RSS API response.
+type ttrssResponse struct {
+	Status  int             `json:"status"`
+	Content json.RawMessage `json:"content"`
+}
+
+// ttrssHeadline is a single article returned by the getHeadlines operation.
+type ttrssHeadline struct {
+	ID      int    `json:"id"`
+	Unread  bool   `json:"unread"`
+	Marked  bool   `json:"marked"`
+	Title   string `json:"title"`
+	Link    string `json:"link"`
+	Content string `json:"content"`
+	Author  string `json:"author"`
+	Updated int64  `json:"updated"`
+}
+
+// newTTRss creates a new TT-RSS API client.
+func newTTRss(cfg config.Sync) *TTRss {
+	return &TTRss{
+		client:   &http.Client{Timeout: 30 * time.Second},
+		feeds:    make(map[string]int),
+		apiURL:   strings.TrimSuffix(strings.TrimSuffix(cfg.URL, "/"), "/api") + "/api/",
+		username: cfg.Username,
+		password: cfg.Password,
+	}
+}
+
+// Login creates a new API session.
+func (t *TTRss) Login() error {
+	var content struct {
+		SessionID string `json:"session_id"`
+	}
+
+	if err := t.call(map[string]interface{}{"op": "login", "user": t.username, "password": t.password}, &content); err != nil {
+		return err
+	}
+
+	t.session = content.SessionID
+	return nil
+}
+
+// Categories returns the TT-RSS categories and their feeds.
+func (t *TTRss) Categories() ([]rss.Category, error) {
+	if t.session == "" {
+		return nil, ErrNotLoggedIn
+	}
+
+	var categories []struct {
+		ID    json.Number `json:"id"`
+		Title string      `json:"title"`
+	}
+
+	if err := t.call(map[string]interface{}{"op": "getCategories"}, &categories); err != nil {
+		return nil, err
+	}
+
+	result := make([]rss.Category, 0, len(categories))
+	for _, cat := range categories {
+		// Negative ids are special categories like "Starred articles" or labels
+		id, err := cat.ID.Int64()
+		if err != nil || id < 0 {
+			continue
+		}
+
+		var feeds []struct {
+			ID      int    `json:"id"`
+			Title   string `json:"title"`
+			FeedURL string `json:"feed_url"`
+		}
+
+		if err = t.call(map[string]interface{}{"op": "getFeeds", "cat_id": id}, &feeds); err != nil {
+			return nil, err
+		}
+
+		category := rss.Category{Name: cat.Title}
+		for _, feed := range feeds {
+			t.feeds[feed.FeedURL] = feed.ID
+			category.Subscriptions = append(category.Subscriptions, rss.Feed{Name: feed.Title, URL: feed.FeedURL})
+		}
+
+		result = append(result, category)
+	}
+
+	return result, nil
+}
+
+// Articles pages through the headlines of a feed on the server, unknown feeds are fetched directly.
+func (t *TTRss) Articles(url string) (cache.SortableArticles, error) {
+	feedID, ok := t.feeds[url]
+	if !ok {
+		return cache.FetchArticles(url)
+	}
+
+	var articles cache.SortableArticles
+	for skip := 0; skip < ttrssMaxArticles; skip += ttrssPageSize {
+		var headlines []ttrssHeadline
+		if err := t.call(map[string]interface{}{
+			"op":           "getHeadlines",
+			"feed_id":      feedID,
+			"limit":        ttrssPageSize,
+			"skip":         skip,
+			"show_content": true,
+			"view_mode":    "all_articles",
+		}, &headlines); err != nil {
+			return nil, err
+		}
+
+		for _, headline := range headlines {
+			updated := time.Unix(headline.Updated, 0)
+			item := gofeed.Item{
+				Title:           headline.Title,
+				GUID:            ttrssItemID + strconv.Itoa(headline.ID),
+				Link:            headline.Link,
+				Links:           []string{headline.Link},
+				Description:     headline.Content,
+				PublishedParsed: &updated,
+			}
+
+			if headline.Author != "" {
+				item.Authors = []*gofeed.Person{{Name: headline.Author}}
+			}
+
+			setState(&item, !headline.Unread, headline.Marked)
+			articles = append(articles, item)
+		}
+
+		if len(headlines) < ttrssPageSize {
+			break
+		}
+	}
+
+	return articles, nil
+}
+
+// SetRead marks the article as read or unread on the server.
+func (t *TTRss) SetRead(item gofeed.Item, read bool) error {
+	return t.updateArticle(item, ttrssFieldUnread, !read)
+}
+
+// SetStarred stars or unstars the article on the server.
+func (t *TTRss) SetStarred(item gofeed.Item, starred bool) error {
+	return t.updateArticle(item, ttrssFieldStarred, starred)
+}
+
+// updateArticle changes a field of an article, articles which didn't come from the server are ignored.
+func (t *TTRss) updateArticle(item gofeed.Item, field int, value bool) error {
+	if !strings.HasPrefix(item.GUID, ttrssItemID) {
+		return nil
+	}
+
+	mode := 0
+	if value {
+		mode = 1
+	}
+
+	return t.call(map[string]interface{}{
+		"op":          "updateArticle",
+		"article_ids": strings.TrimPrefix(item.GUID, ttrssItemID),
+		"mode":        mode,
+		"field":       field,
+	}, nil)
+}
+
+// call performs an API operation and decodes its content into the result.
+func (t *TTRss) call(params map[string]interface{}, result interface{}) error {
+	if params["op"] != "login" {
+		if t.session == "" {
+			return ErrNotLoggedIn
+		}
+
+		params["sid"] = t.session
+	}
+
+	body, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	resp, err := t.client.Post(t.apiURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("operation %s failed: %s", params["op"], resp.Status)
+	}
+
+	var envelope ttrssResponse
+	if err = json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return err
+	}
+
+	if envelope.Status != 0 {
+		var apiErr struct {
+			Error string `json:"error"`
+		}
+
+		_ = json.Unmarshal(envelope.Content, &apiErr)
+		return fmt.Errorf("operation %s failed: %s", params["op"], apiErr.Error)
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.Unmarshal(envelope.Content, result)
+}
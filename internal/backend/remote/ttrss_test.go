@@ -0,0 +1,108 @@
+package remote
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/TypicalAM/goread/internal/config"
+)
+
+// newFakeTTRss creates a fake TT-RSS API server and returns the updates made to the articles
+func newFakeTTRss(t *testing.T) (*httptest.Server, *[]map[string]interface{}) {
+	t.Helper()
+	updates := make([]map[string]interface{}, 0)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var params map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if params["op"] != "login" && params["sid"] != "session" {
+			fmt.Fprint(w, `{"status":1,"content":{"error":"NOT_LOGGED_IN"}}`)
+			return
+		}
+
+		switch params["op"] {
+		case "login":
+			fmt.Fprint(w, `{"status":0,"content":{"session_id":"session"}}`)
+		case "getCategories":
+			fmt.Fprint(w, `{"status":0,"content":[{"id":"-1","title":"Special"},{"id":"3","title":"Linux"}]}`)
+		case "getFeeds":
+			fmt.Fprint(w, `{"status":0,"content":[{"id":7,"title":"LWN","feed_url":"https://lwn.net/headlines/rss"}]}`)
+		case "getHeadlines":
+			// Two full pages and a partial one
+			if params["skip"].(float64) >= 2*float64(ttrssPageSize) {
+				fmt.Fprint(w, `{"status":0,"content":[{"id":1000,"unread":true,"marked":true,"title":"Last","link":"https://lwn.net/1000","updated":1680000000}]}`)
+				return
+			}
+
+			headlines := make([]ttrssHeadline, ttrssPageSize)
+			for i := range headlines {
+				headlines[i] = ttrssHeadline{ID: i, Title: "Old"}
+			}
+
+			content, _ := json.Marshal(headlines)
+			fmt.Fprintf(w, `{"status":0,"content":%s}`, content)
+		case "updateArticle":
+			updates = append(updates, params)
+			fmt.Fprint(w, `{"status":0,"content":{"status":"OK","updated":1}}`)
+		}
+	}))
+
+	return server, &updates
+}
+
+// TestTTRssSync if we get an error then the TT-RSS API isn't used correctly
+func TestTTRssSync(t *testing.T) {
+	server, updates := newFakeTTRss(t)
+	defer server.Close()
+
+	service, err := New(config.Sync{Service: "ttrss", URL: server.URL, Username: "alice", Password: "hunter2"})
+	if err != nil {
+		t.Fatalf("couldn't create the service: %v", err)
+	}
+
+	if _, err = service.Categories(); err != ErrNotLoggedIn {
+		t.Fatalf("expected ErrNotLoggedIn, got %v", err)
+	}
+
+	if err = service.Login(); err != nil {
+		t.Fatalf("couldn't log in: %v", err)
+	}
+
+	categories, err := service.Categories()
+	if err != nil {
+		t.Fatalf("couldn't get the categories: %v", err)
+	}
+
+	if len(categories) != 1 || categories[0].Name != "Linux" || categories[0].Subscriptions[0].URL != "https://lwn.net/headlines/rss" {
+		t.Fatalf("incorrect categories, got %+v", categories)
+	}
+
+	articles, err := service.Articles("https://lwn.net/headlines/rss")
+	if err != nil {
+		t.Fatalf("couldn't get the articles: %v", err)
+	}
+
+	if len(articles) != 2*ttrssPageSize+1 {
+		t.Fatalf("expected %d articles, got %d", 2*ttrssPageSize+1, len(articles))
+	}
+
+	last := articles[len(articles)-1]
+	if read, starred, ok := State(last); !ok || read || !starred {
+		t.Fatalf("expected the last article to be unread and starred")
+	}
+
+	if err = service.SetRead(last, true); err != nil {
+		t.Fatalf("couldn't mark the article as read: %v", err)
+	}
+
+	if len(*updates) != 1 || (*updates)[0]["article_ids"] != "1000" || (*updates)[0]["mode"].(float64) != 0 {
+		t.Fatalf("incorrect updates, got %v", *updates)
+	}
+}
@@ -0,0 +1,45 @@
+package backend
+
+import (
+	"log"
+	"time"
+
+	"github.com/TypicalAM/goread/internal/backend/cache"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/gofeed"
+)
+
+// Vacuum collects the articles which the retention policies don't keep and the feeds which were
+// removed from the cache, the cache is saved right away so that its file shrinks.
+func (b Backend) Vacuum() tea.Cmd {
+	return func() tea.Msg {
+		collected := b.collectGarbage(time.Now())
+		if err := b.Cache.Save(); err != nil {
+			return FetchErrorMsg{err, "Error while saving the cache"}
+		}
+
+		return VacuumedMsg{collected}
+	}
+}
+
+// collectGarbage runs a garbage collection pass over the article store
+func (b Backend) collectGarbage(now time.Time) cache.Collected {
+	log.Println("Collecting the garbage in the cache")
+	return b.Cache.Collect(b.Rss.GetAllURLs(), b.isRetainedRead, b.isRetainedKept, now)
+}
+
+// isRetainedKept reports if the user left something on the article which would be lost with it,
+// the tags, the note or the position where the reading stopped
+func (b Backend) isRetainedKept(item gofeed.Item) bool {
+	if _, ok := b.Annotations.Get(item); ok {
+		return true
+	}
+
+	return b.Positions.Get(item) > 0
+}
+
+// isRetainedRead reports if the article counts as read for the retention policies, the snoozed
+// articles come back unread so they aren't read either
+func (b Backend) isRetainedRead(item gofeed.Item) bool {
+	return b.ReadStatus.IsRead(item) && b.Snoozes.Until(item).IsZero()
+}
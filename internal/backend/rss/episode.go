@@ -0,0 +1,77 @@
+package rss
+
+import (
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/mmcdole/gofeed"
+	ext "github.com/mmcdole/gofeed/extensions"
+)
+
+// mediaExtensions are the file extensions of the episodes which don't specify their type
+var mediaExtensions = map[string]bool{
+	".mp3": true, ".m4a": true, ".m4b": true, ".aac": true, ".ogg": true, ".oga": true, ".opus": true,
+	".flac": true, ".wav": true, ".mp4": true, ".m4v": true, ".webm": true, ".mkv": true, ".mov": true,
+}
+
+// Episode returns the audio or video file attached to the article, the enclosures are preferred
+// over the media:content elements. It returns nil if the article has no episode
+func Episode(item *gofeed.Item) *gofeed.Enclosure {
+	for _, enclosure := range item.Enclosures {
+		if enclosure != nil && isMedia(enclosure.URL, enclosure.Type, "") {
+			return enclosure
+		}
+	}
+
+	for _, content := range mediaContents(item.Extensions) {
+		if isMedia(content.Attrs["url"], content.Attrs["type"], content.Attrs["medium"]) {
+			return &gofeed.Enclosure{
+				URL:    content.Attrs["url"],
+				Length: content.Attrs["fileSize"],
+				Type:   content.Attrs["type"],
+			}
+		}
+	}
+
+	return nil
+}
+
+// mediaContents returns the media:content elements of an article, including the ones in a media:group
+func mediaContents(extensions ext.Extensions) []ext.Extension {
+	media, ok := extensions["media"]
+	if !ok {
+		return nil
+	}
+
+	contents := media["content"]
+	for _, group := range media["group"] {
+		contents = append(contents, group.Children["content"]...)
+	}
+
+	return contents
+}
+
+// isMedia reports if the file is an audio or a video file, the extension is checked if the type is
+// unknown. Only the files on the web are episodes, the url is handed to the player
+func isMedia(rawURL, mimeType, medium string) bool {
+	if !IsWeb(rawURL) {
+		return false
+	}
+
+	switch {
+	case strings.HasPrefix(mimeType, "audio/"), strings.HasPrefix(mimeType, "video/"):
+		return true
+	case medium == "audio", medium == "video":
+		return true
+	case mimeType != "":
+		return false
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+
+	return mediaExtensions[strings.ToLower(path.Ext(parsed.Path))]
+}
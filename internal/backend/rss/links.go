@@ -0,0 +1,123 @@
+package rss
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/TypicalAM/goread/internal/config"
+)
+
+// Links are the settings of the cleanup of the links which are opened and copied
+var Links config.Links
+
+// trackingParams are the query parameters which only tell where the visitors came from, the ones
+// ending with * are prefixes
+var trackingParams = []string{
+	"utm_*", "fbclid", "gclid", "dclid", "gbraid", "wbraid", "msclkid", "yclid", "twclid",
+	"igshid", "mc_cid", "mc_eid", "_hsenc", "_hsmi", "mkt_tok", "oly_anon_id", "oly_enc_id",
+	"vero_id", "wt_mc", "ref_src",
+}
+
+// CleanLink removes the tracking parameters from a link of an article in the feed and applies the
+// link rules matching it. The links which aren't http urls are returned as they are
+func CleanLink(feed, link string) string {
+	parsed, err := url.Parse(link)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return link
+	}
+
+	var strip []string
+	if !Links.KeepTracking {
+		strip = append(strip, trackingParams...)
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+	rewrite := ""
+	for _, rule := range Links.Rules {
+		if (rule.Feed != "" && rule.Feed != feed) || (rule.Host != "" && !onHost(host, rule.Host)) {
+			continue
+		}
+
+		strip = append(strip, rule.StripParams...)
+		if rewrite == "" {
+			rewrite = rule.Rewrite
+		}
+	}
+
+	if rewrite != "" {
+		rewriteLink(parsed, rewrite)
+	}
+
+	if parsed.RawQuery != "" {
+		parsed.RawQuery = stripParams(parsed.RawQuery, strip)
+	}
+
+	return parsed.String()
+}
+
+// IsTrackingParam reports if the query parameter only tells where the visitor came from
+func IsTrackingParam(key string) bool {
+	return matchesParam(strings.ToLower(key), trackingParams)
+}
+
+// HasFeedRules reports if some of the link rules only apply to the links of a feed
+func HasFeedRules() bool {
+	for _, rule := range Links.Rules {
+		if rule.Feed != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// onHost reports if the host is the rule host or one of its subdomains
+func onHost(host, ruleHost string) bool {
+	ruleHost = strings.ToLower(strings.TrimPrefix(ruleHost, "www."))
+	return host == ruleHost || strings.HasSuffix(host, "."+ruleHost)
+}
+
+// rewriteLink moves the link to the host of the rewrite, its scheme is used too if it has one
+func rewriteLink(link *url.URL, rewrite string) {
+	if !strings.Contains(rewrite, "://") {
+		link.Host = rewrite
+		return
+	}
+
+	target, err := url.Parse(rewrite)
+	if err != nil || target.Host == "" {
+		return
+	}
+
+	link.Scheme, link.Host = target.Scheme, target.Host
+}
+
+// stripParams removes the parameters from the query, the order of the other ones is kept
+func stripParams(query string, params []string) string {
+	parts := strings.Split(query, "&")
+	kept := parts[:0]
+	for _, part := range parts {
+		key, _, _ := strings.Cut(part, "=")
+		if unescaped, err := url.QueryUnescape(key); err == nil {
+			key = unescaped
+		}
+
+		if part != "" && !matchesParam(strings.ToLower(key), params) {
+			kept = append(kept, part)
+		}
+	}
+
+	return strings.Join(kept, "&")
+}
+
+// matchesParam reports if the parameter is one of the params, the ones ending with * are prefixes
+func matchesParam(key string, params []string) bool {
+	for _, param := range params {
+		param = strings.ToLower(param)
+		if key == param || strings.HasSuffix(param, "*") && strings.HasPrefix(key, strings.TrimSuffix(param, "*")) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,294 @@
+package rss
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/TypicalAM/goread/internal/backend/query"
+)
+
+// newsboatQueryPrefix marks the query feeds in the newsboat urls file, e.g. `"query:Unread:unread = \"yes\""`
+const newsboatQueryPrefix = "query:"
+
+// newsboatFilterPrefix marks the filtered feeds in the newsboat urls file, e.g. `"filter:~/bin/noads.sh:https://example.com/feed"`
+const newsboatFilterPrefix = "filter:"
+
+// newsboatAttributes maps the newsboat attributes to the ones used in the query feeds
+var newsboatAttributes = map[string]string{
+	"feedtitle":   "feed",
+	"tags":        "category",
+	"description": "content",
+}
+
+// LoadNewsboat will load the feeds from a newsboat urls file. The tags become the categories and
+// the feeds without tags are put in the default category, the title set with a "~" tag is used as
+// the name. The query and the filter feeds are converted, the entries which can't be converted are
+// skipped and returned. Feeds which were already imported
+// are skipped too, so the file can be imported again after it changes
+func (rss *Rss) LoadNewsboat(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var skipped []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields, err := splitNewsboatLine(line)
+		if err != nil {
+			skipped = append(skipped, fmt.Sprintf("%s: %v", line, err))
+			continue
+		}
+
+		if err = rss.addNewsboatFeed(fields); err != nil {
+			skipped = append(skipped, fmt.Sprintf("%s: %v", fields[0], err))
+		}
+	}
+
+	return skipped, scanner.Err()
+}
+
+// addNewsboatFeed adds a single line of the urls file, split into fields
+func (rss *Rss) addNewsboatFeed(fields []string) error {
+	feedURL, name, filterCommand := fields[0], "", ""
+	var categories []string
+	for _, tag := range fields[1:] {
+		switch {
+		case tag == "!":
+			// Hidden feeds are only used by the query feeds in newsboat, goread shows them all
+		case strings.HasPrefix(tag, "~"):
+			name = strings.TrimPrefix(tag, "~")
+		default:
+			categories = append(categories, tag)
+		}
+	}
+
+	switch {
+	case strings.HasPrefix(feedURL, newsboatQueryPrefix):
+		title, expr, ok := strings.Cut(strings.TrimPrefix(feedURL, newsboatQueryPrefix), ":")
+		if !ok {
+			return fmt.Errorf("invalid query feed")
+		}
+
+		expr = convertNewsboatQuery(expr)
+		if _, err := query.Parse(expr); err != nil {
+			return fmt.Errorf("unsupported query: %w", err)
+		}
+
+		if name == "" {
+			name = title
+		}
+
+		feedURL = QueryPrefix + expr
+
+	case strings.HasPrefix(feedURL, newsboatFilterPrefix):
+		// The filter script can't contain a colon, the url after it can
+		command, filteredURL, ok := strings.Cut(strings.TrimPrefix(feedURL, newsboatFilterPrefix), ":")
+		if !ok || command == "" || filteredURL == "" {
+			return fmt.Errorf("invalid filter feed")
+		}
+
+		feedURL, filterCommand = filteredURL, command
+	}
+
+	if len(categories) == 0 {
+		categories = []string{DefaultCategoryName}
+	}
+
+	if name == "" {
+		name = NameFromURL(feedURL)
+	}
+
+	for _, cat := range categories {
+		if err := rss.AddCategory(cat, ""); err != nil && err != ErrAlreadyExists {
+			return err
+		}
+
+		if rss.hasFeed(cat, feedURL) {
+			continue
+		}
+
+		log.Println("Adding newsboat feed:", name)
+		feedName := rss.uniqueFeedName(name, feedURL)
+		if err := rss.AddFeed(cat, feedName, feedURL); err != nil {
+			return err
+		}
+
+		if filterCommand != "" {
+			rss.setFilterCommand(cat, feedName, filterCommand)
+		}
+	}
+
+	return nil
+}
+
+// setFilterCommand sets the filter command of the feed in the category
+func (rss *Rss) setFilterCommand(category, name, command string) {
+	for i, cat := range rss.Categories {
+		if cat.Name != category {
+			continue
+		}
+
+		for j, feed := range cat.Subscriptions {
+			if feed.Name == name {
+				rss.Categories[i].Subscriptions[j].FilterCommand = command
+			}
+		}
+	}
+}
+
+// hasFeed reports if the category already contains a feed with the url
+func (rss Rss) hasFeed(category, feedURL string) bool {
+	feeds, err := rss.GetFeeds(category)
+	if err != nil {
+		return false
+	}
+
+	for _, feed := range feeds {
+		if feed.URL == feedURL {
+			return true
+		}
+	}
+
+	return false
+}
+
+// uniqueFeedName returns a name which isn't used by the feeds with other urls, the feeds are
+// opened by their name so it should be unique
+func (rss Rss) uniqueFeedName(name, feedURL string) string {
+	candidate := name
+	for i := 2; ; i++ {
+		feed, err := rss.GetFeed(candidate)
+		if err != nil || feed.URL == feedURL {
+			return candidate
+		}
+
+		candidate = fmt.Sprintf("%s (%d)", name, i)
+	}
+}
+
+// NameFromURL returns the name of a feed without a title, it's the host of the url, the name of
+// the program of an exec feed, the mailbox of a mailbox feed or the title of a shorthand like r/golang
+func NameFromURL(feedURL string) string {
+	if _, title, ok := ExpandShorthand(feedURL); ok {
+		return title
+	}
+
+	if command := strings.Fields(strings.TrimPrefix(feedURL, ExecPrefix)); strings.HasPrefix(feedURL, ExecPrefix) && len(command) > 0 {
+		return filepath.Base(command[0])
+	}
+
+	// The local feeds are named after their file or directory
+	if IsFile(feedURL) {
+		if path := strings.TrimRight(feedURL[len(FileScheme):], "/"); path != "" {
+			return strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		}
+	}
+
+	parsed, err := url.Parse(feedURL)
+	if err != nil || parsed.Host == "" {
+		return feedURL
+	}
+
+	if mailbox := strings.Trim(parsed.Path, "/"); IsMailbox(feedURL) && mailbox != "" {
+		return mailbox
+	}
+
+	return strings.TrimPrefix(parsed.Host, "www.")
+}
+
+// splitNewsboatLine splits a line of the urls file into the url and the tags, the fields can be
+// quoted and the quotes inside them are escaped with a backslash
+func splitNewsboatLine(line string) ([]string, error) {
+	var fields []string
+	var current strings.Builder
+	inQuotes, inField := false, false
+	runes := []rune(line)
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case inQuotes && r == '\\' && i+1 < len(runes):
+			i++
+			current.WriteRune(runes[i])
+		case r == '"':
+			inQuotes, inField = !inQuotes, true
+		case !inQuotes && (r == ' ' || r == '\t'):
+			if inField {
+				fields = append(fields, current.String())
+				current.Reset()
+				inField = false
+			}
+		case !inQuotes && r == '#' && !inField:
+			// The rest of the line is a comment
+			i = len(runes)
+		default:
+			current.WriteRune(r)
+			inField = true
+		}
+	}
+
+	if inQuotes {
+		return nil, fmt.Errorf("unterminated quote")
+	}
+
+	if inField {
+		fields = append(fields, current.String())
+	}
+
+	return fields, nil
+}
+
+// convertNewsboatQuery renames the newsboat attributes in the filter expression, the quoted
+// values are left alone
+func convertNewsboatQuery(expr string) string {
+	var result, word strings.Builder
+	inQuotes := false
+	flush := func() {
+		if replacement, ok := newsboatAttributes[word.String()]; ok {
+			result.WriteString(replacement)
+		} else {
+			result.WriteString(word.String())
+		}
+
+		word.Reset()
+	}
+
+	runes := []rune(expr)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case inQuotes:
+			result.WriteRune(r)
+			if r == '\\' && i+1 < len(runes) {
+				i++
+				result.WriteRune(runes[i])
+			} else if r == '"' {
+				inQuotes = false
+			}
+		case r == '"':
+			flush()
+			inQuotes = true
+			result.WriteRune(r)
+		case r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z'):
+			word.WriteRune(r)
+		default:
+			flush()
+			result.WriteRune(r)
+		}
+	}
+
+	flush()
+	return result.String()
+}
@@ -1,11 +1,39 @@
 package rss
 
-import "errors"
+import (
+	"errors"
+	"log"
+	"strings"
+)
 
 var ErrAlreadyExists = errors.New("already exists")
 var ErrTooManyItems = errors.New("too many items")
 var ErrReservedName = errors.New("reserved name")
 var ErrEmptyName = errors.New("empty name")
+var ErrNestedInItself = errors.New("a category can't be nested in itself")
+var ErrInvalidTag = errors.New("a tag can't contain whitespace")
+
+// checkCategoryName checks that the name isn't blank and that a nested category has a name of its
+// own. A name is only nested when the category before the last separator exists, so the names like
+// "AC/DC" and the folders coming from the imports and the sync service are kept as they are
+func (rss Rss) checkCategoryName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return ErrEmptyName
+	}
+
+	parent := ParentName(name)
+	if parent == "" {
+		return nil
+	}
+
+	for _, cat := range rss.Categories {
+		if cat.Name == parent && strings.TrimSpace(BaseName(name)) == "" {
+			return ErrEmptyName
+		}
+	}
+
+	return nil
+}
 
 // AddCategory will add a category to the Rss structure
 func (rss *Rss) AddCategory(name string, description string) error {
@@ -26,6 +54,11 @@ func (rss *Rss) AddCategory(name string, description string) error {
 		}
 	}
 
+	// A nested category needs a name of its own
+	if err := rss.checkCategoryName(name); err != nil {
+		return err
+	}
+
 	// Add the category
 	rss.Categories = append(rss.Categories, Category{
 		Name:        name,
@@ -44,7 +77,7 @@ func (rss *Rss) AddFeed(category string, name string, url string) error {
 	}
 
 	// Check if the name is reserved
-	if name == AllFeedsName || name == DownloadedFeedsName {
+	if name == AllFeedsName || name == DownloadedFeedsName || name == DigestName || name == ArchiveName || name == TrashName {
 		return ErrReservedName
 	}
 
@@ -85,21 +118,48 @@ func (rss *Rss) AddFeed(category string, name string, url string) error {
 	return ErrNotFound
 }
 
-// RemoveCategory will remove a category from the Rss structure
-func (rss *Rss) RemoveCategory(name string) error {
-	for i, cat := range rss.Categories {
-		// Check if the category matches
-		if cat.Name != name {
-			continue
+// MergeCategories will add the categories and feeds which are not already in the Rss structure,
+// the exec feeds coming from the sync service are dropped and the control characters are removed
+// from the names
+func (rss *Rss) MergeCategories(categories []Category) error {
+	for _, cat := range categories {
+		cat.Name = StripControl(cat.Name)
+		if err := rss.AddCategory(cat.Name, StripControl(cat.Description)); err != nil && err != ErrAlreadyExists {
+			return err
 		}
 
-		// Remove the category
-		rss.Categories = append(rss.Categories[:i], rss.Categories[i+1:]...)
-		return nil
+		for _, feed := range cat.Subscriptions {
+			if feed.IsExec() {
+				log.Println("Skipping the exec feed:", feed.Name)
+				continue
+			}
+
+			if err := rss.AddFeed(cat.Name, StripControl(feed.Name), feed.URL); err != nil && err != ErrAlreadyExists {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// RemoveCategory will remove a category from the Rss structure, the categories nested in it are
+// removed too
+func (rss *Rss) RemoveCategory(name string) error {
+	var kept []Category
+	for _, cat := range rss.Categories {
+		if cat.Name != name && !IsNestedIn(cat.Name, name) {
+			kept = append(kept, cat)
+		}
 	}
 
 	// We couldn't remove the category
-	return ErrNotFound
+	if len(kept) == len(rss.Categories) {
+		return ErrNotFound
+	}
+
+	rss.Categories = kept
+	return nil
 }
 
 // RemoveFeed will remove a feed from the Rss structure
@@ -126,7 +186,124 @@ func (rss *Rss) RemoveFeed(category string, name string) error {
 	return ErrNotFound
 }
 
-// UpdateCategory will change the name/description of a category by a string key
+// ToggleFullContent will toggle the full content extraction of a feed and return the new state
+func (rss *Rss) ToggleFullContent(category, name string) (bool, error) {
+	for i, cat := range rss.Categories {
+		if cat.Name != category {
+			continue
+		}
+
+		for j, feed := range cat.Subscriptions {
+			if feed.Name == name {
+				rss.Categories[i].Subscriptions[j].FullContent = !feed.FullContent
+				return !feed.FullContent, nil
+			}
+		}
+	}
+
+	return false, ErrNotFound
+}
+
+// SetSortOrder will change the order in which the articles of a feed are shown, the feed can be in
+// many categories under the same name
+func (rss *Rss) SetSortOrder(name, order string) error {
+	if name == AllFeedsName || name == DownloadedFeedsName {
+		return ErrReservedName
+	}
+
+	found := false
+	for i, cat := range rss.Categories {
+		for j, feed := range cat.Subscriptions {
+			if feed.Name == name {
+				rss.Categories[i].Subscriptions[j].Sort = order
+				found = true
+			}
+		}
+	}
+
+	if !found {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// SetTags will replace the tags of a feed, the feed can be in many categories under the same name.
+// The tags can't contain whitespace, the empty and the repeated tags are dropped
+func (rss *Rss) SetTags(name string, tags []string) error {
+	if name == AllFeedsName || name == DownloadedFeedsName {
+		return ErrReservedName
+	}
+
+	seen := make(map[string]bool)
+	var cleaned []string
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+
+		if strings.ContainsAny(tag, " \t\n") {
+			return ErrInvalidTag
+		}
+
+		seen[tag] = true
+		cleaned = append(cleaned, tag)
+	}
+
+	found := false
+	for i, cat := range rss.Categories {
+		for j, feed := range cat.Subscriptions {
+			if feed.Name == name {
+				rss.Categories[i].Subscriptions[j].Tags = cleaned
+				found = true
+			}
+		}
+	}
+
+	if !found {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// UpdateURL will change the url of all the feeds which use the old one, it's used when a feed moves.
+// If another feed already uses the new url nothing is changed
+func (rss *Rss) UpdateURL(from, to string) error {
+	if to == "" {
+		return errors.New("you must include a URL")
+	}
+
+	found, taken := false, false
+	for _, cat := range rss.Categories {
+		for _, feed := range cat.Subscriptions {
+			found = found || feed.URL == from
+			taken = taken || (feed.URL == to && to != from)
+		}
+	}
+
+	if !found {
+		return ErrNotFound
+	}
+
+	if taken {
+		return ErrAlreadyExists
+	}
+
+	for i, cat := range rss.Categories {
+		for j, feed := range cat.Subscriptions {
+			if feed.URL == from {
+				rss.Categories[i].Subscriptions[j].URL = to
+			}
+		}
+	}
+
+	return nil
+}
+
+// UpdateCategory will change the name/description of a category by a string key, the categories
+// nested in it are moved along with it
 func (rss *Rss) UpdateCategory(key, name, desc string) error {
 	// Check if the name is empty
 	if name == "" {
@@ -134,29 +311,48 @@ func (rss *Rss) UpdateCategory(key, name, desc string) error {
 	}
 
 	// Check if the name is reserved
-	if key == AllFeedsName || key == DownloadedFeedsName || name == AllFeedsName || name == DownloadedFeedsName {
+	if key == AllFeedsName || key == DownloadedFeedsName || key == DigestName ||
+		name == AllFeedsName || name == DownloadedFeedsName || name == DigestName {
 		return ErrReservedName
 	}
 
 	// Check if the category already exists
+	found := false
 	for _, cat := range rss.Categories {
 		if cat.Name == name && name != key {
 			return ErrAlreadyExists
 		}
+
+		found = found || cat.Name == key
 	}
 
-	// Find the category
+	// We couldn't find the category
+	if !found {
+		return ErrNotFound
+	}
+
+	// Check if the category would end up inside of itself
+	if IsNestedIn(name, key) {
+		return ErrNestedInItself
+	}
+
+	if err := rss.checkCategoryName(name); err != nil {
+		return err
+	}
+
+	// Update the category and the names of the nested ones
 	for i, cat := range rss.Categories {
-		if cat.Name == key {
-			// Update the category
+		switch {
+		case cat.Name == key:
 			rss.Categories[i].Name = name
 			rss.Categories[i].Description = desc
-			return nil
+
+		case IsNestedIn(cat.Name, key):
+			rss.Categories[i].Name = name + strings.TrimPrefix(cat.Name, key)
 		}
 	}
 
-	// We couldn't find the category
-	return ErrNotFound
+	return nil
 }
 
 // UpdateFeed will change the name/url of a feed by a string key and a category
@@ -167,7 +363,7 @@ func (rss *Rss) UpdateFeed(category, key, name, url string) error {
 	}
 
 	// Check if the name is reserved
-	if name == AllFeedsName || name == DownloadedFeedsName {
+	if name == AllFeedsName || name == DownloadedFeedsName || name == DigestName || name == ArchiveName || name == TrashName {
 		return ErrReservedName
 	}
 
@@ -194,6 +390,11 @@ func (rss *Rss) UpdateFeed(category, key, name, url string) error {
 			// Find the feed
 			for j, feed := range cat.Subscriptions {
 				if feed.Name == key {
+					// The url is shown without the credentials, editing only the name keeps them
+					if url == StripCredentials(feed.URL) {
+						url = feed.URL
+					}
+
 					// Update the feed
 					rss.Categories[i].Subscriptions[j].Name = name
 					rss.Categories[i].Subscriptions[j].URL = url
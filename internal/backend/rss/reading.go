@@ -0,0 +1,114 @@
+package rss
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mmcdole/gofeed"
+	"golang.org/x/net/html"
+)
+
+// DefaultWordsPerMinute is the reading speed of an average adult reader
+const DefaultWordsPerMinute = 200
+
+// WordsPerMinute is the reading speed used to estimate the reading time of the articles
+var WordsPerMinute = DefaultWordsPerMinute
+
+// itemContent returns the html of the article, the full content is preferred over the summary
+func itemContent(item *gofeed.Item) string {
+	if len(item.Content) > len(item.Description) {
+		return item.Content
+	}
+
+	return item.Description
+}
+
+// blockTags are the elements which start on a new line, the text of the others flows together
+var blockTags = map[string]bool{
+	"p": true, "div": true, "br": true, "li": true, "ul": true, "ol": true, "pre": true,
+	"blockquote": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"tr": true, "table": true, "figure": true, "figcaption": true, "section": true, "article": true,
+}
+
+// WordCount returns the number of words in the text of the article
+func WordCount(item *gofeed.Item) int {
+	return len(strings.Fields(Text(item)))
+}
+
+// Text returns the text of the article without the markup, every block of it is on its own line.
+// The contents of the scripts and the styles are dropped. The tokenizer stops at the end of the
+// html or at the first error
+func Text(item *gofeed.Item) string {
+	tokenizer := html.NewTokenizer(strings.NewReader(itemContent(item)))
+	var sb strings.Builder
+	skipped := ""
+	for {
+		switch token := tokenizer.Next(); token {
+		case html.ErrorToken:
+			return collapseSpaces(sb.String())
+		case html.StartTagToken, html.EndTagToken, html.SelfClosingTagToken:
+			name, _ := tokenizer.TagName()
+			switch tag := string(name); {
+			case tag == "script" || tag == "style":
+				skipped = ""
+				if token == html.StartTagToken {
+					skipped = tag
+				}
+			case blockTags[tag]:
+				sb.WriteByte('\n')
+			}
+		case html.TextToken:
+			if skipped == "" {
+				sb.Write(tokenizer.Text())
+			}
+		}
+	}
+}
+
+// collapseSpaces removes the empty lines and joins the runs of the spaces in the text
+func collapseSpaces(text string) string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if fields := strings.Fields(line); len(fields) > 0 {
+			lines = append(lines, strings.Join(fields, " "))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// ReadingMinutes returns the estimated time in minutes it takes to read the words, the articles
+// with any words take at least a minute
+func ReadingMinutes(words int) int {
+	wpm := WordsPerMinute
+	if wpm <= 0 {
+		wpm = DefaultWordsPerMinute
+	}
+
+	return (words + wpm - 1) / wpm
+}
+
+// ReadingStats describes the length of the article, like "1,234 words, 7 min read". It's empty
+// if the article has no words
+func ReadingStats(words int) string {
+	if words == 0 {
+		return ""
+	}
+
+	unit := "words"
+	if words == 1 {
+		unit = "word"
+	}
+
+	return fmt.Sprintf("%s %s, %d min read", groupDigits(words), unit, ReadingMinutes(words))
+}
+
+// groupDigits separates the thousands in the number with commas
+func groupDigits(n int) string {
+	digits := fmt.Sprint(n)
+	for i := len(digits) - 3; i > 0; i -= 3 {
+		digits = digits[:i] + "," + digits[i:]
+	}
+
+	return digits
+}
@@ -1,13 +1,18 @@
 package rss
 
 import (
+	"encoding/xml"
 	"errors"
 	"log"
+	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	md "github.com/JohannesKaufmann/html-to-markdown"
+	"github.com/JohannesKaufmann/html-to-markdown/plugin"
 	"github.com/PuerkitoBio/goquery"
 	"github.com/gilliek/go-opml/opml"
 	"github.com/mmcdole/gofeed"
@@ -20,6 +25,40 @@ var AllFeedsName = "All Feeds"
 // DownloadedFeedsName is the name of the downloaded feeds category
 var DownloadedFeedsName = "Saved"
 
+// DigestName is the name of the daily digests in the welcome tab
+var DigestName = "Digest"
+
+// ArchiveName is the name of the archived articles in the welcome tab
+var ArchiveName = "Archive"
+
+// TrashName is the name of the trashed articles in the welcome tab
+var TrashName = "Trash"
+
+// SearchPrefix is the prefix of the titles of the search result tabs, the rest is the query
+var SearchPrefix = "Search: "
+
+// QueryPrefix marks the urls of the query feeds, the rest of the url is the filter expression
+var QueryPrefix = "query:"
+
+// ExecPrefix marks the urls of the exec feeds, the rest of the url is a command which prints the feed
+var ExecPrefix = "exec:"
+
+// MailboxSchemes are the schemes of the urls of the mailbox feeds, the newsletters in an IMAP mailbox
+var MailboxSchemes = []string{"imaps://", "imap://"}
+
+// GeminiScheme is the scheme of the urls of the feeds on the Gemini protocol
+var GeminiScheme = "gemini://"
+
+// FileScheme is the scheme of the urls of the local feeds, a feed file or a directory of markdown files
+var FileScheme = "file://"
+
+// TagPrefix is the prefix of the titles of the tag tabs, the rest is the tag
+var TagPrefix = "Tag: "
+
+// CategorySeparator separates the names of the nested categories, "Tech/Go" is the Go category
+// inside of the Tech category
+var CategorySeparator = "/"
+
 // DefaultCategoryName is the name of the default category
 var DefaultCategoryName = "News"
 
@@ -71,11 +110,126 @@ type Category struct {
 	Subscriptions []Feed `yaml:"subscriptions"`
 }
 
+// ParentName returns the name of the category which contains the nested category, it's empty for
+// the top level categories
+func ParentName(name string) string {
+	if i := strings.LastIndex(name, CategorySeparator); i >= 0 {
+		return name[:i]
+	}
+
+	return ""
+}
+
+// BaseName returns the name of the category without the names of the categories containing it
+func BaseName(name string) string {
+	if i := strings.LastIndex(name, CategorySeparator); i >= 0 {
+		return name[i+len(CategorySeparator):]
+	}
+
+	return name
+}
+
+// IsNestedIn reports if the category is nested in the parent category, at any depth
+func IsNestedIn(name, parent string) bool {
+	return strings.HasPrefix(name, parent+CategorySeparator)
+}
+
 // Feed is a single rss feed
 type Feed struct {
-	Name        string `yaml:"name"`
-	Description string `yaml:"desc"`
-	URL         string `yaml:"url"`
+	Name          string   `yaml:"name"`
+	Description   string   `yaml:"desc"`
+	URL           string   `yaml:"url"`
+	FullContent   bool     `yaml:"full_content,omitempty"`
+	Sort          string   `yaml:"sort,omitempty"`
+	FilterCommand string   `yaml:"filter_command,omitempty"`
+	Tags          []string `yaml:"tags,omitempty"`
+}
+
+// IsQuery reports if the feed is a query feed, which aggregates the matching articles of the other feeds
+func (f Feed) IsQuery() bool {
+	return strings.HasPrefix(f.URL, QueryPrefix)
+}
+
+// IsExec reports if the feed is an exec feed, which is the output of a local command
+func (f Feed) IsExec() bool {
+	return IsExec(f.URL)
+}
+
+// IsExec reports if the url is the command of an exec feed. The exec feeds run on this machine so
+// they're only accepted from the urls file and from the user, never from the imported or synced feeds
+func IsExec(url string) bool {
+	return strings.HasPrefix(strings.TrimSpace(url), ExecPrefix)
+}
+
+// IsMailbox reports if the feed is a mailbox feed, which turns the messages in a mailbox into articles
+func (f Feed) IsMailbox() bool {
+	return IsMailbox(f.URL)
+}
+
+// IsMailbox reports if the url points to an IMAP mailbox
+func IsMailbox(url string) bool {
+	for _, scheme := range MailboxSchemes {
+		if strings.HasPrefix(strings.ToLower(url), scheme) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// StripCredentials returns the url without the username and the password, so that it can be logged
+// or made into an identifier without leaking the password of a mailbox or a private feed
+func StripCredentials(raw string) string {
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.User == nil {
+		return raw
+	}
+
+	parsed.User = nil
+	return parsed.String()
+}
+
+// IsWeb reports if the url is downloaded over http or https
+func IsWeb(url string) bool {
+	lower := strings.ToLower(strings.TrimSpace(url))
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://")
+}
+
+// IsDiscoverable reports if a feed found on a web page can be subscribed to. The pages only get to
+// point to the feeds on the web and on the Gemini protocol, never to the exec or the local feeds
+func IsDiscoverable(url string) bool {
+	return IsWeb(url) || IsGemini(strings.TrimSpace(url))
+}
+
+// IsGemini reports if the url points to a capsule on the Gemini protocol
+func IsGemini(url string) bool {
+	return strings.HasPrefix(strings.ToLower(url), GeminiScheme)
+}
+
+// IsFile reports if the feed is read from a local file or directory
+func (f Feed) IsFile() bool {
+	return IsFile(f.URL)
+}
+
+// IsFile reports if the url points to a local file or directory
+func IsFile(url string) bool {
+	return strings.HasPrefix(strings.ToLower(url), FileScheme)
+}
+
+// HasTag reports if the feed is tagged with the tag
+func (f Feed) HasTag(tag string) bool {
+	for _, t := range f.Tags {
+		if t == tag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Query returns the filter expression of a query feed
+func (f Feed) Query() string {
+	return strings.TrimSpace(strings.TrimPrefix(f.URL, QueryPrefix))
 }
 
 // New will create a new Rss structure
@@ -151,30 +305,102 @@ func (rss Rss) GetFeeds(categoryName string) ([]Feed, error) {
 	return nil, ErrNotFound
 }
 
+// SubCategories will return the categories nested directly in the category
+func (rss Rss) SubCategories(categoryName string) []Category {
+	var result []Category
+	for _, cat := range rss.Categories {
+		if ParentName(cat.Name) == categoryName && categoryName != "" {
+			result = append(result, cat)
+		}
+	}
+
+	return result
+}
+
 // GetFeedURL will return the url of a feed denoted by the name
 func (rss Rss) GetFeedURL(feedName string) (string, error) {
+	feed, err := rss.GetFeed(feedName)
+	if err != nil {
+		return "", err
+	}
+
+	return feed.URL, nil
+}
+
+// GetFeed will return the feed denoted by the name
+func (rss Rss) GetFeed(feedName string) (Feed, error) {
 	if feedName == AllFeedsName || feedName == DownloadedFeedsName {
-		return "", ErrReservedName
+		return Feed{}, ErrReservedName
 	}
 
 	for _, cat := range rss.Categories {
 		for _, feed := range cat.Subscriptions {
 			if feed.Name == feedName {
-				return feed.URL, nil
+				return feed, nil
+			}
+		}
+	}
+
+	return Feed{}, ErrNotFound
+}
+
+// FilterCommand returns the filter command of the feed with the url, it's empty if the feed
+// isn't filtered
+func (rss Rss) FilterCommand(feedURL string) string {
+	for _, cat := range rss.Categories {
+		for _, feed := range cat.Subscriptions {
+			if feed.URL == feedURL && feed.FilterCommand != "" {
+				return feed.FilterCommand
 			}
 		}
 	}
 
-	return "", ErrNotFound
+	return ""
 }
 
-// GetAllURLs will return a list of all the urls
+// GetAllURLs will return a list of all the urls, the query feeds are skipped
 func (rss Rss) GetAllURLs() []string {
 	var urls []string
 
 	for _, cat := range rss.Categories {
 		for _, feed := range cat.Subscriptions {
-			if feed.URL != AllFeedsName {
+			if feed.URL != AllFeedsName && !feed.IsQuery() {
+				urls = append(urls, feed.URL)
+			}
+		}
+	}
+
+	return urls
+}
+
+// Tags will return the sorted list of the tags used by the feeds
+func (rss Rss) Tags() []string {
+	seen := make(map[string]bool)
+	var tags []string
+	for _, cat := range rss.Categories {
+		for _, feed := range cat.Subscriptions {
+			for _, tag := range feed.Tags {
+				if !seen[tag] {
+					seen[tag] = true
+					tags = append(tags, tag)
+				}
+			}
+		}
+	}
+
+	sort.Strings(tags)
+	return tags
+}
+
+// TagURLs will return the urls of the feeds tagged with the tag, a feed in many categories is
+// returned once and the query feeds are skipped
+func (rss Rss) TagURLs(tag string) []string {
+	seen := make(map[string]bool)
+	var urls []string
+	for _, cat := range rss.Categories {
+		for _, feed := range cat.Subscriptions {
+			if feed.HasTag(tag) && !feed.IsQuery() && !seen[feed.URL] {
+				seen[feed.URL] = true
 				urls = append(urls, feed.URL)
 			}
 		}
@@ -192,22 +418,31 @@ func YassifyItem(item *gofeed.Item) string {
 	mdown += "# " + item.Title + "\n "
 
 	// If there are no authors, then don't add the author
-	if item.Authors != nil {
-		mdown += item.Authors[0].Name + "\n"
+	if len(item.Authors) > 0 && item.Authors[0] != nil && item.Authors[0].Name != "" {
+		mdown += "*" + item.Authors[0].Name + "*\n"
 	}
 
 	// Show when the article was published if available
 	if item.PublishedParsed != nil {
 		mdown += "\n"
-		mdown += "Published: " + item.PublishedParsed.Format("2006-01-02 15:04:05")
+		mdown += "*Published: " + item.PublishedParsed.Format("2006-01-02 15:04:05") + "*"
+	}
+
+	// Show how long the article takes to read
+	if stats := ReadingStats(WordCount(item)); stats != "" {
+		mdown += "\n\n*" + stats + "*"
 	}
 
-	// Convert the html to markdown
+	// Prefer the full content of the article over the summary
+	content := itemContent(item)
+
+	// Convert the sanitized html to markdown, relative links are resolved using the article link
 	mdown += "\n\n"
-	htmlMarkdown, err := HTMLToMarkdown(item.Description)
+	content = SanitizeHTML(content)
+	htmlMarkdown, err := newConverter(item.Link).ConvertString(content)
 	if err != nil {
 		// If there is an error, then just print the html
-		mdown += item.Description
+		mdown += content
 	} else {
 		mdown += htmlMarkdown
 	}
@@ -220,29 +455,55 @@ func YassifyItem(item *gofeed.Item) string {
 		}
 	}
 
+	// Add the episode if it's a podcast
+	if episode := Episode(item); episode != nil {
+		mdown += "\n## Episode\n"
+		mdown += "- " + episode.URL + "\n"
+	}
+
 	// Add padding
 	mdown += "\n\n"
 
-	// Return the markdown
-	return mdown
+	// Return the markdown, the entities decoded by the converter could be control characters too
+	return StripControl(mdown)
 }
 
-// HTMLToMarkdown converts html to markdown using the html-to-markdown library
+// HTMLToMarkdown converts html to markdown using the html-to-markdown library, the html is sanitized first
 func HTMLToMarkdown(content string) (string, error) {
-	// Create a new converter
-	converter := md.NewConverter("", true, nil)
-
-	// Convert the html to markdown
-	markdown, err := converter.ConvertString(content)
+	// Convert the sanitized html to markdown
+	markdown, err := newConverter("").ConvertString(SanitizeHTML(content))
 	if err != nil {
 		return "", err
 	}
 
 	// Return the markdown
-	return markdown, nil
+	return StripControl(markdown), nil
 }
 
-// LoadOPML will load the urls from an opml file.
+// newConverter creates a html to markdown converter, the code blocks are fenced so that glamour can
+// highlight them and the github flavored markdown is used for tables and strikethroughs. Relative
+// links are resolved against the base url if it's given
+func newConverter(base string) *md.Converter {
+	options := &md.Options{CodeBlockStyle: "fenced"}
+	baseURL, err := url.Parse(base)
+	if base != "" && err == nil {
+		options.GetAbsoluteURL = func(_ *goquery.Selection, rawURL, _ string) string {
+			ref, err := url.Parse(strings.TrimSpace(rawURL))
+			if err != nil || ref.Scheme == "data" {
+				return rawURL
+			}
+
+			return baseURL.ResolveReference(ref).String()
+		}
+	}
+
+	converter := md.NewConverter(md.DomainFromURL(base), true, options)
+	converter.Use(plugin.GitHubFlavored())
+	return converter
+}
+
+// LoadOPML will load the urls from an opml file. Top level outlines which contain other outlines
+// are treated as categories, any deeper folders are flattened into their top level category.
 func (rss *Rss) LoadOPML(path string) error {
 	parsed, err := opml.NewOPMLFromFile(path)
 	if err != nil {
@@ -250,29 +511,29 @@ func (rss *Rss) LoadOPML(path string) error {
 	}
 
 	for _, o := range parsed.Outlines() {
-		catName := DefaultCategoryName
-		catDesc := DefaultCategoryDescription
-
-		if o.Type != "rss" {
-			catName = o.Title
-			catDesc = o.Text
-		}
-
-		if err = rss.AddCategory(catName, catDesc); err != nil && err != ErrAlreadyExists {
-			return err
-		}
+		if o.XMLURL != "" {
+			if err = rss.AddCategory(DefaultCategoryName, DefaultCategoryDescription); err != nil && err != ErrAlreadyExists {
+				return err
+			}
 
-		if len(o.Outlines) == 0 {
-			if err = rss.AddFeed(DefaultCategoryName, o.Title, o.XMLURL); err != nil && err != ErrAlreadyExists {
+			if err = rss.addOutline(DefaultCategoryName, o); err != nil {
 				return err
 			}
 
 			continue
 		}
 
-		for _, so := range o.Outlines {
-			log.Println("Adding feed:", so.Title)
-			if err = rss.AddFeed(catName, so.Title, so.XMLURL); err != nil && err != ErrAlreadyExists {
+		catName, catDesc := outlineName(o), StripControl(o.Description)
+		if catDesc == "" && o.Text != catName {
+			catDesc = StripControl(o.Text)
+		}
+
+		if err = rss.AddCategory(catName, catDesc); err != nil && err != ErrAlreadyExists {
+			return err
+		}
+
+		for _, so := range flattenOutlines(o.Outlines) {
+			if err = rss.addOutline(catName, so); err != nil {
 				return err
 			}
 		}
@@ -281,27 +542,37 @@ func (rss *Rss) LoadOPML(path string) error {
 	return nil
 }
 
-// ExportOPML will export the urls to an opml file.
+// ExportOPML will export the urls to an opml (2.0) file.
 func (rss *Rss) ExportOPML(path string) error {
 	result := opml.OPML{
-		Version: "1.0",
-		Head:    opml.Head{Title: "goread - Exported feeds"},
-		Body:    opml.Body{},
+		Version: "2.0",
+		Head: opml.Head{
+			Title:       "goread - Exported feeds",
+			DateCreated: time.Now().Format(time.RFC1123Z),
+		},
+		Body: opml.Body{},
 	}
 
 	for _, cat := range rss.Categories {
 		result.Body.Outlines = append(result.Body.Outlines, opml.Outline{
-			Title: cat.Name,
-			Text:  cat.Description,
+			Text:        cat.Name,
+			Title:       cat.Name,
+			Description: cat.Description,
 		})
 
 		elem := &result.Body.Outlines[len(result.Body.Outlines)-1]
 		for _, feed := range cat.Subscriptions {
+			// Other readers wouldn't understand the query, the exec, the mailbox and the local feeds
+			if feed.IsQuery() || feed.IsExec() || feed.IsMailbox() || feed.IsFile() {
+				continue
+			}
+
 			elem.Outlines = append(elem.Outlines, opml.Outline{
-				Type:   "rss",
-				Text:   feed.Name,
-				Title:  feed.Name,
-				XMLURL: feed.URL,
+				Type:        "rss",
+				Text:        feed.Name,
+				Title:       feed.Name,
+				Description: feed.Description,
+				XMLURL:      feed.URL,
 			})
 		}
 	}
@@ -311,13 +582,48 @@ func (rss *Rss) ExportOPML(path string) error {
 		return err
 	}
 
-	if err = os.WriteFile(path, []byte(data), 0644); err != nil {
+	return os.WriteFile(path, []byte(xml.Header+data), 0600)
+}
+
+// addOutline adds a single opml outline as a feed in the category, duplicates and exec feeds are skipped.
+func (rss *Rss) addOutline(category string, o opml.Outline) error {
+	if IsExec(o.XMLURL) {
+		log.Println("Skipping the exec feed:", outlineName(o))
+		return nil
+	}
+
+	log.Println("Adding feed:", outlineName(o))
+	if err := rss.AddFeed(category, outlineName(o), o.XMLURL); err != nil && err != ErrAlreadyExists {
 		return err
 	}
 
 	return nil
 }
 
+// outlineName returns the display name of an outline, OPML 2.0 only requires the text attribute.
+// The control characters are removed since the names are shown in the lists
+func outlineName(o opml.Outline) string {
+	if o.Title != "" {
+		return StripControl(o.Title)
+	}
+
+	return StripControl(o.Text)
+}
+
+// flattenOutlines returns all the feed outlines contained in the outlines, including nested ones.
+func flattenOutlines(outlines []opml.Outline) []opml.Outline {
+	var result []opml.Outline
+	for _, o := range outlines {
+		if o.XMLURL != "" {
+			result = append(result, o)
+		}
+
+		result = append(result, flattenOutlines(o.Outlines)...)
+	}
+
+	return result
+}
+
 // HTMLToText converts html to text using the goquery library
 func HTMLToText(content string) (string, error) {
 	// Create a new document
@@ -0,0 +1,185 @@
+package rss
+
+import (
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/microcosm-cc/bluemonday"
+)
+
+// trackerHosts are the hosts of the tracking pixels and the share buttons embedded in the articles,
+// their subdomains are trackers too
+var trackerHosts = []string{
+	"feeds.feedburner.com",
+	"feedproxy.google.com",
+	"feeds.feedblitz.com",
+	"assets.feedblitz.com",
+	"feedsportal.com",
+	"pixel.wp.com",
+	"stats.wordpress.com",
+	"google-analytics.com",
+	"doubleclick.net",
+	"pixel.quantserve.com",
+	"counter.theconversation.com",
+	"pi.pardot.com",
+}
+
+// policy keeps the markup which can be shown in the terminal: the text, the links, the images, the
+// lists, the tables and the code blocks with their languages. The scripts, the styles, the frames,
+// the forms and the event handlers are dropped
+var policy = newPolicy()
+
+// newPolicy creates the policy used to sanitize the articles
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	p.AllowDataURIImages()
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+#-]+$`)).OnElements("code")
+	return p
+}
+
+// languagePatterns find the language of a code block in its classes, the sites use the conventions
+// of highlight.js, prism, pygments, google-code-prettify and SyntaxHighlighter
+var languagePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?:^|\s)(?:language|lang|highlight|source)-([\w+#-]+)`),
+	regexp.MustCompile(`(?:^|\s)brush:\s*([\w+#-]+)`),
+	regexp.MustCompile(`(?:^|\s)sourceCode\s+([\w+#-]+)`),
+}
+
+// languageName matches the languages in the data attributes
+var languageName = regexp.MustCompile(`^[\w+#-]+$`)
+
+// SanitizeHTML removes everything from the html of an article which shouldn't reach the terminal,
+// the scripts, the frames, the event handlers, the tracking pixels and the control characters
+func SanitizeHTML(content string) string {
+	return StripControl(policy.Sanitize(prepareHTML(content)))
+}
+
+// prepareHTML removes the tracking pixels, the images which are a pixel big or come from a known
+// tracker, and marks the languages of the code blocks the way the converter understands, before the
+// classes are dropped. The html is returned as it is if it can't be parsed
+func prepareHTML(content string) string {
+	if !strings.Contains(content, "<img") && !strings.Contains(content, "<pre") {
+		return content
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(content))
+	if err != nil {
+		return content
+	}
+
+	removed := doc.Find("img").FilterFunction(func(_ int, img *goquery.Selection) bool {
+		return isPixel(img.AttrOr("width", "")) || isPixel(img.AttrOr("height", "")) || isTracker(img.AttrOr("src", ""))
+	}).Remove()
+
+	marked := 0
+	doc.Find("pre").Each(func(_ int, pre *goquery.Selection) {
+		if markLanguage(pre) {
+			marked++
+		}
+	})
+
+	if removed.Length() == 0 && marked == 0 {
+		return content
+	}
+
+	result, err := doc.Find("body").Html()
+	if err != nil {
+		return content
+	}
+
+	return result
+}
+
+// markLanguage sets the class of the code in the code block to the language of the block, the
+// language is looked up on the code, on the block and on its parent. It reports if one was found
+func markLanguage(pre *goquery.Selection) bool {
+	code := pre.Find("code").First()
+	language := ""
+	for _, s := range []*goquery.Selection{code, pre, pre.Parent()} {
+		if language = codeLanguage(s); language != "" {
+			break
+		}
+	}
+
+	if language == "" {
+		return false
+	}
+
+	if code.Length() == 0 {
+		inner, err := pre.Html()
+		if err != nil {
+			return false
+		}
+
+		pre.SetHtml("<code>" + inner + "</code>")
+		code = pre.Find("code").First()
+	}
+
+	code.SetAttr("class", "language-"+language)
+	return true
+}
+
+// codeLanguage returns the language in the classes or the data attributes of the element
+func codeLanguage(s *goquery.Selection) string {
+	if s.Length() == 0 {
+		return ""
+	}
+
+	for _, attr := range []string{"data-lang", "data-language"} {
+		if language, ok := s.Attr(attr); ok && languageName.MatchString(language) {
+			return strings.ToLower(language)
+		}
+	}
+
+	class := s.AttrOr("class", "")
+	for _, pattern := range languagePatterns {
+		if match := pattern.FindStringSubmatch(class); match != nil {
+			return strings.ToLower(match[1])
+		}
+	}
+
+	return ""
+}
+
+// isPixel reports if the size of an image is at most a single pixel
+func isPixel(size string) bool {
+	size = strings.TrimSuffix(strings.TrimSpace(size), "px")
+	value, err := strconv.Atoi(size)
+	return err == nil && value <= 1
+}
+
+// isTracker reports if the image comes from a known tracker
+func isTracker(src string) bool {
+	parsed, err := url.Parse(strings.TrimSpace(src))
+	if err != nil {
+		return false
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+	for _, tracker := range trackerHosts {
+		if host == tracker || strings.HasSuffix(host, "."+tracker) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// StripControl removes the control characters from the text, the escape sequences in the feeds
+// could otherwise take over the terminal. The tabs and the newlines are kept
+func StripControl(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\t' {
+			return r
+		}
+
+		if r < 0x20 || r >= 0x7f && r <= 0x9f {
+			return -1
+		}
+
+		return r
+	}, text)
+}
@@ -0,0 +1,44 @@
+package rss
+
+import (
+	"strings"
+)
+
+// SavedSearchesName is the name of the category which holds the saved searches
+var SavedSearchesName = "Saved searches"
+
+// SavedSearchesDescription is the description of the category which holds the saved searches
+var SavedSearchesDescription = "Searches recomputed every time they are opened"
+
+// SearchQuery turns the text of a search into the expression of a query feed, every word must
+// appear in the text of the article like in the search. Only the unread articles match if unread is set
+func SearchQuery(text string, unread bool) string {
+	var conditions []string
+	for _, word := range strings.Fields(text) {
+		// The strings in the expressions can't contain quotes
+		if word = strings.ReplaceAll(word, `"`, ""); word != "" {
+			conditions = append(conditions, `text # "`+word+`"`)
+		}
+	}
+
+	if unread {
+		conditions = append(conditions, "unread = yes")
+	}
+
+	return strings.Join(conditions, " and ")
+}
+
+// SaveSearch saves the search under the name as a query feed in the saved searches category,
+// the category is created if it doesn't exist yet
+func (rss *Rss) SaveSearch(name, text string, unread bool) error {
+	expression := SearchQuery(text, unread)
+	if expression == "" {
+		return ErrEmptyName
+	}
+
+	if err := rss.AddCategory(SavedSearchesName, SavedSearchesDescription); err != nil && err != ErrAlreadyExists {
+		return err
+	}
+
+	return rss.AddFeed(SavedSearchesName, name, QueryPrefix+" "+expression)
+}
@@ -0,0 +1,190 @@
+package rss
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// NitterInstance is the host of the Nitter instance which serves the feeds of the Twitter
+// accounts, the Twitter addresses aren't translated if it's empty
+var NitterInstance string
+
+var (
+	subredditShorthand = regexp.MustCompile(`^/?r/([A-Za-z0-9_+]+)/?$`)
+	redditorShorthand  = regexp.MustCompile(`^/?u(?:ser)?/([A-Za-z0-9_-]+)/?$`)
+	fediverseHandle    = regexp.MustCompile(`^@([A-Za-z0-9_.-]+)@([A-Za-z0-9.-]+\.[A-Za-z]{2,})$`)
+	twitterHandle      = regexp.MustCompile(`^@([A-Za-z0-9_]{1,15})$`)
+	validName          = regexp.MustCompile(`^[A-Za-z0-9_.+-]+$`)
+)
+
+// githubPages are the pages of GitHub which aren't users or organizations
+var githubPages = map[string]bool{
+	"orgs": true, "settings": true, "topics": true, "explore": true, "marketplace": true,
+	"sponsors": true, "features": true, "notifications": true, "search": true, "login": true,
+}
+
+// twitterPages are the pages of Twitter which aren't accounts
+var twitterPages = map[string]bool{
+	"home": true, "explore": true, "search": true, "settings": true, "notifications": true,
+	"messages": true, "i": true, "hashtag": true, "login": true, "intent": true, "share": true,
+}
+
+// ExpandShorthand translates the addresses of the well-known sites to the addresses of their feeds:
+// r/golang or a subreddit url to the feed of the subreddit, a YouTube channel, user or playlist to
+// its videos, a GitHub repository to its releases and a Twitter account to its feed on the Nitter
+// instance. The title describes the feed, ok is false if the address isn't recognized. The fediverse
+// accounts can be on any host, see FediverseProfile
+func ExpandShorthand(input string) (feedURL, title string, ok bool) {
+	input = strings.TrimSpace(input)
+	if match := subredditShorthand.FindStringSubmatch(input); match != nil {
+		return "https://www.reddit.com/r/" + match[1] + "/.rss", "r/" + match[1], true
+	}
+
+	if match := redditorShorthand.FindStringSubmatch(input); match != nil {
+		return "https://www.reddit.com/user/" + match[1] + "/.rss", "u/" + match[1], true
+	}
+
+	if match := twitterHandle.FindStringSubmatch(input); match != nil {
+		return nitterFeed(match[1])
+	}
+
+	if !strings.Contains(input, "://") {
+		input = "https://" + input
+	}
+
+	parsed, err := url.Parse(input)
+	if err != nil {
+		return "", "", false
+	}
+
+	var segments []string
+	for _, segment := range strings.Split(parsed.Path, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+
+	// The addresses of the feeds are left as they are
+	if len(segments) > 0 {
+		last := strings.ToLower(segments[len(segments)-1])
+		if strings.HasSuffix(last, ".rss") || strings.HasSuffix(last, ".atom") || strings.HasSuffix(last, ".xml") {
+			return "", "", false
+		}
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+	for _, prefix := range []string{"www.", "old.", "new.", "mobile.", "m."} {
+		host = strings.TrimPrefix(host, prefix)
+	}
+
+	switch host {
+	case "reddit.com":
+		if len(segments) >= 2 && validName.MatchString(segments[1]) {
+			switch segments[0] {
+			case "r":
+				return "https://www.reddit.com/r/" + segments[1] + "/.rss", "r/" + segments[1], true
+			case "u", "user":
+				return "https://www.reddit.com/user/" + segments[1] + "/.rss", "u/" + segments[1], true
+			}
+		}
+
+	case "youtube.com":
+		if list := parsed.Query().Get("list"); list != "" && len(segments) == 1 && (segments[0] == "playlist" || segments[0] == "watch") {
+			return "https://www.youtube.com/feeds/videos.xml?playlist_id=" + url.QueryEscape(list), "YouTube playlist " + list, true
+		}
+
+		if len(segments) >= 2 && validName.MatchString(segments[1]) {
+			switch segments[0] {
+			case "channel":
+				return "https://www.youtube.com/feeds/videos.xml?channel_id=" + segments[1], "YouTube channel " + segments[1], true
+			case "user":
+				return "https://www.youtube.com/feeds/videos.xml?user=" + segments[1], "YouTube " + segments[1], true
+			}
+		}
+
+	case "github.com":
+		switch {
+		case len(segments) == 0 || githubPages[segments[0]]:
+			// Not a user or a repository
+		case len(segments) >= 2 && validName.MatchString(segments[0]) && validName.MatchString(segments[1]):
+			repo := strings.TrimSuffix(segments[1], ".git")
+			return "https://github.com/" + segments[0] + "/" + repo + "/releases.atom", segments[0] + "/" + repo + " releases", true
+		case len(segments) == 1 && validName.MatchString(segments[0]):
+			return "https://github.com/" + segments[0] + ".atom", segments[0] + " on GitHub", true
+		}
+
+	case "twitter.com", "x.com":
+		if len(segments) >= 1 && !twitterPages[segments[0]] && twitterHandle.MatchString("@"+segments[0]) {
+			return nitterFeed(segments[0])
+		}
+
+	case "medium.com":
+		if len(segments) == 1 && strings.HasPrefix(segments[0], "@") && validName.MatchString(segments[0][1:]) {
+			return "https://medium.com/feed/" + segments[0], segments[0] + " on Medium", true
+		}
+
+	default:
+		// The accounts on the Nitter instance itself
+		if len(segments) == 1 && host == nitterHost() && !twitterPages[segments[0]] && twitterHandle.MatchString("@"+segments[0]) {
+			return nitterFeed(segments[0])
+		}
+	}
+
+	return "", "", false
+}
+
+// FediverseProfile returns the address of the profile of a fediverse account, given as @user@instance
+// or as the url of the profile, along with the feed Mastodon and the compatible servers serve at
+// /@user.rss. Any site can have the /@user pages, so the feed is only a guess which is used when
+// nothing is discovered on the profile. The title describes the account, ok is false if the address
+// doesn't look like an account
+func FediverseProfile(input string) (profileURL, feedURL, title string, ok bool) {
+	input = strings.TrimSpace(input)
+	if match := fediverseHandle.FindStringSubmatch(input); match != nil {
+		profileURL = "https://" + match[2] + "/@" + match[1]
+		return profileURL, profileURL + ".rss", "@" + match[1] + "@" + match[2], true
+	}
+
+	parsed, err := url.Parse(input)
+	if err != nil || parsed.Host == "" || parsed.User != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return "", "", "", false
+	}
+
+	name := strings.Trim(parsed.Path, "/")
+	if !strings.HasPrefix(name, "@") || !validName.MatchString(name[1:]) {
+		return "", "", "", false
+	}
+
+	profileURL = parsed.Scheme + "://" + parsed.Host + "/" + name
+	return profileURL, profileURL + ".rss", name + "@" + strings.ToLower(parsed.Hostname()), true
+}
+
+// nitterHost returns the host of the Nitter instance
+func nitterHost() string {
+	instance := NitterInstance
+	if !strings.Contains(instance, "://") {
+		instance = "https://" + instance
+	}
+
+	parsed, err := url.Parse(instance)
+	if err != nil || NitterInstance == "" {
+		return ""
+	}
+
+	return strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+}
+
+// nitterFeed returns the feed of the Twitter account on the Nitter instance
+func nitterFeed(account string) (feedURL, title string, ok bool) {
+	instance := strings.TrimSuffix(NitterInstance, "/")
+	if instance == "" {
+		return "", "", false
+	}
+
+	if !strings.Contains(instance, "://") {
+		instance = "https://" + instance
+	}
+
+	return instance + "/" + account + "/rss", "@" + account, true
+}
@@ -0,0 +1,247 @@
+package backend
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/TypicalAM/goread/internal/backend/cache"
+	"github.com/TypicalAM/goread/internal/config"
+	"github.com/mmcdole/gofeed"
+)
+
+// highlightKey marks the articles which should be highlighted in the article list
+const highlightKey = "goread_highlight"
+
+// alertKey marks the articles which triggered an alert, the value is the matched keyword or empty
+// if the alert came from a rule without keywords
+const alertKey = "goread_alert"
+
+// Action is the thing which happens to the articles matched by a rule
+type Action string
+
+const (
+	// ActionHide removes the article, it never reaches the feed tab
+	ActionHide Action = "hide"
+	// ActionRead marks the article as read
+	ActionRead Action = "read"
+	// ActionHighlight makes the article stand out in the article list
+	ActionHighlight Action = "highlight"
+	// ActionStar saves the article
+	ActionStar Action = "star"
+	// ActionAlert highlights and saves the article, a notification is shown if it's new
+	ActionAlert Action = "alert"
+)
+
+// rule is a compiled filter rule from the config
+type rule struct {
+	feed     string
+	category string
+	title    *regexp.Regexp
+	author   *regexp.Regexp
+	keywords []string
+	action   Action
+}
+
+// newRules compiles the filter rules from the config, the watch keywords become an alert rule
+func newRules(cfg []config.Rule, keywords []string) ([]rule, error) {
+	rules := make([]rule, len(cfg), len(cfg)+1)
+	for i, r := range cfg {
+		action := Action(r.Action)
+		switch action {
+		case ActionHide, ActionRead, ActionHighlight, ActionStar, ActionAlert:
+		default:
+			return nil, fmt.Errorf("rule %d: unknown action %q, expected hide, read, highlight, star or alert", i+1, r.Action)
+		}
+
+		rules[i] = rule{feed: r.Feed, category: r.Category, action: action}
+		var err error
+		if r.Title != "" {
+			if rules[i].title, err = regexp.Compile(r.Title); err != nil {
+				return nil, fmt.Errorf("rule %d: invalid title pattern: %w", i+1, err)
+			}
+		}
+
+		if r.Author != "" {
+			if rules[i].author, err = regexp.Compile(r.Author); err != nil {
+				return nil, fmt.Errorf("rule %d: invalid author pattern: %w", i+1, err)
+			}
+		}
+	}
+
+	if len(keywords) == 0 {
+		return rules, nil
+	}
+
+	alert := rule{action: ActionAlert}
+	for _, keyword := range keywords {
+		if keyword = strings.TrimSpace(keyword); keyword == "" {
+			return nil, fmt.Errorf("alerts: the keywords can't be empty")
+		}
+
+		alert.keywords = append(alert.keywords, keyword)
+	}
+
+	return append(rules, alert), nil
+}
+
+// matches reports if the rule applies to the article from the given feed
+func (r rule) matches(item gofeed.Item, feeds, categories map[string]bool) bool {
+	if r.feed != "" && !feeds[r.feed] {
+		return false
+	}
+
+	if r.category != "" && !categories[r.category] {
+		return false
+	}
+
+	if r.title != nil && !r.title.MatchString(item.Title) {
+		return false
+	}
+
+	if r.keywords != nil && r.keyword(item) == "" {
+		return false
+	}
+
+	if r.author != nil {
+		for _, author := range item.Authors {
+			if author != nil && r.author.MatchString(author.Name) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return true
+}
+
+// keyword returns the first of the keywords of the rule which is mentioned in the article, the
+// case is ignored. It's empty if none of them are mentioned or the rule has no keywords
+func (r rule) keyword(item gofeed.Item) string {
+	if r.keywords == nil {
+		return ""
+	}
+
+	text := cache.SearchableText(item)
+	for _, keyword := range r.keywords {
+		if containsWord(text, strings.ToLower(keyword)) {
+			return keyword
+		}
+	}
+
+	return ""
+}
+
+// containsWord reports if the word is in the text and isn't a part of a longer word, so that
+// "go" doesn't match "google"
+func containsWord(text, word string) bool {
+	for start := 0; ; {
+		i := strings.Index(text[start:], word)
+		if i < 0 {
+			return false
+		}
+
+		i += start
+		before, _ := utf8.DecodeLastRuneInString(text[:i])
+		after, _ := utf8.DecodeRuneInString(text[i+len(word):])
+		if !isWordRune(before) && !isWordRune(after) {
+			return true
+		}
+
+		start = i + 1
+	}
+}
+
+// isWordRune reports if the rune can be a part of a word
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+// applyRules runs the filter rules on the freshly fetched articles of a feed
+func (b Backend) applyRules(url string, articles cache.SortableArticles) cache.SortableArticles {
+	if len(b.rules) == 0 {
+		return articles
+	}
+
+	// The same url can be subscribed to in many categories
+	feeds := make(map[string]bool)
+	categories := make(map[string]bool)
+	for _, cat := range b.Rss.Categories {
+		for _, sub := range cat.Subscriptions {
+			if sub.URL == url {
+				feeds[sub.Name] = true
+				categories[cat.Name] = true
+			}
+		}
+	}
+
+	result := make(cache.SortableArticles, 0, len(articles))
+	for _, item := range articles {
+		hidden := false
+		for _, r := range b.rules {
+			if !r.matches(item, feeds, categories) {
+				continue
+			}
+
+			switch r.action {
+			case ActionHide:
+				hidden = true
+			case ActionRead:
+				b.ReadStatus.MarkAsRead(item)
+			case ActionHighlight:
+				item.Custom = withCustom(item.Custom, highlightKey, "true")
+			case ActionStar:
+				b.star(item)
+			case ActionAlert:
+				item.Custom = withCustom(withCustom(item.Custom, highlightKey, "true"), alertKey, r.keyword(item))
+				b.star(item)
+			}
+		}
+
+		if hidden {
+			log.Println("Hiding article because of a rule:", item.Title)
+			continue
+		}
+
+		result = append(result, item)
+	}
+
+	return result
+}
+
+// star saves an article found by a rule, unless it's already saved or was read
+func (b Backend) star(item gofeed.Item) {
+	if b.ReadStatus.IsRead(item) || b.Cache.IsDownloaded(item) {
+		return
+	}
+
+	log.Println("Saving article because of a rule:", item.Title)
+	b.Cache.AddToDownloaded(item)
+}
+
+// withCustom returns the custom fields of an item with the key set, the original map is not modified
+func withCustom(custom map[string]string, key, value string) map[string]string {
+	result := make(map[string]string, len(custom)+1)
+	for k, v := range custom {
+		result[k] = v
+	}
+
+	result[key] = value
+	return result
+}
+
+// withoutCustom returns a copy of the custom fields of an article without the key
+func withoutCustom(custom map[string]string, key string) map[string]string {
+	result := make(map[string]string, len(custom))
+	for k, v := range custom {
+		if k != key {
+			result[k] = v
+		}
+	}
+
+	return result
+}
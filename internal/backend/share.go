@@ -0,0 +1,47 @@
+package backend
+
+import (
+	"errors"
+	"log"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ShareServices returns the names of the read-later services which are configured
+func (b Backend) ShareServices() []string {
+	names := make([]string, len(b.share))
+	for i, service := range b.share {
+		names[i] = service.Name()
+	}
+
+	return names
+}
+
+// ShareArticle saves the link of the article in the read-later service with the name
+func (b Backend) ShareArticle(feedName string, index int, serviceName string) tea.Cmd {
+	return func() tea.Msg {
+		item, err := b.indexToItem(feedName, index)
+		if err != nil {
+			return FetchErrorMsg{err, "Error while getting the article"}
+		}
+
+		if item.Link == "" {
+			return FetchErrorMsg{errors.New("the article has no link"), "Error while sharing the article"}
+		}
+
+		for _, service := range b.share {
+			if service.Name() != serviceName {
+				continue
+			}
+
+			if err = service.Save(item.Link, item.Title); err != nil {
+				return FetchErrorMsg{err, "Error while sharing the article to " + serviceName}
+			}
+
+			log.Printf("Shared %s to %s\n", item.Link, serviceName)
+			return ArticleSharedMsg{Title: item.Title, Service: serviceName}
+		}
+
+		return FetchErrorMsg{errors.New("the service isn't configured"), "Error while sharing the article to " + serviceName}
+	}
+}
@@ -0,0 +1,54 @@
+package share
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/TypicalAM/goread/internal/config"
+)
+
+// instapaperURL is the address of the Instapaper simple API
+var instapaperURL = "https://www.instapaper.com/api"
+
+// Instapaper shares the articles to Instapaper using the simple API, which only needs the
+// credentials of the user.
+type Instapaper struct {
+	client   *http.Client
+	username string
+	password string
+}
+
+// newInstapaper creates a new Instapaper API client.
+func newInstapaper(client *http.Client, cfg config.Instapaper) *Instapaper {
+	return &Instapaper{client: client, username: cfg.Username, password: cfg.Password}
+}
+
+// Name returns the name of the service.
+func (i *Instapaper) Name() string {
+	return "Instapaper"
+}
+
+// Save adds the article to the unread articles of the user.
+func (i *Instapaper) Save(articleURL, title string) error {
+	form := url.Values{"url": {articleURL}, "title": {title}}
+	req, err := http.NewRequest(http.MethodPost, instapaperURL+"/add", strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(i.username, i.password)
+	resp, err := i.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusForbidden {
+		return errors.New("invalid username or password")
+	}
+
+	return checkStatus(resp, http.StatusCreated)
+}
@@ -0,0 +1,138 @@
+package share
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/TypicalAM/goread/internal/config"
+)
+
+// pocketURL is the address of the Pocket API
+var pocketURL = "https://getpocket.com"
+
+// pocketRedirectURI is where the user is sent after authorizing goread, nothing is listening there
+// so the user comes back to the terminal on their own
+const pocketRedirectURI = "https://github.com/TypicalAM/goread"
+
+// Pocket shares the articles to Pocket.
+type Pocket struct {
+	client      *http.Client
+	consumerKey string
+	accessToken string
+}
+
+// newPocket creates a new Pocket API client.
+func newPocket(client *http.Client, cfg config.Pocket) *Pocket {
+	return &Pocket{client: client, consumerKey: cfg.ConsumerKey, accessToken: cfg.AccessToken}
+}
+
+// Name returns the name of the service.
+func (p *Pocket) Name() string {
+	return "Pocket"
+}
+
+// Save adds the article to the Pocket list of the user.
+func (p *Pocket) Save(articleURL, title string) error {
+	return pocketRequest(p.client, "/v3/add", map[string]string{
+		"url":          articleURL,
+		"title":        title,
+		"consumer_key": p.consumerKey,
+		"access_token": p.accessToken,
+	}, nil)
+}
+
+// PocketLogin is the OAuth authorization of goread in Pocket, it's done from the command line
+// since the user has to visit a page in the browser.
+type PocketLogin struct {
+	client      *http.Client
+	consumerKey string
+	code        string
+}
+
+// NewPocketLogin obtains a request token and returns the login, the user authorizes the token by
+// visiting the page returned by AuthorizeURL.
+func NewPocketLogin(consumerKey string, settings config.HTTP) (*PocketLogin, error) {
+	if consumerKey == "" {
+		return nil, errors.New("the consumer key of the Pocket application isn't set")
+	}
+
+	login := &PocketLogin{client: settings.Client(pocketURL, requestTimeout), consumerKey: consumerKey}
+	var result struct {
+		Code string `json:"code"`
+	}
+
+	err := pocketRequest(login.client, "/v3/oauth/request", map[string]string{
+		"consumer_key": consumerKey,
+		"redirect_uri": pocketRedirectURI,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	login.code = result.Code
+	return login, nil
+}
+
+// AuthorizeURL returns the page where the user authorizes goread.
+func (l *PocketLogin) AuthorizeURL() string {
+	return pocketURL + "/auth/authorize?" + url.Values{
+		"request_token": {l.code},
+		"redirect_uri":  {pocketRedirectURI},
+	}.Encode()
+}
+
+// AccessToken exchanges the authorized request token for the access token of the user.
+func (l *PocketLogin) AccessToken() (string, error) {
+	var result struct {
+		AccessToken string `json:"access_token"`
+	}
+
+	err := pocketRequest(l.client, "/v3/oauth/authorize", map[string]string{
+		"consumer_key": l.consumerKey,
+		"code":         l.code,
+	}, &result)
+	if err != nil {
+		return "", err
+	}
+
+	return result.AccessToken, nil
+}
+
+// pocketRequest sends the parameters to the Pocket API and decodes the response into the result.
+// Pocket explains the errors in the X-Error header
+func pocketRequest(client *http.Client, path string, params map[string]string, result interface{}) error {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, pocketURL+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	req.Header.Set("X-Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+	if reason := resp.Header.Get("X-Error"); resp.StatusCode != http.StatusOK && reason != "" {
+		return errors.New(reason)
+	}
+
+	if err = checkStatus(resp, http.StatusOK); err != nil {
+		return err
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
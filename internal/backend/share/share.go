@@ -0,0 +1,57 @@
+package share
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/TypicalAM/goread/internal/config"
+)
+
+// Service is a read-later service which the articles can be shared to.
+type Service interface {
+	// Name returns the name of the service shown in the share menu.
+	Name() string
+	// Save adds the article to the reading list of the user.
+	Save(url, title string) error
+}
+
+// requestTimeout is how long the requests to the services can take
+const requestTimeout = 30 * time.Second
+
+// New creates the services which have credentials in the config, the requests use the proxy from
+// the http settings.
+func New(cfg config.Share, settings config.HTTP) []Service {
+	var services []Service
+	if cfg.Pocket.ConsumerKey != "" && cfg.Pocket.AccessToken != "" {
+		services = append(services, newPocket(settings.Client(pocketURL, requestTimeout), cfg.Pocket))
+	}
+
+	if cfg.Instapaper.Username != "" {
+		services = append(services, newInstapaper(settings.Client(instapaperURL, requestTimeout), cfg.Instapaper))
+	}
+
+	if cfg.Wallabag.URL != "" && cfg.Wallabag.ClientID != "" {
+		services = append(services, newWallabag(settings.Client(cfg.Wallabag.URL, requestTimeout), cfg.Wallabag))
+	}
+
+	return services
+}
+
+// checkStatus returns an error if the request failed, the body of the response is included
+// since the services explain the errors in it
+func checkStatus(resp *http.Response, expected ...int) error {
+	for _, status := range expected {
+		if resp.StatusCode == status {
+			return nil
+		}
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 256))
+	if len(body) == 0 {
+		return fmt.Errorf("request to %s failed: %s", resp.Request.URL.Host, resp.Status)
+	}
+
+	return fmt.Errorf("request to %s failed: %s: %s", resp.Request.URL.Host, resp.Status, body)
+}
@@ -0,0 +1,159 @@
+package share
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/TypicalAM/goread/internal/config"
+)
+
+// TestShareNew if we get an error then the services without credentials are created
+func TestShareNew(t *testing.T) {
+	if services := New(config.Share{}, config.HTTP{}); len(services) != 0 {
+		t.Fatalf("expected no services, got %d", len(services))
+	}
+
+	services := New(config.Share{
+		Pocket:   config.Pocket{ConsumerKey: "key"},
+		Wallabag: config.Wallabag{URL: "https://wallabag.example.com", ClientID: "id"},
+	}, config.HTTP{})
+
+	if len(services) != 1 || services[0].Name() != "Wallabag" {
+		t.Fatalf("expected only wallabag, got %v", services)
+	}
+}
+
+// TestSharePocket if we get an error then the Pocket API isn't used correctly
+func TestSharePocket(t *testing.T) {
+	var added map[string]string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v3/oauth/request", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"code":"dcba4321"}`)
+	})
+
+	mux.HandleFunc("/v3/oauth/authorize", func(w http.ResponseWriter, r *http.Request) {
+		var params map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&params); err != nil || params["code"] != "dcba4321" {
+			w.Header().Set("X-Error", "Invalid request token")
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		fmt.Fprint(w, `{"access_token":"5678defg","username":"alice"}`)
+	})
+
+	mux.HandleFunc("/v3/add", func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&added); err != nil || added["access_token"] != "5678defg" {
+			w.Header().Set("X-Error", "Invalid access token")
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		fmt.Fprint(w, `{"item":{},"status":1}`)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	pocketURL = server.URL
+
+	login, err := NewPocketLogin("1234-abcd", config.HTTP{})
+	if err != nil {
+		t.Fatalf("couldn't get the request token: %v", err)
+	}
+
+	token, err := login.AccessToken()
+	if err != nil || token != "5678defg" {
+		t.Fatalf("couldn't get the access token, got %q: %v", token, err)
+	}
+
+	services := New(config.Share{Pocket: config.Pocket{ConsumerKey: "1234-abcd", AccessToken: token}}, config.HTTP{})
+	if err = services[0].Save("https://example.com/article", "An article"); err != nil {
+		t.Fatalf("couldn't save the article: %v", err)
+	}
+
+	if added["url"] != "https://example.com/article" || added["title"] != "An article" {
+		t.Fatalf("incorrect article saved, got %v", added)
+	}
+
+	services = New(config.Share{Pocket: config.Pocket{ConsumerKey: "1234-abcd", AccessToken: "wrong"}}, config.HTTP{})
+	if err = services[0].Save("https://example.com/article", "An article"); err == nil || err.Error() != "Invalid access token" {
+		t.Fatalf("expected the error from pocket, got %v", err)
+	}
+}
+
+// TestShareInstapaper if we get an error then the Instapaper API isn't used correctly
+func TestShareInstapaper(t *testing.T) {
+	var added string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "alice" || pass != "hunter2" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		added = r.FormValue("url")
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+	instapaperURL = server.URL
+
+	services := New(config.Share{Instapaper: config.Instapaper{Username: "alice", Password: "hunter2"}}, config.HTTP{})
+	if err := services[0].Save("https://example.com/article", "An article"); err != nil {
+		t.Fatalf("couldn't save the article: %v", err)
+	}
+
+	if added != "https://example.com/article" {
+		t.Fatalf("incorrect article saved, got %q", added)
+	}
+
+	services = New(config.Share{Instapaper: config.Instapaper{Username: "alice", Password: "wrong"}}, config.HTTP{})
+	if err := services[0].Save("https://example.com/article", "An article"); err == nil {
+		t.Fatal("expected the wrong credentials to fail")
+	}
+}
+
+// TestShareWallabag if we get an error then the wallabag API isn't used correctly
+func TestShareWallabag(t *testing.T) {
+	var added string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth/v2/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("grant_type") != "password" || r.FormValue("client_secret") != "secret" || r.FormValue("password") != "hunter2" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":"invalid_grant"}`)
+			return
+		}
+
+		fmt.Fprint(w, `{"access_token":"token","expires_in":3600,"token_type":"bearer"}`)
+	})
+
+	mux.HandleFunc("/api/entries.json", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		added = r.FormValue("url")
+		fmt.Fprint(w, `{"id":1}`)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	cfg := config.Wallabag{URL: server.URL + "/", ClientID: "id", ClientSecret: "secret", Username: "alice", Password: "hunter2"}
+	services := New(config.Share{Wallabag: cfg}, config.HTTP{})
+	if err := services[0].Save("https://example.com/article", "An article"); err != nil {
+		t.Fatalf("couldn't save the article: %v", err)
+	}
+
+	if added != "https://example.com/article" {
+		t.Fatalf("incorrect article saved, got %q", added)
+	}
+
+	cfg.Password = "wrong"
+	services = New(config.Share{Wallabag: cfg}, config.HTTP{})
+	if err := services[0].Save("https://example.com/article", "An article"); err == nil {
+		t.Fatal("expected the wrong credentials to fail")
+	}
+}
@@ -0,0 +1,88 @@
+package share
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/TypicalAM/goread/internal/config"
+)
+
+// Wallabag shares the articles to a wallabag instance, the API uses OAuth with the password grant
+// so a token is requested before every article.
+type Wallabag struct {
+	client *http.Client
+	cfg    config.Wallabag
+}
+
+// newWallabag creates a new wallabag API client.
+func newWallabag(client *http.Client, cfg config.Wallabag) *Wallabag {
+	cfg.URL = strings.TrimSuffix(cfg.URL, "/")
+	return &Wallabag{client: client, cfg: cfg}
+}
+
+// Name returns the name of the service.
+func (w *Wallabag) Name() string {
+	return "Wallabag"
+}
+
+// Save adds the article to the entries of the user.
+func (w *Wallabag) Save(articleURL, title string) error {
+	token, err := w.token()
+	if err != nil {
+		return err
+	}
+
+	resp, err := w.post("/api/entries.json", url.Values{"url": {articleURL}, "title": {title}}, token)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+	return checkStatus(resp, http.StatusOK)
+}
+
+// token requests an access token using the credentials of the user
+func (w *Wallabag) token() (string, error) {
+	resp, err := w.post("/oauth/v2/token", url.Values{
+		"grant_type":    {"password"},
+		"client_id":     {w.cfg.ClientID},
+		"client_secret": {w.cfg.ClientSecret},
+		"username":      {w.cfg.Username},
+		"password":      {w.cfg.Password},
+	}, "")
+	if err != nil {
+		return "", err
+	}
+
+	defer resp.Body.Close()
+	if err = checkStatus(resp, http.StatusOK); err != nil {
+		return "", err
+	}
+
+	var result struct {
+		AccessToken string `json:"access_token"`
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", err
+	}
+
+	return result.AccessToken, nil
+}
+
+// post sends the form to the instance, the token is used if it's given
+func (w *Wallabag) post(path string, form url.Values, token string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodPost, w.cfg.URL+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	return w.client.Do(req)
+}
@@ -0,0 +1,128 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// SnoozeChoices are the usual times until which the articles are snoozed, ParseSnooze understands them
+var SnoozeChoices = []string{"tonight", "tomorrow", "next week"}
+
+// snoozeLayouts are the layouts of the dates and times until which the articles can be snoozed
+var snoozeLayouts = []string{"2006-01-02 15:04", "2006-01-02", "15:04"}
+
+// ParseSnooze returns the time until which an article is snoozed. It's one of the choices
+// ("tonight" is at 20:00, "tomorrow" at 8:00 and "next week" on Monday at 8:00), a duration like
+// "90m", "3h", "2d" or "1w", a date like "2024-05-01", a time like "18:30" or both
+func ParseSnooze(when string, now time.Time) (time.Time, error) {
+	when = strings.ToLower(strings.TrimSpace(when))
+	day := func(days, hour int) time.Time {
+		return time.Date(now.Year(), now.Month(), now.Day()+days, hour, 0, 0, 0, now.Location())
+	}
+
+	var until time.Time
+	switch when {
+	case "":
+		return time.Time{}, errors.New("no time given")
+	case "tonight":
+		if until = day(0, 20); !until.After(now) {
+			until = day(1, 20)
+		}
+	case "tomorrow":
+		until = day(1, 8)
+	case "next week":
+		until = day((7-int(now.Weekday())+int(time.Monday))%7, 8)
+		if !until.After(now.Add(24 * time.Hour)) {
+			until = until.AddDate(0, 0, 7)
+		}
+	default:
+		var err error
+		if until, err = parseSnoozeTime(when, now); err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	if !until.After(now) {
+		return time.Time{}, fmt.Errorf("%s is in the past", until.Format("2006-01-02 15:04"))
+	}
+
+	return until, nil
+}
+
+// parseSnoozeTime parses the durations and the dates until which the articles can be snoozed
+func parseSnoozeTime(when string, now time.Time) (time.Time, error) {
+	if n, err := strconv.Atoi(strings.TrimRight(when, "dw")); err == nil && len(when) > 1 {
+		switch when[len(when)-1] {
+		case 'd':
+			return now.AddDate(0, 0, n), nil
+		case 'w':
+			return now.AddDate(0, 0, 7*n), nil
+		}
+	}
+
+	if duration, err := time.ParseDuration(when); err == nil {
+		return now.Add(duration), nil
+	}
+
+	for _, layout := range snoozeLayouts {
+		parsed, err := time.ParseInLocation(layout, when, now.Location())
+		if err != nil {
+			continue
+		}
+
+		switch layout {
+		case "15:04":
+			// A time is today, or tomorrow if it has already passed
+			parsed = time.Date(now.Year(), now.Month(), now.Day(), parsed.Hour(), parsed.Minute(), 0, 0, now.Location())
+			if !parsed.After(now) {
+				parsed = parsed.AddDate(0, 0, 1)
+			}
+		case "2006-01-02":
+			parsed = parsed.Add(8 * time.Hour)
+		}
+
+		return parsed, nil
+	}
+
+	return time.Time{}, fmt.Errorf("unknown time %q, use tonight, tomorrow, next week, a duration like 3h or 2d or a date like 2006-01-02 15:04", when)
+}
+
+// SnoozeArticle hides an article until the time, it's marked as read until then.
+func (b Backend) SnoozeArticle(feedName string, index int, until time.Time) tea.Cmd {
+	return func() tea.Msg {
+		item, err := b.indexToItem(feedName, index)
+		if err != nil {
+			return FetchErrorMsg{err, "Error while getting the article"}
+		}
+
+		log.Println("Snoozing until", until.Format(time.RFC3339), item.Title)
+		b.Snoozes.Snooze(*item, until)
+		b.ReadStatus.MarkAsRead(*item)
+		if b.Remote != nil {
+			if err = b.Remote.SetRead(*item, true); err != nil {
+				return FetchErrorMsg{err, "Error while syncing the read status"}
+			}
+		}
+
+		return ArticleSnoozedMsg{feedName, index, until}
+	}
+}
+
+// wakeSnoozed marks the articles which were snoozed until now as unread
+func (b Backend) wakeSnoozed(now time.Time) {
+	for _, item := range b.Snoozes.Wake(now) {
+		log.Println("Waking up the snoozed article:", item.Title)
+		b.ReadStatus.MarkAsUnread(item)
+		if b.Remote != nil {
+			if err := b.Remote.SetRead(item, false); err != nil {
+				log.Println("Syncing the read status of the snoozed article failed:", err)
+			}
+		}
+	}
+}
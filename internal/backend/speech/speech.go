@@ -0,0 +1,140 @@
+package speech
+
+import (
+	"errors"
+	"log"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// State is the state of the speaker
+type State int
+
+const (
+	// Stopped means that nothing is read aloud
+	Stopped State = iota
+	// Speaking means that an article is read aloud
+	Speaking
+	// Paused means that the reading is paused and can be resumed
+	Paused
+)
+
+// ErrNoCommand is returned when there is no text-to-speech command to read the articles with
+var ErrNoCommand = errors.New("no text-to-speech command set")
+
+// Speaker reads the articles aloud one at a time using a text-to-speech command, the text is
+// passed to the command on the standard input
+type Speaker struct {
+	command string
+	mu      sync.Mutex
+	cmd     *exec.Cmd
+	title   string
+	state   State
+}
+
+// New creates a speaker using the shell command, the default command of the system is used if
+// it's empty
+func New(command string) *Speaker {
+	if strings.TrimSpace(command) == "" {
+		command = defaultCommand()
+	}
+
+	return &Speaker{command: command}
+}
+
+// defaultCommand returns the text-to-speech command which comes with the system
+func defaultCommand() string {
+	switch runtime.GOOS {
+	case "darwin":
+		return "say"
+	case "windows":
+		return ""
+	default:
+		return "espeak-ng"
+	}
+}
+
+// Speak starts reading the text aloud, the article which is read at the moment is stopped. The
+// returned channel is closed when the reading ends
+func (s *Speaker) Speak(title, text string) (<-chan struct{}, error) {
+	if s.command == "" {
+		return nil, ErrNoCommand
+	}
+
+	if err := s.Stop(); err != nil {
+		return nil, err
+	}
+
+	cmd := shellCommand(s.command)
+	cmd.Stdin = strings.NewReader(text)
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.cmd, s.title, s.state = cmd, title, Speaking
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		if err := cmd.Wait(); err != nil {
+			log.Println("Text-to-speech command ended: ", err)
+		}
+
+		s.mu.Lock()
+		if s.cmd == cmd {
+			s.cmd, s.title, s.state = nil, "", Stopped
+		}
+		s.mu.Unlock()
+		close(done)
+	}()
+
+	return done, nil
+}
+
+// TogglePause pauses the reading or resumes the paused one, it does nothing if nothing is read
+func (s *Speaker) TogglePause() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch s.state {
+	case Speaking:
+		if err := pause(s.cmd); err != nil {
+			return err
+		}
+
+		s.state = Paused
+	case Paused:
+		if err := resume(s.cmd); err != nil {
+			return err
+		}
+
+		s.state = Speaking
+	}
+
+	return nil
+}
+
+// Stop stops the reading, it does nothing if nothing is read
+func (s *Speaker) Stop() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cmd == nil {
+		return nil
+	}
+
+	if err := stop(s.cmd); err != nil {
+		return err
+	}
+
+	s.cmd, s.title, s.state = nil, "", Stopped
+	return nil
+}
+
+// Status returns the state of the speaker and the title of the article which is read
+func (s *Speaker) Status() (State, string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state, s.title
+}
@@ -0,0 +1,88 @@
+package speech
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// waitDone waits for the reading to end
+func waitDone(t *testing.T, done <-chan struct{}) {
+	t.Helper()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the reading didn't end")
+	}
+}
+
+// TestSpeechSpeak if we get an error then the text isn't passed to the command
+func TestSpeechSpeak(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test uses a unix shell")
+	}
+
+	out := filepath.Join(t.TempDir(), "spoken")
+	speaker := New("cat > " + out)
+	done, err := speaker.Speak("Title", "Hello there")
+	if err != nil {
+		t.Fatalf("couldn't start the reading: %v", err)
+	}
+
+	waitDone(t, done)
+	if data, err := os.ReadFile(out); err != nil || string(data) != "Hello there" {
+		t.Errorf("expected the text on the standard input, got %q (%v)", data, err)
+	}
+
+	if state, title := speaker.Status(); state != Stopped || title != "" {
+		t.Errorf("expected the speaker to stop, got %d %q", state, title)
+	}
+}
+
+// TestSpeechControls if we get an error then the reading can't be paused, resumed or stopped
+func TestSpeechControls(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test uses a unix shell")
+	}
+
+	speaker := New("sleep 30")
+	done, err := speaker.Speak("Long read", "text")
+	if err != nil {
+		t.Fatalf("couldn't start the reading: %v", err)
+	}
+
+	if state, title := speaker.Status(); state != Speaking || title != "Long read" {
+		t.Errorf("expected the article to be read, got %d %q", state, title)
+	}
+
+	if err = speaker.TogglePause(); err != nil {
+		t.Fatalf("couldn't pause the reading: %v", err)
+	}
+
+	if state, _ := speaker.Status(); state != Paused {
+		t.Errorf("expected the reading to be paused, got %d", state)
+	}
+
+	if err = speaker.TogglePause(); err != nil {
+		t.Fatalf("couldn't resume the reading: %v", err)
+	}
+
+	if state, _ := speaker.Status(); state != Speaking {
+		t.Errorf("expected the reading to be resumed, got %d", state)
+	}
+
+	if err = speaker.TogglePause(); err != nil {
+		t.Fatalf("couldn't pause the reading: %v", err)
+	}
+
+	if err = speaker.Stop(); err != nil {
+		t.Fatalf("couldn't stop the paused reading: %v", err)
+	}
+
+	waitDone(t, done)
+	if state, _ := speaker.Status(); state != Stopped {
+		t.Errorf("expected the reading to stop, got %d", state)
+	}
+}
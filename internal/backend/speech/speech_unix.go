@@ -0,0 +1,39 @@
+//go:build !windows
+
+package speech
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// shellCommand runs the command using the shell in its own process group, so that the programs
+// started by the shell are paused and stopped along with it
+func shellCommand(command string) *exec.Cmd {
+	cmd := exec.Command("sh", "-c", command) //nolint:gosec
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	return cmd
+}
+
+// pause stops the processes of the command until they are resumed
+func pause(cmd *exec.Cmd) error {
+	return syscall.Kill(-cmd.Process.Pid, syscall.SIGSTOP)
+}
+
+// resume continues the paused processes of the command
+func resume(cmd *exec.Cmd) error {
+	return syscall.Kill(-cmd.Process.Pid, syscall.SIGCONT)
+}
+
+// stop terminates the processes of the command, the paused ones are continued to receive the signal
+func stop(cmd *exec.Cmd) error {
+	if err := syscall.Kill(-cmd.Process.Pid, syscall.SIGTERM); err != nil && err != syscall.ESRCH {
+		return err
+	}
+
+	if err := syscall.Kill(-cmd.Process.Pid, syscall.SIGCONT); err != nil && err != syscall.ESRCH {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+//go:build windows
+
+package speech
+
+import (
+	"errors"
+	"os/exec"
+)
+
+// errNoPause is returned when the reading is paused on windows, the processes can't be suspended
+var errNoPause = errors.New("pausing the reading isn't supported on windows")
+
+// shellCommand runs the command using the shell
+func shellCommand(command string) *exec.Cmd {
+	return exec.Command("cmd", "/C", command) //nolint:gosec
+}
+
+// pause can't stop the processes of the command on windows
+func pause(_ *exec.Cmd) error {
+	return errNoPause
+}
+
+// resume can't continue the processes of the command on windows
+func resume(_ *exec.Cmd) error {
+	return errNoPause
+}
+
+// stop kills the shell running the command
+func stop(cmd *exec.Cmd) error {
+	return cmd.Process.Kill()
+}
@@ -0,0 +1,39 @@
+package backend
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/TypicalAM/goread/internal/backend/rss"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ErrNoSummarizer is returned when an article is summarized without a summarizer in the config
+var ErrNoSummarizer = errors.New("no summarizer set, add a summary command or an API url to the config")
+
+// SummarizeArticle summarizes an article in a feed tab, the summary is sent back as a quote which
+// is shown above the article.
+func (b Backend) SummarizeArticle(feedName string, index int) tea.Cmd {
+	return func() tea.Msg {
+		if b.summarizer == nil {
+			return FetchErrorMsg{ErrNoSummarizer, "Error while summarizing the article"}
+		}
+
+		item, err := b.indexToItem(feedName, index)
+		if err != nil {
+			return FetchErrorMsg{err, "Error while getting the article"}
+		}
+
+		summary, err := b.summarizer.Summarize(item.Title, rss.Text(item))
+		if err != nil {
+			return FetchErrorMsg{err, "Error while summarizing the article"}
+		}
+
+		markdown := "> **TL;DR** *by " + b.summarizer.Name() + "*\n>\n"
+		for _, line := range strings.Split(rss.StripControl(summary), "\n") {
+			markdown += "> " + line + "\n"
+		}
+
+		return ArticleSummarizedMsg{feedName, index, markdown + "\n"}
+	}
+}
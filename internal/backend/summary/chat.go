@@ -0,0 +1,99 @@
+package summary
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Chat summarizes the articles using an OpenAI-compatible chat completions API, like the one of
+// OpenAI, ollama, llama.cpp or LM Studio.
+type Chat struct {
+	client   *http.Client
+	endpoint string
+	key      string
+	model    string
+	prompt   string
+}
+
+// chatMessage is a message of the conversation with the model
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// newChat creates a new chat API client, the url is the base url of the API like
+// http://localhost:11434/v1.
+func newChat(client *http.Client, url, key, model, prompt string) *Chat {
+	return &Chat{
+		client:   client,
+		endpoint: strings.TrimSuffix(url, "/") + "/chat/completions",
+		key:      key,
+		model:    model,
+		prompt:   prompt,
+	}
+}
+
+// Name returns the name of the model.
+func (c *Chat) Name() string {
+	if c.model == "" {
+		return "the language model"
+	}
+
+	return c.model
+}
+
+// Summarize asks the model for the summary of the article.
+func (c *Chat) Summarize(title, text string) (string, error) {
+	body, err := json.Marshal(map[string]any{
+		"model": c.model,
+		"messages": []chatMessage{
+			{Role: "system", Content: c.prompt},
+			{Role: "user", Content: title + "\n\n" + truncate(text)},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if c.key != "" {
+		req.Header.Set("Authorization", "Bearer "+c.key)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 256))
+		return "", fmt.Errorf("request to %s failed: %s: %s", resp.Request.URL.Host, resp.Status, body)
+	}
+
+	var result struct {
+		Choices []struct {
+			Message chatMessage `json:"message"`
+		} `json:"choices"`
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", err
+	}
+
+	if len(result.Choices) == 0 || strings.TrimSpace(result.Choices[0].Message.Content) == "" {
+		return "", errors.New("the summary is empty")
+	}
+
+	return strings.TrimSpace(result.Choices[0].Message.Content), nil
+}
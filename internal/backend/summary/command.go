@@ -0,0 +1,39 @@
+package summary
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/TypicalAM/goread/internal/backend/cache"
+)
+
+// Command summarizes the articles using a shell command, like a local model run by ollama or llm.
+// The title and the text of the article are passed on the standard input.
+type Command struct {
+	command string
+}
+
+// newCommand creates a summarizer using the command.
+func newCommand(command string) *Command {
+	return &Command{command}
+}
+
+// Name returns the name of the program run by the command.
+func (c *Command) Name() string {
+	return strings.Fields(c.command)[0]
+}
+
+// Summarize passes the article to the command and returns its output.
+func (c *Command) Summarize(title, text string) (string, error) {
+	out, err := cache.RunCommand(c.command, strings.NewReader(title+"\n\n"+truncate(text)))
+	if err != nil {
+		return "", err
+	}
+
+	summary := strings.TrimSpace(string(out))
+	if summary == "" {
+		return "", errors.New("the summary is empty")
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,56 @@
+package summary
+
+import (
+	"strings"
+	"time"
+
+	"github.com/TypicalAM/goread/internal/config"
+)
+
+// DefaultPrompt is the instruction given to the language model along with the article
+const DefaultPrompt = "Summarize the following article in at most three short bullet points. Reply with the bullet points only."
+
+// maxTextLength is the largest number of bytes of the article text which is sent, so that the long
+// articles fit in the context of the smaller models
+const maxTextLength = 24000
+
+// Summarizer writes a short summary of the articles.
+type Summarizer interface {
+	// Name returns the name of the summarizer shown with the summary.
+	Name() string
+	// Summarize returns the summary of the article with the title and the text.
+	Summarize(title, text string) (string, error)
+}
+
+// New creates the summarizer set in the config, the command is preferred over the endpoint. It
+// returns nil if there is no summarizer, the articles are never sent anywhere without one. The
+// requests to the endpoint use the proxy from the http settings.
+func New(cfg config.Summary, settings config.HTTP) Summarizer {
+	prompt := cfg.Prompt
+	if prompt == "" {
+		prompt = DefaultPrompt
+	}
+
+	switch {
+	case strings.TrimSpace(cfg.Command) != "":
+		return newCommand(cfg.Command)
+	case cfg.URL != "":
+		return newChat(settings.Client(cfg.URL, 2*time.Minute), cfg.URL, cfg.APIKey, cfg.Model, prompt)
+	default:
+		return nil
+	}
+}
+
+// truncate shortens the text to the largest length, it's cut at the end of a line if possible
+func truncate(text string) string {
+	if len(text) <= maxTextLength {
+		return text
+	}
+
+	text = strings.ToValidUTF8(text[:maxTextLength], "")
+	if i := strings.LastIndexByte(text, '\n'); i > 0 {
+		text = text[:i]
+	}
+
+	return text
+}
@@ -0,0 +1,87 @@
+package summary
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/TypicalAM/goread/internal/config"
+)
+
+// TestSummaryNew if we get an error then the articles could be sent somewhere without being asked to
+func TestSummaryNew(t *testing.T) {
+	if summarizer := New(config.Summary{Model: "llama3", APIKey: "key"}, config.HTTP{}); summarizer != nil {
+		t.Fatalf("expected no summarizer without a command or an url, got %s", summarizer.Name())
+	}
+
+	if _, ok := New(config.Summary{Command: "llm -s tldr", URL: "http://localhost:11434/v1"}, config.HTTP{}).(*Command); !ok {
+		t.Fatal("expected the command to be preferred over the url")
+	}
+
+	chat, ok := New(config.Summary{URL: "http://localhost:11434/v1/"}, config.HTTP{}).(*Chat)
+	if !ok || chat.endpoint != "http://localhost:11434/v1/chat/completions" || chat.prompt != DefaultPrompt {
+		t.Fatalf("expected the chat endpoint with the default prompt, got %#v", chat)
+	}
+}
+
+// TestSummaryCommand if we get an error then the article isn't passed to the command
+func TestSummaryCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test uses a unix shell")
+	}
+
+	summary, err := newCommand("head -n 1").Summarize("The title", "The text")
+	if err != nil {
+		t.Fatalf("couldn't summarize: %v", err)
+	}
+
+	if summary != "The title" {
+		t.Errorf("expected the title on the first line, got %q", summary)
+	}
+
+	if _, err = newCommand("true").Summarize("The title", "The text"); err == nil {
+		t.Error("expected an error with an empty summary")
+	}
+}
+
+// TestSummaryChat if we get an error then the chat completions API isn't used correctly
+func TestSummaryChat(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Model    string        `json:"model"`
+			Messages []chatMessage `json:"messages"`
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil || r.URL.Path != "/v1/chat/completions" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if r.Header.Get("Authorization") != "Bearer secret" || request.Model != "tiny" || len(request.Messages) != 2 {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		reply := "- " + strings.SplitN(request.Messages[1].Content, "\n", 2)[0]
+		_, _ = w.Write([]byte(`{"choices": [{"message": {"role": "assistant", "content": "` + reply + `\n"}}]}`))
+	}))
+	defer server.Close()
+
+	chat := newChat(server.Client(), server.URL+"/v1", "secret", "tiny", DefaultPrompt)
+	summary, err := chat.Summarize("The title", strings.Repeat("word ", maxTextLength))
+	if err != nil {
+		t.Fatalf("couldn't summarize: %v", err)
+	}
+
+	if summary != "- The title" {
+		t.Errorf("expected the summary of the model, got %q", summary)
+	}
+
+	chat.key = ""
+	if _, err = chat.Summarize("The title", "The text"); err == nil {
+		t.Error("expected an error without the key")
+	}
+}
@@ -0,0 +1,40 @@
+package translate
+
+import (
+	"strings"
+
+	"github.com/TypicalAM/goread/internal/backend/cache"
+)
+
+// Command translates the articles using a shell command, like translate-shell or argos-translate.
+// The paragraphs are separated by empty lines on the standard input and in the output.
+type Command struct {
+	command string
+}
+
+// newCommand creates a translator using the command, "%l" in it is replaced with the language.
+func newCommand(command, language string) *Command {
+	return &Command{strings.ReplaceAll(command, "%l", language)}
+}
+
+// Name returns the name of the program run by the command.
+func (c *Command) Name() string {
+	return strings.Fields(c.command)[0]
+}
+
+// Translate passes the paragraphs to the command and splits its output into the paragraphs.
+func (c *Command) Translate(paragraphs []string) ([]string, error) {
+	out, err := cache.RunCommand(c.command, strings.NewReader(strings.Join(paragraphs, "\n\n")))
+	if err != nil {
+		return nil, err
+	}
+
+	var result []string
+	for _, paragraph := range strings.Split(strings.ReplaceAll(string(out), "\r\n", "\n"), "\n\n") {
+		if paragraph = strings.TrimSpace(paragraph); paragraph != "" {
+			result = append(result, paragraph)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,77 @@
+package translate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DeepL translates the articles using the DeepL API, the keys of the free accounts end with ":fx"
+// and use their own host.
+type DeepL struct {
+	client   *http.Client
+	endpoint string
+	key      string
+	language string
+}
+
+// newDeepL creates a new DeepL API client.
+func newDeepL(client *http.Client, key, language string) *DeepL {
+	return &DeepL{client: client, endpoint: deeplEndpoint(key), key: key, language: strings.ToUpper(language)}
+}
+
+// deeplEndpoint returns the address of the API the key belongs to, the free keys end with ":fx"
+func deeplEndpoint(key string) string {
+	if strings.HasSuffix(key, ":fx") {
+		return "https://api-free.deepl.com/v2/translate"
+	}
+
+	return "https://api.deepl.com/v2/translate"
+}
+
+// Name returns the name of the service.
+func (d *DeepL) Name() string {
+	return "DeepL"
+}
+
+// Translate sends the paragraphs as separate texts, so that the translation keeps them apart.
+func (d *DeepL) Translate(paragraphs []string) ([]string, error) {
+	form := url.Values{"text": paragraphs, "target_lang": {d.language}}
+	req, err := http.NewRequest(http.MethodPost, d.endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Authorization", "DeepL-Auth-Key "+d.key)
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 256))
+		return nil, fmt.Errorf("request to %s failed: %s: %s", resp.Request.URL.Host, resp.Status, body)
+	}
+
+	var result struct {
+		Translations []struct {
+			Text string `json:"text"`
+		} `json:"translations"`
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	translated := make([]string, len(result.Translations))
+	for i, translation := range result.Translations {
+		translated[i] = translation.Text
+	}
+
+	return translated, nil
+}
@@ -0,0 +1,38 @@
+package translate
+
+import (
+	"strings"
+	"time"
+
+	"github.com/TypicalAM/goread/internal/config"
+)
+
+// DefaultLanguage is the language the articles are translated to if none is set
+const DefaultLanguage = "en"
+
+// Translator translates the text of the articles.
+type Translator interface {
+	// Name returns the name of the translator shown under the translation.
+	Name() string
+	// Translate translates the paragraphs, the translation can have a different number of them.
+	Translate(paragraphs []string) ([]string, error)
+}
+
+// New creates the translator set in the config, the command is preferred over DeepL. It returns
+// nil if there is no translator. The requests to DeepL use the proxy from the http settings.
+func New(cfg config.Translation, settings config.HTTP) Translator {
+	language := cfg.Language
+	if language == "" {
+		language = DefaultLanguage
+	}
+
+	switch {
+	case strings.TrimSpace(cfg.Command) != "":
+		return newCommand(cfg.Command, language)
+	case cfg.DeepLKey != "":
+		client := settings.Client(deeplEndpoint(cfg.DeepLKey), 30*time.Second)
+		return newDeepL(client, cfg.DeepLKey, language)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,87 @@
+package translate
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/TypicalAM/goread/internal/config"
+)
+
+// TestTranslateNew if we get an error then the wrong translator is used
+func TestTranslateNew(t *testing.T) {
+	if translator := New(config.Translation{}, config.HTTP{}); translator != nil {
+		t.Fatalf("expected no translator, got %s", translator.Name())
+	}
+
+	translator := New(config.Translation{Command: "trans -b :%l", DeepLKey: "key"}, config.HTTP{})
+	if command, ok := translator.(*Command); !ok || command.command != "trans -b :en" {
+		t.Fatalf("expected the command with the default language, got %#v", translator)
+	}
+
+	translator = New(config.Translation{DeepLKey: "key:fx", Language: "de"}, config.HTTP{})
+	if deepl, ok := translator.(*DeepL); !ok || deepl.language != "DE" || !strings.Contains(deepl.endpoint, "api-free") {
+		t.Fatalf("expected the free DeepL API, got %#v", translator)
+	}
+}
+
+// TestTranslateCommand if we get an error then the paragraphs don't survive the command
+func TestTranslateCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test uses a unix shell")
+	}
+
+	translated, err := newCommand("tr a-z A-Z", "en").Translate([]string{"Title", "first paragraph", "second"})
+	if err != nil {
+		t.Fatalf("couldn't translate: %v", err)
+	}
+
+	if strings.Join(translated, "|") != "TITLE|FIRST PARAGRAPH|SECOND" {
+		t.Errorf("expected three translated paragraphs, got %q", translated)
+	}
+}
+
+// TestTranslateDeepL if we get an error then the DeepL API isn't used correctly
+func TestTranslateDeepL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "DeepL-Auth-Key secret" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		if err := r.ParseForm(); err != nil || r.Form.Get("target_lang") != "FR" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		var result struct {
+			Translations []map[string]string `json:"translations"`
+		}
+
+		for _, text := range r.Form["text"] {
+			result.Translations = append(result.Translations, map[string]string{"text": "fr:" + text})
+		}
+
+		_ = json.NewEncoder(w).Encode(result)
+	}))
+	defer server.Close()
+
+	deepl := newDeepL(server.Client(), "secret", "fr")
+	deepl.endpoint = server.URL
+	translated, err := deepl.Translate([]string{"Hello", "World"})
+	if err != nil {
+		t.Fatalf("couldn't translate: %v", err)
+	}
+
+	if strings.Join(translated, "|") != "fr:Hello|fr:World" {
+		t.Errorf("expected the paragraphs to be translated separately, got %q", translated)
+	}
+
+	deepl.key = "wrong"
+	if _, err = deepl.Translate([]string{"Hello"}); err == nil {
+		t.Error("expected an error with a wrong key")
+	}
+}
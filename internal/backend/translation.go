@@ -0,0 +1,49 @@
+package backend
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/TypicalAM/goread/internal/backend/rss"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ErrNoTranslator is returned when an article is translated without a translator in the config
+var ErrNoTranslator = errors.New("no translator set, add a translation command or a DeepL key to the config")
+
+// TranslateArticle translates the title and the text of an article in a feed tab, the translation
+// is sent back as markdown with its title as the heading.
+func (b Backend) TranslateArticle(feedName string, index int) tea.Cmd {
+	return func() tea.Msg {
+		if b.translator == nil {
+			return FetchErrorMsg{ErrNoTranslator, "Error while translating the article"}
+		}
+
+		item, err := b.indexToItem(feedName, index)
+		if err != nil {
+			return FetchErrorMsg{err, "Error while getting the article"}
+		}
+
+		paragraphs := []string{item.Title}
+		if text := rss.Text(item); text != "" {
+			paragraphs = append(paragraphs, strings.Split(text, "\n")...)
+		}
+
+		translated, err := b.translator.Translate(paragraphs)
+		if err != nil {
+			return FetchErrorMsg{err, "Error while translating the article"}
+		}
+
+		if len(translated) == 0 {
+			return FetchErrorMsg{errors.New("the translation is empty"), "Error while translating the article"}
+		}
+
+		markdown := "# " + translated[0] + "\n\n"
+		for _, paragraph := range translated[1:] {
+			markdown += paragraph + "\n\n"
+		}
+
+		markdown += "*Translated by " + b.translator.Name() + "*\n\n"
+		return ArticleTranslatedMsg{feedName, index, rss.StripControl(markdown)}
+	}
+}
@@ -0,0 +1,277 @@
+package config
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Default is the default configuration
+var Default = Config{
+	Backend: Backend{
+		Workers:    8,
+		HostLimit:  2,
+		HostJitter: 500 * time.Millisecond,
+	},
+	Digest: Digest{
+		Hour: 7,
+		Top:  5,
+	},
+}
+
+// Config contains the settings of the application which are not related to the feeds or the colors
+type Config struct {
+	filePath       string
+	BrowserCommand string        `yaml:"browser_command"`
+	DateFormat     string        `yaml:"date_format"`
+	Theme          string        `yaml:"theme"`
+	Icons          string        `yaml:"icons"`
+	Decorations    []Decoration  `yaml:"decorations"`
+	Sync           Sync          `yaml:"sync"`
+	Backend        Backend       `yaml:"backend"`
+	Keymap         Keymap        `yaml:"keymap"`
+	Rules          []Rule        `yaml:"rules"`
+	Alerts         Alerts        `yaml:"alerts"`
+	Digest         Digest        `yaml:"digest"`
+	Retention      Retention     `yaml:"retention"`
+	Scrapers       []Scraper     `yaml:"scrapers"`
+	Links          Links         `yaml:"links"`
+	HTTP           HTTP          `yaml:"http"`
+	Podcasts       Podcasts      `yaml:"podcasts"`
+	Speech         Speech        `yaml:"speech"`
+	Translation    Translation   `yaml:"translation"`
+	Summary        Summary       `yaml:"summary"`
+	Export         Export        `yaml:"export"`
+	Share          Share         `yaml:"share"`
+	Hooks          Hooks         `yaml:"hooks"`
+	Accessibility  Accessibility `yaml:"accessibility"`
+	Layout         Layout        `yaml:"layout"`
+	Reading        Reading       `yaml:"reading"`
+}
+
+// Podcasts contains the settings of the podcast episodes
+type Podcasts struct {
+	Directory string `yaml:"directory"`
+	Player    string `yaml:"player"`
+}
+
+// Speech contains the text-to-speech command which reads the articles aloud, the text is passed to
+// it on the standard input. It's espeak-ng by default, or say on macOS
+type Speech struct {
+	Command string `yaml:"command"`
+}
+
+// Translation contains the translator of the articles, a shell command which gets the text on the
+// standard input and prints the translation or the DeepL API. The language is the language the
+// articles are translated to, "%l" in the command is replaced with it
+type Translation struct {
+	Command  string `yaml:"command"`
+	DeepLKey string `yaml:"deepl_key"`
+	Language string `yaml:"language"`
+}
+
+// Summary contains the summarizer of the articles, nothing is summarized without it. It's either a
+// shell command which gets the article on the standard input and prints the summary or the base url
+// of an OpenAI-compatible API with its key, model and prompt
+type Summary struct {
+	Command string `yaml:"command"`
+	URL     string `yaml:"url"`
+	APIKey  string `yaml:"api_key"`
+	Model   string `yaml:"model"`
+	Prompt  string `yaml:"prompt"`
+}
+
+// Export contains the settings of the exported articles. The filename is a template, "%d" is
+// replaced with the date of the article, "%t" with its title and "%f" with the name of the feed
+type Export struct {
+	Directory string `yaml:"directory"`
+	Filename  string `yaml:"filename"`
+}
+
+// Share contains the credentials of the read-later services the articles can be shared to, the
+// services without credentials aren't shown in the share menu
+type Share struct {
+	Pocket     Pocket     `yaml:"pocket"`
+	Instapaper Instapaper `yaml:"instapaper"`
+	Wallabag   Wallabag   `yaml:"wallabag"`
+}
+
+// Pocket contains the key of the Pocket application and the access token of the user, the token
+// is obtained using the --pocket_login flag
+type Pocket struct {
+	ConsumerKey string `yaml:"consumer_key"`
+	AccessToken string `yaml:"access_token"`
+}
+
+// Instapaper contains the credentials of an Instapaper account
+type Instapaper struct {
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+}
+
+// Wallabag contains the url of a wallabag instance, the credentials of the API client and of the user
+type Wallabag struct {
+	URL          string `yaml:"url"`
+	ClientID     string `yaml:"client_id"`
+	ClientSecret string `yaml:"client_secret"`
+	Username     string `yaml:"username"`
+	Password     string `yaml:"password"`
+}
+
+// Hooks contains the shell commands which are run when the feeds are refreshed. The new articles
+// are passed to on_new_article one by one as JSON on the standard input
+type Hooks struct {
+	OnNewArticle string `yaml:"on_new_article"`
+	PreRefresh   string `yaml:"pre_refresh"`
+	PostRefresh  string `yaml:"post_refresh"`
+}
+
+// Accessibility contains the settings for limited terminals, low vision and screen readers. The
+// code blocks in the articles are shown in the color of the text without the syntax highlighting
+// and the links aren't made clickable for the terminals which don't understand the sequences
+type Accessibility struct {
+	NoColor              bool `yaml:"no_color"`
+	ScreenReader         bool `yaml:"screen_reader"`
+	NoSyntaxHighlighting bool `yaml:"no_syntax_highlighting"`
+	NoHyperlinks         bool `yaml:"no_hyperlinks"`
+}
+
+// Layout contains the placement of the article list and the article in the feed tabs, the list
+// ratio is the percentage of the width, or of the height when stacked, taken by the list. The zen
+// width is the largest width of the article in the zen mode. With duplicate tabs a new tab is
+// opened even if the same one is open already
+type Layout struct {
+	Stacked       bool `yaml:"stacked"`
+	ListRatio     int  `yaml:"list_ratio"`
+	ZenWidth      int  `yaml:"zen_width"`
+	Favicons      bool `yaml:"favicons"`
+	DuplicateTabs bool `yaml:"duplicate_tabs"`
+}
+
+// Reading contains the reading speed used to estimate how long the articles take to read
+type Reading struct {
+	WordsPerMinute int `yaml:"words_per_minute"`
+}
+
+// Rule describes what happens to the articles which match it, the empty fields match everything.
+// The title and the author are regular expressions
+type Rule struct {
+	Feed     string `yaml:"feed"`
+	Category string `yaml:"category"`
+	Title    string `yaml:"title"`
+	Author   string `yaml:"author"`
+	Action   string `yaml:"action"`
+}
+
+// Alerts contains the watch keywords, the articles mentioning them are highlighted and starred. A
+// desktop notification is shown for the new ones if notify is set, using the command if it's given.
+// The command gets the summary, which names the keyword, on the first line of the standard input
+// and the title on the second
+type Alerts struct {
+	Keywords []string `yaml:"keywords"`
+	Notify   bool     `yaml:"notify"`
+	Command  string   `yaml:"command"`
+}
+
+// Backend contains the settings of the feed fetcher
+type Backend struct {
+	Workers            int           `yaml:"workers"`
+	HostLimit          int           `yaml:"host_limit"`
+	HostJitter         time.Duration `yaml:"host_jitter"`
+	RefreshInterval    time.Duration `yaml:"refresh_interval"`
+	AdaptiveRefresh    bool          `yaml:"adaptive_refresh"`
+	MaxRefreshInterval time.Duration `yaml:"max_refresh_interval"`
+	DisableHTTPCache   bool          `yaml:"disable_http_cache"`
+	Dedup              string        `yaml:"dedup"`
+	NitterInstance     string        `yaml:"nitter_instance"`
+	RSSBridge          string        `yaml:"rss_bridge"`
+	TrashRetention     time.Duration `yaml:"trash_retention"`
+}
+
+// Sync contains the settings of a sync service, which keeps the subscriptions and the article state
+type Sync struct {
+	Service  string `yaml:"service"`
+	URL      string `yaml:"url"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+}
+
+// New will create a new config structure
+func New(path string) (*Config, error) {
+	log.Println("Creating new config")
+	if path == "" {
+		defaultPath, err := getDefaultPath()
+		if err != nil {
+			return nil, err
+		}
+
+		path = defaultPath
+	}
+
+	cfg := Default
+	cfg.filePath = path
+	return &cfg, nil
+}
+
+// Load will try to load the config from a file, a missing file is not an error
+func (c *Config) Load() error {
+	log.Println("Loading config from", c.filePath)
+	data, err := os.ReadFile(c.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	if err = yaml.Unmarshal(data, c); err != nil {
+		return err
+	}
+
+	for _, scraper := range c.Scrapers {
+		if err = scraper.validate(); err != nil {
+			return err
+		}
+	}
+
+	for _, rule := range c.Links.Rules {
+		if err = rule.validate(); err != nil {
+			return err
+		}
+	}
+
+	for _, decoration := range c.Decorations {
+		if err = decoration.validate(); err != nil {
+			return err
+		}
+	}
+
+	if err = c.Digest.validate(); err != nil {
+		return err
+	}
+
+	if err = c.Retention.validate(); err != nil {
+		return err
+	}
+
+	return c.HTTP.validate()
+}
+
+// Enabled reports if a sync service is configured
+func (s Sync) Enabled() bool {
+	return s.Service != ""
+}
+
+// getDefaultPath will return the default path for the config file
+func getDefaultPath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, "goread", "config.yml"), nil
+}
@@ -0,0 +1,297 @@
+package config
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// TestConfigLoadNoFile if we get an error then a missing config file is not handled
+func TestConfigLoadNoFile(t *testing.T) {
+	cfg, err := New("non-existent")
+	if err != nil {
+		t.Fatalf("couldn't create the config: %v", err)
+	}
+
+	if err = cfg.Load(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if cfg.Sync.Enabled() {
+		t.Fatal("expected sync to be disabled by default")
+	}
+
+	if cfg.Backend.Workers != Default.Backend.Workers {
+		t.Fatalf("expected %d workers, got %d", Default.Backend.Workers, cfg.Backend.Workers)
+	}
+}
+
+// TestConfigLoadFile if we get an error then the config file is not loaded correctly
+func TestConfigLoadFile(t *testing.T) {
+	cfg, err := New("../test/data/config.yml")
+	if err != nil {
+		t.Fatalf("couldn't create the config: %v", err)
+	}
+
+	if err = cfg.Load(); err != nil {
+		t.Fatalf("couldn't load the config: %v", err)
+	}
+
+	if cfg.Sync.Service != "greader" || cfg.Sync.Username != "alice" {
+		t.Fatalf("incorrect sync settings, got %+v", cfg.Sync)
+	}
+
+	if cfg.BrowserCommand != "firefox --private-window %u" {
+		t.Fatalf("incorrect browser command, got %q", cfg.BrowserCommand)
+	}
+
+	if cfg.DateFormat != "%d.%m %H:%M" {
+		t.Fatalf("incorrect date format, got %q", cfg.DateFormat)
+	}
+
+	if cfg.Backend.RefreshInterval != 15*time.Minute {
+		t.Fatalf("expected a 15m refresh interval, got %v", cfg.Backend.RefreshInterval)
+	}
+}
+
+// testKeymap is a keymap used to test the remapping
+type testKeymap struct {
+	CloseTab      key.Binding
+	OpenInBrowser key.Binding
+}
+
+// TestConfigApplyKeymap if we get an error then the keys from the config are not applied
+func TestConfigApplyKeymap(t *testing.T) {
+	cfg, err := New("../test/data/config.yml")
+	if err != nil {
+		t.Fatalf("couldn't create the config: %v", err)
+	}
+
+	if err = cfg.Load(); err != nil {
+		t.Fatalf("couldn't load the config: %v", err)
+	}
+
+	keymap := testKeymap{
+		CloseTab:      key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "Close tab")),
+		OpenInBrowser: key.NewBinding(key.WithKeys("o"), key.WithHelp("o", "Open in browser")),
+	}
+
+	cfg.Keymap = Keymap{"feed": {"open_in_browser": cfg.Keymap["feed"]["open_in_browser"]}}
+	if err = cfg.ApplyKeymap("feed", &keymap); err != nil {
+		t.Fatalf("couldn't apply the keymap: %v", err)
+	}
+
+	if keys := keymap.OpenInBrowser.Keys(); len(keys) != 2 || keys[0] != "O" || keys[1] != "alt+o" {
+		t.Fatalf("expected the keys to be remapped, got %v", keys)
+	}
+
+	if help := keymap.OpenInBrowser.Help(); help.Key != "O/alt+o" || help.Desc != "Open in browser" {
+		t.Fatalf("expected the help to be updated, got %+v", help)
+	}
+
+	if keys := keymap.CloseTab.Keys(); len(keys) != 1 || keys[0] != "c" {
+		t.Fatalf("expected the other keys to stay the same, got %v", keys)
+	}
+}
+
+// TestConfigApplyKeymapInvalid if we get an error then invalid bindings are accepted
+func TestConfigApplyKeymapInvalid(t *testing.T) {
+	cases := []Keymap{
+		{"feed": {"not_an_action": {"x"}}},
+		{"feed": {"close_tab": {"ctrl+nope"}}},
+		{"feed": {"close_tab": {}}},
+	}
+
+	for _, keymap := range cases {
+		cfg := Default
+		cfg.Keymap = keymap
+		if err := cfg.ApplyKeymap("feed", &testKeymap{}); err == nil {
+			t.Fatalf("expected an error for %v", keymap)
+		}
+	}
+
+	cfg := Default
+	cfg.Keymap = Keymap{"nope": {}}
+	if err := cfg.CheckKeymap("feed"); err == nil {
+		t.Fatal("expected an error for an unknown section")
+	}
+}
+
+// TestConfigHTTP if we get an error then the site settings don't override the global ones
+func TestConfigHTTP(t *testing.T) {
+	cfg, err := New("../test/data/config.yml")
+	if err != nil {
+		t.Fatalf("couldn't create the config: %v", err)
+	}
+
+	if err = cfg.Load(); err != nil {
+		t.Fatalf("couldn't load the config: %v", err)
+	}
+
+	cases := map[string]string{
+		"https://example.com/feed.xml":                   "http://proxy.example.com:3128",
+		"https://www.reddit.com/r/linux/.rss":            "socks5://127.0.0.1:9050",
+		"https://www.reddit.com/r/golang/.rss":           NoProxy,
+		"https://www.reddit.com.evil.example.com/r/.rss": "http://proxy.example.com:3128",
+		"https://www.reddit.com/r/golangcheats/.rss":     "socks5://127.0.0.1:9050",
+		"https://www.reddit.com/r/golang":                NoProxy,
+		"https://WWW.Reddit.com:443/r/golang/.rss":       NoProxy,
+		"https://www.reddit.com:8443/r/linux/.rss":       "http://proxy.example.com:3128",
+		"http://www.reddit.com/r/linux/.rss":             "http://proxy.example.com:3128",
+		"https://www.reddit.com@evil.example.com/.rss":   "http://proxy.example.com:3128",
+	}
+
+	for url, proxy := range cases {
+		if got := cfg.HTTP.For(url).Proxy; got != proxy {
+			t.Fatalf("expected proxy %q for %s, got %q", proxy, url, got)
+		}
+	}
+
+	// The clients of the other services use the same proxies
+	client := cfg.HTTP.Client("https://www.reddit.com/r/linux/.rss", time.Minute)
+	req, _ := http.NewRequest("GET", "https://www.reddit.com/r/linux/.rss", nil)
+	proxy, err := client.Transport.(*http.Transport).Proxy(req)
+	if err != nil || proxy.String() != "socks5://127.0.0.1:9050" || client.Timeout != time.Minute {
+		t.Fatalf("expected the client to use the proxy of the site, got %v (%v)", proxy, err)
+	}
+
+	client = cfg.HTTP.Client("https://www.reddit.com/r/golang", 0)
+	if client.Transport.(*http.Transport).Proxy != nil {
+		t.Fatal("expected the client not to use a proxy")
+	}
+}
+
+// TestConfigHTTPInvalid if we get an error then invalid proxies are accepted
+func TestConfigHTTPInvalid(t *testing.T) {
+	cases := []HTTP{
+		{Request: Request{Proxy: "ftp://proxy.example.com"}},
+		{Request: Request{Proxy: "://"}},
+		{Sites: []Site{{Request: Request{Proxy: "none"}}}},
+		{Sites: []Site{{URL: "https://example.com", Request: Request{Proxy: "gopher://x"}}}},
+		{Sites: []Site{{URL: "www.reddit.com/", Request: Request{Proxy: "none"}}}},
+		{Request: Request{Password: "hunter2"}},
+		{Request: Request{Username: "alice", Password: "hunter2", PasswordCommand: "pass goread"}},
+		{Request: Request{Headers: map[string]string{"X Token": "secret"}}},
+	}
+
+	for _, http := range cases {
+		if err := http.validate(); err == nil {
+			t.Fatalf("expected an error for %+v", http)
+		}
+	}
+}
+
+// TestConfigHTTPCredentials if we get an error then the site credentials or headers are merged incorrectly
+func TestConfigHTTPCredentials(t *testing.T) {
+	cfg := HTTP{
+		Request: Request{Username: "bob", Password: "global", UserAgent: "goread", Headers: map[string]string{"X-A": "1", "X-B": "2"}},
+		Sites: []Site{{
+			URL:     "https://git.example.com/",
+			Request: Request{Username: "alice", PasswordCommand: "echo hunter2", UserAgent: "Mozilla/5.0", Headers: map[string]string{"X-B": "3"}},
+		}},
+	}
+
+	settings := cfg.For("https://git.example.com/alice.atom")
+	if settings.Username != "alice" || settings.Password != "" {
+		t.Fatalf("expected the site credentials, got %+v", settings)
+	}
+
+	if settings.UserAgent != "Mozilla/5.0" {
+		t.Fatalf("expected the site user agent, got %q", settings.UserAgent)
+	}
+
+	if settings.Headers["X-A"] != "1" || settings.Headers["X-B"] != "3" {
+		t.Fatalf("expected the headers to be merged, got %v", settings.Headers)
+	}
+
+	if cfg.Headers["X-B"] != "2" {
+		t.Fatal("expected the global headers to stay the same")
+	}
+
+	password, err := settings.Secret()
+	if err != nil {
+		t.Fatalf("couldn't run the password command: %v", err)
+	}
+
+	if password != "hunter2" {
+		t.Fatalf("expected the output of the command, got %q", password)
+	}
+}
+
+// TestConfigScraperInvalid if we get an error then scrapers which can't be used are accepted
+func TestConfigScraperInvalid(t *testing.T) {
+	cases := []Scraper{
+		{Item: "article"},
+		{URL: "example.com/news", Item: "article"},
+		{URL: "ftp://example.com/news", Item: "article"},
+		{URL: "https://example.com/news"},
+	}
+
+	for _, scraper := range cases {
+		if err := scraper.validate(); err == nil {
+			t.Fatalf("expected an error for %+v", scraper)
+		}
+	}
+
+	if err := (Scraper{URL: "https://example.com/news", Item: "article"}).validate(); err != nil {
+		t.Fatalf("expected the scraper to be valid, got %v", err)
+	}
+}
+
+// TestConfigLinkRuleInvalid if we get an error then the invalid link rules are accepted
+func TestConfigLinkRuleInvalid(t *testing.T) {
+	for _, rule := range []LinkRule{{Rewrite: "old.reddit.com"}, {Host: "reddit.com", Rewrite: "https://"}} {
+		if err := rule.validate(); err == nil {
+			t.Fatalf("expected an error for %+v", rule)
+		}
+	}
+
+	for _, rule := range []LinkRule{{Host: "reddit.com", Rewrite: "old.reddit.com"}, {Feed: "Blog", StripParams: []string{"ref"}}} {
+		if err := rule.validate(); err != nil {
+			t.Fatalf("expected the rule %+v to be valid, got %v", rule, err)
+		}
+	}
+}
+
+// TestConfigDigestInvalid if we get an error then the digest can be made at an hour which doesn't exist
+func TestConfigDigestInvalid(t *testing.T) {
+	for _, digest := range []Digest{{Hour: 24}, {Hour: -1}, {Hour: 7, Top: -1}} {
+		if err := digest.validate(); err == nil {
+			t.Fatalf("expected an error for %+v", digest)
+		}
+	}
+
+	if err := Default.Digest.validate(); err != nil {
+		t.Fatalf("expected the default digest to be valid, got %v", err)
+	}
+}
+
+// TestConfigRetentionInvalid if we get an error then the negative retention limits are accepted
+func TestConfigRetentionInvalid(t *testing.T) {
+	for _, retention := range []Retention{{KeepItems: -1}, {PurgeReadDays: -30}, {MaxSizeMB: -1}} {
+		if err := retention.validate(); err == nil {
+			t.Fatalf("expected an error for %+v", retention)
+		}
+	}
+
+	if Default.Retention.Enabled() {
+		t.Fatal("expected the articles not to be kept by default")
+	}
+}
+
+// TestConfigDecorationInvalid if we get an error then the decorations without a target or with a wrong color are accepted
+func TestConfigDecorationInvalid(t *testing.T) {
+	for _, decoration := range []Decoration{{Icon: "🔥"}, {Feed: "Blog", Category: "News"}, {Feed: "Blog", Color: "orange"}, {Feed: "Blog", Color: "256"}} {
+		if err := decoration.validate(); err == nil {
+			t.Fatalf("expected an error for %+v", decoration)
+		}
+	}
+
+	for _, decoration := range []Decoration{{Feed: "Blog", Color: "#ff6600", Icon: "🔥"}, {Category: "News", Color: "202"}, {Feed: "Blog", Color: "#f60"}} {
+		if err := decoration.validate(); err != nil {
+			t.Fatalf("expected the decoration %+v to be valid, got %v", decoration, err)
+		}
+	}
+}
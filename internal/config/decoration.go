@@ -0,0 +1,37 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// hexColorRe matches the hex colors like #ff6600 or #f60
+var hexColorRe = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// Decoration gives a feed or a category its own color and icon, they're used in the tab titles
+// and in the lists so that the important sources stand out. The icon is usually an emoji
+type Decoration struct {
+	Feed     string `yaml:"feed"`
+	Category string `yaml:"category"`
+	Color    string `yaml:"color"`
+	Icon     string `yaml:"icon"`
+}
+
+// validate checks if the decoration belongs to exactly one feed or category and if the color is valid
+func (d Decoration) validate() error {
+	if (d.Feed == "") == (d.Category == "") {
+		return errors.New("a decoration needs either a feed or a category")
+	}
+
+	if d.Color == "" || hexColorRe.MatchString(d.Color) {
+		return nil
+	}
+
+	if number, err := strconv.Atoi(d.Color); err != nil || number < 0 || number > 255 {
+		return fmt.Errorf("invalid color %q, expected a hex color like #ff6600 or an ANSI color number", d.Color)
+	}
+
+	return nil
+}
@@ -0,0 +1,29 @@
+package config
+
+import (
+	"fmt"
+)
+
+// Digest contains the settings of the daily digest, an article listing the new articles of every
+// category which is made at the hour every day. It's sent to the email address using the sendmail
+// command if the address is set, the message with its headers is passed on the standard input
+type Digest struct {
+	Enabled  bool   `yaml:"enabled"`
+	Hour     int    `yaml:"hour"`
+	Top      int    `yaml:"top"`
+	Email    string `yaml:"email"`
+	Sendmail string `yaml:"sendmail"`
+}
+
+// validate checks if the digest can be made
+func (d Digest) validate() error {
+	if d.Hour < 0 || d.Hour > 23 {
+		return fmt.Errorf("the hour of the digest must be between 0 and 23, got %d", d.Hour)
+	}
+
+	if d.Top < 0 {
+		return fmt.Errorf("the number of the titles in the digest can't be negative, got %d", d.Top)
+	}
+
+	return nil
+}
@@ -0,0 +1,247 @@
+package config
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+)
+
+// HTTP contains the settings of the requests made to the feeds and the websites, the sites
+// override the global settings for the urls on their scheme, host and port which are under
+// their path. The headers are merged
+type HTTP struct {
+	Request `yaml:",inline"`
+	Sites   []Site `yaml:"sites"`
+}
+
+// Site contains the settings of the requests made to a single feed or a whole server
+type Site struct {
+	URL     string `yaml:"url"`
+	Request `yaml:",inline"`
+}
+
+// Request contains the settings of a request, the empty fields use the defaults
+type Request struct {
+	Proxy           string            `yaml:"proxy"`
+	UserAgent       string            `yaml:"user_agent"`
+	Username        string            `yaml:"username"`
+	Password        string            `yaml:"password"`
+	PasswordCommand string            `yaml:"password_command"`
+	Headers         map[string]string `yaml:"headers"`
+}
+
+// NoProxy disables the proxy, including the one from the environment
+const NoProxy = "none"
+
+// Client creates the http client used for the requests to the url, the proxy is picked from the
+// settings and falls back to the one from the environment. A zero timeout means no timeout
+func (h HTTP) Client(target string, timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			Proxy:        h.proxy(target),
+			TLSNextProto: map[string]func(authority string, c *tls.Conn) http.RoundTripper{},
+		},
+	}
+}
+
+// proxy returns the proxy function for the requests to the url, the socks5 proxies are supported
+// by the transport itself
+func (h HTTP) proxy(target string) func(*http.Request) (*url.URL, error) {
+	proxy := h.For(target).Proxy
+	switch proxy {
+	case "":
+		return http.ProxyFromEnvironment
+	case NoProxy:
+		return nil
+	}
+
+	proxyURL, err := url.Parse(proxy)
+	if err != nil {
+		log.Println("Invalid proxy, using the environment:", err)
+		return http.ProxyFromEnvironment
+	}
+
+	return http.ProxyURL(proxyURL)
+}
+
+// For returns the settings of the requests to the url, the sites with longer paths take precedence
+func (h HTTP) For(target string) Request {
+	targetURL, err := url.Parse(target)
+	if err != nil {
+		return h.Request
+	}
+
+	sites := make([]Site, 0, len(h.Sites))
+	paths := make(map[string]string, len(h.Sites))
+	for _, site := range h.Sites {
+		if path, ok := site.matches(targetURL); ok {
+			sites = append(sites, site)
+			paths[site.URL] = path
+		}
+	}
+
+	sort.SliceStable(sites, func(i, j int) bool { return len(paths[sites[i].URL]) < len(paths[sites[j].URL]) })
+	result := h.Request
+	for _, site := range sites {
+		result = result.merge(site.Request)
+	}
+
+	return result
+}
+
+// matches reports if the url is on the same scheme, host and port as the site and under its path, the
+// path only matches whole segments so that a site for /r/go doesn't apply to /r/golang. The matched
+// path is returned as well
+func (s Site) matches(target *url.URL) (string, bool) {
+	siteURL, err := url.Parse(s.URL)
+	if err != nil || siteURL.Host == "" {
+		return "", false
+	}
+
+	if !strings.EqualFold(siteURL.Scheme, target.Scheme) || hostPort(siteURL) != hostPort(target) {
+		return "", false
+	}
+
+	path := strings.TrimSuffix(siteURL.EscapedPath(), "/")
+	targetPath := target.EscapedPath()
+	if path != "" && targetPath != path && !strings.HasPrefix(targetPath, path+"/") {
+		return "", false
+	}
+
+	return path, true
+}
+
+// hostPort returns the lowercase host and port of the url, the default port of the scheme is
+// filled in when the url doesn't have one
+func hostPort(u *url.URL) string {
+	port := u.Port()
+	if port == "" {
+		switch strings.ToLower(u.Scheme) {
+		case "http":
+			port = "80"
+		case "https":
+			port = "443"
+		}
+	}
+
+	return strings.ToLower(u.Hostname()) + ":" + port
+}
+
+// merge returns the settings with the fields set in the other settings replaced
+func (r Request) merge(other Request) Request {
+	if other.Proxy != "" {
+		r.Proxy = other.Proxy
+	}
+
+	if other.UserAgent != "" {
+		r.UserAgent = other.UserAgent
+	}
+
+	// The credentials only make sense together
+	if other.Username != "" {
+		r.Username = other.Username
+		r.Password = other.Password
+		r.PasswordCommand = other.PasswordCommand
+	}
+
+	if len(other.Headers) > 0 {
+		headers := make(map[string]string, len(r.Headers)+len(other.Headers))
+		for name, value := range r.Headers {
+			headers[name] = value
+		}
+
+		for name, value := range other.Headers {
+			headers[name] = value
+		}
+
+		r.Headers = headers
+	}
+
+	return r
+}
+
+// Secret returns the password, the password command is run if it's set. The output of the command
+// is trimmed, so that the secrets can be kept in a password manager or a keyring
+func (r Request) Secret() (string, error) {
+	if r.PasswordCommand == "" {
+		return r.Password, nil
+	}
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", r.PasswordCommand) //nolint:gosec
+	} else {
+		cmd = exec.Command("sh", "-c", r.PasswordCommand) //nolint:gosec
+	}
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("password command %q failed: %w", r.PasswordCommand, err)
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+// validate checks if the settings can be used
+func (r Request) validate() error {
+	if r.Proxy != "" && r.Proxy != NoProxy {
+		proxyURL, err := url.Parse(r.Proxy)
+		if err != nil {
+			return fmt.Errorf("invalid proxy %q: %w", r.Proxy, err)
+		}
+
+		switch proxyURL.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return fmt.Errorf("invalid proxy %q: the scheme must be http, https or socks5", r.Proxy)
+		}
+	}
+
+	if r.Username == "" && (r.Password != "" || r.PasswordCommand != "") {
+		return errors.New("a password is set without a username")
+	}
+
+	if r.Password != "" && r.PasswordCommand != "" {
+		return errors.New("only one of password and password_command can be set")
+	}
+
+	for name := range r.Headers {
+		if name == "" || strings.ContainsAny(name, " :\r\n") {
+			return fmt.Errorf("invalid header name %q", name)
+		}
+	}
+
+	return nil
+}
+
+// validate checks the global settings and the settings of every site
+func (h HTTP) validate() error {
+	if err := h.Request.validate(); err != nil {
+		return fmt.Errorf("http: %w", err)
+	}
+
+	for _, site := range h.Sites {
+		if site.URL == "" {
+			return fmt.Errorf("http: a site is missing its url")
+		}
+
+		if siteURL, err := url.Parse(site.URL); err != nil || siteURL.Scheme == "" || siteURL.Host == "" {
+			return fmt.Errorf("http: site %s: the url must have a scheme and a host", site.URL)
+		}
+
+		if err := site.Request.validate(); err != nil {
+			return fmt.Errorf("http: site %s: %w", site.URL, err)
+		}
+	}
+
+	return nil
+}
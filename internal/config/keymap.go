@@ -0,0 +1,156 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Keymap contains the custom key bindings, the sections are the parts of the interface
+// (e.g. feed) and the actions are the snake case names of the bindings (e.g. open_in_browser)
+type Keymap map[string]map[string][]string
+
+// keyNames contains the names of the special keys understood by bubbletea
+var keyNames = func() map[string]bool {
+	names := make(map[string]bool)
+	for k := tea.KeyType(-100); k <= 127; k++ {
+		if name := k.String(); name != "" && k != tea.KeyRunes {
+			names[name] = true
+		}
+	}
+
+	return names
+}()
+
+// ApplyKeymap remaps the bindings of a keymap struct using the keys from the given section
+func (c *Config) ApplyKeymap(section string, keymap any) error {
+	actions, ok := c.Keymap[section]
+	if !ok {
+		return nil
+	}
+
+	value := reflect.ValueOf(keymap)
+	if value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("keymap: %s is not a pointer to a keymap", section)
+	}
+
+	bindings := bindingFields(value.Elem())
+	for action, keys := range actions {
+		binding, ok := bindings[action]
+		if !ok {
+			return fmt.Errorf("keymap: unknown action %q in section %q, expected one of: %s",
+				action, section, strings.Join(sortedKeys(bindings), ", "))
+		}
+
+		if len(keys) == 0 {
+			return fmt.Errorf("keymap: no keys given for %s.%s", section, action)
+		}
+
+		normalized := make([]string, len(keys))
+		for i, k := range keys {
+			name, err := normalizeKey(k)
+			if err != nil {
+				return fmt.Errorf("keymap: invalid key for %s.%s: %w", section, action, err)
+			}
+
+			normalized[i] = name
+		}
+
+		binding.SetKeys(normalized...)
+		binding.SetHelp(strings.Join(keys, "/"), binding.Help().Desc)
+	}
+
+	return nil
+}
+
+// CheckKeymap reports an error if the keymap has sections which are not in the given list
+func (c *Config) CheckKeymap(sections ...string) error {
+	known := make(map[string]bool, len(sections))
+	for _, section := range sections {
+		known[section] = true
+	}
+
+	for section := range c.Keymap {
+		if !known[section] {
+			sort.Strings(sections)
+			return fmt.Errorf("keymap: unknown section %q, expected one of: %s",
+				section, strings.Join(sections, ", "))
+		}
+	}
+
+	return nil
+}
+
+// bindingFields returns the key bindings of a keymap struct by their snake case names
+func bindingFields(value reflect.Value) map[string]*key.Binding {
+	bindingType := reflect.TypeOf(key.Binding{})
+	result := make(map[string]*key.Binding)
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if field.Type != bindingType || !field.IsExported() {
+			continue
+		}
+
+		result[snakeCase(field.Name)] = value.Field(i).Addr().Interface().(*key.Binding)
+	}
+
+	return result
+}
+
+// normalizeKey checks if a key name can be produced by bubbletea, returns the name it uses
+func normalizeKey(name string) (string, error) {
+	if name == "space" {
+		return " ", nil
+	}
+
+	if keyNames[name] || utf8.RuneCountInString(name) == 1 {
+		return name, nil
+	}
+
+	if rest := strings.TrimPrefix(name, "alt+"); rest != name {
+		if rest == "space" {
+			return "alt+ ", nil
+		}
+
+		if keyNames[rest] || utf8.RuneCountInString(rest) == 1 {
+			return name, nil
+		}
+	}
+
+	return "", fmt.Errorf("%q is not a valid key", name)
+}
+
+// snakeCase converts a field name like OpenInBrowser to open_in_browser
+func snakeCase(name string) string {
+	var sb strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				sb.WriteByte('_')
+			}
+
+			r = unicode.ToLower(r)
+		}
+
+		sb.WriteRune(r)
+	}
+
+	return sb.String()
+}
+
+// sortedKeys returns the keys of the map in alphabetical order
+func sortedKeys(m map[string]*key.Binding) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
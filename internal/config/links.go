@@ -0,0 +1,47 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Links contains the cleanup of the links which are opened and copied. The tracking parameters
+// like utm_source and fbclid are removed from all of them unless they are kept
+type Links struct {
+	KeepTracking bool       `yaml:"keep_tracking"`
+	Rules        []LinkRule `yaml:"rules"`
+}
+
+// LinkRule cleans the links of a feed, the empty fields match every feed and every host. The links
+// on the host and its subdomains are moved to the rewrite, a host like old.reddit.com or an url
+// like https://yewtu.be. The strip params are removed too, the ones ending with * are prefixes
+type LinkRule struct {
+	Feed        string   `yaml:"feed"`
+	Host        string   `yaml:"host"`
+	Rewrite     string   `yaml:"rewrite"`
+	StripParams []string `yaml:"strip_params"`
+}
+
+// validate checks if the rule can be used
+func (r LinkRule) validate() error {
+	if r.Rewrite == "" {
+		return nil
+	}
+
+	if r.Host == "" {
+		return errors.New("a link rule rewrites the links without a host")
+	}
+
+	rewrite := r.Rewrite
+	if !strings.Contains(rewrite, "://") {
+		rewrite = "https://" + rewrite
+	}
+
+	if parsed, err := url.Parse(rewrite); err != nil || parsed.Host == "" {
+		return fmt.Errorf("invalid rewrite %q of the links on %s", r.Rewrite, r.Host)
+	}
+
+	return nil
+}
@@ -0,0 +1,29 @@
+package config
+
+import (
+	"fmt"
+)
+
+// Retention contains the policies which decide how long the articles are kept in the cache. When
+// one of them is set the articles which dropped out of their feed are kept until they're collected,
+// otherwise a feed only has the articles which it lists at the moment
+type Retention struct {
+	KeepItems     int  `yaml:"keep_items"`
+	KeepUnread    bool `yaml:"keep_unread"`
+	PurgeReadDays int  `yaml:"purge_read_days"`
+	MaxSizeMB     int  `yaml:"max_size_mb"`
+}
+
+// Enabled reports if any of the policies is set.
+func (r Retention) Enabled() bool {
+	return r.KeepItems > 0 || r.KeepUnread || r.PurgeReadDays > 0 || r.MaxSizeMB > 0
+}
+
+// validate checks if the policies make sense
+func (r Retention) validate() error {
+	if r.KeepItems < 0 || r.PurgeReadDays < 0 || r.MaxSizeMB < 0 {
+		return fmt.Errorf("the retention limits can't be negative, got %+v", r)
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// Scraper turns a page without a feed into a feed. The item selector finds the articles on the
+// page and the other selectors find their parts inside of every article: the link is the href of
+// the matched element or of the first link inside of it and the date is its datetime attribute or
+// its text. The date format is a Go layout, the common formats are recognized without it
+type Scraper struct {
+	URL         string `yaml:"url"`
+	Item        string `yaml:"item"`
+	Title       string `yaml:"title"`
+	Link        string `yaml:"link"`
+	Date        string `yaml:"date"`
+	DateFormat  string `yaml:"date_format"`
+	Description string `yaml:"description"`
+}
+
+// validate checks if the scraper can be used
+func (s Scraper) validate() error {
+	if s.URL == "" {
+		return errors.New("a scraper has no url")
+	}
+
+	if parsed, err := url.Parse(s.URL); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return fmt.Errorf("invalid scraper url %q", s.URL)
+	}
+
+	if s.Item == "" {
+		return fmt.Errorf("the scraper of %s has no item selector", s.URL)
+	}
+
+	return nil
+}
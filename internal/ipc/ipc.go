@@ -0,0 +1,167 @@
+package ipc
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/TypicalAM/goread/internal/backend/rss"
+)
+
+// ErrAlreadyRunning is returned when another instance is listening on the socket
+var ErrAlreadyRunning = errors.New("another instance is listening on the socket")
+
+// timeout is how long a client waits for the reply
+var timeout = 10 * time.Second
+
+// Request is a command received on the socket, the reply is sent back to the client. The command
+// is the first word of the line and the rest of the line is its argument
+type Request struct {
+	Command string
+	Arg     string
+	reply   chan string
+}
+
+// Reply sends the reply to the client, it has to be called exactly once for every request
+func (r Request) Reply(text string) {
+	r.reply <- text
+}
+
+// Server listens on a unix socket for the commands, one line per connection.
+type Server struct {
+	listener net.Listener
+	requests chan Request
+	path     string
+}
+
+// DefaultPath returns the path of the socket, it's in the runtime directory if there is one
+func DefaultPath() string {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		return filepath.Join(dir, "goread.sock")
+	}
+
+	return filepath.Join(os.TempDir(), fmt.Sprintf("goread-%d.sock", os.Getuid()))
+}
+
+// Listen creates the socket and starts accepting the connections. A socket left behind by an
+// instance which crashed is replaced
+func Listen(path string) (*Server, error) {
+	if conn, err := net.DialTimeout("unix", path, time.Second); err == nil {
+		conn.Close()
+		return nil, ErrAlreadyRunning
+	}
+
+	_ = os.Remove(path)
+	listener, err := listenPrivate(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the user can send the commands, the mode is set again in case the umask wasn't used
+	if err = os.Chmod(path, 0600); err != nil {
+		listener.Close()
+		return nil, err
+	}
+
+	log.Println("Listening for commands on", path)
+	s := &Server{listener: listener, requests: make(chan Request), path: path}
+	go s.accept()
+	return s, nil
+}
+
+// Requests returns the channel on which the requests arrive.
+func (s *Server) Requests() <-chan Request {
+	return s.requests
+}
+
+// Close stops listening and removes the socket.
+func (s *Server) Close() error {
+	err := s.listener.Close()
+	_ = os.Remove(s.path)
+	return err
+}
+
+// accept handles the connections until the server is closed
+func (s *Server) accept() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				log.Println("Error accepting a connection:", err)
+			}
+
+			return
+		}
+
+		go s.handle(conn)
+	}
+}
+
+// handle reads the command from the connection and writes the reply
+func (s *Server) handle(conn net.Conn) {
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(timeout))
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil && line == "" {
+		return
+	}
+
+	command, arg, _ := strings.Cut(strings.TrimSpace(line), " ")
+	if AddsExecFeed(command, strings.TrimSpace(arg)) {
+		fmt.Fprintln(conn, "error: exec feeds can't be added over the socket, add them to the urls file")
+		return
+	}
+
+	req := Request{Command: command, Arg: strings.TrimSpace(arg), reply: make(chan string, 1)}
+	select {
+	case s.requests <- req:
+	case <-time.After(timeout):
+		fmt.Fprintln(conn, "error: goread is busy")
+		return
+	}
+
+	select {
+	case reply := <-req.reply:
+		fmt.Fprintln(conn, reply)
+	case <-time.After(timeout):
+		fmt.Fprintln(conn, "error: no reply")
+	}
+}
+
+// Send sends the command to the instance listening on the socket and returns its reply.
+func Send(path, line string) (string, error) {
+	conn, err := net.DialTimeout("unix", path, time.Second)
+	if err != nil {
+		return "", fmt.Errorf("goread isn't running: %w", err)
+	}
+
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(timeout + time.Second))
+	if _, err = fmt.Fprintln(conn, line); err != nil {
+		return "", err
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil && reply == "" {
+		return "", err
+	}
+
+	return strings.TrimSuffix(reply, "\n"), nil
+}
+
+// AddsExecFeed reports if the command subscribes to an exec feed, directly or through the command
+// line. Their commands would run on this machine so they're only accepted from the urls file
+func AddsExecFeed(command, arg string) bool {
+	if command == "run" {
+		command, arg, _ = strings.Cut(arg, " ")
+		arg = strings.TrimSpace(arg)
+	}
+
+	return (command == "add-feed" || command == "addfeed") && rss.IsExec(arg)
+}
@@ -0,0 +1,103 @@
+package ipc
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestIPCRoundTrip if we get an error then the commands don't reach the instance or the replies don't come back
+func TestIPCRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "goread.sock")
+	server, err := Listen(path)
+	if err != nil {
+		t.Fatalf("couldn't listen on the socket: %v", err)
+	}
+
+	go func() {
+		for req := range server.Requests() {
+			req.Reply(req.Command + ":" + req.Arg)
+		}
+	}()
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("couldn't stat the socket: %v", err)
+	}
+
+	if info.Mode().Perm() != 0600 {
+		t.Fatalf("expected only the user to access the socket, got %v", info.Mode().Perm())
+	}
+
+	reply, err := Send(path, "add-feed https://example.com/feed.xml Example")
+	if err != nil {
+		t.Fatalf("couldn't send the command: %v", err)
+	}
+
+	if reply != "add-feed:https://example.com/feed.xml Example" {
+		t.Fatalf("expected the command and the argument in the reply, got %q", reply)
+	}
+
+	if _, err = Listen(path); err != ErrAlreadyRunning {
+		t.Fatalf("expected the second instance not to listen, got %v", err)
+	}
+
+	if err = server.Close(); err != nil {
+		t.Fatalf("couldn't close the server: %v", err)
+	}
+
+	if _, err = os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("expected the socket to be removed, got %v", err)
+	}
+
+	if _, err = Send(path, "refresh"); err == nil {
+		t.Fatal("expected an error when goread isn't running")
+	}
+}
+
+// TestIPCStaleSocket if we get an error then a socket left behind blocks the next instance
+func TestIPCStaleSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "goread.sock")
+	if err := os.WriteFile(path, nil, 0600); err != nil {
+		t.Fatalf("couldn't create the stale socket: %v", err)
+	}
+
+	server, err := Listen(path)
+	if err != nil {
+		t.Fatalf("couldn't replace the stale socket: %v", err)
+	}
+
+	server.Close()
+}
+
+// TestIPCExecFeed if we get an error then the exec feeds can be added over the socket
+func TestIPCExecFeed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "goread.sock")
+	server, err := Listen(path)
+	if err != nil {
+		t.Fatalf("couldn't listen on the socket: %v", err)
+	}
+
+	defer server.Close()
+	go func() {
+		for req := range server.Requests() {
+			req.Reply("ok")
+		}
+	}()
+
+	for _, line := range []string{"add-feed exec:rm -rf ~", "add-feed  exec:id Name", "run addfeed exec:id"} {
+		reply, err := Send(path, line)
+		if err != nil {
+			t.Fatalf("couldn't send the command: %v", err)
+		}
+
+		if !strings.HasPrefix(reply, "error:") {
+			t.Fatalf("expected %q to be rejected, got %q", line, reply)
+		}
+	}
+
+	if reply, _ := Send(path, "add-feed https://example.com/exec:feed.xml"); reply != "ok" {
+		t.Fatalf("expected a regular feed to be added, got %q", reply)
+	}
+}
@@ -0,0 +1,17 @@
+//go:build !windows
+
+package ipc
+
+import (
+	"net"
+	"syscall"
+)
+
+// listenPrivate creates the socket with a umask which only lets the user connect, so that nobody
+// else can send a command before its mode is set. The umask is shared by the whole process, a file
+// created by another goroutine in the meantime only ends up more private
+func listenPrivate(path string) (net.Listener, error) {
+	old := syscall.Umask(0177)
+	defer syscall.Umask(old)
+	return net.Listen("unix", path)
+}
@@ -0,0 +1,10 @@
+//go:build windows
+
+package ipc
+
+import "net"
+
+// listenPrivate creates the socket, windows has no umask so only the mode set afterwards applies
+func listenPrivate(path string) (net.Listener, error) {
+	return net.Listen("unix", path)
+}
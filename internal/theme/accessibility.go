@@ -0,0 +1,95 @@
+package theme
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// NoColor is the high-contrast mode for limited terminals and low vision, the colors, the italics
+// and the faint text are disabled and the selected items are shown in reverse
+var NoColor bool
+
+// ScreenReader is the mode for the terminal screen readers, it implies the no-color mode. The
+// output is linear text without the box-drawing characters and the icons, the tabs show one pane
+// at a time and the status bar tells the position in the lists
+var ScreenReader bool
+
+// sgrRegex matches the escape sequences which set the style of the text
+var sgrRegex = regexp.MustCompile("\x1b\\[([0-9;]*)m")
+
+// EnableNoColor turns on the no-color mode, the colors are kept so that the mode can be turned
+// off but the markdown of the articles is rendered without them
+func EnableNoColor(c *Colors) {
+	NoColor = true
+	lipgloss.SetColorProfile(termenv.Ascii)
+	c.genMarkdownStyle()
+}
+
+// EnableScreenReader turns on the screen reader mode
+func EnableScreenReader(c *Colors) {
+	ScreenReader = true
+	EnableNoColor(c)
+}
+
+// PlainText removes the styles from the text and replaces the box-drawing characters and the
+// icons with spaces, so that the screen readers don't read them out
+func PlainText(text string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 0x2500 && r <= 0x259f, r >= 0x2b00 && r <= 0x2bff, r >= 0xe000 && r <= 0xf8ff:
+			return ' '
+		}
+
+		return r
+	}, StripStyles(text))
+}
+
+// Emphasize makes the style of a selected item stand out without relying on the colors, it's
+// unchanged if the no-color mode is off
+func Emphasize(style lipgloss.Style) lipgloss.Style {
+	if !NoColor {
+		return style
+	}
+
+	return style.Copy().Reverse(true).Bold(true).Italic(false).Faint(false)
+}
+
+// StripStyles removes the colors, the italics and the faint text from the escape sequences in the
+// text, the bold, the underline and the reverse text are kept
+func StripStyles(text string) string {
+	return sgrRegex.ReplaceAllStringFunc(text, func(sequence string) string {
+		params := sgrRegex.FindStringSubmatch(sequence)[1]
+		if params == "" {
+			return sequence
+		}
+
+		var kept []string
+		codes := strings.Split(params, ";")
+		for i := 0; i < len(codes); i++ {
+			code, err := strconv.Atoi(codes[i])
+			switch {
+			case err != nil, code == 2, code == 3:
+			case code == 38 || code == 48 || code == 58:
+				// Skip the arguments of the extended colors, 5;n or 2;r;g;b
+				if i+1 < len(codes) && codes[i+1] == "5" {
+					i += 2
+				} else if i+1 < len(codes) && codes[i+1] == "2" {
+					i += 4
+				}
+			case code >= 30 && code <= 49, code >= 90 && code <= 107:
+			default:
+				kept = append(kept, codes[i])
+			}
+		}
+
+		if len(kept) == 0 {
+			return ""
+		}
+
+		return "\x1b[" + strings.Join(kept, ";") + "m"
+	})
+}
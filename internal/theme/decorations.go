@@ -0,0 +1,24 @@
+package theme
+
+import "github.com/charmbracelet/lipgloss"
+
+// Decoration is the color and the icon of a feed or a category, the empty fields aren't used
+type Decoration struct {
+	Color lipgloss.Color
+	Icon  string
+}
+
+// FeedDecorations and CategoryDecorations are the decorations set in the config, by the name of the feed or the category
+var (
+	FeedDecorations     = make(map[string]Decoration)
+	CategoryDecorations = make(map[string]Decoration)
+)
+
+// ListIcon returns the icon of the decoration followed by a space, or the fallback if there is no icon
+func (d Decoration) ListIcon(fallback string) string {
+	if d.Icon == "" {
+		return fallback
+	}
+
+	return d.Icon + " "
+}
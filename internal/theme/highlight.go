@@ -0,0 +1,106 @@
+package theme
+
+import (
+	"strings"
+
+	"github.com/alecthomas/chroma"
+	"github.com/alecthomas/chroma/styles"
+	"github.com/charmbracelet/glamour/ansi"
+)
+
+// SyntaxHighlighting highlights the code blocks in the articles using the colors of the colorscheme
+var SyntaxHighlighting = true
+
+// chromaTheme is the name of the chroma style made from the colorscheme, it's registered again
+// whenever the colors change. Glamour registers the style of its chroma config only once, so the
+// code blocks would keep the colors of the first colorscheme
+const chromaTheme = "goread"
+
+// DisableSyntaxHighlighting turns off the highlighting of the code blocks, they are shown in the
+// color of the text
+func DisableSyntaxHighlighting(c *Colors) {
+	SyntaxHighlighting = false
+	c.genMarkdownStyle()
+}
+
+// useChromaTheme registers the chroma config of the code blocks as a chroma style and makes the
+// code blocks use it
+func useChromaTheme(block *ansi.StyleCodeBlock) {
+	config := block.Chroma
+	block.Chroma = nil
+	if config == nil || !SyntaxHighlighting {
+		return
+	}
+
+	entries := chroma.StyleEntries{}
+	for token, style := range map[chroma.TokenType]ansi.StylePrimitive{
+		chroma.Text:                config.Text,
+		chroma.Error:               config.Error,
+		chroma.Comment:             config.Comment,
+		chroma.CommentPreproc:      config.CommentPreproc,
+		chroma.Keyword:             config.Keyword,
+		chroma.KeywordReserved:     config.KeywordReserved,
+		chroma.KeywordNamespace:    config.KeywordNamespace,
+		chroma.KeywordType:         config.KeywordType,
+		chroma.Operator:            config.Operator,
+		chroma.Punctuation:         config.Punctuation,
+		chroma.Name:                config.Name,
+		chroma.NameBuiltin:         config.NameBuiltin,
+		chroma.NameTag:             config.NameTag,
+		chroma.NameAttribute:       config.NameAttribute,
+		chroma.NameClass:           config.NameClass,
+		chroma.NameConstant:        config.NameConstant,
+		chroma.NameDecorator:       config.NameDecorator,
+		chroma.NameException:       config.NameException,
+		chroma.NameFunction:        config.NameFunction,
+		chroma.NameOther:           config.NameOther,
+		chroma.Literal:             config.Literal,
+		chroma.LiteralNumber:       config.LiteralNumber,
+		chroma.LiteralDate:         config.LiteralDate,
+		chroma.LiteralString:       config.LiteralString,
+		chroma.LiteralStringEscape: config.LiteralStringEscape,
+		chroma.GenericDeleted:      config.GenericDeleted,
+		chroma.GenericEmph:         config.GenericEmph,
+		chroma.GenericInserted:     config.GenericInserted,
+		chroma.GenericStrong:       config.GenericStrong,
+		chroma.GenericSubheading:   config.GenericSubheading,
+		chroma.Background:          config.Background,
+	} {
+		if entry := chromaEntry(style); entry != "" {
+			entries[token] = entry
+		}
+	}
+
+	style, err := chroma.NewStyle(chromaTheme, entries)
+	if err != nil {
+		return
+	}
+
+	styles.Register(style)
+	block.Theme = chromaTheme
+}
+
+// chromaEntry returns the chroma style entry of the style, like "bold #ff0000 bg:#000000"
+func chromaEntry(style ansi.StylePrimitive) string {
+	var parts []string
+	if style.Color != nil {
+		parts = append(parts, *style.Color)
+	}
+
+	if style.BackgroundColor != nil {
+		parts = append(parts, "bg:"+*style.BackgroundColor)
+	}
+
+	attrs := []struct {
+		name    string
+		enabled *bool
+	}{{"bold", style.Bold}, {"italic", style.Italic}, {"underline", style.Underline}}
+
+	for _, attr := range attrs {
+		if attr.enabled != nil && *attr.enabled {
+			parts = append(parts, attr.name)
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
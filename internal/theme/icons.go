@@ -0,0 +1,126 @@
+package theme
+
+import "fmt"
+
+// IconSet are the glyphs which decorate the tabs and the lists. The glyphs which are followed by
+// text include the space after them, the empty ones aren't shown
+type IconSet struct {
+	// The icons of the tabs
+	Welcome   string
+	Category  string
+	Feed      string
+	Downloads string
+	Health    string
+	Tags      string
+	Article   string
+
+	// The icons in the lists
+	CategoryItem string
+	FeedItem     string
+	Unread       string
+	Episode      string
+	Starred      string
+	Marked       string
+
+	// Pinned marks the pinned tabs, Error is shown when the articles can't be loaded and Ellipsis
+	// ends the shortened titles
+	Pinned   string
+	Error    string
+	Ellipsis string
+
+	// The glyphs of the text: Collapsed and Expanded mark the nested categories, Description leads
+	// the descriptions in the lists, Tail ends the text cut to fit, Separator parts the details of
+	// the articles and Rule draws the lines
+	Collapsed   string
+	Expanded    string
+	Description string
+	Tail        string
+	Separator   string
+	Rule        string
+}
+
+// DefaultIcons is the default set, it uses a few nerd font glyphs and emojis
+var DefaultIcons = IconSet{
+	Welcome:     "﫢",
+	Category:    "﫜",
+	Feed:        "",
+	Episode:     "🎧 ",
+	Marked:      "✓ ",
+	Pinned:      "📌",
+	Error:       "",
+	Collapsed:   "▸ ",
+	Expanded:    "▾ ",
+	Description: "⮡",
+	Tail:        "…",
+	Separator:   "·",
+	Rule:        "─",
+}
+
+// NerdFontIcons decorate everything with the nerd font glyphs, it needs a patched font
+var NerdFontIcons = IconSet{
+	Welcome:      "\uf015",
+	Category:     "\uf07c",
+	Feed:         "\uf09e",
+	Downloads:    "\uf019",
+	Health:       "\uf21e",
+	Tags:         "\uf02c",
+	Article:      "\uf15c",
+	CategoryItem: "\uf07b ",
+	FeedItem:     "\uf09e ",
+	Unread:       "\uf111 ",
+	Episode:      "\uf025 ",
+	Starred:      "\uf005 ",
+	Marked:       "\uf00c ",
+	Pinned:       "\uf08d",
+	Error:        "\uf071",
+	Ellipsis:     "…",
+	Collapsed:    "▸ ",
+	Expanded:     "▾ ",
+	Description:  "⮡",
+	Tail:         "…",
+	Separator:    "·",
+	Rule:         "─",
+}
+
+// ASCIIIcons only use the plain ASCII characters, for the minimal terminals and fonts
+var ASCIIIcons = IconSet{
+	Welcome:     "~",
+	Category:    "+",
+	Feed:        "=",
+	Downloads:   "v",
+	Health:      "!",
+	Tags:        "#",
+	Article:     "-",
+	Unread:      "* ",
+	Episode:     "[ep] ",
+	Starred:     "[*] ",
+	Marked:      "[x] ",
+	Pinned:      "^",
+	Error:       "!",
+	Ellipsis:    "...",
+	Collapsed:   "> ",
+	Expanded:    "v ",
+	Description: "\\_",
+	Tail:        "...",
+	Separator:   "|",
+	Rule:        "-",
+}
+
+// Icons is the icon set in use
+var Icons = DefaultIcons
+
+// SetIcons switches to the icon set with the name, nerdfont or ascii. An empty name is the default set
+func SetIcons(name string) error {
+	switch name {
+	case "", "default":
+		Icons = DefaultIcons
+	case "nerdfont":
+		Icons = NerdFontIcons
+	case "ascii":
+		Icons = ASCIIIcons
+	default:
+		return fmt.Errorf("unknown icon set %q, expected nerdfont or ascii", name)
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package theme
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -31,19 +32,20 @@ var Default = Colors{
 
 // Colors is a struct that contains all the colors for the application
 type Colors struct {
-	MarkdownStyle ansi.StyleConfig `json:"-"` // Just generate this at runtime
-	Color2        lipgloss.Color   `json:"color2"`
-	BgDarker      lipgloss.Color   `json:"bg_darker"`
-	Text          lipgloss.Color   `json:"text"`
-	TextDark      lipgloss.Color   `json:"text_dark"`
-	Color1        lipgloss.Color   `json:"color1"`
-	FilePath      string           `json:"-"`
-	Color3        lipgloss.Color   `json:"color3"`
-	Color4        lipgloss.Color   `json:"color4"`
-	Color5        lipgloss.Color   `json:"color5"`
-	Color6        lipgloss.Color   `json:"color6"`
-	Color7        lipgloss.Color   `json:"color7"`
-	BgDark        lipgloss.Color   `json:"bg_dark"`
+	MarkdownStyle ansi.StyleConfig `json:"-" yaml:"-"` // Just generate this at runtime
+	Color2        lipgloss.Color   `json:"color2" yaml:"color2"`
+	BgDarker      lipgloss.Color   `json:"bg_darker" yaml:"bg_darker"`
+	Text          lipgloss.Color   `json:"text" yaml:"text"`
+	TextDark      lipgloss.Color   `json:"text_dark" yaml:"text_dark"`
+	Color1        lipgloss.Color   `json:"color1" yaml:"color1"`
+	FilePath      string           `json:"-" yaml:"-"`
+	Name          string           `json:"-" yaml:"-"` // The name of the chosen theme, empty if the colorscheme file is used
+	Color3        lipgloss.Color   `json:"color3" yaml:"color3"`
+	Color4        lipgloss.Color   `json:"color4" yaml:"color4"`
+	Color5        lipgloss.Color   `json:"color5" yaml:"color5"`
+	Color6        lipgloss.Color   `json:"color6" yaml:"color6"`
+	Color7        lipgloss.Color   `json:"color7" yaml:"color7"`
+	BgDark        lipgloss.Color   `json:"bg_dark" yaml:"bg_dark"`
 }
 
 // New will create a new colorscheme and try to load it
@@ -64,14 +66,14 @@ func New(path string) (*Colors, error) {
 	return &colors, nil
 }
 
-// Load will load the colorscheme from a JSON file
+// Load will load the colorscheme from a JSON, TOML or YAML file
 func (c *Colors) Load() error {
 	fileContent, err := os.ReadFile(c.FilePath)
 	if err != nil {
 		return err
 	}
 
-	if err = json.Unmarshal(fileContent, c); err != nil {
+	if err = decode(c.FilePath, fileContent, c); err != nil {
 		return err
 	}
 
@@ -99,15 +101,25 @@ func (c Colors) Save() error {
 	return nil
 }
 
-// Convert takes the information from a pywal file and converts it to a colorscheme
+// pywalColors is the colors file generated by pywal
+type pywalColors struct {
+	Special struct {
+		Background string `json:"background"`
+		Foreground string `json:"foreground"`
+	} `json:"special"`
+	Colors map[string]string `json:"colors"`
+}
+
+// Convert takes the information from a pywal file and converts it to a colorscheme, the default
+// pywal file is used if the path is empty
 func (c *Colors) Convert(pywalFilePath string) error {
 	if pywalFilePath == "" {
-		cacheDir, err := os.UserCacheDir()
+		defaultPath, err := pywalPath()
 		if err != nil {
 			return err
 		}
 
-		pywalFilePath = filepath.Join(cacheDir, "wal", "colors.json")
+		pywalFilePath = defaultPath
 	}
 
 	fileContent, err := os.ReadFile(pywalFilePath)
@@ -115,25 +127,38 @@ func (c *Colors) Convert(pywalFilePath string) error {
 		return err
 	}
 
-	var walColorscheme map[string]interface{}
-	err = json.Unmarshal(fileContent, &walColorscheme)
-	if err != nil {
+	var wal pywalColors
+	if err = json.Unmarshal(fileContent, &wal); err != nil {
 		return err
 	}
 
-	// Set the colors
-	c.BgDark = lipgloss.Color(walColorscheme["special"].(map[string]interface{})["background"].(string))
-	c.BgDarker = lipgloss.Color(walColorscheme["special"].(map[string]interface{})["background"].(string))
-	c.Text = lipgloss.Color(walColorscheme["special"].(map[string]interface{})["foreground"].(string))
-	c.TextDark = lipgloss.Color(walColorscheme["special"].(map[string]interface{})["foreground"].(string))
-	c.Color1 = lipgloss.Color(walColorscheme["colors"].(map[string]interface{})["color1"].(string))
-	c.Color2 = lipgloss.Color(walColorscheme["colors"].(map[string]interface{})["color2"].(string))
-	c.Color3 = lipgloss.Color(walColorscheme["colors"].(map[string]interface{})["color3"].(string))
-	c.Color4 = lipgloss.Color(walColorscheme["colors"].(map[string]interface{})["color4"].(string))
-	c.Color5 = lipgloss.Color(walColorscheme["colors"].(map[string]interface{})["color5"].(string))
-	c.Color6 = lipgloss.Color(walColorscheme["colors"].(map[string]interface{})["color6"].(string))
-	c.Color7 = lipgloss.Color(walColorscheme["colors"].(map[string]interface{})["color7"].(string))
+	for _, name := range []string{"color1", "color2", "color3", "color4", "color5", "color6", "color7"} {
+		if wal.Colors[name] == "" {
+			return fmt.Errorf("the pywal file doesn't have %s", name)
+		}
+	}
 
+	if wal.Special.Background == "" || wal.Special.Foreground == "" {
+		return errors.New("the pywal file doesn't have the background and the foreground")
+	}
+
+	// Set the colors, the dimmed text uses the bright black if there is one
+	c.BgDark = lipgloss.Color(wal.Special.Background)
+	c.BgDarker = lipgloss.Color(wal.Special.Background)
+	c.Text = lipgloss.Color(wal.Special.Foreground)
+	c.TextDark = lipgloss.Color(wal.Special.Foreground)
+	if wal.Colors["color8"] != "" {
+		c.TextDark = lipgloss.Color(wal.Colors["color8"])
+	}
+
+	c.Color1 = lipgloss.Color(wal.Colors["color1"])
+	c.Color2 = lipgloss.Color(wal.Colors["color2"])
+	c.Color3 = lipgloss.Color(wal.Colors["color3"])
+	c.Color4 = lipgloss.Color(wal.Colors["color4"])
+	c.Color5 = lipgloss.Color(wal.Colors["color5"])
+	c.Color6 = lipgloss.Color(wal.Colors["color6"])
+	c.Color7 = lipgloss.Color(wal.Colors["color7"])
+	c.genMarkdownStyle()
 	return nil
 }
 
@@ -155,6 +180,16 @@ func (c Colors) PrettyPrint() string {
 	return strings.Join(result, "\n")
 }
 
+// pywalPath returns the path of the colors file generated by pywal
+func pywalPath() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(cacheDir, "wal", "colors.json"), nil
+}
+
 // getDefaultPath returns the default path for the colorscheme file
 func getDefaultPath() (string, error) {
 	// Get the default config path
@@ -169,6 +204,11 @@ func getDefaultPath() (string, error) {
 
 // generateMarkDownStyle generates the markdown style from the colorscheme
 func (c *Colors) genMarkdownStyle() {
+	if NoColor {
+		c.MarkdownStyle = glamour.ASCIIStyleConfig
+		return
+	}
+
 	c.MarkdownStyle = ansi.StyleConfig{
 		Document: ansi.StyleBlock{
 			StylePrimitive: ansi.StylePrimitive{
@@ -223,7 +263,7 @@ func (c *Colors) genMarkdownStyle() {
 		},
 		Enumeration: ansi.StylePrimitive{
 			BlockPrefix: ". ",
-			Color:       stringPtr("#8be9fd"),
+			Color:       stringPtr(string(c.Color3)),
 		},
 		Task: ansi.StyleTask{
 			StylePrimitive: ansi.StylePrimitive{},
@@ -359,6 +399,17 @@ func (c *Colors) genMarkdownStyle() {
 			BlockPrefix: "\n🠶 ",
 		},
 	}
+
+	// The syntax highlighting only understands hex colors, the code isn't highlighted with the
+	// colors of the terminal palette
+	for _, color := range []lipgloss.Color{c.BgDark, c.Text, c.TextDark, c.Color1, c.Color2, c.Color3, c.Color4, c.Color5, c.Color6} {
+		if !strings.HasPrefix(string(color), "#") {
+			c.MarkdownStyle.CodeBlock.Chroma = nil
+			break
+		}
+	}
+
+	useChromaTheme(&c.MarkdownStyle.CodeBlock)
 }
 
 func boolPtr(b bool) *bool       { return &b }
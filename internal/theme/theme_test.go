@@ -1,7 +1,17 @@
 package theme
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/alecthomas/chroma"
+	"github.com/alecthomas/chroma/styles"
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/lipgloss"
 )
 
 // TestThemeLoadNoFile if we get an error then the default theme is not generated
@@ -35,3 +45,261 @@ func TestPywalConvert(t *testing.T) {
 		t.Errorf("Theme not converted correctly")
 	}
 }
+
+// TestThemeSetTheme if we get an error then the named themes aren't loaded from their files
+func TestThemeSetTheme(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "themes"), 0755); err != nil {
+		t.Fatalf("couldn't create the themes directory: %v", err)
+	}
+
+	files := map[string]string{
+		"colorscheme.json":  `{"text": "#000001"}`,
+		"themes/paper.toml": "# A light theme\n[colors]\ntext = \"#000002\" # the text\nbg_dark = '#ffffff'\n",
+		"themes/ink.yaml":   "text: \"#000003\"\n",
+		"themes/nord.json":  `{"text": "#000004"}`,
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatalf("couldn't write %s: %v", name, err)
+		}
+	}
+
+	colors, err := New(filepath.Join(dir, "colorscheme.json"))
+	if err != nil {
+		t.Fatalf("couldn't create the theme: %v", err)
+	}
+
+	t.Setenv("XDG_CACHE_HOME", dir)
+	expected := []string{CustomName, "default", "gruvbox", "ink", "nord", "paper", "terminal"}
+	if names := colors.Themes(); strings.Join(names, ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected the themes %v, got %v", expected, names)
+	}
+
+	for name, text := range map[string]lipgloss.Color{"paper": "#000002", "ink": "#000003", "nord": "#000004", CustomName: "#000001", "gruvbox": Builtin["gruvbox"].Text} {
+		if err = colors.SetTheme(name); err != nil {
+			t.Fatalf("couldn't set the theme %s: %v", name, err)
+		}
+
+		if colors.Text != text || colors.Name != name {
+			t.Fatalf("expected the text color %s of the theme %s, got %s", text, name, colors.Text)
+		}
+	}
+
+	if err = colors.SetTheme("paper"); err != nil || colors.BgDark != "#ffffff" || colors.Color1 != Default.Color1 {
+		t.Fatalf("expected the missing colors of the theme to be the default ones, got %s and %s, %v", colors.BgDark, colors.Color1, err)
+	}
+
+	if err = colors.SetTheme("missing"); !errors.Is(err, ErrUnknownTheme) {
+		t.Fatalf("expected an unknown theme error, got %v", err)
+	}
+
+	if err = os.WriteFile(filepath.Join(dir, "themes/paper.toml"), []byte("text = \"#000005\"\n"), 0600); err != nil {
+		t.Fatalf("couldn't change the theme: %v", err)
+	}
+
+	if err = colors.Reload(); err != nil || colors.Text != "#000005" {
+		t.Fatalf("expected the changed theme to be reloaded, got %s, %v", colors.Text, err)
+	}
+}
+
+// TestThemePywal if we get an error then the pywal theme doesn't follow the colors generated by pywal
+func TestThemePywal(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", dir)
+	colors, err := New(filepath.Join(dir, "colorscheme.json"))
+	if err != nil {
+		t.Fatalf("couldn't create the theme: %v", err)
+	}
+
+	if err = colors.SetTheme(PywalName); err == nil {
+		t.Fatal("expected an error without the pywal colors")
+	}
+
+	content, err := os.ReadFile("../test/data/pywal.json")
+	if err != nil {
+		t.Fatalf("couldn't read the pywal colors: %v", err)
+	}
+
+	if err = os.MkdirAll(filepath.Join(dir, "wal"), 0755); err != nil {
+		t.Fatalf("couldn't create the pywal directory: %v", err)
+	}
+
+	if err = os.WriteFile(filepath.Join(dir, "wal", "colors.json"), content, 0600); err != nil {
+		t.Fatalf("couldn't write the pywal colors: %v", err)
+	}
+
+	if names := strings.Join(colors.Themes(), ","); !strings.Contains(names, PywalName) {
+		t.Fatalf("expected the pywal theme to be listed, got %s", names)
+	}
+
+	if err = colors.SetTheme(PywalName); err != nil {
+		t.Fatalf("couldn't set the pywal theme: %v", err)
+	}
+
+	if colors.BgDark != "#040612" || colors.TextDark != "#6a8e9a" || colors.Color5 != "#1E5AA6" {
+		t.Fatalf("expected the pywal colors, got %s, %s and %s", colors.BgDark, colors.TextDark, colors.Color5)
+	}
+
+	if err = os.WriteFile(filepath.Join(dir, "wal", "colors.json"), []byte(`{"colors": {}}`), 0600); err != nil {
+		t.Fatalf("couldn't write the pywal colors: %v", err)
+	}
+
+	if err = colors.Reload(); err == nil {
+		t.Fatal("expected an error for incomplete pywal colors")
+	}
+}
+
+// TestThemeTerminal if we get an error then the articles can't be rendered with the terminal palette
+func TestThemeTerminal(t *testing.T) {
+	colors, err := New(filepath.Join(t.TempDir(), "colorscheme.json"))
+	if err != nil {
+		t.Fatalf("couldn't create the theme: %v", err)
+	}
+
+	if err = colors.SetTheme("terminal"); err != nil {
+		t.Fatalf("couldn't set the terminal theme: %v", err)
+	}
+
+	renderer, err := glamour.NewTermRenderer(glamour.WithStyles(colors.MarkdownStyle))
+	if err != nil {
+		t.Fatalf("couldn't create the renderer: %v", err)
+	}
+
+	if _, err = renderer.Render("# Title\n\n```go\nfunc main() {}\n```\n"); err != nil {
+		t.Fatalf("couldn't render with the terminal palette: %v", err)
+	}
+}
+
+// TestThemeBase16 if we get an error then the base16 schemes aren't mapped to the colors
+func TestThemeBase16(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "themes"), 0755); err != nil {
+		t.Fatalf("couldn't create the themes directory: %v", err)
+	}
+
+	bases := []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9", "A", "B", "C", "D", "E", "F"}
+	classic := "scheme: \"Classic\"\nauthor: \"Someone\"\n"
+	palette := "system: \"base16\"\nname: \"Palette\"\npalette:\n"
+	for i, base := range bases {
+		classic += fmt.Sprintf("base0%s: \"%06x\"\n", base, i)
+		palette += fmt.Sprintf("  base0%s: \"#%06x\"\n", base, i+16)
+	}
+
+	files := map[string]string{"classic.yaml": classic, "palette.yml": palette, "broken.yaml": "base00: \"123\"\n"}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, "themes", name), []byte(content), 0600); err != nil {
+			t.Fatalf("couldn't write %s: %v", name, err)
+		}
+	}
+
+	colors, err := New(filepath.Join(dir, "colorscheme.json"))
+	if err != nil {
+		t.Fatalf("couldn't create the theme: %v", err)
+	}
+
+	if err = colors.SetTheme("classic"); err != nil {
+		t.Fatalf("couldn't set the classic base16 scheme: %v", err)
+	}
+
+	if colors.BgDarker != "#000000" || colors.Text != "#000005" || colors.Color1 != "#00000e" || colors.Color7 != "#00000c" {
+		t.Fatalf("expected the base16 colors, got %s, %s, %s and %s", colors.BgDarker, colors.Text, colors.Color1, colors.Color7)
+	}
+
+	if err = colors.SetTheme("palette"); err != nil {
+		t.Fatalf("couldn't set the base16 scheme with a palette: %v", err)
+	}
+
+	if colors.BgDark != "#000011" || colors.Color4 != "#000018" {
+		t.Fatalf("expected the base16 colors from the palette, got %s and %s", colors.BgDark, colors.Color4)
+	}
+
+	if err = colors.SetTheme("broken"); err == nil {
+		t.Fatal("expected an error for a base16 scheme with missing colors")
+	}
+}
+
+// TestThemeStripStyles if we get an error then the no-color mode leaves colors or italics behind
+func TestThemeStripStyles(t *testing.T) {
+	tests := map[string]string{
+		"\x1b[1;3mtitle\x1b[0m":                   "\x1b[1mtitle\x1b[0m",
+		"\x1b[38;5;60mdim\x1b[39m":                "dim",
+		"\x1b[48;2;1;2;3;4mcell\x1b[m":            "\x1b[4mcell\x1b[m",
+		"\x1b[2;7;31mreverse\x1b[0m":              "\x1b[7mreverse\x1b[0m",
+		"plain \x1b[1mbold\x1b[22m \x1b[97mwhite": "plain \x1b[1mbold\x1b[22m white",
+	}
+
+	for input, expected := range tests {
+		if got := StripStyles(input); got != expected {
+			t.Errorf("expected %q, got %q", expected, got)
+		}
+	}
+}
+
+// TestThemePlainText if we get an error then the screen readers read out the borders and the icons
+func TestThemePlainText(t *testing.T) {
+	input := "┌────┐\n│\x1b[3mitem\x1b[0m│\n└────┘\n ⮡  Feed"
+	expected := "      \n item\x1b[0m \n      \n     Feed"
+	if got := PlainText(input); got != expected {
+		t.Fatalf("expected %q, got %q", expected, got)
+	}
+}
+
+// TestThemeSyntaxHighlighting if we get an error then the code blocks don't follow the colorscheme
+func TestThemeSyntaxHighlighting(t *testing.T) {
+	defer func() { SyntaxHighlighting = true }()
+
+	colors, err := New(filepath.Join(t.TempDir(), "colorscheme.json"))
+	if err != nil {
+		t.Fatalf("couldn't create the theme: %v", err)
+	}
+
+	keyword := func() string {
+		return styles.Get(colors.MarkdownStyle.CodeBlock.Theme).Get(chroma.Keyword).Colour.String()
+	}
+
+	if colors.MarkdownStyle.CodeBlock.Theme != chromaTheme || keyword() != strings.ToLower(string(Default.Color1)) {
+		t.Fatalf("expected the keywords in the color %s, got %s", Default.Color1, keyword())
+	}
+
+	if err = colors.SetTheme("gruvbox"); err != nil {
+		t.Fatalf("couldn't set the theme: %v", err)
+	}
+
+	if keyword() != string(Builtin["gruvbox"].Color1) {
+		t.Fatalf("expected the keywords in the color of the new theme %s, got %s", Builtin["gruvbox"].Color1, keyword())
+	}
+
+	DisableSyntaxHighlighting(colors)
+	if colors.MarkdownStyle.CodeBlock.Theme != "" || colors.MarkdownStyle.CodeBlock.Chroma != nil {
+		t.Fatal("expected the code blocks not to be highlighted")
+	}
+}
+
+// TestThemeSetIcons if we get an error then the icon sets can't be switched or aren't plain ASCII
+func TestThemeSetIcons(t *testing.T) {
+	defer func() { Icons = DefaultIcons }()
+
+	if err := SetIcons("nerdfont"); err != nil || Icons != NerdFontIcons {
+		t.Fatalf("expected the nerd font icons, got %v", err)
+	}
+
+	if err := SetIcons("ascii"); err != nil || Icons != ASCIIIcons {
+		t.Fatalf("expected the ascii icons, got %v", err)
+	}
+
+	for _, r := range fmt.Sprintf("%v", Icons) {
+		if r > 127 {
+			t.Errorf("expected only ascii characters, got %q", r)
+		}
+	}
+
+	if err := SetIcons("emoji"); err == nil {
+		t.Error("expected an error with an unknown icon set")
+	}
+
+	if err := SetIcons(""); err != nil || Icons != DefaultIcons {
+		t.Errorf("expected the default icons, got %v", err)
+	}
+}
@@ -0,0 +1,296 @@
+package theme
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/charmbracelet/lipgloss"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// CustomName is the name of the theme loaded from the colorscheme file
+	CustomName = "custom"
+
+	// PywalName is the name of the theme converted from the colors generated by pywal, it's
+	// converted again every time it's loaded so it follows the wallpaper
+	PywalName = "pywal"
+)
+
+// ErrUnknownTheme is returned when there is no theme with the name
+var ErrUnknownTheme = errors.New("unknown theme")
+
+// extensions are the extensions of the theme files, in the order they are looked for
+var extensions = []string{".json", ".toml", ".yml", ".yaml"}
+
+// Builtin are the themes which don't need a file
+var Builtin = map[string]Colors{
+	"default": Default,
+	"gruvbox": {
+		BgDark:   "#282828",
+		BgDarker: "#1d2021",
+		Text:     "#ebdbb2",
+		TextDark: "#928374",
+		Color1:   "#d3869b",
+		Color2:   "#fabd2f",
+		Color3:   "#83a598",
+		Color4:   "#fb4934",
+		Color5:   "#b8bb26",
+		Color6:   "#fe8019",
+		Color7:   "#8ec07c",
+	},
+	// The terminal theme uses the default colors and the ANSI palette of the terminal
+	"terminal": {
+		BgDark:   "",
+		BgDarker: "",
+		Text:     "",
+		TextDark: "8",
+		Color1:   "5",
+		Color2:   "3",
+		Color3:   "4",
+		Color4:   "1",
+		Color5:   "2",
+		Color6:   "11",
+		Color7:   "13",
+	},
+	"nord": {
+		BgDark:   "#3b4252",
+		BgDarker: "#2e3440",
+		Text:     "#eceff4",
+		TextDark: "#7b88a1",
+		Color1:   "#b48ead",
+		Color2:   "#ebcb8b",
+		Color3:   "#88c0d0",
+		Color4:   "#bf616a",
+		Color5:   "#a3be8c",
+		Color6:   "#d08770",
+		Color7:   "#8fbcbb",
+	},
+}
+
+// Themes returns the names of the themes which can be chosen, the built-in ones and the ones in
+// the themes directory next to the colorscheme file
+func (c Colors) Themes() []string {
+	names := []string{CustomName}
+	seen := map[string]bool{CustomName: true}
+	for name := range Builtin {
+		seen[name] = true
+		names = append(names, name)
+	}
+
+	if path, err := pywalPath(); err == nil {
+		if _, err = os.Stat(path); err == nil {
+			seen[PywalName] = true
+			names = append(names, PywalName)
+		}
+	}
+
+	entries, _ := os.ReadDir(c.themesDir())
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		name := strings.TrimSuffix(entry.Name(), ext)
+		if !entry.IsDir() && supported(ext) && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names[1:])
+	return names
+}
+
+// SetTheme replaces the colors with the theme with the name, the files in the themes directory
+// take precedence over the built-in themes. The custom theme is read from the colorscheme file
+func (c *Colors) SetTheme(name string) error {
+	colors := Default
+	switch path, found := c.findTheme(name); {
+	case name == CustomName:
+		colors.FilePath = c.FilePath
+		if err := colors.Load(); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+
+	case name == PywalName && !found:
+		if err := colors.Convert(""); err != nil {
+			return fmt.Errorf("converting the pywal colors: %w", err)
+		}
+
+	case found:
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		if err = decode(path, content, &colors); err != nil {
+			return fmt.Errorf("parsing %s: %w", path, err)
+		}
+
+	default:
+		builtin, ok := Builtin[name]
+		if !ok {
+			return fmt.Errorf("%w %q", ErrUnknownTheme, name)
+		}
+
+		colors = builtin
+	}
+
+	colors.FilePath = c.FilePath
+	colors.Name = name
+	colors.genMarkdownStyle()
+	*c = colors
+	return nil
+}
+
+// Reload reads the theme again, so that the changes to its file are shown without restarting
+func (c *Colors) Reload() error {
+	name := c.Name
+	if name == "" {
+		name = CustomName
+	}
+
+	return c.SetTheme(name)
+}
+
+// themesDir returns the directory with the theme files
+func (c Colors) themesDir() string {
+	return filepath.Join(filepath.Dir(c.FilePath), "themes")
+}
+
+// findTheme returns the path of the theme file with the name
+func (c Colors) findTheme(name string) (string, bool) {
+	for _, ext := range extensions {
+		path := filepath.Join(c.themesDir(), name+ext)
+		if _, err := os.Stat(path); err == nil {
+			return path, true
+		}
+	}
+
+	return "", false
+}
+
+// supported returns whether the theme files with the extension can be read
+func supported(ext string) bool {
+	for _, known := range extensions {
+		if ext == known {
+			return true
+		}
+	}
+
+	return false
+}
+
+// decode parses the theme file based on its extension, the keys are the same in every format
+func decode(path string, content []byte, c *Colors) error {
+	switch filepath.Ext(path) {
+	case ".toml":
+		values, err := parseTOML(content)
+		if err != nil {
+			return err
+		}
+
+		// The keys of the flat table are the json keys of the colors
+		data, err := json.Marshal(values)
+		if err != nil {
+			return err
+		}
+
+		return json.Unmarshal(data, c)
+
+	case ".yml", ".yaml":
+		var scheme base16Scheme
+		if err := yaml.Unmarshal(content, &scheme); err == nil && scheme.palette()["base00"] != "" {
+			return scheme.apply(c)
+		}
+
+		return yaml.Unmarshal(content, c)
+	}
+
+	return json.Unmarshal(content, c)
+}
+
+// base16Scheme is a base16 scheme file, the colors are at the top level in the classic schemes
+// and in the palette in the newer ones
+type base16Scheme struct {
+	Palette map[string]string `yaml:"palette"`
+	Colors  map[string]string `yaml:",inline"`
+}
+
+// palette returns the base00 to base0F colors of the scheme
+func (s base16Scheme) palette() map[string]string {
+	if len(s.Palette) > 0 {
+		return s.Palette
+	}
+
+	return s.Colors
+}
+
+// apply maps the base16 colors to the colors of goread, the backgrounds and the text use the
+// grayscale bases and the accents use the colors of the syntax highlighting
+func (s base16Scheme) apply(c *Colors) error {
+	palette := s.palette()
+	roles := []struct {
+		color *lipgloss.Color
+		base  string
+	}{
+		{&c.BgDarker, "base00"},
+		{&c.BgDark, "base01"},
+		{&c.TextDark, "base03"},
+		{&c.Text, "base05"},
+		{&c.Color1, "base0E"},
+		{&c.Color2, "base0A"},
+		{&c.Color3, "base0D"},
+		{&c.Color4, "base08"},
+		{&c.Color5, "base0B"},
+		{&c.Color6, "base09"},
+		{&c.Color7, "base0C"},
+	}
+
+	for _, role := range roles {
+		value := strings.TrimPrefix(strings.TrimSpace(palette[role.base]), "#")
+		if len(value) != 6 {
+			return fmt.Errorf("the base16 scheme doesn't have a valid %s", role.base)
+		}
+
+		*role.color = lipgloss.Color("#" + value)
+	}
+
+	return nil
+}
+
+// parseTOML returns the string values of a TOML theme, the keys of the tables are flattened since
+// all the colors are on the same level
+func parseTOML(content []byte) (map[string]string, error) {
+	var doc map[string]interface{}
+	if err := toml.Unmarshal(content, &doc); err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string)
+	flattenTOML(doc, values)
+	return values, nil
+}
+
+// flattenTOML collects the string values of the table and the tables nested in it, the keys are
+// visited in order so that the same key in two tables always gets the same value
+func flattenTOML(table map[string]interface{}, values map[string]string) {
+	keys := make([]string, 0, len(table))
+	for key := range table {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	for _, key := range keys {
+		switch value := table[key].(type) {
+		case string:
+			values[key] = value
+		case map[string]interface{}:
+			flattenTOML(value, values)
+		}
+	}
+}
@@ -5,27 +5,84 @@ import (
 	"log"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/TypicalAM/goread/internal/backend"
+	"github.com/TypicalAM/goread/internal/backend/cache"
+	"github.com/TypicalAM/goread/internal/backend/query"
 	"github.com/TypicalAM/goread/internal/backend/rss"
+	"github.com/TypicalAM/goread/internal/ipc"
 	"github.com/TypicalAM/goread/internal/theme"
+	"github.com/TypicalAM/goread/internal/ui/favicon"
 	"github.com/TypicalAM/goread/internal/ui/popup"
 	"github.com/TypicalAM/goread/internal/ui/tab"
+	"github.com/TypicalAM/goread/internal/ui/tab/article"
 	"github.com/TypicalAM/goread/internal/ui/tab/category"
+	"github.com/TypicalAM/goread/internal/ui/tab/downloads"
 	"github.com/TypicalAM/goread/internal/ui/tab/feed"
+	"github.com/TypicalAM/goread/internal/ui/tab/health"
 	"github.com/TypicalAM/goread/internal/ui/tab/overview"
+	"github.com/TypicalAM/goread/internal/ui/tab/tags"
 
+	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/reflow/truncate"
 )
 
+// DefaultRefreshInterval is the interval of the background refresh, zero disables it
+var DefaultRefreshInterval time.Duration
+
+// AllowDuplicateTabs opens a new tab every time, otherwise the tab which is open already is focused
+var AllowDuplicateTabs bool
+
+// messageTimeout is how long a message stays in the status bar
+const messageTimeout = 5 * time.Second
+
+// clearMessageMsg is sent when the message shown in the status bar expires, the id tells if it
+// was replaced by a newer one in the meantime
+type clearMessageMsg struct{ id int }
+
+// refreshTickMsg is sent when the background refresh should start
+type refreshTickMsg struct{}
+
+// refreshAllMsg is sent when all the feeds should be refreshed right away
+type refreshAllMsg struct{}
+
+// toggleOfflineMsg, toggleZenMsg, showHelpMsg, closeTabMsg, pinTabMsg, showDownloadsMsg, showHealthMsg,
+// showTagsMsg, nextUnreadFeedMsg and saveSearchMsg run the browser actions from the command palette
+type (
+	toggleOfflineMsg  struct{}
+	toggleZenMsg      struct{}
+	showHelpMsg       struct{}
+	closeTabMsg       struct{}
+	pinTabMsg         struct{}
+	showDownloadsMsg  struct{}
+	showHealthMsg     struct{}
+	showTagsMsg       struct{}
+	nextUnreadFeedMsg struct{}
+	saveSearchMsg     struct{}
+)
+
+// focusTabMsg is sent when a tab should be focused
+type focusTabMsg struct{ index int }
+
 // Keymap contains the key bindings for the browser
 type Keymap struct {
 	CloseTab          key.Binding
 	CycleTabs         key.Binding
 	ShowHelp          key.Binding
 	ToggleOfflineMode key.Binding
+	CommandPalette    key.Binding
+	CommandLine       key.Binding
+	NextUnreadFeed    key.Binding
+	ToggleZenMode     key.Binding
+	PauseSpeech       key.Binding
+	StopSpeech        key.Binding
+	MoveTabLeft       key.Binding
+	MoveTabRight      key.Binding
+	PinTab            key.Binding
 }
 
 // DefaultKeymap contains the default key bindings for the browser
@@ -43,8 +100,44 @@ var DefaultKeymap = Keymap{
 		key.WithHelp("h", "Help"),
 	),
 	ToggleOfflineMode: key.NewBinding(
-		key.WithKeys("o", "ctrl+o"),
-		key.WithHelp("o", "Offline mode"),
+		key.WithKeys("ctrl+o"),
+		key.WithHelp("ctrl+o", "Offline mode"),
+	),
+	CommandPalette: key.NewBinding(
+		key.WithKeys("ctrl+p"),
+		key.WithHelp("ctrl+p", "Commands"),
+	),
+	CommandLine: key.NewBinding(
+		key.WithKeys(":"),
+		key.WithHelp(":", "Command line"),
+	),
+	NextUnreadFeed: key.NewBinding(
+		key.WithKeys("]"),
+		key.WithHelp("]", "Next unread feed"),
+	),
+	ToggleZenMode: key.NewBinding(
+		key.WithKeys("z"),
+		key.WithHelp("z", "Zen mode"),
+	),
+	PauseSpeech: key.NewBinding(
+		key.WithKeys("P"),
+		key.WithHelp("P", "Pause reading aloud"),
+	),
+	StopSpeech: key.NewBinding(
+		key.WithKeys("X"),
+		key.WithHelp("X", "Stop reading aloud"),
+	),
+	MoveTabLeft: key.NewBinding(
+		key.WithKeys("<"),
+		key.WithHelp("<", "Move tab left"),
+	),
+	MoveTabRight: key.NewBinding(
+		key.WithKeys(">"),
+		key.WithHelp(">", "Move tab right"),
+	),
+	PinTab: key.NewBinding(
+		key.WithKeys("!"),
+		key.WithHelp("!", "Pin tab"),
 	),
 }
 
@@ -54,22 +147,41 @@ func (k *Keymap) SetEnabled(enabled bool) {
 	k.CycleTabs.SetEnabled(enabled)
 	k.ShowHelp.SetEnabled(enabled)
 	k.ToggleOfflineMode.SetEnabled(enabled)
+	k.CommandPalette.SetEnabled(enabled)
+	k.CommandLine.SetEnabled(enabled)
+	k.NextUnreadFeed.SetEnabled(enabled)
+	k.ToggleZenMode.SetEnabled(enabled)
+	k.PauseSpeech.SetEnabled(enabled)
+	k.StopSpeech.SetEnabled(enabled)
+	k.MoveTabLeft.SetEnabled(enabled)
+	k.MoveTabRight.SetEnabled(enabled)
+	k.PinTab.SetEnabled(enabled)
 }
 
 // Model is used to store the state of the application
 type Model struct {
 	popup          tea.Model
+	cmdLine        cmdLine
 	backend        *backend.Backend
+	lastRefresh    time.Time
 	style          style
 	msg            string
+	msgID          int
+	unread         backend.UnreadCountMsg
 	keymap         Keymap
 	tabs           []tab.Tab
 	activeTab      int
+	pinned         int
 	height         int
 	width          int
 	waitingForSize bool
 	quitting       bool
+	commandMode    bool
 	offline        bool
+	zen            bool
+	refreshing     bool
+	requests       <-chan ipc.Request
+	pendingMove    *backend.FeedMovedMsg
 }
 
 // New returns a new model with some sensible defaults
@@ -79,19 +191,51 @@ func New(colors *theme.Colors, backend *backend.Backend) Model {
 	return Model{
 		style:          newStyle(colors),
 		backend:        backend,
+		cmdLine:        newCmdLine(colors),
 		waitingForSize: true,
 		keymap:         DefaultKeymap,
-		msg:            "Pro-tip - press [ctrl+h] to view the help page",
 	}
 }
 
 // Init initializes the model
 func (m Model) Init() tea.Cmd {
-	return nil
+	if m.requests != nil {
+		return tea.Batch(scheduleRefresh(), waitForRequest(m.requests))
+	}
+
+	return scheduleRefresh()
 }
 
-// Update handles the terminal size, modifying rss items and modifying tabs
+// Update handles the messages, a new message in the status bar is cleared after a while
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(clearMessageMsg); ok {
+		if msg.id == m.msgID {
+			m.msg = ""
+		}
+
+		return m, nil
+	}
+
+	previous := m.msg
+	updated, cmd := m.update(msg)
+	model, ok := updated.(Model)
+	if !ok || model.msg == "" || model.msg == previous {
+		return updated, cmd
+	}
+
+	model.msgID++
+	id := model.msgID
+	return model, tea.Batch(cmd, tea.Tick(messageTimeout, func(time.Time) tea.Msg {
+		return clearMessageMsg{id}
+	}))
+}
+
+// update handles the terminal size, modifying rss items and modifying tabs
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(remoteRequestMsg); ok {
+		return m.handleRemote(msg.req)
+	}
+
 	if m.waitingForSize {
 		return m.waitForSize(msg)
 	}
@@ -111,6 +255,170 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.msg = fmt.Sprintf("%s: %s", msg.Description, msg.Err.Error())
 		return m, nil
 
+	case backend.FetchProgressMsg:
+		m.msg = fmt.Sprintf("Fetched %d/%d feeds", msg.Done, msg.Total)
+		if msg.Err != nil {
+			log.Printf("Error fetching %s: %v\n", rss.StripCredentials(msg.URL), msg.Err)
+		}
+
+		return m, msg.Next
+
+	case backend.VacuumedMsg:
+		m.msg = fmt.Sprintf("Removed %d articles and %d old feeds from the cache", msg.Collected.Articles, msg.Collected.Feeds)
+		return m, nil
+
+	case refreshTickMsg:
+		if m.offline || m.refreshing {
+			return m, scheduleRefresh()
+		}
+
+		m.refreshing = true
+		return m, tea.Batch(m.backend.RefreshFeeds(false), scheduleRefresh())
+
+	case refreshAllMsg:
+		switch {
+		case m.offline:
+			m.msg = "Error refreshing: offline mode is enabled"
+		case m.refreshing:
+			m.msg = "The feeds are already being refreshed"
+		default:
+			m.refreshing = true
+			m.msg = "Refreshing all feeds"
+			return m, m.backend.RefreshFeeds(true)
+		}
+
+		return m, nil
+
+	case themePickerMsg:
+		return m.showThemePicker()
+
+	case themePreviewMsg:
+		return m.previewTheme(msg)
+
+	case themeChosenMsg:
+		return m.setTheme(msg.name)
+
+	case themeReloadMsg:
+		return m.reloadTheme()
+
+	case commandChosenMsg:
+		m.popup = nil
+		m.keymap.SetEnabled(true)
+		return m.update(msg.msg)
+
+	case cmdLineDoneMsg:
+		m.commandMode = false
+		m.keymap.SetEnabled(true)
+		if msg.line == "" {
+			return m, nil
+		}
+
+		return m.runCommand(msg.line)
+
+	case toggleOfflineMsg:
+		return m.toggleOffline()
+
+	case toggleZenMsg:
+		return m.toggleZen()
+
+	case saveSearchMsg:
+		return m.saveSearch("")
+
+	case showHelpMsg:
+		return m.showHelp()
+
+	case closeTabMsg:
+		return m.closeTab()
+
+	case pinTabMsg:
+		return m.togglePin()
+
+	case showDownloadsMsg:
+		return m.showDownloads()
+
+	case showHealthMsg:
+		return m.showHealth()
+
+	case showTagsMsg:
+		return m.showTags("")
+
+	case nextUnreadFeedMsg:
+		return m.nextUnreadFeed()
+
+	case pauseSpeechMsg:
+		return m.pauseSpeech()
+
+	case stopSpeechMsg:
+		return m.stopSpeech()
+
+	case focusTabMsg:
+		if msg.index >= 0 && msg.index < len(m.tabs) {
+			m.activeTab = msg.index
+			m.msg = ""
+		}
+
+		return m, m.reloadActiveTab()
+
+	case backend.RefreshedMsg:
+		m.refreshing = false
+		m.lastRefresh = msg.Time
+		log.Println("Refreshed feeds in the background")
+
+		for i := range m.tabs {
+			updated, _ := m.tabs[i].Update(tab.RefreshMsg{Active: false})
+			m.tabs[i] = updated.(tab.Tab)
+		}
+
+		return m, tea.Batch(m.reloadActiveTab(), m.backend.CountUnread(), m.backend.CheckMoved())
+
+	case backend.FeedMovedMsg:
+		// The question waits for the next fetch if something else is open
+		if m.popup != nil || m.commandMode || m.pendingMove != nil {
+			return m, nil
+		}
+
+		m.pendingMove = &msg
+		m.msg = fmt.Sprintf("%s moved to %s", msg.Name, msg.NewURL)
+		log.Println(m.msg)
+
+		bg := m.View()
+		width := m.width / 2
+		m.popup = popup.NewChoice(m.style.colors, bg, width, "The feed "+msg.Name+" moved, update it?", true)
+
+		m.keymap.SetEnabled(false)
+		return m, m.popup.Init()
+
+	case backend.UnreadCountMsg:
+		m.unread = msg
+		for i := range m.tabs {
+			updated, _ := m.tabs[i].Update(msg)
+			m.tabs[i] = updated.(tab.Tab)
+		}
+
+		return m, nil
+
+	case backend.ShowMessageMsg:
+		m.msg = string(msg)
+		log.Println(m.msg)
+		return m, nil
+
+	case backend.ReadStatusChangedMsg:
+		return m, m.backend.CountUnread()
+
+	case backend.FetchArticleSuccessMsg:
+		// The articles might have been fetched for the first time, the tab which asked for them
+		// might not be the active one anymore
+		var cmd tea.Cmd
+		for i := range m.tabs {
+			if _, ok := m.tabs[i].(feed.Model); ok && m.tabs[i].Title() == msg.FeedName {
+				updated, tabCmd := m.tabs[i].Update(msg)
+				m.tabs[i] = updated.(tab.Tab)
+				cmd = tea.Batch(cmd, tabCmd)
+			}
+		}
+
+		return m, tea.Batch(cmd, m.backend.CountUnread(), m.backend.CheckMoved())
+
 	case overview.ChosenCategoryMsg:
 		m.popup = nil
 		m.keymap.SetEnabled(true)
@@ -136,23 +444,195 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.popup = nil
 		m.keymap.SetEnabled(true)
 
-		if msg.IsEdit {
-			if err := m.backend.Rss.UpdateFeed(msg.Parent, msg.OldName, msg.Name, msg.URL); err != nil {
-				m.msg = fmt.Sprintf("Error updating feed: %s", err.Error())
-			} else {
-				m.msg = fmt.Sprintf("Updated feed %s", msg.Name)
+		// The query feeds don't point to a website, only their expression is checked
+		isQuery := strings.HasPrefix(msg.URL, rss.QueryPrefix)
+		if isQuery {
+			if _, err := query.Parse(strings.TrimPrefix(msg.URL, rss.QueryPrefix)); err != nil {
+				m.msg = fmt.Sprintf("Error in the query: %s", err.Error())
+				return m, nil
 			}
-		} else {
-			if err := m.backend.Rss.AddFeed(msg.Parent, msg.Name, msg.URL); err != nil {
-				m.msg = fmt.Sprintf("Error adding feed: %s", err.Error())
-			} else {
-				m.msg = fmt.Sprintf("Added feed %s", msg.Name)
+		}
+
+		// The shorthands like r/golang already point to a feed
+		feedURL, _, expanded := rss.ExpandShorthand(msg.URL)
+		if expanded && !isQuery {
+			msg.URL = feedURL
+		}
+
+		if !msg.IsEdit {
+			if m.offline || isQuery || expanded || rss.IsExec(msg.URL) || rss.IsMailbox(msg.URL) || rss.IsGemini(msg.URL) || rss.IsFile(msg.URL) {
+				return m.addFeed(msg.Parent, msg.Name, msg.URL)
 			}
+
+			// Look for the actual feed if the url points to a website
+			m.msg = fmt.Sprintf("Looking for feeds at %s", msg.URL)
+			return m, m.backend.DiscoverFeeds(msg.Parent, msg.Name, msg.URL)
+		}
+
+		if err := m.backend.Rss.UpdateFeed(msg.Parent, msg.OldName, msg.Name, msg.URL); err != nil {
+			m.msg = fmt.Sprintf("Error updating feed: %s", err.Error())
+		} else {
+			m.msg = fmt.Sprintf("Updated feed %s", msg.Name)
 		}
 
 		log.Println(m.msg)
 		return m, m.backend.FetchFeeds(msg.Parent)
 
+	case backend.DiscoveredFeedsMsg:
+		if msg.Feeds = discoverable(msg.Feeds); msg.Err == nil && len(msg.Feeds) == 0 {
+			msg.Err = cache.ErrNoFeeds
+		}
+
+		switch {
+		case msg.Err != nil:
+			log.Printf("Couldn't discover feeds at %s: %v\n", msg.URL, msg.Err)
+			return m.addFeed(msg.Parent, msg.Name, msg.URL)
+
+		case len(msg.Feeds) == 1:
+			return m.addFeed(msg.Parent, msg.Name, msg.Feeds[0].URL)
+		}
+
+		bg := m.View()
+		width := m.width / 2
+		height := 5 + 2*len(msg.Feeds)
+		if height > m.height {
+			height = m.height
+		}
+
+		m.popup = category.NewFeedPicker(m.style.colors, bg, width, height, msg.Name, msg.Parent, msg.Feeds)
+		m.keymap.SetEnabled(false)
+		m.msg = ""
+		return m, m.popup.Init()
+
+	case backend.NewBridgeFeedMsg:
+		m.msg = "Loading the bridges"
+		return m, m.backend.FetchBridges(msg.Parent)
+
+	case backend.BridgesLoadedMsg:
+		if msg.Err != nil {
+			m.msg = fmt.Sprintf("Error loading the bridges: %s", msg.Err.Error())
+			log.Println(m.msg)
+			return m, nil
+		}
+
+		m.msg = ""
+		bg := m.View()
+		m.popup = category.NewBridgePicker(m.style.colors, bg, m.width/2, m.height*3/4, msg.Parent, msg.Bridges)
+		m.keymap.SetEnabled(false)
+		return m, m.popup.Init()
+
+	case category.PickedBridgeMsg:
+		m.popup = nil
+		m.keymap.SetEnabled(true)
+		return m.addFeed(msg.Parent, msg.Name, msg.URL)
+
+	case category.PickedFeedMsg:
+		m.popup = nil
+		m.keymap.SetEnabled(true)
+		if !rss.IsDiscoverable(msg.URL) {
+			m.msg = fmt.Sprintf("Error adding feed: %s isn't a web feed", msg.URL)
+			return m, nil
+		}
+
+		return m.addFeed(msg.Parent, msg.Name, msg.URL)
+
+	case overview.ChosenOPMLMsg:
+		m.popup = nil
+		m.keymap.SetEnabled(true)
+		return m.manageOPML(msg)
+
+	case overview.ChosenSearchMsg:
+		m.popup = nil
+		m.keymap.SetEnabled(true)
+		return m.search(msg.Query)
+
+	case backend.AnnotateMsg:
+		annotation, err := m.backend.Annotation(msg.FeedName, msg.Index)
+		if err != nil {
+			m.msg = fmt.Sprintf("Error annotating the article: %s", err.Error())
+			return m, nil
+		}
+
+		bg := m.View()
+		width := m.width / 2
+		height := 10
+		m.popup = feed.NewNotePopup(m.style.colors, bg, width, height, msg.FeedName, msg.Index, annotation.Tags, annotation.Note)
+
+		m.keymap.SetEnabled(false)
+		return m, m.popup.Init()
+
+	case feed.ChosenNoteMsg:
+		m.popup = nil
+		m.keymap.SetEnabled(true)
+		return m, m.backend.SetAnnotation(msg.FeedName, msg.Index, msg.Tags, msg.Note)
+
+	case backend.SnoozeMsg:
+		bg := m.View()
+		width := m.width / 2
+		height := 14
+		m.popup = feed.NewSnoozePopup(m.style.colors, bg, width, height, msg.FeedName, msg.Index)
+
+		m.keymap.SetEnabled(false)
+		return m, m.popup.Init()
+
+	case feed.ChosenSnoozeMsg:
+		until, err := backend.ParseSnooze(msg.When, time.Now())
+		if err != nil {
+			m.msg = fmt.Sprintf("Error snoozing the article: %s", err.Error())
+			return m, nil
+		}
+
+		m.popup = nil
+		m.keymap.SetEnabled(true)
+		return m, m.backend.SnoozeArticle(msg.FeedName, msg.Index, until)
+
+	case backend.ArticleSnoozedMsg:
+		// The article disappears from all the tabs showing it
+		var cmds []tea.Cmd
+		for i := range m.tabs {
+			updated, cmd := m.tabs[i].Update(msg)
+			m.tabs[i] = updated.(tab.Tab)
+			cmds = append(cmds, cmd)
+		}
+
+		m.msg = "Snoozed the article until " + msg.Until.Format("Mon 2 Jan 15:04")
+		return m, tea.Batch(append(cmds, m.backend.CountUnread())...)
+
+	case backend.ArchiveMsg:
+		return m, m.backend.ArchiveArticle(msg.FeedName, msg.Index, msg.State)
+
+	case backend.ArticleArchivedMsg:
+		// The article disappears from the tabs showing it, the archive and the trash are loaded again
+		var cmds []tea.Cmd
+		for i := range m.tabs {
+			updated, cmd := m.tabs[i].Update(msg)
+			m.tabs[i] = updated.(tab.Tab)
+			cmds = append(cmds, cmd)
+		}
+
+		switch msg.State {
+		case cache.Archived:
+			m.msg = "Archived the article"
+		case cache.Trashed:
+			m.msg = fmt.Sprintf("Moved the article to the trash, it's deleted after %d days", int(cache.TrashRetention.Hours()/24))
+		default:
+			m.msg = "Restored the article"
+		}
+
+		return m, tea.Batch(append(cmds, m.backend.CountUnread())...)
+
+	case backend.ArticleAnnotatedMsg:
+		// The article can be open in a few tabs, only the ones with the same title know its index
+		var cmds []tea.Cmd
+		for i := range m.tabs {
+			updated, cmd := m.tabs[i].Update(msg)
+			m.tabs[i] = updated.(tab.Tab)
+			cmds = append(cmds, cmd)
+		}
+
+		m.msg = "Saved the tags and the note"
+		return m, tea.Batch(append(cmds, m.backend.CountUnread())...)
+
 	case tab.NewTabMsg:
 		return m.createNewTab(msg)
 
@@ -198,85 +678,293 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case backend.MarkAsReadMsg:
 		return m, m.backend.MarkAsRead(msg.FeedName, msg.Index)
 
+	case backend.ReadAloudMsg:
+		return m, m.backend.ReadAloud(msg.FeedName, msg.Index)
+
+	case backend.SpeechStartedMsg:
+		log.Println("Started reading aloud: ", msg.Title)
+		return m, waitSpeech(msg.Done)
+
+	case backend.SpeechEndedMsg:
+		return m, nil
+
+	case backend.SavePositionMsg:
+		return m, m.backend.SetPosition(msg.FeedName, msg.Index, msg.Line)
+
 	case backend.MarkAsUnreadMsg:
 		return m, m.backend.MarkAsUnread(msg.FeedName, msg.Index)
 
-	case backend.MakeChoiceMsg:
-		bg := m.View()
-		width := m.width / 2
-		m.popup = popup.NewChoice(m.style.colors, bg, width, msg.Question, msg.Default)
+	case backend.DownloadEpisodeMsg:
+		return m, m.backend.DownloadEpisode(msg.FeedName, msg.Index)
 
-		m.keymap.SetEnabled(false)
-		return m, m.popup.Init()
+	case backend.EpisodeQueuedMsg:
+		if msg.Downloaded {
+			m.msg = fmt.Sprintf("The episode %s is already downloaded", msg.Title)
+		} else {
+			m.msg = fmt.Sprintf("Downloading %s, the progress is in the %s tab", msg.Title, downloads.Title)
+		}
 
-	case popup.ChoiceResultMsg:
-		m.keymap.SetEnabled(true)
-		m.popup = nil
+		log.Println(m.msg)
+		return m, nil
 
-	case tea.WindowSizeMsg:
-		m.width = msg.Width
-		m.height = msg.Height
-		m.msg = ""
+	case backend.ShowCommentsMsg:
+		m.msg = "Loading the comments"
+		return m, m.backend.FetchComments(msg.FeedName, msg.Index)
 
+	case backend.CommentsLoadedMsg:
+		var cmds []tea.Cmd
 		for i := range m.tabs {
-			m.tabs[i] = m.tabs[i].SetSize(m.width, m.height-5)
+			updated, cmd := m.tabs[i].Update(msg)
+			m.tabs[i] = updated.(tab.Tab)
+			cmds = append(cmds, cmd)
 		}
 
-	case backend.SetEnableKeybindMsg:
-		m.keymap.SetEnabled(bool(msg))
-		log.Println("Disabling keybinds, propagating")
+		m.msg = ""
+		return m, tea.Batch(cmds...)
 
-	case tea.KeyMsg:
-		switch {
-		case msg.String() == "ctrl+c":
-			m.quitting = true
-			return m, tea.Quit
+	case backend.TranslateMsg:
+		m.msg = "Translating the article"
+		return m, m.backend.TranslateArticle(msg.FeedName, msg.Index)
 
-		case msg.String() == "esc":
-			// If we are showing a popup, close it. We leave esc handling to the model.
-			if m.popup != nil {
-				m.keymap.SetEnabled(true)
-				m.popup = nil
-				return m, nil
-			}
+	case backend.ArticleTranslatedMsg:
+		var cmds []tea.Cmd
+		for i := range m.tabs {
+			updated, cmd := m.tabs[i].Update(msg)
+			m.tabs[i] = updated.(tab.Tab)
+			cmds = append(cmds, cmd)
+		}
 
-		case key.Matches(msg, m.keymap.CloseTab):
-			if len(m.tabs) == 1 {
-				m.quitting = true
-				return m, tea.Quit
-			}
+		m.msg = ""
+		return m, tea.Batch(cmds...)
 
-			// Close the current tab
-			m.tabs = append(m.tabs[:m.activeTab], m.tabs[m.activeTab+1:]...)
-			m.activeTab--
+	case backend.SummarizeMsg:
+		m.msg = "Summarizing the article"
+		return m, m.backend.SummarizeArticle(msg.FeedName, msg.Index)
 
-			if m.activeTab < 0 {
-				m.activeTab = 0
-			}
+	case backend.ArticleSummarizedMsg:
+		var cmds []tea.Cmd
+		for i := range m.tabs {
+			updated, cmd := m.tabs[i].Update(msg)
+			m.tabs[i] = updated.(tab.Tab)
+			cmds = append(cmds, cmd)
+		}
 
-			m.msg = fmt.Sprintf("Closed tab - %s", m.tabs[m.activeTab].Title())
-			return m, nil
+		m.msg = ""
+		return m, tea.Batch(cmds...)
 
-		case key.Matches(msg, m.keymap.CycleTabs):
-			m.activeTab++
-			if m.activeTab > len(m.tabs)-1 {
-				m.activeTab = 0
-			}
+	case backend.ExportArticleMsg:
+		return m, m.backend.ExportArticle(msg.FeedName, msg.Index, msg.HTML)
 
-			m.msg = ""
-			return m, nil
+	case backend.ArticleExportedMsg:
+		m.msg = fmt.Sprintf("Exported the article to %s", msg.Path)
+		return m, nil
 
-		case key.Matches(msg, m.keymap.ShowHelp):
-			return m.showHelp()
+	case backend.ShareArticlesMsg:
+		return m.shareArticles(msg)
 
-		case key.Matches(msg, m.keymap.ToggleOfflineMode):
-			return m.toggleOffline()
-		}
-	}
+	case backend.ArticleSharedMsg:
+		m.msg = fmt.Sprintf("Shared %s to %s", msg.Title, msg.Service)
+		return m, nil
 
-	// If we are showing a popup, we need to update the popup
-	if m.popup != nil {
-		m.popup, cmd = m.popup.Update(msg)
+	case backend.MarkAllAsReadMsg:
+		return m, m.backend.MarkAllAsRead(msg.FeedName)
+
+	case backend.MarkCategoryAsReadMsg:
+		return m, m.backend.MarkCategoryAsRead(msg.Category)
+
+	case backend.MarkOldAsReadMsg:
+		bg := m.View()
+		width := m.width / 2
+		height := 17
+		m.popup = overview.NewAgePopup(m.style.colors, bg, width, height)
+
+		m.keymap.SetEnabled(false)
+		return m, m.popup.Init()
+
+	case overview.ChosenAgeMsg:
+		m.popup = nil
+		m.keymap.SetEnabled(true)
+		m.msg = fmt.Sprintf("Marking the articles older than %d days as read", msg.Days)
+		return m, m.backend.MarkOlderAsRead(msg.Days)
+
+	case backend.MarkedAllAsReadMsg:
+		m.msg = fmt.Sprintf("Marked %d articles as read", msg.Count)
+		log.Println(m.msg)
+
+		// The unread counts and the articles shown in the other tabs changed
+		for i := range m.tabs {
+			updated, _ := m.tabs[i].Update(tab.RefreshMsg{Active: false})
+			m.tabs[i] = updated.(tab.Tab)
+		}
+
+		return m, tea.Batch(m.reloadActiveTab(), m.backend.CountUnread())
+
+	case backend.LoadImageMsg:
+		return m, m.backend.FetchImage(msg.URL)
+
+	case backend.LoadFaviconMsg:
+		return m, m.backend.FetchFavicon(msg.FeedName)
+
+	case backend.FaviconLoadedMsg:
+		return m, favicon.Set(msg)
+
+	case backend.ToggleFullContentMsg:
+		enabled, err := m.backend.Rss.ToggleFullContent(msg.Category, msg.FeedName)
+		switch {
+		case err != nil:
+			m.msg = fmt.Sprintf("Error toggling full content: %s", err.Error())
+		case enabled:
+			m.msg = fmt.Sprintf("Full content enabled for %s", msg.FeedName)
+		default:
+			m.msg = fmt.Sprintf("Full content disabled for %s", msg.FeedName)
+		}
+
+		log.Println(m.msg)
+		return m, nil
+
+	case backend.EditTagsMsg:
+		// The tags are edited in the command line, the current ones are typed in already
+		line := "tag "
+		if subscription, err := m.backend.Rss.GetFeed(msg.FeedName); err == nil && len(subscription.Tags) > 0 {
+			line += strings.Join(subscription.Tags, " ") + " "
+		}
+
+		return m.showCmdLine(line)
+
+	case backend.SetSortOrderMsg:
+		// The built-in feeds and the search results keep the order only while the tab is open
+		m.msg = fmt.Sprintf("Changed the sort order of %s", msg.FeedName)
+		if err := m.backend.Rss.SetSortOrder(msg.FeedName, msg.Order); err != nil && err != rss.ErrNotFound && err != rss.ErrReservedName {
+			m.msg = fmt.Sprintf("Error saving the sort order: %s", err.Error())
+		}
+
+		log.Println(m.msg)
+		return m, nil
+
+	case backend.ManageOPMLMsg:
+		bg := m.View()
+		width := m.width / 2
+		height := 17
+		m.popup = overview.NewOPMLPopup(m.style.colors, bg, width, height, msg.Export)
+
+		m.keymap.SetEnabled(false)
+		return m, m.popup.Init()
+
+	case backend.SearchMsg:
+		bg := m.View()
+		width := m.width / 2
+		height := 17
+		m.popup = overview.NewSearchPopup(m.style.colors, bg, width, height)
+
+		m.keymap.SetEnabled(false)
+		return m, m.popup.Init()
+
+	case backend.MakeChoiceMsg:
+		bg := m.View()
+		width := m.width / 2
+		m.popup = popup.NewChoice(m.style.colors, bg, width, msg.Question, msg.Default)
+
+		m.keymap.SetEnabled(false)
+		return m, m.popup.Init()
+
+	case popup.ChoiceResultMsg:
+		m.keymap.SetEnabled(true)
+		m.popup = nil
+		if m.pendingMove != nil {
+			return m.moveFeed(msg.Result)
+		}
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.msg = ""
+
+		for i := range m.tabs {
+			m.tabs[i] = m.tabs[i].SetSize(m.width, m.tabHeight())
+		}
+
+	case backend.SetEnableKeybindMsg:
+		m.keymap.SetEnabled(bool(msg))
+		log.Println("Disabling keybinds, propagating")
+
+	case tea.KeyMsg:
+		switch {
+		case msg.String() == "ctrl+c":
+			m.quitting = true
+			return m, tea.Quit
+
+		case m.commandMode:
+			m.cmdLine, cmd = m.cmdLine.Update(msg, m.completeCommand)
+			return m, cmd
+
+		case msg.String() == "esc":
+			// If we are showing a popup, close it. We leave esc handling to the model.
+			if m.popup != nil {
+				// The previewed theme is only kept if it was chosen
+				if picker, ok := m.popup.(themePicker); ok {
+					*m.style.colors = picker.original
+					m = m.restyle()
+				}
+
+				m.keymap.SetEnabled(true)
+				m.popup = nil
+				if m.pendingMove != nil {
+					return m.moveFeed(false)
+				}
+
+				return m, nil
+			}
+
+		case key.Matches(msg, m.keymap.CloseTab):
+			return m.closeTab()
+
+		case key.Matches(msg, m.keymap.CycleTabs):
+			m.activeTab++
+			if m.activeTab > len(m.tabs)-1 {
+				m.activeTab = 0
+			}
+
+			m.msg = ""
+			return m, m.reloadActiveTab()
+
+		case key.Matches(msg, m.keymap.ShowHelp):
+			return m.showHelp()
+
+		case key.Matches(msg, m.keymap.ToggleOfflineMode):
+			return m.toggleOffline()
+
+		case key.Matches(msg, m.keymap.ToggleZenMode):
+			return m.toggleZen()
+
+		case key.Matches(msg, m.keymap.CommandPalette):
+			return m.showPalette()
+
+		case key.Matches(msg, m.keymap.CommandLine):
+			return m.showCmdLine("")
+
+		case key.Matches(msg, m.keymap.NextUnreadFeed):
+			return m.nextUnreadFeed()
+
+		case key.Matches(msg, m.keymap.PauseSpeech):
+			return m.pauseSpeech()
+
+		case key.Matches(msg, m.keymap.StopSpeech):
+			return m.stopSpeech()
+
+		case key.Matches(msg, m.keymap.MoveTabLeft):
+			return m.moveTab(-1)
+
+		case key.Matches(msg, m.keymap.MoveTabRight):
+			return m.moveTab(1)
+
+		case key.Matches(msg, m.keymap.PinTab):
+			return m.togglePin()
+		}
+	}
+
+	// If we are showing a popup, we need to update the popup
+	if m.popup != nil {
+		m.popup, cmd = m.popup.Update(msg)
 		return m, cmd
 	}
 
@@ -287,6 +975,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // View renders the tab bar, the active tab and the status bar
 func (m Model) View() string {
+	switch {
+	case theme.ScreenReader:
+		return theme.PlainText(m.view())
+	case theme.NoColor:
+		return theme.StripStyles(m.view())
+	}
+
+	return m.view()
+}
+
+// view renders the browser with the styles of the theme
+func (m Model) view() string {
 	if m.quitting {
 		return "Goodbye!"
 	}
@@ -299,6 +999,10 @@ func (m Model) View() string {
 		return m.popup.View()
 	}
 
+	if m.zen {
+		return m.zenView()
+	}
+
 	var b strings.Builder
 	b.WriteString(m.renderTabBar())
 	b.WriteRune('\n')
@@ -307,24 +1011,33 @@ func (m Model) View() string {
 	b.WriteRune('\n')
 	b.WriteString(m.renderStatusBar())
 	b.WriteRune('\n')
+	b.WriteString(m.renderHelpLine())
+	return b.String()
+}
 
-	if strings.Contains(m.msg, "Error") {
-		b.WriteString(m.style.errMsg.Render(m.msg))
-	} else {
-		b.WriteString(m.msg)
+// zenView renders only the active tab, the command line takes the last line when it's open
+func (m Model) zenView() string {
+	height := m.height
+	if m.commandMode {
+		height--
 	}
 
-	return b.String()
+	view := lipgloss.NewStyle().Height(height).MaxHeight(height).Render(m.tabs[m.activeTab].View())
+	if m.commandMode {
+		view += "\n" + m.cmdLine.View(m.width)
+	}
+
+	return view
 }
 
 // ShortHelp returns the short help for the browser.
 func (m Model) ShortHelp() []key.Binding {
-	return []key.Binding{m.keymap.CloseTab, m.keymap.CycleTabs, m.keymap.ToggleOfflineMode}
+	return []key.Binding{m.keymap.CloseTab, m.keymap.CycleTabs, m.keymap.ToggleOfflineMode, m.keymap.CommandPalette, m.keymap.CommandLine, m.keymap.NextUnreadFeed, m.keymap.ToggleZenMode}
 }
 
 // FullHelp returns the full help for the browser.
 func (m Model) FullHelp() [][]key.Binding {
-	result := [][]key.Binding{m.ShortHelp()}
+	result := [][]key.Binding{m.ShortHelp(), {m.keymap.MoveTabLeft, m.keymap.MoveTabRight, m.keymap.PinTab}}
 	result = append(result, m.tabs[m.activeTab].FullHelp()...)
 	return result
 }
@@ -343,20 +1056,20 @@ func (m Model) waitForSize(msg tea.Msg) (tea.Model, tea.Cmd) {
 	m.tabs = append(m.tabs, overview.New(
 		m.style.colors,
 		m.width,
-		m.height-5,
+		m.tabHeight(),
 		"Welcome",
 		m.backend.FetchCategories,
 	))
 
-	return m, m.tabs[0].Init()
+	return m, tea.Batch(m.tabs[0].Init(), m.backend.CountUnread())
 }
 
 // createNewTab bootstraps the new tab and adds it to the model
 func (m Model) createNewTab(msg tab.NewTabMsg) (Model, tea.Cmd) {
 	var newTab tab.Tab
-	height := m.height - 5
+	height := m.tabHeight()
 
-	switch msg.Sender.(type) {
+	switch sender := msg.Sender.(type) {
 	case overview.Model:
 		switch msg.Title {
 		case rss.AllFeedsName:
@@ -367,23 +1080,138 @@ func (m Model) createNewTab(msg tab.NewTabMsg) (Model, tea.Cmd) {
 			newTab = feed.New(m.style.colors, m.width, height, msg.Title, m.backend.FetchDownloadedArticles).
 				DisableSaving()
 
+		case rss.DigestName:
+			newTab = feed.New(m.style.colors, m.width, height, msg.Title, m.backend.FetchDigests).
+				DisableDeleting()
+
+		case rss.ArchiveName:
+			newTab = feed.New(m.style.colors, m.width, height, msg.Title, m.backend.FetchArchive).
+				ShowArchived(cache.Archived).
+				DisableDeleting()
+
+		case rss.TrashName:
+			newTab = feed.New(m.style.colors, m.width, height, msg.Title, m.backend.FetchArchive).
+				ShowArchived(cache.Trashed).
+				DisableDeleting()
+
 		default:
 			newTab = category.New(m.style.colors, m.width, height, msg.Title, m.backend.FetchFeeds)
 		}
 
 	case category.Model:
+		var order string
+		if subscription, err := m.backend.Rss.GetFeed(msg.Title); err == nil {
+			order = subscription.Sort
+		}
+
 		newTab = feed.New(m.style.colors, m.width, height, msg.Title, m.backend.FetchArticles).
+			DisableDeleting().
+			SetSortOrder(order)
+
+	case tags.Model:
+		newTab = feed.New(m.style.colors, m.width, height, rss.TagPrefix+msg.Title, m.backend.FetchTagArticles).
 			DisableDeleting()
+
+	case feed.Model:
+		title, link, markdown, ok := sender.Article()
+		if !ok {
+			return m, nil
+		}
+
+		newTab = article.New(m.style.colors, m.width, height, title, link, markdown, feed.OpenURL)
+	}
+
+	// The tab which is open already is focused instead of opening it again
+	if i := m.findTab(newTab); i >= 0 && !AllowDuplicateTabs {
+		if msg.Background {
+			m.msg = fmt.Sprintf("%s is already open", newTab.Title())
+			return m, nil
+		}
+
+		m.activeTab = i
+		m.msg = ""
+		return m, m.reloadActiveTab()
+	}
+
+	// Only the feed tabs can wait with loading until they're focused
+	if deferred, ok := newTab.(feed.Model); ok && msg.Background {
+		m.tabs = append(m.tabs, deferred.Defer())
+		m.msg = fmt.Sprintf("Opened %s in the background", msg.Title)
+		return m, nil
+	}
+
+	return m.insertTab(newTab)
+}
+
+// findTab returns the index of an open tab showing the same thing, -1 if there is none
+func (m Model) findTab(newTab tab.Tab) int {
+	if newTab == nil {
+		return -1
+	}
+
+	key := tab.Key(newTab)
+	for i, t := range m.tabs {
+		if tab.Key(t) == key {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// insertTab inserts the tab after the active tab and focuses it, it's never put among the pinned tabs
+func (m Model) insertTab(newTab tab.Tab) (Model, tea.Cmd) {
+	index := m.activeTab + 1
+	if index < m.pinned {
+		index = m.pinned
 	}
 
-	// Insert the tab after the active tab
-	m.tabs = append(m.tabs[:m.activeTab+1], append([]tab.Tab{newTab}, m.tabs[m.activeTab+1:]...)...)
-	m.activeTab++
+	m.tabs = append(m.tabs[:index], append([]tab.Tab{newTab}, m.tabs[index:]...)...)
+	m.activeTab = index
 	m.msg = ""
 
 	return m, newTab.Init()
 }
 
+// search opens a tab with the cached articles matching the query
+func (m Model) search(query string) (tea.Model, tea.Cmd) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		m.msg = "Error searching: no query given"
+		return m, nil
+	}
+
+	log.Println("Searching for", query)
+	newTab := feed.New(m.style.colors, m.width, m.tabHeight(), rss.SearchPrefix+query, m.backend.SearchArticles).
+		DisableDeleting()
+
+	return m.insertTab(newTab)
+}
+
+// saveSearch saves the search of the active tab under the name, the query is used as the name if
+// it's empty. The saved search is a query feed so its articles are found again when it's opened
+func (m Model) saveSearch(name string) (tea.Model, tea.Cmd) {
+	active, ok := m.tabs[m.activeTab].(feed.Model)
+	if !ok || !strings.HasPrefix(active.Title(), rss.SearchPrefix) {
+		m.msg = "Error saving the search: use it in a search tab"
+		return m, nil
+	}
+
+	query := strings.TrimPrefix(active.Title(), rss.SearchPrefix)
+	if name == "" {
+		name = query
+	}
+
+	if err := m.backend.Rss.SaveSearch(name, query, active.UnreadOnly()); err != nil {
+		m.msg = fmt.Sprintf("Error saving the search: %s", err.Error())
+		return m, nil
+	}
+
+	m.msg = fmt.Sprintf("Saved the search as %s in %s", name, rss.SavedSearchesName)
+	log.Println(m.msg)
+	return m, tea.Batch(m.backend.FetchCategories(""), m.backend.CountUnread())
+}
+
 // deleteItem deletes the focused item from the backend
 func (m Model) deleteItem(msg backend.DeleteItemMsg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
@@ -404,23 +1232,56 @@ func (m Model) deleteItem(msg backend.DeleteItemMsg) (tea.Model, tea.Cmd) {
 		}
 
 	case feed.Model:
-		cmd = m.backend.FetchDownloadedArticles("", false)
-		if msg.Sender.Title() == rss.DownloadedFeedsName {
-			index, err := strconv.Atoi(msg.ItemName)
+		if msg.Sender.Title() != rss.DownloadedFeedsName {
+			break
+		}
+
+		// Several marked articles are deleted at once, their indexes are separated by commas
+		var indexes []int
+		for _, field := range strings.Split(msg.ItemName, ",") {
+			index, err := strconv.Atoi(field)
 			if err != nil {
 				m.msg = fmt.Sprintf("Error deleting download %s: %s", msg.ItemName, err.Error())
+				log.Println(m.msg)
+				return m, nil
 			}
 
-			if err := m.backend.Cache.RemoveFromDownloaded(index); err != nil {
-				m.msg = fmt.Sprintf("Error deleting download %s: %s", msg.ItemName, err.Error())
-			}
+			indexes = append(indexes, index)
 		}
+
+		cmd = tea.Sequence(m.backend.RemoveDownloaded(indexes...), m.backend.FetchDownloadedArticles("", false))
 	}
 
 	log.Println(m.msg)
 	return m, cmd
 }
 
+// addFeed adds a new feed to the category
+func (m Model) addFeed(parent, name, url string) (tea.Model, tea.Cmd) {
+	if err := m.backend.Rss.AddFeed(parent, name, url); err != nil {
+		m.msg = fmt.Sprintf("Error adding feed: %s", err.Error())
+	} else {
+		m.msg = fmt.Sprintf("Added feed %s", name)
+	}
+
+	log.Println(m.msg)
+	return m, m.backend.FetchFeeds(parent)
+}
+
+// discoverable keeps the discovered feeds which a page may point to
+func discoverable(feeds []cache.DiscoveredFeed) []cache.DiscoveredFeed {
+	var kept []cache.DiscoveredFeed
+	for _, feed := range feeds {
+		if rss.IsDiscoverable(feed.URL) {
+			kept = append(kept, feed)
+		} else {
+			log.Println("Skipping the discovered feed", feed.URL)
+		}
+	}
+
+	return kept
+}
+
 // downloadItem downloads an item
 func (m Model) downloadItem(msg backend.DownloadItemMsg) (tea.Model, tea.Cmd) {
 	log.Println("Downloading item", msg.FeedName, msg.Index)
@@ -428,6 +1289,31 @@ func (m Model) downloadItem(msg backend.DownloadItemMsg) (tea.Model, tea.Cmd) {
 	return m, m.backend.DownloadItem(msg.FeedName, msg.Index)
 }
 
+// manageOPML imports or exports the feeds using the chosen OPML file
+func (m Model) manageOPML(msg overview.ChosenOPMLMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case msg.Path == "":
+		m.msg = "Error managing OPML: no file path given"
+
+	case msg.Export:
+		if err := m.backend.Rss.ExportOPML(msg.Path); err != nil {
+			m.msg = fmt.Sprintf("Error exporting OPML file: %s", err.Error())
+		} else {
+			m.msg = fmt.Sprintf("Exported feeds to %s", msg.Path)
+		}
+
+	default:
+		if err := m.backend.Rss.LoadOPML(msg.Path); err != nil {
+			m.msg = fmt.Sprintf("Error importing OPML file: %s", err.Error())
+		} else {
+			m.msg = fmt.Sprintf("Imported feeds from %s", msg.Path)
+		}
+	}
+
+	log.Println(m.msg)
+	return m, m.backend.FetchCategories("")
+}
+
 // showHelp shows the help menu as a popup.
 func (m Model) showHelp() (tea.Model, tea.Cmd) {
 	bg := m.View()
@@ -439,6 +1325,253 @@ func (m Model) showHelp() (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// closeTab closes the active tab, the program quits if it's the last one
+func (m Model) closeTab() (tea.Model, tea.Cmd) {
+	if m.activeTab < m.pinned {
+		m.msg = fmt.Sprintf("The tab is pinned, unpin it with %s to close it", m.keymap.PinTab.Help().Key)
+		return m, nil
+	}
+
+	if len(m.tabs) == 1 {
+		m.quitting = true
+		return m, tea.Quit
+	}
+
+	m.tabs = append(m.tabs[:m.activeTab], m.tabs[m.activeTab+1:]...)
+	m.activeTab--
+
+	if m.activeTab < 0 {
+		m.activeTab = 0
+	}
+
+	m.msg = fmt.Sprintf("Closed tab - %s", m.tabs[m.activeTab].Title())
+	return m, m.reloadActiveTab()
+}
+
+// moveTab moves the active tab by the step in the tab bar, the pinned tabs stay in front of the others
+func (m Model) moveTab(step int) (tea.Model, tea.Cmd) {
+	first, last := m.pinned, len(m.tabs)-1
+	if m.activeTab < m.pinned {
+		first, last = 0, m.pinned-1
+	}
+
+	target := m.activeTab + step
+	if target < first || target > last {
+		return m, nil
+	}
+
+	m.tabs[m.activeTab], m.tabs[target] = m.tabs[target], m.tabs[m.activeTab]
+	m.activeTab = target
+	return m, nil
+}
+
+// togglePin pins the active tab after the other pinned tabs or unpins it, the unpinned tab
+// becomes the first of the other tabs
+func (m Model) togglePin() (tea.Model, tea.Cmd) {
+	active := m.tabs[m.activeTab]
+	wasPinned := m.activeTab < m.pinned
+	m.tabs = append(m.tabs[:m.activeTab], m.tabs[m.activeTab+1:]...)
+	if wasPinned {
+		m.pinned--
+	}
+
+	m.tabs = append(m.tabs[:m.pinned], append([]tab.Tab{active}, m.tabs[m.pinned:]...)...)
+	m.activeTab = m.pinned
+	if wasPinned {
+		m.msg = fmt.Sprintf("Unpinned tab - %s", active.Title())
+		return m, nil
+	}
+
+	m.pinned++
+	m.msg = fmt.Sprintf("Pinned tab - %s", active.Title())
+	return m, nil
+}
+
+// showPalette shows the command palette as a popup.
+func (m Model) showPalette() (tea.Model, tea.Cmd) {
+	bg := m.View()
+	width := m.width * 2 / 3
+	height := m.height * 2 / 3
+	if height > 24 {
+		height = 24
+	}
+
+	m.popup = newPalette(m.style.colors, bg, width, height, m.commands())
+	m.keymap.SetEnabled(false)
+	return m, m.popup.Init()
+}
+
+// commands returns the commands available in the command palette
+func (m Model) commands() []command {
+	active := m.tabs[m.activeTab]
+	cmds := []command{{"Search articles", "", backend.SearchMsg{}}}
+
+	switch active.(type) {
+	case overview.Model:
+		cmds = append(cmds, command{"New category", "", backend.NewItemMsg{Sender: active}})
+	case category.Model:
+		cmds = append(cmds, command{"Add feed", "to " + active.Title(), backend.NewItemMsg{Sender: active}})
+		cmds = append(cmds, command{"Add feed from RSS-Bridge", "to " + active.Title(), backend.NewBridgeFeedMsg{Parent: active.Title()}})
+	case feed.Model:
+		cmds = append(cmds, command{"Mark all as read", "in " + active.Title(), backend.MarkAllAsReadMsg{FeedName: active.Title()}})
+		if strings.HasPrefix(active.Title(), rss.SearchPrefix) {
+			cmds = append(cmds, command{"Save this search", "in " + rss.SavedSearchesName, saveSearchMsg{}})
+		}
+	}
+
+	offline := "Enable offline mode"
+	if m.offline {
+		offline = "Disable offline mode"
+	}
+
+	cmds = append(cmds,
+		command{"Refresh all feeds", "", refreshAllMsg{}},
+		command{"Go to the next unread feed", "", nextUnreadFeedMsg{}},
+		command{"Mark old articles as read", "in all the feeds", backend.MarkOldAsReadMsg{}},
+		command{offline, "", toggleOfflineMsg{}},
+		command{"Toggle zen mode", "only the article on the whole screen", toggleZenMsg{}},
+	)
+
+	cmds = append(cmds, m.speechCommands()...)
+	cmds = append(cmds,
+		command{"Import OPML", "", backend.ManageOPMLMsg{Export: false}},
+		command{"Export OPML", "", backend.ManageOPMLMsg{Export: true}},
+		command{"Show help", "", showHelpMsg{}},
+		command{"Show downloads", "podcast episodes", showDownloadsMsg{}},
+		command{"Show feed health", "last fetches and errors", showHealthMsg{}},
+		command{"Show tags", "of the feeds and the articles", showTagsMsg{}},
+		command{"Choose a theme", "with a live preview", themePickerMsg{}},
+		command{"Reload the theme", "from its file", themeReloadMsg{}},
+		command{"Close tab", active.Title(), closeTabMsg{}},
+	)
+
+	if m.activeTab < m.pinned {
+		cmds = append(cmds, command{"Unpin tab", active.Title(), pinTabMsg{}})
+	} else {
+		cmds = append(cmds, command{"Pin tab", active.Title(), pinTabMsg{}})
+	}
+
+	for i, t := range m.tabs {
+		if i != m.activeTab {
+			cmds = append(cmds, command{"Go to tab " + t.Title(), "", focusTabMsg{i}})
+		}
+	}
+
+	welcome := overview.Model{}
+	cmds = append(cmds, command{"Open " + rss.AllFeedsName, "all the articles", tab.NewTabMsg{Sender: welcome, Title: rss.AllFeedsName}})
+	for _, cat := range m.backend.Rss.Categories {
+		if cat.Name == rss.AllFeedsName {
+			continue
+		}
+
+		cmds = append(cmds, command{"Open category " + cat.Name, cat.Description, tab.NewTabMsg{Sender: welcome, Title: cat.Name}})
+	}
+
+	for _, cat := range m.backend.Rss.Categories {
+		for _, sub := range cat.Subscriptions {
+			cmds = append(cmds, command{"Open feed " + sub.Name, "in " + cat.Name, tab.NewTabMsg{Sender: category.Model{}, Title: sub.Name}})
+		}
+	}
+
+	for _, tag := range m.backend.Tags() {
+		cmds = append(cmds, command{"Open tag " + tag, "the tagged feeds and articles", tab.NewTabMsg{Sender: tags.Model{}, Title: tag}})
+	}
+
+	return cmds
+}
+
+// showDownloads focuses the downloads tab, it's opened if it's not open yet
+func (m Model) showDownloads() (tea.Model, tea.Cmd) {
+	for i, t := range m.tabs {
+		if _, ok := t.(downloads.Model); ok {
+			m.activeTab = i
+			m.msg = ""
+			return m, m.reloadActiveTab()
+		}
+	}
+
+	return m.insertTab(downloads.New(m.style.colors, m.width, m.tabHeight(), m.backend.FetchDownloads))
+}
+
+// showHealth focuses the feed health tab, it's opened if it's not open yet
+func (m Model) showHealth() (tea.Model, tea.Cmd) {
+	for i, t := range m.tabs {
+		if _, ok := t.(health.Model); ok {
+			m.activeTab = i
+			m.msg = ""
+			return m, m.reloadActiveTab()
+		}
+	}
+
+	return m.insertTab(health.New(m.style.colors, m.width, m.tabHeight(), m.backend.FetchHealth))
+}
+
+// showTags focuses the tags tab, it's opened if it's not open yet. With a tag the articles of the
+// feeds tagged with it are opened instead
+func (m Model) showTags(tag string) (tea.Model, tea.Cmd) {
+	if tag != "" {
+		for _, known := range m.backend.Tags() {
+			if known == tag {
+				return m.createNewTab(tab.NewTabMsg{Sender: tags.Model{}, Title: tag})
+			}
+		}
+
+		m.msg = fmt.Sprintf("Error opening %q: no feed is tagged with it", tag)
+		return m, nil
+	}
+
+	for i, t := range m.tabs {
+		if _, ok := t.(tags.Model); ok {
+			m.activeTab = i
+			m.msg = ""
+			return m, m.reloadActiveTab()
+		}
+	}
+
+	return m.insertTab(tags.New(m.style.colors, m.width, m.tabHeight(), m.backend.FetchTags))
+}
+
+// nextUnreadFeed focuses the first feed with unread articles after the active one, the feeds are
+// in the order of the urls file. The feed is opened if it's not open yet
+func (m Model) nextUnreadFeed() (tea.Model, tea.Cmd) {
+	var names []string
+	seen := make(map[string]bool)
+	for _, cat := range m.backend.Rss.Categories {
+		for _, sub := range cat.Subscriptions {
+			if !seen[sub.Name] && m.unread.Feeds[sub.Name] > 0 {
+				seen[sub.Name] = true
+				names = append(names, sub.Name)
+			}
+		}
+	}
+
+	if len(names) == 0 {
+		m.msg = "There are no unread articles"
+		return m, nil
+	}
+
+	// Start after the active feed, the search wraps around
+	next := names[0]
+	if _, ok := m.tabs[m.activeTab].(feed.Model); ok {
+		for i, name := range names {
+			if name == m.tabs[m.activeTab].Title() {
+				next = names[(i+1)%len(names)]
+				break
+			}
+		}
+	}
+
+	for i, t := range m.tabs {
+		if _, ok := t.(feed.Model); ok && t.Title() == next {
+			m.activeTab = i
+			m.msg = ""
+			return m, m.reloadActiveTab()
+		}
+	}
+
+	return m.createNewTab(tab.NewTabMsg{Sender: category.Model{}, Title: next})
+}
+
 // toggleOffline toggles the offline mode
 func (m Model) toggleOffline() (tea.Model, tea.Cmd) {
 	m.offline = !m.offline
@@ -454,11 +1587,78 @@ func (m Model) toggleOffline() (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// toggleZen toggles the zen mode, the active tab takes the whole screen and the open article is
+// shown without the list
+func (m Model) toggleZen() (tea.Model, tea.Cmd) {
+	m.zen = !m.zen
+	for i := range m.tabs {
+		updated, _ := m.tabs[i].SetSize(m.width, m.tabHeight()).Update(tab.ZenMsg{Enabled: m.zen})
+		m.tabs[i] = updated.(tab.Tab)
+	}
+
+	log.Println("Zen mode: ", m.zen)
+	return m, nil
+}
+
+// moveFeed updates the url of the feed which moved if the user agreed, the declined moves aren't
+// brought up again until the next start
+func (m Model) moveFeed(accepted bool) (tea.Model, tea.Cmd) {
+	moved := *m.pendingMove
+	m.pendingMove = nil
+	if !accepted {
+		m.backend.SkipMove(moved.URL)
+		m.msg = fmt.Sprintf("Kept the old url of %s", moved.Name)
+		return m, m.backend.CheckMoved()
+	}
+
+	if err := m.backend.MoveFeed(moved.URL, moved.NewURL); err != nil {
+		m.backend.SkipMove(moved.URL)
+		m.msg = fmt.Sprintf("Error updating the feed: %s", err.Error())
+		return m, nil
+	}
+
+	m.msg = fmt.Sprintf("Updated the url of %s", moved.Name)
+	log.Println(m.msg)
+	return m, m.backend.CheckMoved()
+}
+
+// tabHeight returns the height of the tabs, the tab bar, the status bar and the help line take
+// the rest of the screen. In the zen mode there is nothing else on the screen
+func (m Model) tabHeight() int {
+	if m.zen {
+		return m.height
+	}
+
+	return m.height - 5
+}
+
+// reloadActiveTab lets the active tab reload its data if it was refreshed in the background
+func (m Model) reloadActiveTab() tea.Cmd {
+	updated, cmd := m.tabs[m.activeTab].Update(tab.RefreshMsg{Active: true})
+	m.tabs[m.activeTab] = updated.(tab.Tab)
+	return cmd
+}
+
+// scheduleRefresh schedules the next background refresh, if it's enabled
+func scheduleRefresh() tea.Cmd {
+	if DefaultRefreshInterval <= 0 {
+		return nil
+	}
+
+	return tea.Tick(DefaultRefreshInterval, func(time.Time) tea.Msg {
+		return refreshTickMsg{}
+	})
+}
+
 // renderTabBar renders the tab bar at the top of the screen
 func (m Model) renderTabBar() string {
+	if theme.ScreenReader {
+		return m.renderTabLine()
+	}
+
 	tabs := make([]string, len(m.tabs))
 	for i := range m.tabs {
-		tabs[i] = m.style.attachIcon(m.tabs[i], m.tabs[i].Title(), i == m.activeTab)
+		tabs[i] = m.style.attachIcon(m.tabs[i], m.tabs[i].Title(), m.unreadBadge(m.tabs[i]), i == m.activeTab, i < m.pinned)
 	}
 
 	if lipgloss.Width(strings.Join(tabs, "")) > m.width {
@@ -478,17 +1678,109 @@ func (m Model) renderTabBar() string {
 	return lipgloss.JoinHorizontal(lipgloss.Left, row, gap)
 }
 
-// renderStatusBar is used to render the status bar at the bottom of the screen
+// renderTabLine announces the active tab as plain text for the screen readers, the other tabs
+// aren't listed so the line changes only when the active tab does
+func (m Model) renderTabLine() string {
+	active := m.tabs[m.activeTab]
+	line := fmt.Sprintf("Tab %d of %d, %s: %s", m.activeTab+1, len(m.tabs), strings.ToLower(active.Style().Name), active.Title())
+	if m.activeTab < m.pinned {
+		line += ", pinned"
+	}
+
+	if badge := m.unreadBadge(active); badge != "" {
+		line += " " + badge
+	}
+
+	return m.style.activeTab.Copy().Padding(0).Render(truncate.StringWithTail(line, uint(m.width), theme.Icons.Tail))
+}
+
+// unreadBadge returns the number of unread articles shown in the title of a tab, the welcome tab,
+// the saved articles and the search results don't have one
+func (m Model) unreadBadge(t tab.Tab) string {
+	switch t.(type) {
+	case category.Model:
+		return backend.UnreadBadge(m.unread.Categories[t.Title()])
+	case feed.Model:
+		if t.Title() == rss.AllFeedsName {
+			return backend.UnreadBadge(m.unread.Total)
+		}
+
+		if strings.HasPrefix(t.Title(), rss.TagPrefix) {
+			return backend.UnreadBadge(m.unread.Tags[strings.TrimPrefix(t.Title(), rss.TagPrefix)])
+		}
+
+		return backend.UnreadBadge(m.unread.Feeds[t.Title()])
+	}
+
+	return ""
+}
+
+// renderStatusBar is used to render the status bar at the bottom of the screen, it shows the
+// type of the active tab, where the articles come from, the active filter, the last message,
+// the article read aloud, the progress in the open article, the number of the unread articles and the time of the last refresh
 func (m Model) renderStatusBar() string {
-	row := m.style.styleStatusBarCell(m.tabs[m.activeTab], m.offline)
+	left := m.style.styleStatusBarCell(m.tabs[m.activeTab], m.offline) +
+		m.style.infoStatusBarCell.Render(m.backend.Source())
+
+	if filterer, ok := m.tabs[m.activeTab].(tab.Filterer); ok && filterer.Filter() != "" {
+		left += m.style.infoStatusBarCell.Render("Filter: " + filterer.Filter())
+	}
+
+	if positioner, ok := m.tabs[m.activeTab].(tab.Positioner); ok && theme.ScreenReader {
+		left += m.style.infoStatusBarCell.Render(positioner.Position())
+	}
+
+	var right string
+	if status := m.speechStatus(); status != "" {
+		right += m.style.infoStatusBarCell.Render(status)
+	}
+
+	if progresser, ok := m.tabs[m.activeTab].(tab.Progresser); ok && progresser.Progress() != "" {
+		right += m.style.infoStatusBarCell.Render(progresser.Progress())
+	}
+
+	right += m.style.refreshStatusBarCell.Render(fmt.Sprintf("%d unread", m.unread.Total))
+	switch {
+	case m.refreshing:
+		right += m.style.refreshStatusBarCell.Render("Refreshing...")
+	case !m.lastRefresh.IsZero():
+		right += m.style.refreshStatusBarCell.Render("Refreshed " + m.lastRefresh.Format("15:04"))
+	}
+
+	var msg string
+	room := m.width - lipgloss.Width(left) - lipgloss.Width(right)
+	if m.msg != "" && room > 2 {
+		msgStyle := m.style.msgStatusBarCell
+		if strings.Contains(m.msg, "Error") {
+			msgStyle = m.style.errStatusBarCell
+		}
+
+		msg = msgStyle.Render(truncate.StringWithTail(m.msg, uint(room-2), theme.Icons.Tail))
+	}
 
 	var gapAmount int
-	if m.width-lipgloss.Width(row) < 0 {
-		gapAmount = 0
-	} else {
-		gapAmount = m.width - lipgloss.Width(row)
+	if room-lipgloss.Width(msg) > 0 {
+		gapAmount = room - lipgloss.Width(msg)
 	}
 
 	gap := m.style.statusBarGap.Render(strings.Repeat(" ", gapAmount))
-	return lipgloss.JoinHorizontal(lipgloss.Bottom, row, gap)
+	return lipgloss.JoinHorizontal(lipgloss.Bottom, left, msg, gap, right)
+}
+
+// renderHelpLine renders the most important key bindings of the active tab below the status bar,
+// the command line is shown there instead when it's open
+func (m Model) renderHelpLine() string {
+	if m.commandMode {
+		return m.cmdLine.View(m.width)
+	}
+
+	helpLine := help.New()
+	helpLine.Width = m.width
+	helpLine.Styles.ShortKey = m.style.helpLine.Copy().Bold(true)
+	helpLine.Styles.ShortDesc = m.style.helpLine
+	helpLine.Styles.ShortSeparator = m.style.helpLine
+	helpLine.Styles.Ellipsis = m.style.helpLine
+
+	bindings := []key.Binding{m.keymap.ShowHelp, m.keymap.CommandPalette}
+	return helpLine.ShortHelpView(append(bindings, m.tabs[m.activeTab].ShortHelp()...))
 }
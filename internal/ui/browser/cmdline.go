@@ -0,0 +1,481 @@
+package browser
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/TypicalAM/goread/internal/backend/rss"
+	"github.com/TypicalAM/goread/internal/theme"
+	"github.com/TypicalAM/goread/internal/ui/tab"
+	"github.com/TypicalAM/goread/internal/ui/tab/category"
+	"github.com/TypicalAM/goread/internal/ui/tab/feed"
+	"github.com/TypicalAM/goread/internal/ui/tab/overview"
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/reflow/truncate"
+)
+
+// cmdLineDoneMsg is sent when the command line is closed, the line is empty if it was cancelled
+type cmdLineDoneMsg struct{ line string }
+
+// cmdLine is the vim-like command line, it remembers the commands which were run
+type cmdLine struct {
+	input       textinput.Model
+	hint        lipgloss.Style
+	history     []string
+	position    int
+	draft       string
+	prefix      string
+	completions []string
+	completion  int
+}
+
+// newCmdLine creates a new command line
+func newCmdLine(colors *theme.Colors) cmdLine {
+	input := textinput.New()
+	input.Prompt = ":"
+	input.PromptStyle = lipgloss.NewStyle().Foreground(colors.Color1).Bold(true)
+	input.TextStyle = lipgloss.NewStyle().Foreground(colors.Text)
+	input.Cursor.SetMode(cursor.CursorStatic)
+
+	return cmdLine{
+		input: input,
+		hint:  lipgloss.NewStyle().Foreground(colors.TextDark),
+	}
+}
+
+// restyle rebuilds the styles of the command line after the colors changed
+func (c cmdLine) restyle(colors *theme.Colors) cmdLine {
+	fresh := newCmdLine(colors)
+	c.input.PromptStyle = fresh.input.PromptStyle
+	c.input.TextStyle = fresh.input.TextStyle
+	c.hint = fresh.hint
+	return c
+}
+
+// open puts the line in the command line and focuses it, the line is usually empty
+func (c cmdLine) open(line string) (cmdLine, tea.Cmd) {
+	c.input.SetValue(line)
+	c.input.CursorEnd()
+	c.position = len(c.history)
+	c.draft = ""
+	c.completions = nil
+	return c, c.input.Focus()
+}
+
+// Update handles the keys typed in the command line, the completer returns the words which can
+// complete the last word of the line and the part of the line before it
+func (c cmdLine) Update(msg tea.KeyMsg, completer func(line string) (string, []string)) (cmdLine, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		line := strings.TrimSpace(c.input.Value())
+		if line != "" && (len(c.history) == 0 || c.history[len(c.history)-1] != line) {
+			c.history = append(c.history, line)
+		}
+
+		c.input.Blur()
+		return c, func() tea.Msg { return cmdLineDoneMsg{line} }
+
+	case "esc":
+		c.input.Blur()
+		return c, func() tea.Msg { return cmdLineDoneMsg{} }
+
+	case "backspace":
+		// Like in vim, erasing the colon closes the command line
+		if c.input.Value() == "" {
+			c.input.Blur()
+			return c, func() tea.Msg { return cmdLineDoneMsg{} }
+		}
+
+	case "up", "ctrl+p":
+		c.browseHistory(-1)
+		return c, nil
+
+	case "down", "ctrl+n":
+		c.browseHistory(1)
+		return c, nil
+
+	case "tab", "shift+tab":
+		if c.completions == nil {
+			c.prefix, c.completions = completer(c.input.Value())
+			c.completion = -1
+		}
+
+		c.cycleCompletions(msg.String() == "tab")
+		return c, nil
+	}
+
+	c.completions = nil
+	var cmd tea.Cmd
+	c.input, cmd = c.input.Update(msg)
+	return c, cmd
+}
+
+// browseHistory replaces the line with an older or a newer one from the history, the line
+// which was being typed is restored after the newest one
+func (c *cmdLine) browseHistory(step int) {
+	if c.position == len(c.history) {
+		c.draft = c.input.Value()
+	}
+
+	c.position += step
+	switch {
+	case c.position < 0:
+		c.position = 0
+	case c.position >= len(c.history):
+		c.position = len(c.history)
+		c.input.SetValue(c.draft)
+		c.input.CursorEnd()
+		return
+	}
+
+	c.completions = nil
+	c.input.SetValue(c.history[c.position])
+	c.input.CursorEnd()
+}
+
+// cycleCompletions puts the next or the previous completion in the line
+func (c *cmdLine) cycleCompletions(forward bool) {
+	if len(c.completions) == 0 {
+		return
+	}
+
+	if forward {
+		c.completion = (c.completion + 1) % len(c.completions)
+	} else if c.completion--; c.completion < 0 {
+		c.completion = len(c.completions) - 1
+	}
+
+	c.input.SetValue(c.prefix + c.completions[c.completion])
+	c.input.CursorEnd()
+}
+
+// View renders the command line, the other completions are shown after it
+func (c cmdLine) View(width int) string {
+	line := c.input.View()
+	if len(c.completions) < 2 {
+		return line
+	}
+
+	var others []string
+	for i, completion := range c.completions {
+		if i != c.completion {
+			others = append(others, completion)
+		}
+	}
+
+	room := width - lipgloss.Width(line) - 2
+	if room <= 0 {
+		return line
+	}
+
+	return line + c.hint.Render("  "+truncate.StringWithTail(strings.Join(others, "  "), uint(room), theme.Icons.Tail))
+}
+
+// matchPrefix returns the candidates which start with the partial word, the case is ignored
+func matchPrefix(partial string, candidates []string) []string {
+	var result []string
+	for _, candidate := range candidates {
+		if strings.HasPrefix(strings.ToLower(candidate), strings.ToLower(partial)) {
+			result = append(result, candidate)
+		}
+	}
+
+	return result
+}
+
+// lineCommands are the commands which can be run from the command line
+var lineCommands = []string{
+	"addfeed", "close", "downloads", "filter", "health", "help", "offline", "open",
+	"pin", "q", "qa", "quit", "refresh", "savesearch", "search", "sort", "tab", "tag", "tags", "theme", "untag", "vacuum", "zen",
+}
+
+// showCmdLine opens the command line in place of the help line, the line is typed in it already
+func (m Model) showCmdLine(line string) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.commandMode = true
+	m.cmdLine, cmd = m.cmdLine.open(line)
+	m.keymap.SetEnabled(false)
+	return m, cmd
+}
+
+// runCommand runs a line typed in the command line
+func (m Model) runCommand(line string) (tea.Model, tea.Cmd) {
+	log.Println("Running command", line)
+	name, arg, _ := strings.Cut(line, " ")
+	arg = strings.TrimSpace(arg)
+	active := m.tabs[m.activeTab]
+
+	switch name {
+	case "addfeed":
+		return m.addFeedCommand(arg)
+
+	case "open":
+		return m.openCommand(arg)
+
+	case "filter":
+		if _, ok := active.(feed.Model); !ok || (arg != "unread" && arg != "all") {
+			m.msg = "Error filtering: use :filter unread or :filter all in a feed"
+			return m, nil
+		}
+
+		return m.updateActiveTab(tab.FilterMsg{Filter: arg})
+
+	case "sort":
+		order, ok := feed.ParseSortOrder(arg)
+		if _, isFeed := active.(feed.Model); !ok || !isFeed {
+			m.msg = fmt.Sprintf("Error sorting: use :sort with one of %s in a feed", strings.Join(feed.SortOrderNames(), ", "))
+			return m, nil
+		}
+
+		return m.updateActiveTab(tab.SortMsg{Order: order})
+
+	case "search":
+		return m.search(arg)
+
+	case "savesearch":
+		return m.saveSearch(arg)
+
+	case "refresh":
+		return m.update(refreshAllMsg{})
+
+	case "offline":
+		return m.toggleOffline()
+
+	case "vacuum":
+		m.msg = "Collecting the garbage in the cache"
+		return m, m.backend.Vacuum()
+
+	case "zen":
+		return m.toggleZen()
+
+	case "help":
+		return m.showHelp()
+
+	case "downloads":
+		return m.showDownloads()
+
+	case "health":
+		return m.showHealth()
+
+	case "tags":
+		return m.showTags(arg)
+
+	case "tag", "untag":
+		return m.tagCommand(name, strings.Fields(arg))
+
+	case "tab":
+		number, err := strconv.Atoi(arg)
+		if err != nil || number < 1 || number > len(m.tabs) {
+			m.msg = fmt.Sprintf("Error switching tabs: use :tab with a number from 1 to %d", len(m.tabs))
+			return m, nil
+		}
+
+		return m.update(focusTabMsg{number - 1})
+
+	case "theme":
+		switch arg {
+		case "":
+			return m.showThemePicker()
+		case "reload":
+			return m.reloadTheme()
+		}
+
+		return m.setTheme(arg)
+
+	case "pin":
+		return m.togglePin()
+
+	case "q", "close":
+		return m.closeTab()
+
+	case "qa", "quit":
+		m.quitting = true
+		return m, tea.Quit
+	}
+
+	m.msg = fmt.Sprintf("Error running the command: unknown command %q", name)
+	return m, nil
+}
+
+// addFeedCommand adds the feed from the url to the open category, it's added to the default
+// category if a category isn't open
+func (m Model) addFeedCommand(arg string) (tea.Model, tea.Cmd) {
+	fields := strings.Fields(arg)
+	if len(fields) == 0 {
+		m.msg = "Error adding feed: use :addfeed <url> [name]"
+		return m, nil
+	}
+
+	chosen := category.ChosenFeedMsg{URL: fields[0], Name: strings.Join(fields[1:], " ")}
+	if chosen.Name == "" {
+		chosen.Name = rss.NameFromURL(chosen.URL)
+	}
+
+	if _, ok := m.tabs[m.activeTab].(category.Model); ok {
+		chosen.Parent = m.tabs[m.activeTab].Title()
+		return m.update(chosen)
+	}
+
+	// The feeds are reloaded after adding, they are shown in the tab of the default category
+	chosen.Parent = rss.DefaultCategoryName
+	if err := m.backend.Rss.AddCategory(rss.DefaultCategoryName, rss.DefaultCategoryDescription); err != nil && err != rss.ErrAlreadyExists {
+		m.msg = fmt.Sprintf("Error adding feed: %s", err.Error())
+		return m, nil
+	}
+
+	model, openCmd := m.createNewTab(tab.NewTabMsg{Sender: overview.Model{}, Title: rss.DefaultCategoryName})
+	updated, addCmd := model.update(chosen)
+	return updated, tea.Batch(openCmd, addCmd)
+}
+
+// tagCommand replaces the tags of the selected feed in a category or the feed of the active tab,
+// without the tags it shows the current ones. The untag command removes all of them
+func (m Model) tagCommand(name string, tags []string) (tea.Model, tea.Cmd) {
+	feedName := m.tagTarget()
+	if feedName == "" {
+		m.msg = fmt.Sprintf("Error tagging: use :%s in a category or a feed", name)
+		return m, nil
+	}
+
+	if name == "tag" && len(tags) == 0 {
+		subscription, _ := m.backend.Rss.GetFeed(feedName)
+		m.msg = fmt.Sprintf("%s has no tags", feedName)
+		if len(subscription.Tags) > 0 {
+			m.msg = fmt.Sprintf("Tags of %s: %s", feedName, strings.Join(subscription.Tags, " "))
+		}
+
+		return m, nil
+	}
+
+	if name == "untag" {
+		tags = nil
+	}
+
+	if err := m.backend.Rss.SetTags(feedName, tags); err != nil {
+		m.msg = fmt.Sprintf("Error tagging: %s", err.Error())
+		return m, nil
+	}
+
+	m.msg = fmt.Sprintf("Removed the tags of %s", feedName)
+	if len(tags) > 0 {
+		m.msg = fmt.Sprintf("Tagged %s with %s", feedName, strings.Join(tags, " "))
+	}
+
+	log.Println(m.msg)
+	return m, m.backend.CountUnread()
+}
+
+// tagTarget returns the name of the feed which is tagged by the tag commands, it's empty if the
+// active tab doesn't show a feed which can be tagged
+func (m Model) tagTarget() string {
+	switch active := m.tabs[m.activeTab].(type) {
+	case category.Model:
+		return active.SelectedFeed()
+	case feed.Model:
+		if _, err := m.backend.Rss.GetFeed(active.Title()); err == nil {
+			return active.Title()
+		}
+	}
+
+	return ""
+}
+
+// openCommand opens the item with the number in the active tab or the category or the feed
+// with the name
+func (m Model) openCommand(arg string) (tea.Model, tea.Cmd) {
+	if number, err := strconv.Atoi(arg); err == nil {
+		return m.updateActiveTab(tab.OpenItemMsg{Number: number})
+	}
+
+	for _, name := range m.openNames() {
+		if !strings.EqualFold(name, arg) {
+			continue
+		}
+
+		// The categories are opened from the welcome tab and the feeds from a category
+		var sender tab.Tab = category.Model{}
+		_, err := m.backend.Rss.GetFeeds(name)
+		if err == nil || name == rss.AllFeedsName || name == rss.DownloadedFeedsName || name == rss.DigestName ||
+			name == rss.ArchiveName || name == rss.TrashName {
+			sender = overview.Model{}
+		}
+
+		return m.update(tab.NewTabMsg{Sender: sender, Title: name})
+	}
+
+	m.msg = fmt.Sprintf("Error opening %q: there is no such category or feed", arg)
+	return m, nil
+}
+
+// openNames returns the names of the categories and the feeds which can be opened
+func (m Model) openNames() []string {
+	names := []string{rss.AllFeedsName, rss.DownloadedFeedsName, rss.ArchiveName, rss.TrashName}
+	seen := map[string]bool{rss.AllFeedsName: true, rss.DownloadedFeedsName: true, rss.ArchiveName: true, rss.TrashName: true}
+	if m.backend.Digests != nil {
+		names = append(names, rss.DigestName)
+		seen[rss.DigestName] = true
+	}
+
+	for _, cat := range m.backend.Rss.Categories {
+		if !seen[cat.Name] {
+			seen[cat.Name] = true
+			names = append(names, cat.Name)
+		}
+	}
+
+	for _, cat := range m.backend.Rss.Categories {
+		for _, sub := range cat.Subscriptions {
+			if !seen[sub.Name] {
+				seen[sub.Name] = true
+				names = append(names, sub.Name)
+			}
+		}
+	}
+
+	return names
+}
+
+// completeCommand returns the words which complete the last word of the line and the part of
+// the line before it, the names of the commands and their arguments are completed
+func (m Model) completeCommand(line string) (string, []string) {
+	name, arg, found := strings.Cut(strings.TrimLeft(line, " "), " ")
+	if !found {
+		return "", matchPrefix(name, lineCommands)
+	}
+
+	prefix := name + " "
+	arg = strings.TrimLeft(arg, " ")
+	switch name {
+	case "open":
+		return prefix, matchPrefix(arg, m.openNames())
+	case "sort":
+		return prefix, matchPrefix(arg, feed.SortOrderNames())
+	case "filter":
+		return prefix, matchPrefix(arg, []string{"unread", "all"})
+	case "theme":
+		return prefix, matchPrefix(arg, append(m.style.colors.Themes(), "reload"))
+	case "tags":
+		return prefix, matchPrefix(arg, m.backend.Tags())
+	case "tag":
+		// Every word is a tag, only the last one is completed
+		if i := strings.LastIndex(arg, " "); i >= 0 {
+			prefix, arg = prefix+arg[:i+1], arg[i+1:]
+		}
+
+		return prefix, matchPrefix(arg, m.backend.Tags())
+	}
+
+	return prefix, nil
+}
+
+// updateActiveTab sends the message to the active tab
+func (m Model) updateActiveTab(msg tea.Msg) (tea.Model, tea.Cmd) {
+	updated, cmd := m.tabs[m.activeTab].Update(msg)
+	m.tabs[m.activeTab] = updated.(tab.Tab)
+	return m, cmd
+}
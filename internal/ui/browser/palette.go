@@ -0,0 +1,183 @@
+package browser
+
+import (
+	"strings"
+
+	"github.com/TypicalAM/goread/internal/theme"
+	"github.com/TypicalAM/goread/internal/ui/popup"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
+)
+
+// command is an action which can be run from the command palette
+type command struct {
+	title string
+	desc  string
+	msg   tea.Msg
+}
+
+// commandChosenMsg is sent when a command is chosen in the palette, the browser handles its message
+type commandChosenMsg struct{ msg tea.Msg }
+
+// commands is a list of commands which can be searched
+type commands []command
+
+// String returns the text which is matched against the query, needed for fuzzy matching
+func (c commands) String(i int) string {
+	return c[i].title
+}
+
+// Len returns the amount of commands, needed for fuzzy matching
+func (c commands) Len() int {
+	return len(c)
+}
+
+// Palette is a popup that lists the available commands and filters them using fuzzy matching.
+type Palette struct {
+	queryInput textinput.Model
+	style      paletteStyle
+	overlay    popup.Overlay
+	commands   commands
+	matches    fuzzy.Matches
+	selected   int
+	maxShown   int
+}
+
+// newPalette returns a new command palette popup.
+func newPalette(colors *theme.Colors, bgRaw string, width, height int, cmds []command) *Palette {
+	queryInput := textinput.New()
+	queryInput.Prompt = "> "
+	queryInput.Placeholder = "Type a command"
+	queryInput.Width = width - 8
+	queryInput.Focus()
+
+	p := &Palette{
+		queryInput: queryInput,
+		style:      newPaletteStyle(colors, width, height),
+		overlay:    popup.NewOverlay(bgRaw, width, height),
+		commands:   cmds,
+		maxShown:   height - 7,
+	}
+
+	p.filter()
+	return p
+}
+
+// Init initializes the popup.
+func (p Palette) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Update handles the query input and the selection.
+func (p Palette) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "enter":
+			if len(p.matches) == 0 {
+				return p, nil
+			}
+
+			chosen := p.commands[p.matches[p.selected].Index].msg
+			return p, func() tea.Msg { return commandChosenMsg{chosen} }
+
+		case "up", "shift+tab", "ctrl+k":
+			if p.selected > 0 {
+				p.selected--
+			}
+
+			return p, nil
+
+		case "down", "tab", "ctrl+j":
+			if p.selected < len(p.matches)-1 {
+				p.selected++
+			}
+
+			return p, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	query := p.queryInput.Value()
+	p.queryInput, cmd = p.queryInput.Update(msg)
+	if p.queryInput.Value() != query {
+		p.filter()
+	}
+
+	return p, cmd
+}
+
+// View renders the popup.
+func (p Palette) View() string {
+	// Keep the selected command visible
+	start := 0
+	if p.selected >= p.maxShown {
+		start = p.selected - p.maxShown + 1
+	}
+
+	end := start + p.maxShown
+	if end > len(p.matches) {
+		end = len(p.matches)
+	}
+
+	rows := make([]string, 0, p.maxShown)
+	for i := start; i < end; i++ {
+		rows = append(rows, p.renderMatch(p.matches[i], i == p.selected))
+	}
+
+	if len(rows) == 0 {
+		rows = append(rows, p.style.desc.Render("No matching commands"))
+	}
+
+	return p.overlay.WrapView(p.style.box.Render(lipgloss.JoinVertical(
+		lipgloss.Left,
+		p.style.title.Render("Commands"),
+		p.style.input.Render(p.queryInput.View()),
+		p.style.list.Render(strings.Join(rows, "\n")),
+	)))
+}
+
+// filter finds the commands matching the query, all the commands are shown if there is no query
+func (p *Palette) filter() {
+	p.selected = 0
+	query := strings.TrimSpace(p.queryInput.Value())
+	if query != "" {
+		p.matches = fuzzy.FindFrom(query, p.commands)
+		return
+	}
+
+	p.matches = make(fuzzy.Matches, len(p.commands))
+	for i, cmd := range p.commands {
+		p.matches[i] = fuzzy.Match{Str: cmd.title, Index: i}
+	}
+}
+
+// renderMatch renders a single command and highlights the matched characters
+func (p Palette) renderMatch(match fuzzy.Match, selected bool) string {
+	titleStyle, matchStyle := p.style.command, p.style.match
+	if selected {
+		titleStyle, matchStyle = p.style.selectedCommand, p.style.selectedMatch
+	}
+
+	matched := make(map[int]bool, len(match.MatchedIndexes))
+	for _, i := range match.MatchedIndexes {
+		matched[i] = true
+	}
+
+	var title strings.Builder
+	for i, r := range match.Str {
+		if matched[i] {
+			title.WriteString(matchStyle.Render(string(r)))
+		} else {
+			title.WriteString(titleStyle.Render(string(r)))
+		}
+	}
+
+	cmd := p.commands[match.Index]
+	if cmd.desc == "" {
+		return title.String()
+	}
+
+	return title.String() + p.style.desc.Render(" "+cmd.desc)
+}
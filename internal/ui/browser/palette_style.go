@@ -0,0 +1,54 @@
+package browser
+
+import (
+	"github.com/TypicalAM/goread/internal/theme"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// paletteStyle is the style for the command palette popup.
+type paletteStyle struct {
+	box             lipgloss.Style
+	title           lipgloss.Style
+	input           lipgloss.Style
+	list            lipgloss.Style
+	command         lipgloss.Style
+	match           lipgloss.Style
+	selectedCommand lipgloss.Style
+	selectedMatch   lipgloss.Style
+	desc            lipgloss.Style
+}
+
+// newPaletteStyle creates a new style for the command palette.
+func newPaletteStyle(colors *theme.Colors, width, height int) paletteStyle {
+	command := lipgloss.NewStyle().
+		Foreground(colors.Text)
+
+	selectedCommand := lipgloss.NewStyle().
+		Foreground(colors.Color3).
+		Italic(true)
+
+	return paletteStyle{
+		box: lipgloss.NewStyle().
+			Width(width - 2).
+			Height(height - 2).
+			Border(lipgloss.NormalBorder()).
+			BorderForeground(colors.Color1),
+		title: lipgloss.NewStyle().
+			Align(lipgloss.Center).
+			Margin(1, 0, 0, 0).
+			Width(width - 2).
+			Foreground(colors.Text).
+			Italic(true),
+		input: lipgloss.NewStyle().
+			Margin(1, 2),
+		list: lipgloss.NewStyle().
+			Margin(0, 2).
+			MaxWidth(width - 6),
+		command:         command,
+		match:           command.Copy().Foreground(colors.Color2).Bold(true),
+		selectedCommand: theme.Emphasize(selectedCommand),
+		selectedMatch:   theme.Emphasize(selectedCommand.Copy().Foreground(colors.Color2).Bold(true)),
+		desc: lipgloss.NewStyle().
+			Foreground(colors.TextDark),
+	}
+}
@@ -0,0 +1,115 @@
+package browser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/TypicalAM/goread/internal/ipc"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// remoteRequestMsg is sent when a command arrives on the socket
+type remoteRequestMsg struct{ req ipc.Request }
+
+// remoteCommands are the commands which can be sent to the socket
+var remoteCommands = []string{"add-feed", "open", "open-url", "refresh", "run", "unread-count"}
+
+// SetRequests makes the browser handle the commands which arrive on the socket
+func (m Model) SetRequests(requests <-chan ipc.Request) Model {
+	m.requests = requests
+	return m
+}
+
+// waitForRequest waits for the next command from the socket
+func waitForRequest(requests <-chan ipc.Request) tea.Cmd {
+	return func() tea.Msg {
+		return remoteRequestMsg{<-requests}
+	}
+}
+
+// handleRemote runs a command which arrived on the socket and replies to it, the replies start
+// with "error:" if the command failed
+func (m Model) handleRemote(req ipc.Request) (tea.Model, tea.Cmd) {
+	wait := waitForRequest(m.requests)
+	if m.waitingForSize {
+		req.Reply("error: goread is still starting")
+		return m, wait
+	}
+
+	if req.Command == "unread-count" {
+		count, ok := m.unread.Total, true
+		if req.Arg != "" {
+			count, ok = m.unread.Feeds[req.Arg]
+			if !ok {
+				count, ok = m.unread.Categories[req.Arg]
+			}
+		}
+
+		if !ok {
+			req.Reply(fmt.Sprintf("error: there is no category or feed named %q", req.Arg))
+		} else {
+			req.Reply(strconv.Itoa(count))
+		}
+
+		return m, wait
+	}
+
+	// The socket refuses them too, the browser checks again before anything is added
+	if ipc.AddsExecFeed(req.Command, req.Arg) {
+		req.Reply("error: exec feeds can't be added over the socket, add them to the urls file")
+		return m, wait
+	}
+
+	var updated tea.Model
+	var cmd tea.Cmd
+	m.msg = ""
+	switch req.Command {
+	case "refresh":
+		updated, cmd = m.update(refreshAllMsg{})
+
+	case "open-url":
+		name := m.feedNameFromURL(req.Arg)
+		if name == "" {
+			req.Reply(fmt.Sprintf("error: there is no feed with the url %q", req.Arg))
+			return m, wait
+		}
+
+		updated, cmd = m.openCommand(name)
+
+	case "open":
+		updated, cmd = m.openCommand(req.Arg)
+
+	case "add-feed":
+		updated, cmd = m.addFeedCommand(req.Arg)
+
+	case "run":
+		updated, cmd = m.runCommand(req.Arg)
+
+	default:
+		req.Reply(fmt.Sprintf("error: unknown command %q, use one of %s", req.Command, strings.Join(remoteCommands, ", ")))
+		return m, wait
+	}
+
+	// The commands show their errors in the status bar
+	if model, ok := updated.(Model); ok && strings.HasPrefix(model.msg, "Error") {
+		req.Reply("error: " + model.msg)
+	} else {
+		req.Reply("ok")
+	}
+
+	return updated, tea.Batch(cmd, wait)
+}
+
+// feedNameFromURL returns the name of the subscription with the url
+func (m Model) feedNameFromURL(url string) string {
+	for _, cat := range m.backend.Rss.Categories {
+		for _, sub := range cat.Subscriptions {
+			if sub.URL == url {
+				return sub.Name
+			}
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,126 @@
+package browser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/TypicalAM/goread/internal/backend"
+	"github.com/TypicalAM/goread/internal/theme"
+	"github.com/TypicalAM/goread/internal/ui/popup"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// shareMenu is a popup where the user picks the read-later service the articles are shared to
+type shareMenu struct {
+	style    paletteStyle
+	overlay  popup.Overlay
+	msg      backend.ShareArticlesMsg
+	services []string
+	selected int
+}
+
+// newShareMenu returns a new share menu popup.
+func newShareMenu(colors *theme.Colors, bgRaw string, width, height int, msg backend.ShareArticlesMsg, services []string) shareMenu {
+	return shareMenu{
+		style:    newPaletteStyle(colors, width, height),
+		overlay:  popup.NewOverlay(bgRaw, width, height),
+		msg:      msg,
+		services: services,
+	}
+}
+
+// Init initializes the popup.
+func (s shareMenu) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles the selection, the services can also be picked by their number.
+func (s shareMenu) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return s, nil
+	}
+
+	switch keyMsg.String() {
+	case "down", "j", "tab":
+		s.selected = (s.selected + 1) % len(s.services)
+
+	case "up", "k", "shift+tab":
+		s.selected = (s.selected - 1 + len(s.services)) % len(s.services)
+
+	case "enter":
+		return s, s.choose(s.selected)
+
+	default:
+		if key := keyMsg.String(); len(key) == 1 && key[0] >= '1' && int(key[0]-'0') <= len(s.services) {
+			return s, s.choose(int(key[0] - '1'))
+		}
+	}
+
+	return s, nil
+}
+
+// choose shares the articles to the service, the browser handles the message
+func (s shareMenu) choose(index int) tea.Cmd {
+	chosen := s.msg
+	chosen.Service = s.services[index]
+	return func() tea.Msg { return commandChosenMsg{chosen} }
+}
+
+// View renders the popup.
+func (s shareMenu) View() string {
+	rows := make([]string, len(s.services))
+	for i, service := range s.services {
+		style := s.style.command
+		if i == s.selected {
+			style = s.style.selectedCommand
+		}
+
+		rows[i] = style.Render(fmt.Sprintf("%d. %s", i+1, service))
+	}
+
+	title := "Share the article to"
+	if len(s.msg.Indexes) > 1 {
+		title = fmt.Sprintf("Share %d articles to", len(s.msg.Indexes))
+	}
+
+	return s.overlay.WrapView(s.style.box.Render(lipgloss.JoinVertical(
+		lipgloss.Left,
+		s.style.title.Render(title),
+		s.style.input.Render(strings.Join(rows, "\n")),
+	)))
+}
+
+// shareArticles shares the articles to the chosen service, the share menu is shown if the service
+// isn't chosen and there is more than one
+func (m Model) shareArticles(msg backend.ShareArticlesMsg) (tea.Model, tea.Cmd) {
+	services := m.backend.ShareServices()
+	switch {
+	case len(services) == 0:
+		m.msg = "Error sharing: no read-later service is configured, add one in the share section of the config"
+		return m, nil
+
+	case msg.Service == "" && len(services) > 1:
+		bg := m.View()
+		width := m.width / 3
+		if width < 30 {
+			width = 30
+		}
+
+		m.popup = newShareMenu(m.style.colors, bg, width, len(services)+7, msg, services)
+		m.keymap.SetEnabled(false)
+		return m, m.popup.Init()
+
+	case msg.Service == "":
+		msg.Service = services[0]
+	}
+
+	m.msg = fmt.Sprintf("Sharing to %s", msg.Service)
+	cmds := make([]tea.Cmd, len(msg.Indexes))
+	for i, index := range msg.Indexes {
+		cmds[i] = m.backend.ShareArticle(msg.FeedName, index, msg.Service)
+	}
+
+	return m, tea.Batch(cmds...)
+}
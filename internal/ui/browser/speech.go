@@ -0,0 +1,86 @@
+package browser
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/TypicalAM/goread/internal/backend"
+	"github.com/TypicalAM/goread/internal/backend/speech"
+	"github.com/TypicalAM/goread/internal/theme"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/muesli/reflow/truncate"
+)
+
+// speechTitleWidth is the largest width of the title of the article read aloud in the status bar
+const speechTitleWidth = 30
+
+// pauseSpeechMsg and stopSpeechMsg control the reading aloud from the command palette
+type (
+	pauseSpeechMsg struct{}
+	stopSpeechMsg  struct{}
+)
+
+// waitSpeech waits for the reading to end, so that the status bar stops showing it
+func waitSpeech(done <-chan struct{}) tea.Cmd {
+	return func() tea.Msg {
+		<-done
+		return backend.SpeechEndedMsg{}
+	}
+}
+
+// pauseSpeech pauses the reading aloud or resumes the paused one
+func (m Model) pauseSpeech() (tea.Model, tea.Cmd) {
+	if err := m.backend.Speaker.TogglePause(); err != nil {
+		m.msg = fmt.Sprintf("Error pausing the reading: %s", err.Error())
+		log.Println(m.msg)
+	}
+
+	return m, nil
+}
+
+// stopSpeech stops the reading aloud
+func (m Model) stopSpeech() (tea.Model, tea.Cmd) {
+	if err := m.backend.Speaker.Stop(); err != nil {
+		m.msg = fmt.Sprintf("Error stopping the reading: %s", err.Error())
+		log.Println(m.msg)
+	}
+
+	return m, nil
+}
+
+// speechStatus describes the article read aloud in the status bar, it's empty if nothing is read
+func (m Model) speechStatus() string {
+	if m.backend.Speaker == nil {
+		return ""
+	}
+
+	state, title := m.backend.Speaker.Status()
+	title = truncate.StringWithTail(title, speechTitleWidth, theme.Icons.Tail)
+	switch state {
+	case speech.Speaking:
+		return fmt.Sprintf("Reading %s (%s pause, %s stop)", title,
+			m.keymap.PauseSpeech.Help().Key, m.keymap.StopSpeech.Help().Key)
+	case speech.Paused:
+		return fmt.Sprintf("Paused %s (%s resume, %s stop)", title,
+			m.keymap.PauseSpeech.Help().Key, m.keymap.StopSpeech.Help().Key)
+	default:
+		return ""
+	}
+}
+
+// speechCommands returns the commands of the palette which control the reading aloud
+func (m Model) speechCommands() []command {
+	if m.backend.Speaker == nil {
+		return nil
+	}
+
+	state, title := m.backend.Speaker.Status()
+	switch state {
+	case speech.Speaking:
+		return []command{{"Pause reading aloud", title, pauseSpeechMsg{}}, {"Stop reading aloud", title, stopSpeechMsg{}}}
+	case speech.Paused:
+		return []command{{"Resume reading aloud", title, pauseSpeechMsg{}}, {"Stop reading aloud", title, stopSpeechMsg{}}}
+	default:
+		return nil
+	}
+}
@@ -9,7 +9,6 @@ import (
 // style is the internal style of the browser
 type style struct {
 	colors               *theme.Colors
-	errMsg               lipgloss.Style
 	activeTab            lipgloss.Style
 	activeTabIcon        lipgloss.Style
 	tab                  lipgloss.Style
@@ -18,14 +17,15 @@ type style struct {
 	statusBarGap         lipgloss.Style
 	statusBarCell        lipgloss.Style
 	offlineStatusBarCell lipgloss.Style
+	refreshStatusBarCell lipgloss.Style
+	infoStatusBarCell    lipgloss.Style
+	msgStatusBarCell     lipgloss.Style
+	errStatusBarCell     lipgloss.Style
+	helpLine             lipgloss.Style
 }
 
 // newStyle creates a new style
 func newStyle(colors *theme.Colors) style {
-	errMsg := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#f08ca8")).
-		Italic(true)
-
 	activeTab := lipgloss.NewStyle().
 		Padding(0, 7, 0, 1).
 		Italic(true).
@@ -58,10 +58,21 @@ func newStyle(colors *theme.Colors) style {
 		Padding(0, 1).
 		Foreground(colors.BgDark)
 
+	refreshStatusBarCell := statusBarCell.Copy().
+		Bold(false).
+		Background(colors.BgDark).
+		Foreground(colors.TextDark)
+
+	msgStatusBarCell := refreshStatusBarCell.Copy().
+		Foreground(colors.Text)
+
+	helpLine := lipgloss.NewStyle().
+		Foreground(colors.TextDark).
+		Faint(true)
+
 	return style{
 		colors:               colors,
-		errMsg:               errMsg,
-		activeTab:            activeTab,
+		activeTab:            theme.Emphasize(activeTab),
 		activeTabIcon:        activeTabIcon,
 		tab:                  tabStyle,
 		tabIcon:              tabIcon,
@@ -69,11 +80,17 @@ func newStyle(colors *theme.Colors) style {
 		statusBarGap:         statusBarGap,
 		statusBarCell:        statusBarCell,
 		offlineStatusBarCell: statusBarCell.Copy().Background(colors.TextDark),
+		refreshStatusBarCell: refreshStatusBarCell,
+		infoStatusBarCell:    statusBarCell.Copy().Bold(false).Background(colors.BgDarker).Foreground(colors.Text),
+		msgStatusBarCell:     msgStatusBarCell,
+		errStatusBarCell:     msgStatusBarCell.Copy().Foreground(lipgloss.Color("#f08ca8")).Italic(true),
+		helpLine:             helpLine,
 	}
 }
 
-// attachIcon attaches an icon based on the tab type
-func (s style) attachIcon(tabToStyle tab.Tab, title string, active bool) string {
+// attachIcon attaches an icon based on the tab type, the badge is shown after the shortened title
+// and the pinned tabs are marked
+func (s style) attachIcon(tabToStyle tab.Tab, title, badge string, active, pinned bool) string {
 	var iconStyle, textStyle lipgloss.Style
 	if active {
 		iconStyle, textStyle = s.activeTabIcon, s.activeTab
@@ -82,7 +99,15 @@ func (s style) attachIcon(tabToStyle tab.Tab, title string, active bool) string
 	}
 
 	if len(title) > 12 {
-		title = title[:12] + ""
+		title = title[:12] + theme.Icons.Ellipsis
+	}
+
+	if pinned {
+		title += " " + theme.Icons.Pinned
+	}
+
+	if badge != "" {
+		title += " " + badge
 	}
 
 	tabStyle := tabToStyle.Style()
@@ -0,0 +1,187 @@
+package browser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/TypicalAM/goread/internal/theme"
+	"github.com/TypicalAM/goread/internal/ui/popup"
+	"github.com/TypicalAM/goread/internal/ui/tab"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// themePickerMsg is sent when the theme picker should be shown
+type themePickerMsg struct{}
+
+// themePreviewMsg is sent when another theme is selected in the picker, it's shown right away
+type themePreviewMsg struct{ selected int }
+
+// themeChosenMsg is sent when the theme should be used
+type themeChosenMsg struct{ name string }
+
+// themeReloadMsg is sent when the theme should be read again from its file
+type themeReloadMsg struct{}
+
+// themePicker is a popup which lists the themes, the selected theme is previewed in the background
+type themePicker struct {
+	style    paletteStyle
+	overlay  popup.Overlay
+	names    []string
+	selected int
+	original theme.Colors
+}
+
+// newThemePicker returns a new theme picker popup, the original colors are restored if the
+// picker is closed without choosing
+func newThemePicker(colors *theme.Colors, bgRaw string, width, height int, names []string, selected int, original theme.Colors) themePicker {
+	return themePicker{
+		style:    newPaletteStyle(colors, width, height),
+		overlay:  popup.NewOverlay(bgRaw, width, height),
+		names:    names,
+		selected: selected,
+		original: original,
+	}
+}
+
+// Init initializes the popup.
+func (t themePicker) Init() tea.Cmd {
+	return nil
+}
+
+// Update moves the selection, every move previews the selected theme.
+func (t themePicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return t, nil
+	}
+
+	switch keyMsg.String() {
+	case "down", "j", "tab":
+		t.selected = (t.selected + 1) % len(t.names)
+
+	case "up", "k", "shift+tab":
+		t.selected = (t.selected - 1 + len(t.names)) % len(t.names)
+
+	case "enter":
+		chosen := themeChosenMsg{t.names[t.selected]}
+		return t, func() tea.Msg { return commandChosenMsg{chosen} }
+
+	default:
+		return t, nil
+	}
+
+	selected := t.selected
+	return t, func() tea.Msg { return themePreviewMsg{selected} }
+}
+
+// View renders the popup.
+func (t themePicker) View() string {
+	rows := make([]string, len(t.names))
+	for i, name := range t.names {
+		style := t.style.command
+		if i == t.selected {
+			style = t.style.selectedCommand
+		}
+
+		if name == theme.CustomName {
+			name += t.style.desc.Render(" - the colorscheme file")
+		}
+
+		rows[i] = style.Render(name)
+	}
+
+	return t.overlay.WrapView(t.style.box.Render(lipgloss.JoinVertical(
+		lipgloss.Left,
+		t.style.title.Render("Choose a theme"),
+		t.style.input.Render(strings.Join(rows, "\n")),
+	)))
+}
+
+// showThemePicker shows the theme picker with the current theme selected
+func (m Model) showThemePicker() (tea.Model, tea.Cmd) {
+	names := m.style.colors.Themes()
+	current := m.style.colors.Name
+	if current == "" {
+		current = theme.CustomName
+	}
+
+	var selected int
+	for i, name := range names {
+		if name == current {
+			selected = i
+		}
+	}
+
+	return m.openThemePicker(names, selected, *m.style.colors)
+}
+
+// openThemePicker opens the theme picker over the browser rendered with the current colors
+func (m Model) openThemePicker(names []string, selected int, original theme.Colors) (tea.Model, tea.Cmd) {
+	m.popup = nil
+	bg := m.View()
+	width := m.width / 3
+	if width < 30 {
+		width = 30
+	}
+
+	height := len(names) + 7
+	if height > m.height {
+		height = m.height
+	}
+
+	m.popup = newThemePicker(m.style.colors, bg, width, height, names, selected, original)
+	m.keymap.SetEnabled(false)
+	return m, m.popup.Init()
+}
+
+// previewTheme shows the theme selected in the picker
+func (m Model) previewTheme(msg themePreviewMsg) (tea.Model, tea.Cmd) {
+	picker, ok := m.popup.(themePicker)
+	if !ok {
+		return m, nil
+	}
+
+	if err := m.style.colors.SetTheme(picker.names[msg.selected]); err != nil {
+		*m.style.colors = picker.original
+		m.msg = fmt.Sprintf("Error previewing the theme: %s", err.Error())
+	}
+
+	return m.restyle().openThemePicker(picker.names, msg.selected, picker.original)
+}
+
+// setTheme uses the theme with the name, the colors of the open tabs are changed right away
+func (m Model) setTheme(name string) (tea.Model, tea.Cmd) {
+	if err := m.style.colors.SetTheme(name); err != nil {
+		m.msg = fmt.Sprintf("Error setting the theme: %s", err.Error())
+		return m, nil
+	}
+
+	m = m.restyle()
+	m.msg = fmt.Sprintf("Using the theme %s, set theme: %s in the config to keep it", name, name)
+	return m, nil
+}
+
+// reloadTheme reads the theme from its file again
+func (m Model) reloadTheme() (tea.Model, tea.Cmd) {
+	if err := m.style.colors.Reload(); err != nil {
+		m.msg = fmt.Sprintf("Error reloading the theme: %s", err.Error())
+		return m, nil
+	}
+
+	m = m.restyle()
+	m.msg = "Reloaded the theme"
+	return m, nil
+}
+
+// restyle rebuilds the styles of the browser and the tabs after the colors changed
+func (m Model) restyle() Model {
+	m.style = newStyle(m.style.colors)
+	m.cmdLine = m.cmdLine.restyle(m.style.colors)
+	for i := range m.tabs {
+		updated, _ := m.tabs[i].Update(tab.RestyleMsg{})
+		m.tabs[i] = updated.(tab.Tab)
+	}
+
+	return m
+}
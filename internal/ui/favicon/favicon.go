@@ -0,0 +1,103 @@
+package favicon
+
+import (
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/TypicalAM/goread/internal/backend"
+	"github.com/TypicalAM/goread/internal/theme"
+	"github.com/TypicalAM/goread/internal/ui/graphics"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Width is the number of the cells taken up by an icon
+const Width = 2
+
+// Enabled shows the icons of the feeds next to their names
+var Enabled bool
+
+// Protocol is the graphics protocol used to display the icons, without the kitty protocol they are
+// drawn using the half blocks. The iTerm and sixel images would be drawn again with every line of
+// the list which changes
+var Protocol graphics.Protocol
+
+var (
+	mu        sync.Mutex
+	icons     = make(map[string]string)
+	requested = make(map[string]bool)
+)
+
+// Load returns the command which loads the icons of the feeds, every icon is only loaded once
+func Load(feedNames ...string) tea.Cmd {
+	if !Enabled || theme.NoColor {
+		return nil
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	var cmds []tea.Cmd
+	for _, name := range feedNames {
+		if name != "" && !requested[name] {
+			requested[name] = true
+			cmds = append(cmds, backend.LoadFavicon(name))
+		}
+	}
+
+	return tea.Batch(cmds...)
+}
+
+// Set keeps the loaded icon of a feed, the returned command transmits it to the terminal
+func Set(msg backend.FaviconLoadedMsg) tea.Cmd {
+	if msg.Err != nil {
+		log.Printf("Error loading the icon of %s: %v\n", msg.FeedName, msg.Err)
+		return nil
+	}
+
+	var icon string
+	var transmit tea.Cmd
+	if Protocol == graphics.Kitty {
+		img, err := graphics.Decode(msg.Data, Width, 1)
+		if err != nil {
+			log.Printf("Error decoding the icon of %s: %v\n", msg.FeedName, err)
+			return nil
+		}
+
+		kittyImage := graphics.NewKittyImage(img)
+		icon = kittyImage.Placeholder() + strings.Repeat(" ", Width-img.Cols)
+		transmit = func() tea.Msg {
+			if err := kittyImage.Transmit(graphics.Terminal); err != nil {
+				log.Printf("Error transmitting the icon of %s: %v\n", msg.FeedName, err)
+			}
+
+			return nil
+		}
+	} else {
+		var err error
+		if icon, err = graphics.Blocks(msg.Data, Width, 1); err != nil {
+			log.Printf("Error decoding the icon of %s: %v\n", msg.FeedName, err)
+			return nil
+		}
+	}
+
+	mu.Lock()
+	icons[msg.FeedName] = icon
+	mu.Unlock()
+	return transmit
+}
+
+// Get returns the icon of the feed followed by a space. It's blank if the feed has no icon, so that
+// the names stay aligned, and empty if the icons aren't shown
+func Get(feedName string) string {
+	if !Enabled || theme.NoColor {
+		return ""
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if icon, ok := icons[feedName]; ok {
+		return icon + " "
+	}
+
+	return strings.Repeat(" ", Width+1)
+}
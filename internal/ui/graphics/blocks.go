@@ -0,0 +1,85 @@
+package graphics
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// upperHalf and lowerHalf draw two pixels in a cell, the foreground one and the background one
+const (
+	upperHalf = "▀"
+	lowerHalf = "▄"
+)
+
+// Blocks draws a tiny version of an image using the half blocks, every cell shows two pixels
+// stacked on top of each other. It works in every terminal with colors, so it's the fallback for
+// the small images like the icons
+func Blocks(data []byte, cols, rows int) (string, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+
+	bounds := img.Bounds()
+	if bounds.Dx() == 0 || bounds.Dy() == 0 {
+		return "", errors.New("empty image")
+	}
+
+	lines := make([]string, rows)
+	for row := 0; row < rows; row++ {
+		var line strings.Builder
+		for col := 0; col < cols; col++ {
+			top, topOk := average(img, col, 2*row, cols, 2*rows)
+			bottom, bottomOk := average(img, col, 2*row+1, cols, 2*rows)
+			switch {
+			case topOk && bottomOk:
+				line.WriteString(lipgloss.NewStyle().Foreground(top).Background(bottom).Render(upperHalf))
+			case topOk:
+				line.WriteString(lipgloss.NewStyle().Foreground(top).Render(upperHalf))
+			case bottomOk:
+				line.WriteString(lipgloss.NewStyle().Foreground(bottom).Render(lowerHalf))
+			default:
+				line.WriteString(" ")
+			}
+		}
+
+		lines[row] = line.String()
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// average returns the average color of a part of the image, which is split into a grid of the
+// given size. It reports false if the part is mostly transparent
+func average(img image.Image, x, y, width, height int) (lipgloss.Color, bool) {
+	bounds := img.Bounds()
+	x0, x1 := bounds.Min.X+x*bounds.Dx()/width, bounds.Min.X+(x+1)*bounds.Dx()/width
+	y0, y1 := bounds.Min.Y+y*bounds.Dy()/height, bounds.Min.Y+(y+1)*bounds.Dy()/height
+	if x1 == x0 {
+		x1++
+	}
+
+	if y1 == y0 {
+		y1++
+	}
+
+	var r, g, b, a, count uint64
+	for py := y0; py < y1; py++ {
+		for px := x0; px < x1; px++ {
+			pr, pg, pb, pa := img.At(px, py).RGBA()
+			r, g, b, a, count = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa), count+1
+		}
+	}
+
+	if a < count*0x8000 {
+		return "", false
+	}
+
+	// The colors are premultiplied by the alpha, so they're divided by it to get the visible color
+	return lipgloss.Color(fmt.Sprintf("#%02x%02x%02x", r*0xff/a, g*0xff/a, b*0xff/a)), true
+}
@@ -0,0 +1,16 @@
+//go:build !windows
+
+package graphics
+
+import "golang.org/x/sys/unix"
+
+// DetectCellSize asks the terminal for the size of its cells in pixels, the defaults are kept if
+// the terminal doesn't tell
+func DetectCellSize(f File) {
+	size, err := unix.IoctlGetWinsize(int(f.Fd()), unix.TIOCGWINSZ)
+	if err != nil || size.Col == 0 || size.Row == 0 || size.Xpixel == 0 || size.Ypixel == 0 {
+		return
+	}
+
+	CellWidth, CellHeight = int(size.Xpixel/size.Col), int(size.Ypixel/size.Row)
+}
@@ -0,0 +1,6 @@
+//go:build windows
+
+package graphics
+
+// DetectCellSize keeps the default size of the cells, the console doesn't report it
+func DetectCellSize(_ File) {}
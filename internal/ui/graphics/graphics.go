@@ -0,0 +1,119 @@
+package graphics
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/png"
+	"os"
+	"strings"
+
+	// Register the decoders of the common image formats
+	_ "image/gif"
+	_ "image/jpeg"
+)
+
+// ErrUnsupported is returned when the images can't be displayed in the terminal
+var ErrUnsupported = errors.New("images are not supported in this terminal")
+
+// Protocol is a graphics protocol supported by a terminal
+type Protocol int
+
+const (
+	// None means that the terminal can't display images
+	None Protocol = iota
+	// Kitty is the kitty graphics protocol, it's also supported by ghostty
+	Kitty
+	// ITerm is the iTerm2 inline images protocol, it's also supported by wezterm
+	ITerm
+	// Sixel is the sixel protocol, supported by foot, mlterm and xterm (with the right options)
+	Sixel
+)
+
+// String returns the name of the protocol
+func (p Protocol) String() string {
+	switch p {
+	case Kitty:
+		return "kitty"
+	case ITerm:
+		return "iterm"
+	case Sixel:
+		return "sixel"
+	default:
+		return "none"
+	}
+}
+
+// Inline reports if the images can be displayed inside the application. The kitty images are placed
+// using unicode placeholders, the iTerm and sixel images are drawn by the Output after every frame
+func (p Protocol) Inline() bool {
+	return p != None
+}
+
+// Detect guesses the graphics protocol supported by the terminal using its environment variables
+func Detect() Protocol {
+	term := os.Getenv("TERM")
+	termProgram := os.Getenv("TERM_PROGRAM")
+
+	switch {
+	case os.Getenv("KITTY_WINDOW_ID") != "" || term == "xterm-kitty":
+		return Kitty
+	case termProgram == "ghostty" || term == "xterm-ghostty":
+		return Kitty
+	case termProgram == "iTerm.app" || termProgram == "WezTerm":
+		return ITerm
+	case strings.HasPrefix(term, "foot") || strings.HasPrefix(term, "mlterm") || strings.Contains(term, "sixel"):
+		return Sixel
+	default:
+		return None
+	}
+}
+
+// Image is a decoded image which is ready to be transmitted to the terminal
+type Image struct {
+	png    []byte
+	pixels image.Image
+	Cols   int
+	Rows   int
+}
+
+// Decode decodes an image and computes its size in cells so that it fits in the given amount of
+// columns and rows. A terminal cell is assumed to be twice as high as it's wide
+func Decode(data []byte, maxCols, maxRows int) (Image, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return Image{}, err
+	}
+
+	bounds := img.Bounds()
+	if bounds.Dx() == 0 || bounds.Dy() == 0 {
+		return Image{}, errors.New("empty image")
+	}
+
+	// Don't upscale small images too much, assume that a cell is around 8 pixels wide
+	cols := bounds.Dx() / 8
+	if cols > maxCols {
+		cols = maxCols
+	}
+
+	rows := cols * bounds.Dy() / bounds.Dx() / 2
+	if rows > maxRows {
+		rows = maxRows
+		cols = rows * 2 * bounds.Dx() / bounds.Dy()
+	}
+
+	if rows < 1 {
+		rows = 1
+	}
+
+	if cols < 1 {
+		cols = 1
+	}
+
+	var buf bytes.Buffer
+	if err = png.Encode(&buf, img); err != nil {
+		return Image{}, err
+	}
+
+	return Image{png: buf.Bytes(), pixels: img, Cols: cols, Rows: rows}, nil
+}
@@ -0,0 +1,182 @@
+package graphics
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/muesli/ansi"
+)
+
+// The iTerm and sixel images are drawn where the cursor is, so unlike the kitty placeholders they
+// can't be a part of the view. Every row of an image starts with a placeholder which looks like a
+// short escape sequence instead, so that it takes up no space. The Output removes them from the
+// frames and draws the images once the frame is written, the images are drawn again whenever one
+// of their rows is repainted
+const inlinePlaceholder = "\x1b]1337;%d;%dI"
+
+// inlinePattern matches the placeholders, the first number is the id of the image and the second
+// one is the row
+var inlinePattern = regexp.MustCompile("\x1b\\]1337;(\\d+);(\\d+)I")
+
+// lineBreakPattern matches the ends of the lines in a frame, the lines which didn't change are
+// skipped by moving the cursor down
+var lineBreakPattern = regexp.MustCompile("\r\n|\x1b\\[1B")
+
+// maxInlineImages is how many encoded images are kept to be drawn again, the oldest ones are dropped
+const maxInlineImages = 64
+
+var (
+	inlineMu     sync.Mutex
+	inlineImages = make(map[uint32]inlineImage)
+	inlineOrder  []uint32
+)
+
+// inlineImage is an encoded image which is drawn at the cursor
+type inlineImage struct {
+	seq  string
+	rows int
+}
+
+// InlineImage is an image drawn by the terminal using the iTerm or the sixel protocol
+type InlineImage struct {
+	Image
+	ID uint32
+}
+
+// NewInlineImage encodes the image for the protocol, it's drawn whenever its placeholder is written
+func NewInlineImage(img Image, protocol Protocol) InlineImage {
+	seq := encodeITerm(img)
+	if protocol == Sixel {
+		seq = encodeSixel(img)
+	}
+
+	id := nextID()
+	inlineMu.Lock()
+	defer inlineMu.Unlock()
+	inlineImages[id] = inlineImage{seq, img.Rows}
+	inlineOrder = append(inlineOrder, id)
+	if len(inlineOrder) > maxInlineImages {
+		delete(inlineImages, inlineOrder[0])
+		inlineOrder = inlineOrder[1:]
+	}
+
+	return InlineImage{img, id}
+}
+
+// Placeholder returns the text which reserves the space of the image, every row of the image is a
+// single line
+func (ii InlineImage) Placeholder() string {
+	lines := make([]string, ii.Rows)
+	for row := range lines {
+		lines[row] = fmt.Sprintf(inlinePlaceholder, ii.ID, row) + strings.Repeat(" ", ii.Cols)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Visible removes the placeholders of the images which aren't entirely in the view, an image can't
+// be cut so the rows which are visible stay blank
+func Visible(view string) string {
+	if !strings.Contains(view, "\x1b]1337;") {
+		return view
+	}
+
+	seen := make(map[uint32]map[int]int)
+	for _, match := range inlinePattern.FindAllStringSubmatch(view, -1) {
+		id, row := parsePlaceholder(match[1], match[2])
+		if seen[id] == nil {
+			seen[id] = make(map[int]int)
+		}
+
+		seen[id][row]++
+	}
+
+	inlineMu.Lock()
+	defer inlineMu.Unlock()
+	return inlinePattern.ReplaceAllStringFunc(view, func(match string) string {
+		groups := inlinePattern.FindStringSubmatch(match)
+		id, _ := parsePlaceholder(groups[1], groups[2])
+		img, ok := inlineImages[id]
+		if !ok || len(seen[id]) != img.rows {
+			return ""
+		}
+
+		// The same image shown twice can't be told apart
+		for _, count := range seen[id] {
+			if count != 1 {
+				return ""
+			}
+		}
+
+		return match
+	})
+}
+
+// drawInline removes the placeholders from the frame and draws their images after it. The frame
+// ends at the start of its last line, the images are placed relative to it and the cursor is put
+// back afterwards. The images which would reach the last line aren't drawn, the terminal could
+// scroll the screen
+func drawInline(frame []byte) []byte {
+	matches := inlinePattern.FindAllSubmatchIndex(frame, -1)
+	if matches == nil {
+		return frame
+	}
+
+	inlineMu.Lock()
+	defer inlineMu.Unlock()
+
+	lines := len(lineBreakPattern.FindAllIndex(frame, -1))
+	drawn := make(map[uint32]bool)
+	var result, images bytes.Buffer
+	last := 0
+	for _, match := range matches {
+		result.Write(frame[last:match[0]])
+		last = match[1]
+
+		id, row := parsePlaceholder(string(frame[match[2]:match[3]]), string(frame[match[4]:match[5]]))
+		img, ok := inlineImages[id]
+		if !ok || drawn[id] {
+			continue
+		}
+
+		// The image starts this many lines above the last line of the frame
+		up := len(lineBreakPattern.FindAllIndex(frame[match[1]:], -1)) + row
+		if up > lines || up < img.rows {
+			continue
+		}
+
+		lineStart := 0
+		if breaks := lineBreakPattern.FindAllIndex(frame[:match[0]], -1); len(breaks) > 0 {
+			lineStart = breaks[len(breaks)-1][1]
+		}
+
+		drawn[id] = true
+		images.WriteString("\x1b8")
+		fmt.Fprintf(&images, "\x1b[%dA", up)
+		if col := ansi.PrintableRuneWidth(string(frame[lineStart:match[0]])); col > 0 {
+			fmt.Fprintf(&images, "\x1b[%dC", col)
+		}
+
+		images.WriteString(img.seq)
+	}
+
+	result.Write(frame[last:])
+	if images.Len() > 0 {
+		result.WriteString("\x1b7")
+		result.Write(images.Bytes())
+		result.WriteString("\x1b8")
+	}
+
+	return result.Bytes()
+}
+
+// parsePlaceholder returns the id of the image and the row of a placeholder
+func parsePlaceholder(id, row string) (uint32, int) {
+	parsedID, _ := strconv.ParseUint(id, 10, 32)
+	parsedRow, _ := strconv.Atoi(row)
+	return uint32(parsedID), parsedRow
+}
@@ -0,0 +1,13 @@
+package graphics
+
+import (
+	"encoding/base64"
+	"fmt"
+)
+
+// encodeITerm returns the sequence which draws the image at the cursor using the iTerm2 inline
+// images protocol, the image is stretched over its cells since their size was already computed
+func encodeITerm(img Image) string {
+	return fmt.Sprintf("\x1b]1337;File=inline=1;size=%d;width=%d;height=%d;preserveAspectRatio=0;doNotMoveCursor=1:%s\a",
+		len(img.png), img.Cols, img.Rows, base64.StdEncoding.EncodeToString(img.png))
+}
@@ -0,0 +1,113 @@
+package graphics
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+// placeholder is the character which is replaced by the terminal with a part of the image
+const placeholder = "\U0010EEEE"
+
+// chunkSize is the maximum size of a single chunk of the transmitted data
+const chunkSize = 4096
+
+// diacritics encode the row and the column of a placeholder cell, see the rowcolumn-diacritics
+// table in the kitty documentation
+var diacritics = []rune{
+	0x0305, 0x030D, 0x030E, 0x0310, 0x0312, 0x033D, 0x033E, 0x033F, 0x0346, 0x034A, 0x034B, 0x034C,
+	0x0350, 0x0351, 0x0352, 0x0357, 0x035B, 0x0363, 0x0364, 0x0365, 0x0366, 0x0367, 0x0368, 0x0369,
+	0x036A, 0x036B, 0x036C, 0x036D, 0x036E, 0x036F, 0x0483, 0x0484, 0x0485, 0x0486, 0x0487, 0x0592,
+	0x0593, 0x0594, 0x0595, 0x0597, 0x0598, 0x0599, 0x059C, 0x059D, 0x059E, 0x059F, 0x05A0, 0x05A1,
+	0x05A8, 0x05A9, 0x05AB, 0x05AC, 0x05AF, 0x05C4, 0x0610, 0x0611, 0x0612, 0x0613, 0x0614, 0x0615,
+	0x0616, 0x0617, 0x0657, 0x0658, 0x0659, 0x065A, 0x065B, 0x065D, 0x065E, 0x06D6, 0x06D7, 0x06D8,
+	0x06D9, 0x06DA, 0x06DB, 0x06DC, 0x06DF, 0x06E0, 0x06E1, 0x06E2, 0x06E4, 0x06E7, 0x06E8, 0x06EB,
+	0x06EC, 0x0730, 0x0732, 0x0733, 0x0735, 0x0736, 0x073A, 0x073D, 0x073F, 0x0740, 0x0741, 0x0743,
+}
+
+// MaxRows is the maximum amount of rows an image can take up
+var MaxRows = len(diacritics)
+
+// lastID is the id of the last transmitted image, the ids are encoded in 24 bit colors
+var lastID = uint32(os.Getpid()&0xff) << 16
+
+// KittyImage is an image transmitted to a terminal supporting the kitty graphics protocol
+type KittyImage struct {
+	Image
+	ID uint32
+}
+
+// NewKittyImage assigns a new id to the image
+func NewKittyImage(img Image) KittyImage {
+	return KittyImage{img, nextID()}
+}
+
+// nextID returns the id of a new image, it's never zero
+func nextID() uint32 {
+	id := atomic.AddUint32(&lastID, 1) & 0xffffff
+	if id == 0 {
+		id = atomic.AddUint32(&lastID, 1) & 0xffffff
+	}
+
+	return id
+}
+
+// Transmit sends the image to the terminal and creates a virtual placement, which is displayed
+// wherever the placeholders are printed. The chunks are written at once, so that the output of
+// the application doesn't end up between them when the writer is an Output
+func (ki KittyImage) Transmit(w io.Writer) error {
+	data := base64.StdEncoding.EncodeToString(ki.png)
+	var sb strings.Builder
+	first := true
+	for len(data) > 0 {
+		chunk := data
+		if len(chunk) > chunkSize {
+			chunk = chunk[:chunkSize]
+		}
+
+		data = data[len(chunk):]
+		more := 0
+		if len(data) > 0 {
+			more = 1
+		}
+
+		var seq string
+		if first {
+			seq = fmt.Sprintf("\x1b_Ga=T,U=1,f=100,q=2,i=%d,c=%d,r=%d,m=%d;%s\x1b\\", ki.ID, ki.Cols, ki.Rows, more, chunk)
+		} else {
+			seq = fmt.Sprintf("\x1b_Gm=%d;%s\x1b\\", more, chunk)
+		}
+
+		sb.WriteString(seq)
+		first = false
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// Placeholder returns the text which displays the image, every row of the image is a single line
+func (ki KittyImage) Placeholder() string {
+	color := fmt.Sprintf("\x1b[38;2;%d;%d;%dm", ki.ID>>16&0xff, ki.ID>>8&0xff, ki.ID&0xff)
+	rows := ki.Rows
+	if rows > MaxRows {
+		rows = MaxRows
+	}
+
+	lines := make([]string, rows)
+	for row := 0; row < rows; row++ {
+		// Only the first cell needs the diacritics, the next ones continue the row
+		lines[row] = color + placeholder + string(diacritics[row]) + string(diacritics[0]) +
+			strings.Repeat(placeholder, ki.Cols-1) + "\x1b[39m"
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// DeleteAll returns the sequence which removes all the transmitted images from the terminal memory
+func DeleteAll() string {
+	return "\x1b_Ga=d,d=A,q=2\x1b\\"
+}
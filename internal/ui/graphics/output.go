@@ -0,0 +1,42 @@
+package graphics
+
+import (
+	"io"
+	"os"
+	"sync"
+)
+
+// File is the terminal which the program writes to
+type File interface {
+	io.ReadWriter
+	Fd() uintptr
+}
+
+// Terminal is where the images are transmitted, it's the output of the program once it's wrapped
+// with NewOutput. The commands run in their own goroutines, so they can't write to the terminal
+// directly without mixing their sequences with the frames of the program
+var Terminal io.Writer = os.Stdout
+
+// Output is the output of the program which the images are transmitted through, the frames and the
+// images are written under the same lock so that they never mix
+type Output struct {
+	File
+	mu *sync.Mutex
+}
+
+// NewOutput returns the output writing to the terminal
+func NewOutput(f File) Output {
+	return Output{f, &sync.Mutex{}}
+}
+
+// Write writes the data to the terminal, nothing else is written until it's done. The iTerm and
+// sixel images in the frame are drawn after it
+func (o Output) Write(data []byte) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if _, err := o.File.Write(drawInline(data)); err != nil {
+		return 0, err
+	}
+
+	return len(data), nil
+}
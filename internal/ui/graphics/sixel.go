@@ -0,0 +1,114 @@
+package graphics
+
+import (
+	"fmt"
+	"image"
+	"image/color/palette"
+	"image/draw"
+	"strings"
+)
+
+// CellWidth and CellHeight are the size of a terminal cell in pixels, the sixel images are scaled
+// to the cells they take up. DetectCellSize asks the terminal for the real size
+var (
+	CellWidth  = 8
+	CellHeight = 16
+)
+
+// encodeSixel returns the sequence which draws the image at the cursor using the sixel protocol.
+// The image is scaled to fit its cells and dithered to a palette, the transparent pixels are left
+// alone
+func encodeSixel(img Image) string {
+	bounds := img.pixels.Bounds()
+	width, height := img.Cols*CellWidth, img.Rows*CellHeight
+	if width*bounds.Dy() > height*bounds.Dx() {
+		width = height * bounds.Dx() / bounds.Dy()
+	} else {
+		height = width * bounds.Dy() / bounds.Dx()
+	}
+
+	if width < 1 || height < 1 {
+		return ""
+	}
+
+	scaled := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			scaled.Set(x, y, img.pixels.At(bounds.Min.X+x*bounds.Dx()/width, bounds.Min.Y+y*bounds.Dy()/height))
+		}
+	}
+
+	paletted := image.NewPaletted(scaled.Bounds(), palette.Plan9)
+	draw.FloydSteinberg.Draw(paletted, paletted.Bounds(), scaled, image.Point{})
+	opaque := func(x, y int) bool { return scaled.Pix[y*scaled.Stride+x*4+3] >= 0x80 }
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "\x1bP0;1;0q\"1;1;%d;%d", width, height)
+	defined := make([]bool, len(palette.Plan9))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if index := paletted.ColorIndexAt(x, y); opaque(x, y) && !defined[index] {
+				defined[index] = true
+				r, g, b, _ := palette.Plan9[index].RGBA()
+				fmt.Fprintf(&sb, "#%d;2;%d;%d;%d", index, r*100/0xffff, g*100/0xffff, b*100/0xffff)
+			}
+		}
+	}
+
+	// Every band is six pixels high, the colors of a band are drawn over each other
+	band := make([]byte, width)
+	for top := 0; top < height; top += 6 {
+		used := make([]bool, len(palette.Plan9))
+		for y := top; y < top+6 && y < height; y++ {
+			for x := 0; x < width; x++ {
+				used[paletted.ColorIndexAt(x, y)] = used[paletted.ColorIndexAt(x, y)] || opaque(x, y)
+			}
+		}
+
+		for index, ok := range used {
+			if !ok {
+				continue
+			}
+
+			for x := 0; x < width; x++ {
+				var bits byte
+				for y := top; y < top+6 && y < height; y++ {
+					if opaque(x, y) && int(paletted.ColorIndexAt(x, y)) == index {
+						bits |= 1 << (y - top)
+					}
+				}
+
+				band[x] = '?' + bits
+			}
+
+			fmt.Fprintf(&sb, "#%d", index)
+			writeSixels(&sb, strings.TrimRight(string(band), "?"))
+			sb.WriteByte('$')
+		}
+
+		if top+6 < height {
+			sb.WriteByte('-')
+		}
+	}
+
+	sb.WriteString("\x1b\\")
+	return sb.String()
+}
+
+// writeSixels writes the sixels of a color in a band, the repeated ones are shortened
+func writeSixels(sb *strings.Builder, sixels string) {
+	for start := 0; start < len(sixels); {
+		end := start
+		for end < len(sixels) && sixels[end] == sixels[start] {
+			end++
+		}
+
+		if end-start > 3 {
+			fmt.Fprintf(sb, "!%d%c", end-start, sixels[start])
+		} else {
+			sb.WriteString(sixels[start:end])
+		}
+
+		start = end
+	}
+}
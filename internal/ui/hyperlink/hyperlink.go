@@ -0,0 +1,131 @@
+package hyperlink
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"golang.org/x/term"
+)
+
+// Enabled makes the links clickable in the terminals which support the OSC 8 escape sequences
+var Enabled = true
+
+// resizeInterval is how often the size of the terminal is checked
+const resizeInterval = 250 * time.Millisecond
+
+// The views can't contain the OSC 8 sequences themselves, the width of the text is measured by
+// skipping the escape sequences until the first letter and the urls are full of letters. The views
+// contain placeholders which look like short escape sequences instead, they are replaced with the
+// real sequences when the output is written to the terminal
+const (
+	placeholderStart = "\x1b]8;%dL"
+	placeholderEnd   = "\x1b]8;L"
+	linkEnd          = "\x1b]8;;\x1b\\"
+)
+
+// placeholderPattern matches the placeholders and the ends of the lines, the links are closed at
+// the end of every line
+var placeholderPattern = regexp.MustCompile("\x1b\\]8;(\\d*)L|\n")
+
+var (
+	mu   sync.Mutex
+	ids  = make(map[string]int)
+	urls []string
+)
+
+// Wrap makes the text a link to the url, it's returned as it is if the links are disabled
+func Wrap(text, url string) string {
+	if !Enabled || url == "" || strings.ContainsAny(url, "\x1b\x07") {
+		return text
+	}
+
+	mu.Lock()
+	id, ok := ids[url]
+	if !ok {
+		id = len(urls)
+		ids[url] = id
+		urls = append(urls, url)
+	}
+	mu.Unlock()
+
+	return fmt.Sprintf(placeholderStart, id) + text + placeholderEnd
+}
+
+// Output is the output of the program which replaces the placeholders with the links
+type Output struct {
+	*os.File
+}
+
+// NewOutput returns the output writing to the terminal
+func NewOutput(f *os.File) Output {
+	return Output{f}
+}
+
+// Write writes the data to the terminal with the links
+func (o Output) Write(data []byte) (int, error) {
+	if _, err := o.File.Write(expand(data)); err != nil {
+		return 0, err
+	}
+
+	return len(data), nil
+}
+
+// expand replaces the placeholders with the OSC 8 sequences, the links which are still open at
+// the end of a line are closed so that they don't spill into the other panes
+func expand(data []byte) []byte {
+	mu.Lock()
+	defer mu.Unlock()
+
+	open := false
+	result := placeholderPattern.ReplaceAllFunc(data, func(match []byte) []byte {
+		closing := ""
+		if open {
+			closing, open = linkEnd, false
+		}
+
+		if match[0] == '\n' {
+			return []byte(closing + "\n")
+		}
+
+		id, err := strconv.Atoi(string(match[len("\x1b]8;") : len(match)-1]))
+		if err != nil || id >= len(urls) {
+			return []byte(closing)
+		}
+
+		open = true
+		return []byte(closing + "\x1b]8;;" + urls[id] + "\x1b\\")
+	})
+
+	if open {
+		result = append(result, linkEnd...)
+	}
+
+	return result
+}
+
+// WatchSize sends the size of the terminal to the program whenever it changes, bubbletea only
+// watches it if its output is the terminal itself. The watching stops when the channel is closed
+func WatchSize(p *tea.Program, f *os.File, done <-chan struct{}) {
+	width, height := 0, 0
+	ticker := time.NewTicker(resizeInterval)
+	defer ticker.Stop()
+
+	for {
+		if w, h, err := term.GetSize(int(f.Fd())); err == nil && (w != width || h != height) {
+			width, height = w, h
+			p.Send(tea.WindowSizeMsg{Width: w, Height: h})
+		}
+
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+	}
+}
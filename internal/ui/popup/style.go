@@ -41,7 +41,7 @@ func newStyle(colors *theme.Colors, width, height int) style {
 
 	return style{
 		button:       buttonStyle,
-		activeButton: activeButtonStyle,
+		activeButton: theme.Emphasize(activeButtonStyle),
 		question:     question,
 		general:      general,
 	}
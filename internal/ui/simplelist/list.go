@@ -1,6 +1,7 @@
 package simplelist
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 
@@ -13,9 +14,11 @@ import (
 
 // Keymap is the Keymap for the list
 type Keymap struct {
-	Open key.Binding
-	Up   key.Binding
-	Down key.Binding
+	Open   key.Binding
+	Up     key.Binding
+	Down   key.Binding
+	Mark   key.Binding
+	Visual key.Binding
 }
 
 // DefaultKeymap is the default keymap for the list
@@ -32,12 +35,22 @@ var DefaultKeymap = Keymap{
 		key.WithKeys("down", "j"),
 		key.WithHelp("↓/j", "Move down"),
 	),
+	Mark: key.NewBinding(
+		key.WithKeys(" "),
+		key.WithHelp("space", "Mark"),
+	),
+	Visual: key.NewBinding(
+		key.WithKeys("v"),
+		key.WithHelp("v", "Mark range"),
+	),
 }
 
 // Item is an item in the list
 type Item struct {
 	title string
+	label string
 	desc  string
+	badge string
 }
 
 // NewItem creates a new item
@@ -63,6 +76,32 @@ func (i Item) FilterValue() string {
 	return i.title
 }
 
+// Badge returns the text displayed next to the title of the item
+func (i Item) Badge() string {
+	return i.badge
+}
+
+// SetBadge returns a copy of the item with a text displayed next to its title
+func (i Item) SetBadge(badge string) Item {
+	i.badge = badge
+	return i
+}
+
+// Label returns the text displayed in place of the title, it's the title unless it was changed
+func (i Item) Label() string {
+	if i.label == "" {
+		return i.title
+	}
+
+	return i.label
+}
+
+// SetLabel returns a copy of the item displayed with the label, the title still identifies it
+func (i Item) SetLabel(label string) Item {
+	i.label = label
+	return i
+}
+
 // Model contains state of the list
 type Model struct {
 	Keymap       Keymap
@@ -74,7 +113,11 @@ type Model struct {
 	page         int
 	itemsPerPage int
 	selected     int
+	anchor       int
+	marked       map[string]bool
 	showDesc     bool
+	icons        func(name string) string
+	nameColors   func(name string) lipgloss.Color
 }
 
 // New creates a new list
@@ -95,6 +138,8 @@ func New(colors *theme.Colors, title string, height int, showDesc bool) Model {
 		itemsPerPage: itemsPerPage,
 		showDesc:     showDesc,
 		style:        style,
+		anchor:       -1,
+		marked:       make(map[string]bool),
 	}
 }
 
@@ -106,6 +151,16 @@ func (m Model) Init() tea.Cmd {
 // Update updates the model
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, m.Keymap.Mark):
+			m.toggleMark()
+			return m, nil
+
+		case key.Matches(msg, m.Keymap.Visual):
+			m.markRange()
+			return m, nil
+		}
+
 		switch msg.String() {
 		case "up", "k":
 			m.selected--
@@ -162,10 +217,43 @@ func (m Model) View() string {
 			break
 		}
 
+		var badge string
+		name := m.items[i].FilterValue()
+		if item, ok := m.items[i].(Item); ok {
+			name = item.Label()
+			if item.Badge() != "" {
+				badge = m.style.badgeStyle.Render(item.Badge())
+			}
+		}
+
+		itemStyle, selectedStyle := m.style.itemStyle, m.style.selectedStyle
+		if m.nameColors != nil {
+			if color := m.nameColors(m.items[i].FilterValue()); color != "" {
+				itemStyle = itemStyle.Copy().Foreground(color)
+				selectedStyle = selectedStyle.Copy().Foreground(color)
+			}
+		}
+
+		title := itemStyle.Render(name)
+		if i == m.selected {
+			title = selectedStyle.Render(name)
+		}
+
+		if m.marked[m.items[i].FilterValue()] {
+			title = m.style.markedStyle.Render(theme.Icons.Marked + name)
+		}
+
+		var icon string
+		if m.icons != nil {
+			icon = m.icons(m.items[i].FilterValue())
+		}
+
 		b.WriteString(lipgloss.JoinHorizontal(
 			lipgloss.Left,
 			m.style.styleIndex(i, i == m.selected),
-			m.style.itemStyle.Render(m.items[i].FilterValue()),
+			icon,
+			title,
+			badge,
 		))
 		b.WriteRune('\n')
 
@@ -195,6 +283,11 @@ func (m *Model) SetHeight(height int) {
 	m.height = height
 }
 
+// Restyle rebuilds the style of the list after the colors changed
+func (m *Model) Restyle() {
+	m.style = newListStyle(m.colors)
+}
+
 // Items returns the items in the list
 func (m Model) Items() []list.Item {
 	return m.items
@@ -207,6 +300,101 @@ func (m *Model) SetItems(items []list.Item) {
 	}
 
 	m.items = items
+
+	// Keep the marks of the items which are still there
+	present := make(map[string]bool, len(items))
+	for _, item := range items {
+		present[item.FilterValue()] = true
+	}
+
+	for name := range m.marked {
+		if !present[name] {
+			delete(m.marked, name)
+		}
+	}
+
+	if m.anchor >= len(items) {
+		m.anchor = -1
+	}
+}
+
+// SetIcons sets the function which returns the icon shown in front of the item with the name
+func (m *Model) SetIcons(icons func(name string) string) {
+	m.icons = icons
+}
+
+// SetColors sets the function which returns the color of the name of the item, an empty color keeps
+// the color of the list
+func (m *Model) SetColors(colors func(name string) lipgloss.Color) {
+	m.nameColors = colors
+}
+
+// SetBadge changes the badge of the item with the name
+func (m *Model) SetBadge(name, badge string) {
+	for i, item := range m.items {
+		if item, ok := item.(Item); ok && item.FilterValue() == name {
+			m.items[i] = item.SetBadge(badge)
+		}
+	}
+}
+
+// Marked returns the marked items in the order of the list
+func (m Model) Marked() []list.Item {
+	var result []list.Item
+	for _, item := range m.items {
+		if m.marked[item.FilterValue()] {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
+
+// ClearMarks unmarks all the items
+func (m *Model) ClearMarks() {
+	m.marked = make(map[string]bool)
+	m.anchor = -1
+}
+
+// toggleMark marks or unmarks the selected item and moves to the next one
+func (m *Model) toggleMark() {
+	if len(m.items) == 0 {
+		return
+	}
+
+	name := m.items[m.selected].FilterValue()
+	if m.marked[name] {
+		delete(m.marked, name)
+	} else {
+		m.marked[name] = true
+	}
+
+	m.anchor = m.selected
+	if m.selected < len(m.items)-1 {
+		m.selected++
+		if m.selected >= (m.page+1)*m.itemsPerPage {
+			m.page++
+		}
+	}
+}
+
+// markRange marks the items between the last marked item and the selected one
+func (m *Model) markRange() {
+	if m.anchor < 0 {
+		m.toggleMark()
+		return
+	}
+
+	from, to := m.anchor, m.selected
+	if from > to {
+		from, to = to, from
+	}
+
+	for i := from; i <= to && i < len(m.items); i++ {
+		m.marked[m.items[i].FilterValue()] = true
+	}
+
+	m.anchor = m.selected
 }
 
 // IsEmpty checks if the list is empty
@@ -243,11 +431,25 @@ func (m *Model) SetIndex(index int) {
 	m.selected = index
 }
 
+// Position describes the selected item for the screen readers
+func (m Model) Position() string {
+	if len(m.items) == 0 {
+		return "no items"
+	}
+
+	return fmt.Sprintf("item %d of %d", m.selected+1, len(m.items))
+}
+
 // ShortHelp returns the short help for the list
 func (m Model) ShortHelp() []key.Binding {
 	return []key.Binding{m.Keymap.Open, m.Keymap.Up, m.Keymap.Down}
 }
 
+// MarkHelp returns the help for marking the items
+func (m Model) MarkHelp() []key.Binding {
+	return []key.Binding{m.Keymap.Mark, m.Keymap.Visual}
+}
+
 // FullHelp returns the full help for the list
 func (m Model) FullHelp() [][]key.Binding {
 	return [][]key.Binding{m.ShortHelp()}
@@ -9,10 +9,13 @@ import (
 
 // listStyle is the style of the list.
 type listStyle struct {
-	colors       *theme.Colors
-	titleStyle   lipgloss.Style
-	noItemsStyle lipgloss.Style
-	itemStyle    lipgloss.Style
+	colors        *theme.Colors
+	titleStyle    lipgloss.Style
+	noItemsStyle  lipgloss.Style
+	itemStyle     lipgloss.Style
+	selectedStyle lipgloss.Style
+	markedStyle   lipgloss.Style
+	badgeStyle    lipgloss.Style
 
 	bracketStyle lipgloss.Style
 	numberStyle  lipgloss.Style
@@ -34,6 +37,14 @@ func newListStyle(colors *theme.Colors) listStyle {
 		MarginLeft(3).
 		Foreground(colors.Color2)
 
+	markedStyle := itemStyle.Copy().
+		Foreground(colors.Color4).
+		Bold(true)
+
+	badgeStyle := lipgloss.NewStyle().
+		MarginLeft(1).
+		Foreground(colors.TextDark)
+
 	bracketStyle := lipgloss.NewStyle().
 		Foreground(colors.Color7)
 
@@ -41,12 +52,15 @@ func newListStyle(colors *theme.Colors) listStyle {
 		Foreground(colors.Color6)
 
 	return listStyle{
-		colors:       colors,
-		titleStyle:   titleStyle,
-		noItemsStyle: noItemsStyle,
-		itemStyle:    itemStyle,
-		bracketStyle: bracketStyle,
-		numberStyle:  numberStyle,
+		colors:        colors,
+		titleStyle:    titleStyle,
+		noItemsStyle:  noItemsStyle,
+		itemStyle:     itemStyle,
+		selectedStyle: theme.Emphasize(itemStyle),
+		markedStyle:   markedStyle,
+		badgeStyle:    badgeStyle,
+		bracketStyle:  bracketStyle,
+		numberStyle:   numberStyle,
 	}
 }
 
@@ -62,7 +76,7 @@ func (s listStyle) styleDescription(description string) string {
 		MarginLeft(1).
 		Foreground(s.colors.Color3)
 
-	return arrowStyle.Render("⮡") + textStyle.Render(description)
+	return arrowStyle.Render(theme.Icons.Description) + textStyle.Render(description)
 }
 
 // styleIndex will style the index of the item
@@ -70,7 +84,7 @@ func (s listStyle) styleIndex(index int, isSelected bool) string {
 	// If the index is the active index render it differently
 	numberStyle := s.numberStyle.Copy()
 	if isSelected {
-		numberStyle = numberStyle.Background(s.colors.Text)
+		numberStyle = theme.Emphasize(numberStyle.Background(s.colors.Text))
 	}
 
 	// Check if the index is a digit
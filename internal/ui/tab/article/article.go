@@ -0,0 +1,206 @@
+package article
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/TypicalAM/goread/internal/backend"
+	"github.com/TypicalAM/goread/internal/theme"
+	"github.com/TypicalAM/goread/internal/ui/tab"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Keymap contains the key bindings for this tab
+type Keymap struct {
+	OpenInBrowser key.Binding
+}
+
+// DefaultKeymap contains the default key bindings for this tab
+var DefaultKeymap = Keymap{
+	OpenInBrowser: key.NewBinding(
+		key.WithKeys("o"),
+		key.WithHelp("o", "Open in browser"),
+	),
+}
+
+// SetEnabled allows to disable/enable shortcuts
+func (k *Keymap) SetEnabled(enabled bool) {
+	k.OpenInBrowser.SetEnabled(enabled)
+}
+
+// Model contains the state of this tab, it keeps a single article open while the other tabs are
+// used. The article is rendered again when the size or the theme change
+type Model struct {
+	colors   *theme.Colors
+	keymap   Keymap
+	style    style
+	viewport viewport.Model
+	opener   func(url string) error
+	title    string
+	link     string
+	markdown string
+	width    int
+	height   int
+}
+
+// New creates a new article tab with the markdown of the article, the opener opens the link in the browser
+func New(colors *theme.Colors, width, height int, title, link, markdown string, opener func(url string) error) Model {
+	log.Println("Creating new article tab")
+	m := Model{
+		colors:   colors,
+		keymap:   DefaultKeymap,
+		style:    newStyle(colors),
+		viewport: viewport.New(width, 0),
+		opener:   opener,
+		title:    title,
+		link:     link,
+		markdown: markdown,
+	}
+
+	return m.SetSize(width, height).(Model)
+}
+
+// Title returns the title of the tab
+func (m Model) Title() string {
+	return m.title
+}
+
+// Identity returns the link of the article, the title is used if it has no link
+func (m Model) Identity() string {
+	if m.link == "" {
+		return m.title
+	}
+
+	return m.link
+}
+
+// Style returns the style of the tab
+func (m Model) Style() tab.Style {
+	return tab.Style{
+		Color: m.colors.Color2,
+		Icon:  theme.Icons.Article,
+		Name:  "ARTICLE",
+	}
+}
+
+// Progress describes how much of the article was read
+func (m Model) Progress() string {
+	if m.viewport.TotalLineCount() == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("line %d of %d, %d%%", m.viewport.YOffset+1, m.viewport.TotalLineCount(),
+		int(m.viewport.ScrollPercent()*100))
+}
+
+// Position describes the line of the article for the screen readers
+func (m Model) Position() string {
+	return fmt.Sprintf("article text, line %d of %d", m.viewport.YOffset+1, m.viewport.TotalLineCount())
+}
+
+// SetSize sets the dimensions of the tab, the article is wrapped to the new width
+func (m Model) SetSize(width, height int) tab.Tab {
+	if width == m.width && height == m.height {
+		return m
+	}
+
+	m.width = width
+	m.height = height
+	m.viewport.Width = width
+	m.viewport.Height = height - lipgloss.Height(m.header()) - m.style.viewport.GetVerticalFrameSize()
+	return m.render()
+}
+
+// Init initializes the tab
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update updates the variables of the tab
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tab.RestyleMsg:
+		m.style = newStyle(m.colors)
+		return m.render(), nil
+
+	case backend.SetEnableKeybindMsg:
+		m.keymap.SetEnabled(bool(msg))
+		return m, nil
+
+	case tea.KeyMsg:
+		switch {
+		case msg.String() == "esc":
+			return m, backend.StartQuitting()
+
+		case key.Matches(msg, m.keymap.OpenInBrowser):
+			if m.link == "" {
+				return m, nil
+			}
+
+			if err := m.opener(m.link); err != nil {
+				return m, func() tea.Msg { return backend.FetchErrorMsg{Err: err, Description: "Error opening the article"} }
+			}
+
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+// View returns the view of the tab
+func (m Model) View() string {
+	return lipgloss.JoinVertical(lipgloss.Left, m.header(), m.style.viewport.Render(m.viewport.View()))
+}
+
+// ShortHelp returns the short help for this tab
+func (m Model) ShortHelp() []key.Binding {
+	return []key.Binding{m.keymap.OpenInBrowser, m.viewport.KeyMap.Down, m.viewport.KeyMap.Up}
+}
+
+// FullHelp returns the full help for this tab
+func (m Model) FullHelp() [][]key.Binding {
+	return [][]key.Binding{m.ShortHelp(), {
+		m.viewport.KeyMap.PageDown,
+		m.viewport.KeyMap.PageUp,
+		m.viewport.KeyMap.HalfPageDown,
+		m.viewport.KeyMap.HalfPageUp,
+	}}
+}
+
+// header returns the title and the link of the article shown above it
+func (m Model) header() string {
+	if m.link == "" {
+		return m.style.title.Render(m.title)
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, m.style.title.Render(m.title), m.style.link.Render(m.link))
+}
+
+// render renders the markdown of the article for the width of the tab, the scroll position is kept
+func (m Model) render() Model {
+	renderer, err := glamour.NewTermRenderer(
+		glamour.WithStyles(m.colors.MarkdownStyle),
+		glamour.WithWordWrap(m.width-2),
+	)
+
+	var styled string
+	if err == nil {
+		styled, err = renderer.Render(m.markdown)
+	}
+
+	if err != nil {
+		styled = fmt.Sprintf("We have encountered an error styling the content: %s", err)
+	}
+
+	offset := m.viewport.YOffset
+	m.viewport.SetContent(styled)
+	m.viewport.SetYOffset(offset)
+	return m
+}
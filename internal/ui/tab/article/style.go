@@ -0,0 +1,34 @@
+package article
+
+import (
+	"github.com/TypicalAM/goread/internal/theme"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// style is the style of the article tab.
+type style struct {
+	title    lipgloss.Style
+	link     lipgloss.Style
+	viewport lipgloss.Style
+}
+
+// newStyle creates a new style for the article tab.
+func newStyle(colors *theme.Colors) style {
+	title := lipgloss.NewStyle().
+		Foreground(colors.Color2).
+		Italic(true).
+		MarginLeft(2)
+
+	link := lipgloss.NewStyle().
+		Foreground(colors.TextDark).
+		MarginLeft(2)
+
+	viewport := lipgloss.NewStyle().
+		MarginTop(1)
+
+	return style{
+		title:    title,
+		link:     link,
+		viewport: viewport,
+	}
+}
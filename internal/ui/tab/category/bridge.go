@@ -0,0 +1,443 @@
+package category
+
+import (
+	"strings"
+
+	"github.com/TypicalAM/goread/internal/backend/cache"
+	"github.com/TypicalAM/goread/internal/theme"
+	"github.com/TypicalAM/goread/internal/ui/popup"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
+)
+
+// PickedBridgeMsg is the message sent when the parameters of a bridge are filled in.
+type PickedBridgeMsg struct {
+	Name   string
+	URL    string
+	Parent string
+}
+
+// bridgeList is a list of bridges which can be searched, needed for fuzzy matching
+type bridgeList []cache.Bridge
+
+// String returns the name of the bridge which is matched against the query
+func (b bridgeList) String(i int) string {
+	return b[i].Name
+}
+
+// Len returns the amount of bridges
+func (b bridgeList) Len() int {
+	return len(b)
+}
+
+// bridgeField is the input of a single parameter of a bridge
+type bridgeField struct {
+	param   cache.BridgeParam
+	input   textinput.Model
+	choice  int
+	checked bool
+}
+
+// newBridgeField returns the input of the parameter filled with its default value
+func newBridgeField(param cache.BridgeParam, width int) bridgeField {
+	field := bridgeField{param: param, checked: param.Type == "checkbox" && param.Default != ""}
+	for i, value := range param.Values {
+		if value.Value == param.Default {
+			field.choice = i
+		}
+	}
+
+	field.input = textinput.New()
+	field.input.Prompt = ""
+	field.input.Placeholder = param.Example
+	field.input.Width = width
+	field.input.SetValue(param.Default)
+	return field
+}
+
+// isText reports if the value of the parameter is typed in
+func (f bridgeField) isText() bool {
+	return f.param.Type != "list" && f.param.Type != "checkbox"
+}
+
+// value returns the value of the parameter as it's sent to the bridge
+func (f bridgeField) value() string {
+	switch f.param.Type {
+	case "list":
+		if len(f.param.Values) == 0 {
+			return ""
+		}
+
+		return f.param.Values[f.choice].Value
+
+	case "checkbox":
+		if f.checked {
+			return "on"
+		}
+
+		return ""
+	}
+
+	return f.input.Value()
+}
+
+// view renders the value of the parameter
+func (f bridgeField) view() string {
+	switch f.param.Type {
+	case "list":
+		if len(f.param.Values) == 0 {
+			return ""
+		}
+
+		return "‹ " + f.param.Values[f.choice].Title + " ›"
+
+	case "checkbox":
+		if f.checked {
+			return "[x]"
+		}
+
+		return "[ ]"
+	}
+
+	return f.input.View()
+}
+
+// BridgePicker is the popup where a user can pick a bridge of RSS-Bridge and fill in its
+// parameters, the bridge is searched by its name first.
+type BridgePicker struct {
+	style      popupStyle
+	overlay    popup.Overlay
+	queryInput textinput.Model
+	bridges    bridgeList
+	matches    fuzzy.Matches
+	parent     string
+	selected   int
+	maxShown   int
+	width      int
+
+	// The form of the chosen bridge, the context can be switched if there are several
+	bridge  *cache.Bridge
+	context int
+	fields  [][]bridgeField
+	focused int
+	err     string
+}
+
+// NewBridgePicker returns a new bridge picker popup.
+func NewBridgePicker(colors *theme.Colors, bgRaw string, width, height int,
+	parent string, bridges []cache.Bridge) BridgePicker {
+
+	queryInput := textinput.New()
+	queryInput.Prompt = "> "
+	queryInput.Placeholder = "Search the bridges"
+	queryInput.Width = width - 14
+	queryInput.Focus()
+
+	p := BridgePicker{
+		style:      newPopupStyle(colors, width, height),
+		overlay:    popup.NewOverlay(bgRaw, width, height),
+		queryInput: queryInput,
+		bridges:    bridges,
+		parent:     parent,
+		maxShown:   (height - 9) / 2,
+		width:      width,
+	}
+
+	if p.maxShown < 1 {
+		p.maxShown = 1
+	}
+
+	p.filter()
+	return p
+}
+
+// Init initializes the popup.
+func (p BridgePicker) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Update updates the popup.
+func (p BridgePicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if p.bridge != nil {
+		return p.updateForm(msg)
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "down", "tab", "ctrl+j":
+			if p.selected < len(p.matches)-1 {
+				p.selected++
+			}
+
+			return p, nil
+
+		case "up", "shift+tab", "ctrl+k":
+			if p.selected > 0 {
+				p.selected--
+			}
+
+			return p, nil
+
+		case "enter":
+			if len(p.matches) == 0 {
+				return p, nil
+			}
+
+			return p.choose(p.bridges[p.matches[p.selected].Index])
+		}
+	}
+
+	var cmd tea.Cmd
+	query := p.queryInput.Value()
+	p.queryInput, cmd = p.queryInput.Update(msg)
+	if p.queryInput.Value() != query {
+		p.filter()
+	}
+
+	return p, cmd
+}
+
+// updateForm handles the inputs of the parameters.
+func (p BridgePicker) updateForm(msg tea.Msg) (tea.Model, tea.Cmd) {
+	field := p.focusedField()
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "down", "tab":
+			cmd := p.focus(p.focused + 1)
+			return p, cmd
+
+		case "up", "shift+tab":
+			cmd := p.focus(p.focused - 1)
+			return p, cmd
+
+		case "enter":
+			return p.confirm()
+
+		case "left", "right":
+			step := 1
+			if msg.String() == "left" {
+				step = -1
+			}
+
+			switch {
+			case field == nil:
+				p.context = (p.context + step + len(p.fields)) % len(p.fields)
+				return p, nil
+
+			case field.param.Type == "list" && len(field.param.Values) > 0:
+				field.choice = (field.choice + step + len(field.param.Values)) % len(field.param.Values)
+				return p, nil
+			}
+
+		case " ":
+			if field != nil && field.param.Type == "checkbox" {
+				field.checked = !field.checked
+				return p, nil
+			}
+		}
+	}
+
+	if field == nil || !field.isText() {
+		return p, nil
+	}
+
+	var cmd tea.Cmd
+	field.input, cmd = field.input.Update(msg)
+	return p, cmd
+}
+
+// choose opens the form of the bridge, the bridges without parameters are picked right away
+func (p BridgePicker) choose(bridge cache.Bridge) (tea.Model, tea.Cmd) {
+	p.bridge = &bridge
+	p.fields = make([][]bridgeField, len(bridge.Contexts))
+	for i, context := range bridge.Contexts {
+		for _, param := range context.Params {
+			p.fields[i] = append(p.fields[i], newBridgeField(param, p.width-14))
+		}
+	}
+
+	if len(p.fields) == 1 && len(p.fields[0]) == 0 {
+		return p.confirm()
+	}
+
+	p.queryInput.Blur()
+	cmd := p.focus(0)
+	return p, cmd
+}
+
+// confirm sends the address of the feed made by the bridge, the error is shown if a parameter is
+// missing
+func (p BridgePicker) confirm() (tea.Model, tea.Cmd) {
+	values := make(map[string]string)
+	name := p.bridge.Name
+	for _, field := range p.fields[p.context] {
+		value := field.value()
+		values[field.param.ID] = value
+		if field.isText() && value != "" && name == p.bridge.Name {
+			name += " " + value
+		}
+	}
+
+	url, err := cache.BridgeFeedURL(*p.bridge, p.bridge.Contexts[p.context], values)
+	if err != nil {
+		p.err = err.Error()
+		return p, nil
+	}
+
+	parent := p.parent
+	return p, func() tea.Msg { return PickedBridgeMsg{name, url, parent} }
+}
+
+// hasContexts reports if the bridge has several contexts which can be switched
+func (p BridgePicker) hasContexts() bool {
+	return len(p.fields) > 1
+}
+
+// focusedField returns the field which is being edited, nil if the context is focused
+func (p *BridgePicker) focusedField() *bridgeField {
+	index := p.focused
+	if p.hasContexts() {
+		index--
+	}
+
+	if index < 0 || index >= len(p.fields[p.context]) {
+		return nil
+	}
+
+	return &p.fields[p.context][index]
+}
+
+// focus moves the focus to the field with the index, it wraps around
+func (p *BridgePicker) focus(index int) tea.Cmd {
+	if field := p.focusedField(); field != nil {
+		field.input.Blur()
+	}
+
+	count := len(p.fields[p.context])
+	if p.hasContexts() {
+		count++
+	}
+
+	p.focused = (index + count) % count
+	if field := p.focusedField(); field != nil && field.isText() {
+		return field.input.Focus()
+	}
+
+	return nil
+}
+
+// filter finds the bridges matching the query, all the bridges are shown if there is no query
+func (p *BridgePicker) filter() {
+	p.selected = 0
+	query := strings.TrimSpace(p.queryInput.Value())
+	if query != "" {
+		p.matches = fuzzy.FindFrom(query, p.bridges)
+		return
+	}
+
+	p.matches = make(fuzzy.Matches, len(p.bridges))
+	for i, bridge := range p.bridges {
+		p.matches[i] = fuzzy.Match{Str: bridge.Name, Index: i}
+	}
+}
+
+// View renders the popup.
+func (p BridgePicker) View() string {
+	if p.bridge != nil {
+		return p.viewForm()
+	}
+
+	question := p.style.heading.Render("Pick a bridge")
+	query := p.style.item.Render(p.queryInput.View())
+
+	// Keep the selected bridge visible
+	start := 0
+	if p.selected >= p.maxShown {
+		start = p.selected - p.maxShown + 1
+	}
+
+	end := start + p.maxShown
+	if end > len(p.matches) {
+		end = len(p.matches)
+	}
+
+	choices := []string{""}
+	for i := start; i < end; i++ {
+		bridge := p.bridges[p.matches[i].Index]
+		itemStyle, titleStyle := p.style.choice, p.style.choiceTitle
+		if i == p.selected {
+			itemStyle, titleStyle = p.style.item, p.style.itemTitle
+		}
+
+		choices = append(choices, itemStyle.Render(lipgloss.JoinVertical(
+			lipgloss.Left,
+			titleStyle.Render(bridge.Name),
+			p.style.itemField.Render(truncate(bridge.Description, p.width-14)),
+		)))
+	}
+
+	if len(choices) == 1 {
+		choices = append(choices, p.style.choice.Render(p.style.choiceTitle.Render("No matching bridges")))
+	}
+
+	popup := lipgloss.JoinVertical(lipgloss.Left, question, query, lipgloss.JoinVertical(lipgloss.Left, choices...))
+	return p.overlay.WrapView(p.style.general.Render(popup))
+}
+
+// viewForm renders the parameters of the chosen bridge.
+func (p BridgePicker) viewForm() string {
+	heading := p.bridge.Name
+	if p.err != "" {
+		heading = p.err
+	}
+
+	rows := []string{p.style.heading.Render(heading)}
+	if p.hasContexts() {
+		rows = append(rows, p.renderRow("Kind", "‹ "+p.bridge.Contexts[p.context].Name+" ›", p.focused == 0))
+	}
+
+	offset := 0
+	if p.hasContexts() {
+		offset = 1
+	}
+
+	// Keep the focused parameter visible
+	fields := p.fields[p.context]
+	start := 0
+	if p.focused-offset >= p.maxShown {
+		start = p.focused - offset - p.maxShown + 1
+	}
+
+	for i := start; i < len(fields) && i < start+p.maxShown; i++ {
+		title := fields[i].param.Name
+		if fields[i].param.Required {
+			title += " *"
+		}
+
+		rows = append(rows, p.renderRow(title, fields[i].view(), p.focused == i+offset))
+	}
+
+	return p.overlay.WrapView(p.style.general.Render(lipgloss.JoinVertical(lipgloss.Left, rows...)))
+}
+
+// renderRow renders the title and the value of a parameter
+func (p BridgePicker) renderRow(title, value string, focused bool) string {
+	itemStyle, titleStyle := p.style.choice, p.style.choiceTitle
+	if focused {
+		itemStyle, titleStyle = p.style.item, p.style.itemTitle
+	}
+
+	return itemStyle.Render(lipgloss.JoinVertical(lipgloss.Left, titleStyle.Render(title), p.style.itemField.Render(value)))
+}
+
+// truncate shortens the text to the width, the end is replaced with an ellipsis
+func truncate(text string, width int) string {
+	runes, tail := []rune(text), []rune(theme.Icons.Tail)
+	if width <= len(tail) || len(runes) <= width {
+		return text
+	}
+
+	return string(runes[:width-len(tail)]) + theme.Icons.Tail
+}
@@ -1,27 +1,41 @@
 package category
 
 import (
+	"fmt"
 	"log"
+	"strconv"
 
 	"github.com/TypicalAM/goread/internal/backend"
 	"github.com/TypicalAM/goread/internal/theme"
+	"github.com/TypicalAM/goread/internal/ui/favicon"
 	"github.com/TypicalAM/goread/internal/ui/popup"
 	"github.com/TypicalAM/goread/internal/ui/simplelist"
 	"github.com/TypicalAM/goread/internal/ui/tab"
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// choice is the action which waits for the confirmation of the user
+type choice int
+
+const (
+	choiceDelete choice = iota
+	choiceMarkAsRead
 )
 
 // Model contains the state of this tab
 type Model struct {
-	colors *theme.Colors
-	reader backend.Fetcher
-	title  string
-	keymap Keymap
-	list   simplelist.Model
-	width  int
-	height int
-	loaded bool
+	colors  *theme.Colors
+	reader  backend.Fetcher
+	title   string
+	keymap  Keymap
+	list    simplelist.Model
+	width   int
+	height  int
+	pending choice
+	loaded  bool
+	stale   bool
 }
 
 // New creates a new category tab with sensible defaults
@@ -43,13 +57,18 @@ func (m Model) Title() string {
 	return m.title
 }
 
+// Position describes the selected item for the screen readers
+func (m Model) Position() string {
+	return m.list.Position()
+}
+
 // Style returns the style of the tab
 func (m Model) Style() tab.Style {
 	return tab.Style{
 		Color: m.colors.Color5,
-		Icon:  "﫜",
+		Icon:  theme.Icons.Category,
 		Name:  "CATEGORY",
-	}
+	}.Decorate(theme.CategoryDecorations[m.title])
 }
 
 // SetSize sets the dimensions of the tab
@@ -75,21 +94,81 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case backend.FetchSuccessMsg:
 		if !m.loaded {
 			m.list = simplelist.New(m.colors, m.title, m.height, false)
+			m.list.SetIcons(feedIcon)
+			m.list.SetColors(feedColor)
 			m.loaded = true
 		}
 
 		m.list.SetItems(msg.Items)
+		names := make([]string, len(msg.Items))
+		for i, item := range msg.Items {
+			names[i] = item.FilterValue()
+		}
+
+		return m, favicon.Load(names...)
+
+	case backend.UnreadCountMsg:
+		if m.loaded {
+			for _, item := range m.list.Items() {
+				m.list.SetBadge(item.FilterValue(), backend.UnreadBadge(msg.Feeds[item.FilterValue()]))
+			}
+		}
+
 		return m, nil
 
 	case backend.SetEnableKeybindMsg:
 		m.keymap.SetEnabled(bool(msg))
 		return m, nil
 
+	case tab.RestyleMsg:
+		if m.loaded {
+			m.list.Restyle()
+		}
+
+		return m, nil
+
+	case tab.RefreshMsg:
+		if !msg.Active {
+			m.stale = true
+			return m, nil
+		}
+
+		if !m.stale {
+			return m, nil
+		}
+
+		m.stale = false
+		return m, m.reader(m.title)
+
+	case tab.OpenItemMsg:
+		item, ok := m.list.GetItem(strconv.Itoa(msg.Number))
+		if !m.loaded || !ok {
+			return m, nil
+		}
+
+		m.list.SetIndex(msg.Number)
+		return m, tab.NewTab(m, item.FilterValue())
+
 	case popup.ChoiceResultMsg:
 		if !msg.Result {
 			return m, nil
 		}
 
+		if m.pending == choiceMarkAsRead {
+			return m, backend.MarkCategoryAsRead(m.title)
+		}
+
+		if marked := m.markedNames(); len(marked) > 0 {
+			m.list.ClearMarks()
+			m.list.SetIndex(0)
+			cmds := make([]tea.Cmd, len(marked))
+			for i, name := range marked {
+				cmds[i] = backend.DeleteItem(m, name)
+			}
+
+			return m, tea.Sequence(cmds...)
+		}
+
 		delItemName := m.list.SelectedItem().FilterValue()
 		itemCount := len(m.list.Items())
 
@@ -118,9 +197,29 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 			return m, nil
 
+		case key.Matches(msg, m.keymap.OpenInBackground):
+			if marked := m.markedNames(); len(marked) > 0 {
+				m.list.ClearMarks()
+				cmds := make([]tea.Cmd, len(marked))
+				for i, name := range marked {
+					cmds[i] = tab.NewBackgroundTab(m, name)
+				}
+
+				return m, tea.Sequence(cmds...)
+			}
+
+			if !m.list.IsEmpty() {
+				return m, tab.NewBackgroundTab(m, m.list.SelectedItem().FilterValue())
+			}
+
+			return m, nil
+
 		case key.Matches(msg, m.keymap.NewFeed):
 			return m, backend.NewItem(m)
 
+		case key.Matches(msg, m.keymap.BridgeFeed):
+			return m, backend.NewBridgeFeed(m.title)
+
 		case key.Matches(msg, m.keymap.EditFeed):
 			// If the list is empty, return nothing
 			if m.list.IsEmpty() {
@@ -132,10 +231,32 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, backend.EditItem(m, fields)
 
 		case key.Matches(msg, m.keymap.DeleteFeed):
+			if marked := m.markedNames(); len(marked) > 0 {
+				m.pending = choiceDelete
+				return m, backend.MakeChoice(fmt.Sprintf("Delete %d feeds?", len(marked)), true)
+			}
+
 			if !m.list.IsEmpty() {
+				m.pending = choiceDelete
 				return m, backend.MakeChoice("Delete this feed?", true)
 			}
 
+		case key.Matches(msg, m.keymap.MarkAllAsRead):
+			if !m.list.IsEmpty() {
+				m.pending = choiceMarkAsRead
+				return m, backend.MakeChoice("Mark all the articles in "+m.title+" as read?", true)
+			}
+
+		case key.Matches(msg, m.keymap.ToggleFullContent):
+			if !m.list.IsEmpty() {
+				return m, backend.ToggleFullContent(m.title, m.list.SelectedItem().FilterValue())
+			}
+
+		case key.Matches(msg, m.keymap.EditTags):
+			if !m.list.IsEmpty() {
+				return m, backend.EditTags(m.list.SelectedItem().FilterValue())
+			}
+
 		default:
 			if item, ok := m.list.GetItem(msg.String()); ok {
 				return m, tab.NewTab(m, item.FilterValue())
@@ -157,12 +278,50 @@ func (m Model) View() string {
 	return m.list.View()
 }
 
+// SelectedFeed returns the name of the selected feed, it's empty if there are no feeds
+func (m Model) SelectedFeed() string {
+	if !m.loaded || m.list.IsEmpty() {
+		return ""
+	}
+
+	return m.list.SelectedItem().FilterValue()
+}
+
+// markedNames returns the names of the marked feeds
+func (m Model) markedNames() []string {
+	var names []string
+	for _, item := range m.list.Marked() {
+		names = append(names, item.FilterValue())
+	}
+
+	return names
+}
+
 // ShortHelp returns the short help for this tab
 func (m Model) ShortHelp() []key.Binding {
-	return []key.Binding{m.keymap.NewFeed, m.keymap.EditFeed, m.keymap.DeleteFeed}
+	return []key.Binding{m.keymap.NewFeed, m.keymap.EditFeed, m.keymap.DeleteFeed, m.keymap.ToggleFullContent, m.keymap.MarkAllAsRead}
 }
 
 // FullHelp returns the full help for this tab
 func (m Model) FullHelp() [][]key.Binding {
-	return [][]key.Binding{m.ShortHelp(), m.list.ShortHelp()}
+	return [][]key.Binding{m.ShortHelp(), {m.keymap.OpenInBackground, m.keymap.EditTags, m.keymap.BridgeFeed}, m.list.ShortHelp(), m.list.MarkHelp()}
+}
+
+// feedIcon returns the icon of the feed from the config or the icon of its site if the icons are
+// shown, the icon of the icon set otherwise
+func feedIcon(feedName string) string {
+	if decoration, ok := theme.FeedDecorations[feedName]; ok && decoration.Icon != "" {
+		return decoration.ListIcon("")
+	}
+
+	if icon := favicon.Get(feedName); icon != "" {
+		return icon
+	}
+
+	return theme.Icons.FeedItem
+}
+
+// feedColor returns the color of the feed from the config
+func feedColor(feedName string) lipgloss.Color {
+	return theme.FeedDecorations[feedName].Color
 }
@@ -4,9 +4,14 @@ import "github.com/charmbracelet/bubbles/key"
 
 // Keymap contains the key bindings for this tab
 type Keymap struct {
-	NewFeed    key.Binding
-	EditFeed   key.Binding
-	DeleteFeed key.Binding
+	NewFeed           key.Binding
+	EditFeed          key.Binding
+	DeleteFeed        key.Binding
+	ToggleFullContent key.Binding
+	MarkAllAsRead     key.Binding
+	EditTags          key.Binding
+	BridgeFeed        key.Binding
+	OpenInBackground  key.Binding
 }
 
 // DefaultKeymap contains the default key bindings for this tab
@@ -23,6 +28,26 @@ var DefaultKeymap = Keymap{
 		key.WithKeys("d", "ctrl+d"),
 		key.WithHelp("d/ctrl+d", "Delete"),
 	),
+	ToggleFullContent: key.NewBinding(
+		key.WithKeys("f"),
+		key.WithHelp("f", "Full content"),
+	),
+	MarkAllAsRead: key.NewBinding(
+		key.WithKeys("A"),
+		key.WithHelp("A", "Mark category as read"),
+	),
+	EditTags: key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("t", "Tags"),
+	),
+	BridgeFeed: key.NewBinding(
+		key.WithKeys("b"),
+		key.WithHelp("b", "New from RSS-Bridge"),
+	),
+	OpenInBackground: key.NewBinding(
+		key.WithKeys("o"),
+		key.WithHelp("o", "Open in background"),
+	),
 }
 
 // SetEnabled allows to disable/enable shortcuts
@@ -30,4 +55,9 @@ func (m *Keymap) SetEnabled(enabled bool) {
 	m.NewFeed.SetEnabled(enabled)
 	m.EditFeed.SetEnabled(enabled)
 	m.DeleteFeed.SetEnabled(enabled)
+	m.ToggleFullContent.SetEnabled(enabled)
+	m.MarkAllAsRead.SetEnabled(enabled)
+	m.EditTags.SetEnabled(enabled)
+	m.BridgeFeed.SetEnabled(enabled)
+	m.OpenInBackground.SetEnabled(enabled)
 }
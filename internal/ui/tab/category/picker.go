@@ -0,0 +1,90 @@
+package category
+
+import (
+	"github.com/TypicalAM/goread/internal/backend/cache"
+	"github.com/TypicalAM/goread/internal/theme"
+	"github.com/TypicalAM/goread/internal/ui/popup"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// PickedFeedMsg is the message sent when one of the discovered feeds is picked.
+type PickedFeedMsg struct {
+	Name   string
+	URL    string
+	Parent string
+}
+
+// FeedPicker is the popup where a user can pick one of the feeds discovered on a page.
+type FeedPicker struct {
+	style    popupStyle
+	overlay  popup.Overlay
+	feeds    []cache.DiscoveredFeed
+	name     string
+	parent   string
+	selected int
+}
+
+// NewFeedPicker returns a new feed picker popup.
+func NewFeedPicker(colors *theme.Colors, bgRaw string, width, height int,
+	name, parent string, feeds []cache.DiscoveredFeed) FeedPicker {
+
+	return FeedPicker{
+		style:   newPopupStyle(colors, width, height),
+		overlay: popup.NewOverlay(bgRaw, width, height),
+		feeds:   feeds,
+		name:    name,
+		parent:  parent,
+	}
+}
+
+// Init initializes the popup.
+func (p FeedPicker) Init() tea.Cmd {
+	return nil
+}
+
+// Update updates the popup.
+func (p FeedPicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "down", "j", "tab":
+			p.selected = (p.selected + 1) % len(p.feeds)
+
+		case "up", "k", "shift+tab":
+			p.selected = (p.selected - 1 + len(p.feeds)) % len(p.feeds)
+
+		case "enter":
+			name, url, parent := p.name, p.feeds[p.selected].URL, p.parent
+			return p, func() tea.Msg { return PickedFeedMsg{name, url, parent} }
+		}
+	}
+
+	return p, nil
+}
+
+// View renders the popup.
+func (p FeedPicker) View() string {
+	question := p.style.heading.Render("Multiple feeds found, pick one")
+
+	choices := make([]string, len(p.feeds))
+	for i, feed := range p.feeds {
+		title := feed.Title
+		if title == "" {
+			title = feed.URL
+		}
+
+		itemStyle, titleStyle := p.style.choice, p.style.choiceTitle
+		if i == p.selected {
+			itemStyle, titleStyle = p.style.item, p.style.itemTitle
+		}
+
+		choices[i] = itemStyle.Render(lipgloss.JoinVertical(
+			lipgloss.Left,
+			titleStyle.Render(title),
+			p.style.itemField.Render(feed.URL),
+		))
+	}
+
+	popup := lipgloss.JoinVertical(lipgloss.Left, question, lipgloss.JoinVertical(lipgloss.Left, choices...))
+	return p.overlay.WrapView(p.style.general.Render(popup))
+}
@@ -7,11 +7,13 @@ import (
 
 // popupStyle is the style of the popup window.
 type popupStyle struct {
-	general   lipgloss.Style
-	heading   lipgloss.Style
-	item      lipgloss.Style
-	itemTitle lipgloss.Style
-	itemField lipgloss.Style
+	general     lipgloss.Style
+	heading     lipgloss.Style
+	item        lipgloss.Style
+	itemTitle   lipgloss.Style
+	itemField   lipgloss.Style
+	choice      lipgloss.Style
+	choiceTitle lipgloss.Style
 }
 
 // newPopupStyle creates a new popup style.
@@ -42,11 +44,19 @@ func newPopupStyle(colors *theme.Colors, width, height int) popupStyle {
 	itemField := lipgloss.NewStyle().
 		Foreground(colors.Color2)
 
+	choice := item.Copy().
+		BorderForeground(colors.TextDark)
+
+	choiceTitle := lipgloss.NewStyle().
+		Foreground(colors.TextDark)
+
 	return popupStyle{
-		general:   general,
-		heading:   heading,
-		item:      item,
-		itemTitle: itemTitle,
-		itemField: itemField,
+		general:     general,
+		heading:     heading,
+		item:        item,
+		itemTitle:   itemTitle,
+		itemField:   itemField,
+		choice:      choice,
+		choiceTitle: choiceTitle,
 	}
 }
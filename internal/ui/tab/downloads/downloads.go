@@ -0,0 +1,83 @@
+package downloads
+
+import (
+	"time"
+
+	"github.com/TypicalAM/goread/internal/backend"
+	"github.com/TypicalAM/goread/internal/theme"
+	"github.com/TypicalAM/goread/internal/ui/tab"
+	"github.com/TypicalAM/goread/internal/ui/tab/listtab"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Title is the title of the downloads tab
+const Title = "Downloads"
+
+// pollInterval is the interval in which the progress of the downloads is updated
+const pollInterval = time.Second
+
+// pollMsg is sent when the progress should be updated, the old polls are ignored
+type pollMsg struct{ generation int }
+
+// Model contains the state of this tab
+type Model struct {
+	listtab.Model
+	generation int
+}
+
+// New creates a new downloads tab, the fetcher returns the downloads
+func New(colors *theme.Colors, width, height int, fetcher backend.Fetcher) Model {
+	return Model{Model: listtab.New(listtab.Config{
+		Title:     Title,
+		ListTitle: "Episodes",
+		Empty:     "No episodes are downloaded yet",
+		Noun:      "download",
+		Name:      "DOWNLOADS",
+		Icon:      func() string { return theme.Icons.Downloads },
+		Color:     func(colors *theme.Colors) lipgloss.Color { return colors.Color4 },
+	}, colors, width, height, fetcher)}
+}
+
+// SetSize sets the dimensions of the tab
+func (m Model) SetSize(width, height int) tab.Tab {
+	m.Model = m.Resize(width, height)
+	return m
+}
+
+// Init initializes the tab
+func (m Model) Init() tea.Cmd {
+	return tea.Batch(m.Fetch(), poll(m.generation))
+}
+
+// Update updates the variables of the tab
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case pollMsg:
+		// The polls stop when the tab isn't active, the messages don't reach it
+		if msg.generation != m.generation {
+			return m, nil
+		}
+
+		return m, tea.Batch(m.Fetch(), poll(m.generation))
+
+	case tab.RefreshMsg:
+		if !msg.Active {
+			return m, nil
+		}
+
+		m.generation++
+		return m, tea.Batch(m.Fetch(), poll(m.generation))
+	}
+
+	var cmd tea.Cmd
+	m.Model, cmd = m.Model.Update(msg)
+	return m, cmd
+}
+
+// poll schedules the next update of the progress
+func poll(generation int) tea.Cmd {
+	return tea.Tick(pollInterval, func(time.Time) tea.Msg {
+		return pollMsg{generation}
+	})
+}
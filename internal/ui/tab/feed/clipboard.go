@@ -0,0 +1,20 @@
+package feed
+
+import (
+	"os"
+
+	"github.com/atotto/clipboard"
+	"github.com/aymanbagabas/go-osc52"
+)
+
+// copyToClipboard copies the text to the system clipboard. Over ssh or when there is no clipboard
+// tool the OSC 52 escape sequence asks the terminal to do it instead
+func copyToClipboard(text string) {
+	if os.Getenv("SSH_TTY") == "" && !clipboard.Unsupported {
+		if err := clipboard.WriteAll(text); err == nil {
+			return
+		}
+	}
+
+	osc52.NewOutput(os.Stdout, os.Environ()).Copy(text)
+}
@@ -0,0 +1,168 @@
+package feed
+
+import (
+	"strings"
+
+	"github.com/TypicalAM/goread/internal/backend"
+	"github.com/TypicalAM/goread/internal/backend/cache"
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/glamour/ansi"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxFoldDepth is the deepest level of the replies which can be folded, folding it again shows
+// all the replies
+const maxFoldDepth = 3
+
+// commentSeparator separates the comments in the markdown of a thread, the links are numbered
+// across the whole thread before it's split again
+const commentSeparator = "\n\n\x1e\n\n"
+
+// guide is drawn on the left of the replies once for every level
+const guide = "  │"
+
+// minCommentWidth is the narrowest width at which the deeply nested replies are rendered
+const minCommentWidth = 20
+
+// thread is the comment thread which is shown in place of an article
+type thread struct {
+	index    int
+	title    string
+	comments []cache.Comment
+	depth    int
+}
+
+// blocks returns the comments with the replies folded below the depth
+func (t *thread) blocks() []backend.CommentBlock {
+	return backend.CommentBlocks(t.title, t.comments, t.depth)
+}
+
+// markdown returns the markdown of the comments, separated so that they can be rendered one by one
+func (t *thread) markdown() string {
+	blocks := t.blocks()
+	parts := make([]string, len(blocks))
+	for i, block := range blocks {
+		parts[i] = block.Markdown
+	}
+
+	return strings.Join(parts, commentSeparator)
+}
+
+// fold shows one more level of the replies, the first fold shows only the top-level comments and
+// the one after the deepest level unfolds everything
+func (t *thread) fold() {
+	if t.depth++; t.depth > maxFoldDepth {
+		t.depth = 0
+	}
+}
+
+// threadOpen reports if the comments of the selected article are shown
+func (m Model) threadOpen() bool {
+	return m.thread != nil && m.thread.index == m.index()
+}
+
+// content returns the markdown of the selected article, the comments or the translation replace it
+// while they are open. The summary is shown above the article
+func (m Model) content() string {
+	if m.threadOpen() {
+		return m.thread.markdown()
+	}
+
+	content := m.articleContent[m.index()]
+	if m.translationShown() {
+		content = m.translation.markdown
+	}
+
+	if m.summaryShown() {
+		content = m.summary.markdown + content
+	}
+
+	return content
+}
+
+// renderPlain renders the markdown without the colors for the link selector and the search
+func (m Model) renderPlain(markdown string) (string, error) {
+	if m.threadOpen() {
+		return m.renderThread(markdown, glamour.NoTTYStyleConfig, lipgloss.NewStyle())
+	}
+
+	return m.noColorTr.Render(markdown)
+}
+
+// renderThread renders every comment on its own, narrower by its level, and draws the guides on
+// the left of the replies. Glamour can't nest the quotes or the lists with several paragraphs
+func (m Model) renderThread(markdown string, styles ansi.StyleConfig, guideStyle lipgloss.Style) (string, error) {
+	levels := make([]int, 0)
+	for _, block := range m.thread.blocks() {
+		levels = append(levels, block.Level)
+	}
+
+	// The references of the numbered links follow the last comment, they aren't indented
+	parts := strings.Split(markdown, commentSeparator)
+	last := parts[len(parts)-1]
+	if i := strings.Index(last, "\n\n"+referencesHeading); i >= 0 {
+		parts = append(parts[:len(parts)-1], last[:i], last[i:])
+	}
+
+	renderers := make(map[int]*glamour.TermRenderer)
+	var b strings.Builder
+	for i, part := range parts {
+		level := 0
+		if i < len(levels) {
+			level = levels[i]
+		}
+
+		tr, ok := renderers[level]
+		if !ok {
+			width := m.style.viewportWidth - 2 - level*len([]rune(guide))
+			if width < minCommentWidth {
+				width = minCommentWidth
+			}
+
+			var err error
+			if tr, err = glamour.NewTermRenderer(glamour.WithStyles(styles), glamour.WithWordWrap(width)); err != nil {
+				return "", err
+			}
+
+			renderers[level] = tr
+		}
+
+		rendered, err := tr.Render(part)
+		if err != nil {
+			return "", err
+		}
+
+		prefix := guideStyle.Render(strings.Repeat(guide, level))
+		for _, line := range trimBlankLines(strings.Split(rendered, "\n")) {
+			b.WriteString(prefix + line + "\n")
+		}
+
+		// The guide continues between the replies on the same level
+		next := 0
+		if i+1 < len(levels) && i+1 < len(parts) {
+			next = levels[i+1]
+		}
+
+		if next > level {
+			next = level
+		}
+
+		b.WriteString(guideStyle.Render(strings.Repeat(guide, next)) + "\n")
+	}
+
+	return b.String(), nil
+}
+
+// trimBlankLines removes the empty lines which glamour puts around the rendered markdown
+func trimBlankLines(lines []string) []string {
+	blank := func(line string) bool { return strings.TrimSpace(ansiPattern.ReplaceAllString(line, "")) == "" }
+	for len(lines) > 0 && blank(lines[0]) {
+		lines = lines[1:]
+	}
+
+	for len(lines) > 0 && blank(lines[len(lines)-1]) {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
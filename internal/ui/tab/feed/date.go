@@ -0,0 +1,97 @@
+package feed
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DateFormat is the strftime format of the dates shown next to the titles of the articles, the
+// dates are relative (e.g. "3h ago") if it's empty
+var DateFormat = ""
+
+// strftimeLayouts maps the strftime directives to the go layouts
+var strftimeLayouts = map[byte]string{
+	'a': "Mon",
+	'A': "Monday",
+	'b': "Jan",
+	'B': "January",
+	'd': "02",
+	'e': "_2",
+	'F': "2006-01-02",
+	'h': "Jan",
+	'H': "15",
+	'I': "03",
+	'm': "01",
+	'M': "04",
+	'p': "PM",
+	'R': "15:04",
+	'S': "05",
+	'T': "15:04:05",
+	'y': "06",
+	'Y': "2006",
+	'z': "-0700",
+	'Z': "MST",
+}
+
+// formatDate returns the date shown next to the title of an article, nothing is shown if the
+// article doesn't have a date
+func formatDate(published, now time.Time) string {
+	if published.IsZero() {
+		return ""
+	}
+
+	if DateFormat != "" {
+		return strftime(DateFormat, published.Local())
+	}
+
+	return relativeDate(published, now)
+}
+
+// relativeDate describes how long ago the article was published, the articles from the future
+// are treated as new ones
+func relativeDate(published, now time.Time) string {
+	age := now.Sub(published)
+	switch {
+	case age < time.Minute:
+		return "now"
+	case age < time.Hour:
+		return fmt.Sprintf("%dm ago", int(age.Minutes()))
+	case age < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(age.Hours()))
+	case age < 30*24*time.Hour:
+		return fmt.Sprintf("%dd ago", int(age.Hours()/24))
+	case age < 365*24*time.Hour:
+		return fmt.Sprintf("%dmo ago", int(age.Hours()/24/30))
+	default:
+		return fmt.Sprintf("%dy ago", int(age.Hours()/24/365))
+	}
+}
+
+// strftime formats the time using a strftime format, the unknown directives are kept as they are
+func strftime(format string, t time.Time) string {
+	var sb strings.Builder
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' || i+1 == len(format) {
+			sb.WriteByte(format[i])
+			continue
+		}
+
+		i++
+		switch directive := format[i]; directive {
+		case '%':
+			sb.WriteByte('%')
+		case 'j':
+			sb.WriteString(fmt.Sprintf("%03d", t.YearDay()))
+		default:
+			if layout, ok := strftimeLayouts[directive]; ok {
+				sb.WriteString(t.Format(layout))
+			} else {
+				sb.WriteByte('%')
+				sb.WriteByte(directive)
+			}
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,164 @@
+package feed
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/TypicalAM/goread/internal/backend"
+	"github.com/TypicalAM/goread/internal/backend/rss"
+	"github.com/TypicalAM/goread/internal/theme"
+	"github.com/TypicalAM/goread/internal/ui/favicon"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/reflow/truncate"
+)
+
+// delegate renders the articles in the list, read articles are dimmed.
+type delegate struct {
+	list.DefaultDelegate
+	readStyles        list.DefaultItemStyles
+	highlightedStyles list.DefaultItemStyles
+	headerStyle       lipgloss.Style
+	title             string
+}
+
+// newDelegate creates a new article delegate for the tab with the title.
+func newDelegate(title string, styles, readStyles, highlightedStyles list.DefaultItemStyles, headerStyle lipgloss.Style) delegate {
+	itemDelegate := list.NewDefaultDelegate()
+	itemDelegate.ShowDescription = true
+	itemDelegate.Styles = styles
+	itemDelegate.SetHeight(3)
+
+	return delegate{
+		DefaultDelegate:   itemDelegate,
+		readStyles:        readStyles,
+		highlightedStyles: highlightedStyles,
+		headerStyle:       headerStyle,
+		title:             title,
+	}
+}
+
+// iconToken takes the place of the icon of the feed until the article is rendered, the icon has
+// its own colors so it can't be styled along with the title
+var iconToken = strings.Repeat("\uE000", favicon.Width+1)
+
+// decoratedItem shows the icons in front of the title of an article and its length and date after it
+type decoratedItem struct {
+	backend.ArticleItem
+	prefix string
+	meta   string
+	width  int
+}
+
+// Title returns the title of the article with the icons, the length and the date are aligned to
+// the right and the title is shortened to make room for them
+func (i decoratedItem) Title() string {
+	title := i.prefix + i.ArticleItem.Title()
+	room := i.width - lipgloss.Width(i.meta) - 1
+	if i.meta == "" || room < 1 {
+		return title
+	}
+
+	title = truncate.StringWithTail(title, uint(room), theme.Icons.Tail)
+	return title + strings.Repeat(" ", i.width-lipgloss.Width(title)-lipgloss.Width(i.meta)) + i.meta
+}
+
+// articleMeta returns the length of the article, like "1.2k words · 6 min", and its date
+func articleMeta(article backend.ArticleItem, now time.Time) string {
+	var parts []string
+	if words := article.Words(); words > 0 {
+		count := fmt.Sprint(words)
+		if words >= 1000 {
+			count = fmt.Sprintf("%.1fk", float64(words)/1000)
+		}
+
+		parts = append(parts, fmt.Sprintf("%s words %s %d min", count, theme.Icons.Separator, rss.ReadingMinutes(words)))
+	}
+
+	if date := formatDate(article.Published(), now); date != "" {
+		parts = append(parts, date)
+	}
+
+	return strings.Join(parts, " "+theme.Icons.Separator+" ")
+}
+
+// Render renders a single article, using the dimmed styles if it was read and the highlighted
+// styles if a rule highlighted it. The marked articles and the ones with an episode get an icon,
+// the length and the date are shown next to the title. The articles in the tabs which combine several
+// feeds show the icons of their feeds. The group headers are drawn on the last line of the item, right above their articles
+func (d delegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	if header, ok := item.(groupHeader); ok {
+		text := string(header) + " "
+		rule := m.Width() - lipgloss.Width(text) - d.headerStyle.GetHorizontalFrameSize()
+		if rule > 0 {
+			text += strings.Repeat(theme.Icons.Rule, rule)
+		}
+
+		fmt.Fprint(w, strings.Repeat("\n", d.Height()-1)+d.headerStyle.Render(text))
+		return
+	}
+
+	article, ok := item.(backend.ArticleItem)
+	if !ok {
+		d.DefaultDelegate.Render(w, m, index, item)
+		return
+	}
+
+	styledDelegate := d.DefaultDelegate
+	switch {
+	case article.IsRead():
+		styledDelegate.Styles = d.readStyles
+	case article.IsHighlighted():
+		styledDelegate.Styles = d.highlightedStyles
+	}
+
+	var prefix string
+	if article.IsMarked() {
+		prefix += theme.Icons.Marked
+	}
+
+	if !article.IsRead() {
+		prefix += theme.Icons.Unread
+	}
+
+	if article.IsSaved() {
+		prefix += theme.Icons.Starred
+	}
+
+	if article.IsEpisode() {
+		prefix += theme.Icons.Episode
+	}
+
+	var icon string
+	if article.Feed() != "" && article.Feed() != d.title {
+		icon = favicon.Get(article.Feed())
+	}
+
+	if icon != "" {
+		prefix = iconToken + prefix
+	}
+
+	width := m.Width() - styledDelegate.Styles.NormalTitle.GetHorizontalPadding()
+	item = decoratedItem{article, prefix, articleMeta(article, time.Now()), width}
+	if icon == "" {
+		styledDelegate.Render(w, m, index, item)
+		return
+	}
+
+	// The style of the title is started again after the icon, which resets the colors
+	var b strings.Builder
+	styledDelegate.Render(&b, m, index, item)
+	rendered := b.String()
+	if i := strings.Index(rendered, iconToken); i >= 0 {
+		var restyle string
+		if codes := ansiPattern.FindAllString(rendered[:i], -1); len(codes) > 0 {
+			restyle = codes[len(codes)-1]
+		}
+
+		rendered = rendered[:i] + icon + restyle + rendered[i+len(iconToken):]
+	}
+
+	fmt.Fprint(w, rendered)
+}
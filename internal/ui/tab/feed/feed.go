@@ -3,12 +3,18 @@ package feed
 import (
 	"fmt"
 	"log"
+	"strconv"
 	"strings"
+	"sync/atomic"
+	"time"
+	"unicode"
 
 	"github.com/TypicalAM/goread/internal/backend"
+	"github.com/TypicalAM/goread/internal/backend/cache"
 	"github.com/TypicalAM/goread/internal/theme"
+	"github.com/TypicalAM/goread/internal/ui/favicon"
+	"github.com/TypicalAM/goread/internal/ui/graphics"
 	"github.com/TypicalAM/goread/internal/ui/popup"
-	"github.com/TypicalAM/goread/internal/ui/simplelist"
 	"github.com/TypicalAM/goread/internal/ui/tab"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
@@ -17,9 +23,34 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/glamour"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/reflow/truncate"
 	"github.com/muesli/reflow/wrap"
 )
 
+// minRetryDelay and maxRetryDelay are the bounds of the delay between the retries of a failed fetch
+const (
+	minRetryDelay = 5 * time.Second
+	maxRetryDelay = 5 * time.Minute
+)
+
+// positionDelay is how long the scrolling has to stop before the reading position is saved
+const positionDelay = time.Second
+
+// positionSaver delays the saving of the reading position until the scrolling stops. The timers
+// check the generation from their own goroutines, so it's atomic
+type positionSaver struct {
+	generation atomic.Int64
+	article    int
+	line       int
+	pending    bool
+}
+
+// retryMsg is sent when a failed fetch should be retried, it's ignored by the other tabs
+type retryMsg struct {
+	title   string
+	attempt int
+}
+
 // Model contains the state of this tab
 type Model struct {
 	list            list.Model
@@ -28,19 +59,41 @@ type Model struct {
 	noColorTr       *glamour.TermRenderer
 	colors          *theme.Colors
 	selector        *selector
+	thread          *thread
+	translation     *translation
+	summary         *summary
+	finder          *finder
+	positions       *positionSaver
 	title           string
 	viewport        viewport.Model
 	keymap          Keymap
 	articleContent  []string
+	links           []string
+	markers         []int
+	linkNumber      int
+	article         int
+	items           []list.Item
+	shown           []int
+	anchor          int
+	sortOrder       string
+	images          map[string]string
+	requestedImages map[string]bool
+	failedImages    map[string]int
 	spinner         spinner.Model
 	style           style
 	height          int
 	width           int
 	errShown        bool
 	loaded          bool
+	stale           bool
+	deferred        bool
 	viewportOpen    bool
 	viewportFocused bool
+	unreadOnly      bool
+	archive         cache.ArchiveState
 	lastFilterState list.FilterState
+	retryAt         time.Time
+	retries         int
 }
 
 // New creates a new feed tab with sensible defaults
@@ -52,15 +105,20 @@ func New(colors *theme.Colors, width, height int, title string, fetcher backend.
 
 	// Create the model
 	return Model{
-		colors:   colors,
-		style:    newStyle(colors, width, height),
-		width:    width,
-		height:   height,
-		selector: newSelector(colors),
-		spinner:  spin,
-		title:    title,
-		fetcher:  fetcher,
-		keymap:   DefaultKeymap,
+		colors:       colors,
+		style:        newStyle(colors, DefaultLayout, width, height),
+		width:        width,
+		height:       height,
+		selector:     newSelector(colors),
+		finder:       newFinder(colors),
+		positions:    &positionSaver{},
+		spinner:      spin,
+		title:        title,
+		fetcher:      fetcher,
+		keymap:       DefaultKeymap,
+		images:       make(map[string]string),
+		failedImages: make(map[string]int),
+		anchor:       -1,
 	}
 }
 
@@ -73,9 +131,9 @@ func (m Model) Title() string {
 func (m Model) Style() tab.Style {
 	return tab.Style{
 		Color: m.colors.Color3,
-		Icon:  "",
+		Icon:  theme.Icons.Feed,
 		Name:  "FEED",
-	}
+	}.Decorate(theme.FeedDecorations[m.title])
 }
 
 // SetSize sets the dimensions of the tab
@@ -85,9 +143,9 @@ func (m Model) SetSize(width, height int) tab.Tab {
 	}
 
 	m.style = m.style.setSize(width, height)
-	m.list.SetSize(m.style.listWidth, height)
+	m.list.SetSize(m.style.listWidth, m.style.listHeight)
 	m.viewport.Width = m.style.viewportWidth
-	m.viewport.Height = height
+	m.viewport.Height = m.style.viewportHeight
 	m.width = width
 	m.height = height
 	newTab, _ := m.updateViewport()
@@ -96,6 +154,10 @@ func (m Model) SetSize(width, height int) tab.Tab {
 
 // Init initializes the tab
 func (m Model) Init() tea.Cmd {
+	if m.deferred {
+		return nil
+	}
+
 	return tea.Batch(m.spinner.Tick, m.fetcher(m.title, false))
 }
 
@@ -104,29 +166,226 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case backend.FetchErrorMsg:
 		m.errShown = true
-		return m, nil
+		if m.loaded {
+			return m, nil
+		}
+
+		return m.scheduleRetry()
+
+	case retryMsg:
+		if msg.title != m.title || msg.attempt != m.retries || !m.errShown || m.loaded {
+			return m, nil
+		}
+
+		return m.retry()
 
 	case backend.FetchArticleSuccessMsg:
-		return m.loadTab(msg.Items, msg.ArticleContents), nil
+		m.errShown = false
+		m.retries = 0
+		feeds := make([]string, len(msg.Items))
+		for i, item := range msg.Items {
+			feeds[i] = item.(backend.ArticleItem).Feed()
+		}
+
+		return m.loadTab(msg.Items, msg.ArticleContents), favicon.Load(feeds...)
+
+	case backend.ImageLoadedMsg:
+		return m.loadImage(msg)
+
+	case backend.ArticleAnnotatedMsg:
+		if msg.FeedName != m.title || !m.loaded || msg.Index >= len(m.articleContent) {
+			return m, nil
+		}
+
+		// The open article shows the new tags and note right away, the reading position is kept
+		m.articleContent[msg.Index] = msg.Content
+		if !m.viewportOpen || m.index() != msg.Index {
+			return m, nil
+		}
+
+		offset := m.viewport.YOffset
+		updated, cmd := m.updateViewport()
+		m = updated.(Model)
+		m.viewport.SetYOffset(offset)
+		return m, cmd
+
+	case backend.ArticleSnoozedMsg:
+		if msg.FeedName != m.title || !m.loaded || msg.Index >= len(m.items) {
+			return m, nil
+		}
+
+		// The snoozed article disappears from the list, the next one is shown if it was open
+		m.items[msg.Index] = m.items[msg.Index].(backend.ArticleItem).SetSnoozedUntil(msg.Until).SetRead(true)
+		cmd := m.showItems()
+		if !m.viewportOpen || m.list.SelectedItem() == nil {
+			m.viewportOpen = false
+			return m, cmd
+		}
+
+		updated, viewportCmd := m.updateViewport()
+		return updated, tea.Batch(cmd, viewportCmd)
+
+	case backend.ArticleArchivedMsg:
+		// The articles of the archive and the trash change with every move, so they are loaded again
+		if m.archive != cache.Kept && m.loaded {
+			m.viewportOpen = false
+			m.loaded = false
+			return m, tea.Batch(m.spinner.Tick, m.fetcher(m.title, false))
+		}
+
+		if msg.FeedName != m.title || !m.loaded || msg.Index >= len(m.items) {
+			return m, nil
+		}
+
+		item := m.items[msg.Index].(backend.ArticleItem).SetArchiveState(msg.State)
+		if msg.State != cache.Kept {
+			item = item.SetRead(true)
+		}
+
+		m.items[msg.Index] = item
+		cmd := m.showItems()
+		if !m.viewportOpen || m.list.SelectedItem() == nil {
+			m.viewportOpen = false
+			return m, cmd
+		}
+
+		updated, viewportCmd := m.updateViewport()
+		return updated, tea.Batch(cmd, viewportCmd)
+
+	case backend.CommentsLoadedMsg:
+		if msg.FeedName != m.title || !m.loaded || msg.Index != m.index() {
+			return m, nil
+		}
+
+		m.thread = &thread{msg.Index, msg.Title, msg.Comments, 0}
+		m.viewportOpen = true
+		return m.updateViewport()
+
+	case backend.ArticleTranslatedMsg:
+		if msg.FeedName != m.title || !m.loaded || msg.Index != m.index() {
+			return m, nil
+		}
+
+		m.translation = &translation{msg.Index, msg.Markdown, true}
+		m.viewportOpen = true
+		return m.updateViewport()
+
+	case backend.ArticleSummarizedMsg:
+		if msg.FeedName != m.title || !m.loaded || msg.Index != m.index() {
+			return m, nil
+		}
+
+		m.summary = &summary{msg.Index, msg.Markdown, true}
+		m.viewportOpen = true
+		return m.updateViewport()
 
 	case backend.SetEnableKeybindMsg:
 		m.keymap.SetEnabled(bool(msg))
 		return m, nil
 
+	case tab.RestyleMsg:
+		return m.restyle(), nil
+
+	case tab.ZenMsg:
+		layout := m.style.layout
+		layout.Zen = msg.Enabled
+		if msg.Enabled && m.viewportOpen {
+			m.viewportFocused = true
+		}
+
+		return m.setLayout(layout)
+
+	case tab.RefreshMsg:
+		if !msg.Active {
+			m.stale = true
+			return m, nil
+		}
+
+		// The tab opened in the background loads once it's focused, the articles are sent to the active tab
+		if m.deferred {
+			m.deferred = false
+			return m, m.Init()
+		}
+
+		// The retries are lost while the tab isn't active
+		if m.errShown && !m.loaded {
+			return m.retry()
+		}
+
+		if !m.stale || !m.loaded {
+			return m, nil
+		}
+
+		// Reload the articles from the cache, the list is kept until they arrive
+		m.stale = false
+		return m, m.fetcher(m.title, false)
+
+	case tab.OpenItemMsg:
+		if !m.loaded {
+			return m, nil
+		}
+
+		return m.openArticle(msg.Number)
+
+	case tab.FilterMsg:
+		if !m.loaded {
+			return m, nil
+		}
+
+		m.unreadOnly = msg.Filter == "unread"
+		if msg.Filter == "all" {
+			m.list.SetFilteringEnabled(false)
+			m.list.SetFilteringEnabled(true)
+		}
+
+		return m, m.showItems()
+
+	case tab.SortMsg:
+		m.sortOrder = msg.Order
+		if !m.loaded {
+			return m, backend.SetSortOrder(m.title, m.sortOrder)
+		}
+
+		return m, tea.Batch(m.showItems(), backend.SetSortOrder(m.title, m.sortOrder))
+
 	case popup.ChoiceResultMsg:
 		if !msg.Result {
 			return m, nil
 		}
 
-		_ = m.selector.open()
+		_ = m.selector.open(m.cleanLink)
 		return m, nil
 
 	case tea.KeyMsg:
 		if !m.loaded {
+			if m.errShown && key.Matches(msg, m.keymap.RefreshArticles) {
+				return m.retry()
+			}
+
 			return m, nil
 		}
 
+		if m.finder.typing {
+			return m.updateFinder(msg)
+		}
+
+		// The number typed in the article chooses the link which is opened or copied
+		if m.viewportFocused && msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && unicode.IsDigit(msg.Runes[0]) {
+			if m.linkNumber < 1000 {
+				m.linkNumber = m.linkNumber*10 + int(msg.Runes[0]-'0')
+			}
+
+			return m, nil
+		}
+
+		linkNumber := m.linkNumber
+		m.linkNumber = 0
+
 		switch {
+		case msg.String() == "esc" && m.finder.active:
+			m.closeFinder()
+			return m, nil
+
 		case msg.String() == "esc":
 			if m.list.FilterState() == list.Unfiltered {
 				return m, backend.StartQuitting()
@@ -150,16 +409,33 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.viewportOpen = true
 			}
 
+			// Only the focused pane is shown to the screen readers and in the zen mode, so the
+			// article takes the focus
+			if theme.ScreenReader || m.style.layout.Zen {
+				m.viewportFocused = true
+			}
+
 			return m.updateViewport()
 
 		case key.Matches(msg, m.keymap.ToggleFocus):
-			if !m.viewportOpen {
+			if !m.viewportOpen || m.style.layout.Zen {
 				return m, nil
 			}
 
 			m.viewportFocused = !m.viewportFocused
 			return m, nil
 
+		case key.Matches(msg, m.keymap.ToggleLayout):
+			layout := m.style.layout
+			layout.Stacked = !layout.Stacked
+			return m.setLayout(layout)
+
+		case key.Matches(msg, m.keymap.GrowList):
+			return m.setLayout(m.style.layout.resize(listRatioStep))
+
+		case key.Matches(msg, m.keymap.ShrinkList):
+			return m.setLayout(m.style.layout.resize(-listRatioStep))
+
 		case key.Matches(msg, m.keymap.RefreshArticles):
 			m.viewportOpen = false
 			m.loaded = false
@@ -168,25 +444,292 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Batch(m.spinner.Tick, m.fetcher(m.title, true))
 
 		case key.Matches(msg, m.keymap.SaveArticle):
-			return m, backend.DownloadItem(m.title, m.list.Index())
+			var cmds []tea.Cmd
+			for _, index := range m.targets() {
+				cmds = append(cmds, backend.DownloadItem(m.title, index))
+				m.items[index] = m.items[index].(backend.ArticleItem).SetSaved(true)
+			}
+
+			return m, tea.Sequence(append(cmds, m.clearMarks())...)
+
+		case key.Matches(msg, m.keymap.ExportMarkdown), key.Matches(msg, m.keymap.ExportHTML):
+			asHTML := key.Matches(msg, m.keymap.ExportHTML)
+			var cmds []tea.Cmd
+			for _, index := range m.targets() {
+				cmds = append(cmds, backend.ExportArticle(m.title, index, asHTML))
+			}
+
+			return m, tea.Sequence(append(cmds, m.clearMarks())...)
+
+		case key.Matches(msg, m.keymap.Share):
+			indexes := m.targets()
+			if len(indexes) == 0 {
+				return m, nil
+			}
+
+			return m, tea.Sequence(backend.ShareArticles(m.title, indexes), m.clearMarks())
+
+		case key.Matches(msg, m.keymap.Annotate):
+			if _, ok := m.list.SelectedItem().(backend.ArticleItem); !ok {
+				return m, nil
+			}
+
+			return m, backend.Annotate(m.title, m.index())
+
+		case key.Matches(msg, m.keymap.Snooze):
+			if _, ok := m.list.SelectedItem().(backend.ArticleItem); !ok {
+				return m, nil
+			}
+
+			return m, backend.Snooze(m.title, m.index())
+
+		case key.Matches(msg, m.keymap.OpenInTab):
+			if _, _, _, ok := m.Article(); !ok {
+				return m, nil
+			}
+
+			return m, tab.NewTab(m, m.list.SelectedItem().(backend.ArticleItem).Title())
+
+		case key.Matches(msg, m.keymap.Archive), key.Matches(msg, m.keymap.Trash):
+			item, ok := m.list.SelectedItem().(backend.ArticleItem)
+			if !ok {
+				return m, nil
+			}
+
+			// Putting an article away in the state it's already in restores it
+			state := cache.Archived
+			if key.Matches(msg, m.keymap.Trash) {
+				state = cache.Trashed
+			}
+
+			if item.ArchiveState() == state {
+				state = cache.Kept
+			}
+
+			return m, backend.Archive(m.title, m.index(), state)
+
+		case key.Matches(msg, m.keymap.Comments):
+			if _, ok := m.list.SelectedItem().(backend.ArticleItem); !ok {
+				return m, nil
+			}
+
+			// The comments are closed if they are open
+			if m.thread != nil && m.thread.index == m.index() {
+				m.thread = nil
+				return m.updateViewport()
+			}
+
+			return m, backend.ShowComments(m.title, m.index())
+
+		case key.Matches(msg, m.keymap.Translate):
+			if _, ok := m.list.SelectedItem().(backend.ArticleItem); !ok {
+				return m, nil
+			}
+
+			// The translation is only requested once, after that it's toggled with the original
+			if m.translation != nil && m.translation.index == m.index() {
+				m.translation.shown = !m.translation.shown
+				m.viewportOpen = true
+				return m.updateViewport()
+			}
+
+			return m, backend.Translate(m.title, m.index())
+
+		case key.Matches(msg, m.keymap.Summarize):
+			if _, ok := m.list.SelectedItem().(backend.ArticleItem); !ok {
+				return m, nil
+			}
+
+			// The summary is only requested once, after that it's shown and hidden
+			if m.summary != nil && m.summary.index == m.index() {
+				m.summary.shown = !m.summary.shown
+				m.viewportOpen = true
+				return m.updateViewport()
+			}
+
+			return m, backend.Summarize(m.title, m.index())
+
+		case m.thread != nil && key.Matches(msg, m.keymap.FoldReplies):
+			if m.thread.index != m.index() {
+				return m, nil
+			}
+
+			m.thread.fold()
+			return m.updateViewport()
 
 		case key.Matches(msg, m.keymap.DeleteFromSaved):
-			return m, backend.DeleteItem(m, fmt.Sprintf("%d", m.list.Index()))
+			var indexes []string
+			for _, index := range m.targets() {
+				indexes = append(indexes, strconv.Itoa(index))
+			}
+
+			if len(indexes) == 0 {
+				return m, nil
+			}
+
+			m.clearMarks()
+			return m, backend.DeleteItem(m, strings.Join(indexes, ","))
+
+		case !m.viewportFocused && key.Matches(msg, m.keymap.Mark):
+			m.toggleMark()
+			return m, nil
+
+		case !m.viewportFocused && key.Matches(msg, m.keymap.Visual):
+			m.markRange()
+			return m, nil
+
+		case linkNumber > 0 && key.Matches(msg, m.keymap.OpenInBrowser):
+			link, err := m.link(linkNumber)
+			if err == nil {
+				err = OpenURL(m.cleanLink(link))
+			}
+
+			if err != nil {
+				return m, func() tea.Msg { return backend.FetchErrorMsg{Err: err, Description: "Error opening the link"} }
+			}
+
+			return m, nil
+
+		case linkNumber > 0 && key.Matches(msg, m.keymap.CopyLink):
+			link, err := m.link(linkNumber)
+			if err != nil {
+				return m, func() tea.Msg { return backend.FetchErrorMsg{Err: err, Description: "Error copying the link"} }
+			}
+
+			link = m.cleanLink(link)
+			copyToClipboard(link)
+			return m, backend.ShowMessage("Copied " + link)
+
+		case key.Matches(msg, m.keymap.CopyLink), key.Matches(msg, m.keymap.CopyTitle):
+			item, ok := m.list.SelectedItem().(backend.ArticleItem)
+			if !ok || item.Link() == "" {
+				return m, nil
+			}
+
+			text := m.cleanLink(item.Link())
+			if key.Matches(msg, m.keymap.CopyTitle) {
+				text = item.Title() + " — " + text
+			}
+
+			copyToClipboard(text)
+			return m, backend.ShowMessage("Copied " + text)
+
+		case key.Matches(msg, m.keymap.OpenInBrowser):
+			item, ok := m.list.SelectedItem().(backend.ArticleItem)
+			if !ok || item.Link() == "" {
+				return m, nil
+			}
+
+			if err := OpenURL(m.cleanLink(item.Link())); err != nil {
+				return m, func() tea.Msg { return backend.FetchErrorMsg{Err: err, Description: "Error opening the article"} }
+			}
+
+			return m, nil
+
+		case key.Matches(msg, m.keymap.DownloadEpisode):
+			item, ok := m.list.SelectedItem().(backend.ArticleItem)
+			if !ok || !item.IsEpisode() {
+				return m, nil
+			}
+
+			return m, backend.DownloadEpisode(m.title, m.index())
+
+		case key.Matches(msg, m.keymap.PlayEpisode):
+			item, ok := m.list.SelectedItem().(backend.ArticleItem)
+			if !ok || !item.IsEpisode() {
+				return m, nil
+			}
+
+			if err := playEpisode(item.Episode(), item.Title()); err != nil {
+				return m, func() tea.Msg { return backend.FetchErrorMsg{Err: err, Description: "Error playing the episode"} }
+			}
+
+			return m, nil
+
+		case key.Matches(msg, m.keymap.ReadAloud):
+			if _, ok := m.list.SelectedItem().(backend.ArticleItem); !ok {
+				return m, nil
+			}
+
+			return m, backend.ReadAloud(m.title, m.index())
+
+		case key.Matches(msg, m.keymap.ToggleRead):
+			// The articles are marked as read if any of them is unread, otherwise they are marked as unread
+			indexes, read := m.targets(), false
+			if len(indexes) == 0 {
+				return m, nil
+			}
+
+			for _, index := range indexes {
+				if !m.items[index].(backend.ArticleItem).IsRead() {
+					read = true
+				}
+			}
 
-		case key.Matches(msg, m.keymap.MarkAsUnread):
-			item := m.list.SelectedItem().(list.DefaultItem)
-			if strings.HasPrefix(item.Title(), "✓ ") {
-				title := strings.Join(strings.Split(item.Title(), " ")[1:], " ")
-				m.list.SetItem(m.list.Index(), simplelist.NewItem(title, item.Description()))
+			cmds := make([]tea.Cmd, 0, len(indexes)+1)
+			for _, index := range indexes {
+				m.items[index] = m.items[index].(backend.ArticleItem).SetRead(read).SetMarked(false)
+				if read {
+					cmds = append(cmds, backend.MarkAsRead(m.title, index))
+				} else {
+					cmds = append(cmds, backend.MarkAsUnread(m.title, index))
+				}
 			}
 
-			return m, backend.MarkAsUnread(m.title, m.list.Index())
+			m.anchor = -1
+			return m, tea.Sequence(append(cmds, m.showItems())...)
+
+		case key.Matches(msg, m.keymap.MarkAllAsRead):
+			for i := range m.items {
+				m.items[i] = m.items[i].(backend.ArticleItem).SetRead(true)
+			}
+
+			return m, tea.Batch(m.showItems(), backend.MarkAllAsRead(m.title))
+
+		case key.Matches(msg, m.keymap.ToggleUnreadOnly):
+			m.unreadOnly = !m.unreadOnly
+			return m, m.showItems()
+
+		case key.Matches(msg, m.keymap.CycleSortOrder):
+			m.sortOrder = nextSortOrder(m.sortOrder)
+			return m, tea.Batch(m.showItems(), backend.SetSortOrder(m.title, m.sortOrder))
+
+		case m.viewportFocused && key.Matches(msg, m.keymap.NextArticle):
+			return m.moveArticle(1, false)
+
+		case m.viewportFocused && key.Matches(msg, m.keymap.PrevArticle):
+			return m.moveArticle(-1, false)
+
+		case m.viewportFocused && key.Matches(msg, m.keymap.Find):
+			cmd := m.finder.start()
+			m.viewport.Height = m.style.viewportHeight - 1
+			return m, tea.Sequence(cmd, backend.SetEnableKeybind(false))
+
+		case m.viewportFocused && m.finder.active && key.Matches(msg, m.keymap.NextMatch):
+			m.finder.cycle(1)
+			m.showMatches()
+			return m, nil
+
+		case m.viewportFocused && m.finder.active && key.Matches(msg, m.keymap.PrevMatch):
+			m.finder.cycle(-1)
+			m.showMatches()
+			return m, nil
+
+		case key.Matches(msg, m.keymap.NextUnread):
+			return m.moveArticle(1, true)
+
+		case key.Matches(msg, m.keymap.PrevUnread):
+			return m.moveArticle(-1, true)
 
 		case key.Matches(msg, m.keymap.CycleSelection):
 			if !m.viewportFocused {
 				return m, nil
 			}
 
+			if m.finder.active {
+				m.closeFinder()
+			}
+
 			m.viewport.SetContent(m.selector.cycle())
 			return m, nil
 		}
@@ -205,11 +748,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	var cmd tea.Cmd
 	if m.viewportFocused {
+		offset := m.viewport.YOffset
 		m.viewport, cmd = m.viewport.Update(msg)
-		return m, cmd
+		if m.viewport.YOffset == offset {
+			return m, cmd
+		}
+
+		return m, tea.Batch(cmd, m.savePosition())
 	}
 
+	previous := m.list.Index()
 	m.list, cmd = m.list.Update(msg)
+	m.skipHeader(previous)
 	if m.list.FilterState() == m.lastFilterState {
 		return m, cmd
 	}
@@ -221,28 +771,51 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // loadTab is fired when the items are retrieved from the backend
 func (m Model) loadTab(items []list.Item, articleContents []string) tab.Tab {
-	itemDelegate := list.NewDefaultDelegate()
-	itemDelegate.ShowDescription = true
-	itemDelegate.Styles = m.style.listItems
-	itemDelegate.SetHeight(3)
+	itemDelegate := newDelegate(m.title, m.style.listItems, m.style.readListItems, m.style.hlListItems, m.style.groupHeader)
+
+	// Remember the selected article, the list might be reloaded after a background refresh
+	var selected string
+	if item, ok := m.list.SelectedItem().(backend.ArticleItem); m.loaded && ok {
+		selected = item.Title()
+	}
 
 	// Wrap the descs, it's better to do it upfront then to rely on the list pagination
 	for i := range items {
-		item := items[i].(list.DefaultItem)
-		items[i] = simplelist.NewItem(item.Title(), wrap.String(item.Description(), m.style.listWidth-4))
+		item := items[i].(backend.ArticleItem)
+		items[i] = item.SetDescription(wrap.String(item.Description(), m.style.listWidth-4))
 	}
 
-	m.list = list.New(items, itemDelegate, m.style.listWidth, m.height)
+	m.items = items
+	m.anchor = -1
+	m.list = list.New(nil, itemDelegate, m.style.listWidth, m.style.listHeight)
+	m.list.Styles.Title = m.style.listTitle
+	m.list.Styles.TitleBar = m.style.listTitleBar
+	m.showItems()
 
 	m.list.SetShowHelp(false)
-	m.list.SetShowTitle(false)
 	m.list.SetShowStatusBar(false)
 	m.list.DisableQuitKeybindings()
 	m.list.KeyMap.NextPage.SetEnabled(false)
 	m.list.KeyMap.PrevPage.SetEnabled(false)
 	m.list.KeyMap.CloseFullHelp.SetEnabled(false)
 
-	m.viewport = viewport.New(m.style.viewportWidth, m.height)
+	for i, item := range m.list.Items() {
+		if article, ok := item.(backend.ArticleItem); ok && selected != "" && article.Title() == selected {
+			m.list.Select(i)
+			break
+		}
+	}
+
+	// The indexes might have changed, the comments would belong to another article
+	if m.loaded {
+		m.articleContent = articleContents
+		m.thread = nil
+		m.translation = nil
+		m.summary = nil
+		return m
+	}
+
+	m.viewport = viewport.New(m.style.viewportWidth, m.style.viewportHeight)
 	m.articleContent = articleContents
 
 	colorTr, err := glamour.NewTermRenderer(
@@ -274,6 +847,366 @@ func (m Model) loadTab(items []list.Item, articleContents []string) tab.Tab {
 	return m
 }
 
+// restyle rebuilds the styles after the colors or the layout changed, the open article is rendered
+// again with the new colors and the new width
+func (m Model) restyle() tab.Tab {
+	m.style = newStyle(m.colors, m.style.layout, m.width, m.height)
+	m.spinner.Style = lipgloss.NewStyle().Foreground(m.colors.Color1)
+	m.selector.linkStyle = newSelector(m.colors).linkStyle
+	finder := newFinder(m.colors)
+	m.finder.input.PromptStyle = finder.input.PromptStyle
+	m.finder.matchStyle, m.finder.currentStyle = finder.matchStyle, finder.currentStyle
+	if !m.loaded {
+		return m
+	}
+
+	m.list.SetDelegate(newDelegate(m.title, m.style.listItems, m.style.readListItems, m.style.hlListItems, m.style.groupHeader))
+	m.list.SetSize(m.style.listWidth, m.style.listHeight)
+	m.viewport.Width = m.style.viewportWidth
+	m.viewport.Height = m.style.viewportHeight
+	if m.finder.active {
+		m.viewport.Height--
+	}
+
+	m.list.Styles.Title = m.style.listTitle
+	m.list.Styles.TitleBar = m.style.listTitleBar
+	colorTr, err := glamour.NewTermRenderer(
+		glamour.WithStyles(m.colors.MarkdownStyle),
+		glamour.WithWordWrap(m.style.viewportWidth-2),
+	)
+	if err != nil {
+		return m
+	}
+
+	m.colorTr = colorTr
+	if !m.viewportOpen || len(m.articleContent) == 0 {
+		return m
+	}
+
+	rawText, _ := numberLinks(m.content())
+	if styledText, _, err := m.renderArticle(rawText); err == nil {
+		offset := m.viewport.YOffset
+		m.viewport.SetContent(styledText)
+		m.viewport.SetYOffset(offset)
+	}
+
+	return m
+}
+
+// scheduleRetry schedules the next attempt to fetch the articles, the delay doubles after every failure
+func (m Model) scheduleRetry() (tea.Model, tea.Cmd) {
+	m.retries++
+	delay := minRetryDelay
+	for i := 1; i < m.retries && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+
+	m.retryAt = time.Now().Add(delay)
+	title, attempt := m.title, m.retries
+	log.Printf("Fetching %s failed, retrying in %v\n", title, delay)
+	return m, tea.Tick(delay, func(time.Time) tea.Msg {
+		return retryMsg{title, attempt}
+	})
+}
+
+// retry fetches the articles again after a failure
+func (m Model) retry() (tea.Model, tea.Cmd) {
+	m.errShown = false
+	return m, tea.Batch(m.spinner.Tick, m.fetcher(m.title, false))
+}
+
+// showItems puts the articles in the list in the sort order, only the unread ones are shown if the
+// filter is on. The articles sorted by date are grouped under headers, the headers have the index
+// -1 in the shown indexes. The selected article stays selected if it's still shown
+func (m *Model) showItems() tea.Cmd {
+	selected := -1
+	if len(m.shown) > 0 && m.list.SelectedItem() != nil {
+		selected = m.index()
+	}
+
+	indexes, dated := make([]int, 0, len(m.items)), false
+	for i, item := range m.items {
+		if item.(backend.ArticleItem).ArchiveState() != m.archive || item.(backend.ArticleItem).IsSnoozed() || m.unreadOnly && item.(backend.ArticleItem).IsRead() && i != selected {
+			continue
+		}
+
+		indexes = append(indexes, i)
+		dated = dated || !item.(backend.ArticleItem).Published().IsZero()
+	}
+
+	sortIndexes(indexes, m.items, m.sortOrder)
+	grouped, now, lastGroup := dated && groupedOrder(m.sortOrder), time.Now(), ""
+	m.shown = make([]int, 0, len(indexes))
+	visible := make([]list.Item, 0, len(indexes))
+	for _, index := range indexes {
+		if group := dateGroup(m.items[index].(backend.ArticleItem).Published(), now); grouped && group != lastGroup {
+			m.shown = append(m.shown, -1)
+			visible = append(visible, groupHeader(group))
+			lastGroup = group
+		}
+
+		m.shown = append(m.shown, index)
+		visible = append(visible, m.items[index])
+	}
+
+	m.list.Title = fmt.Sprintf("All articles (%d)", len(m.items))
+	if m.unreadOnly {
+		m.list.Title = fmt.Sprintf("Unread articles (%d)", len(indexes))
+	}
+
+	if label, ok := sortLabels[m.sortOrder]; ok {
+		m.list.Title += ", " + label
+	}
+
+	if marked := len(m.marked()); marked > 0 {
+		m.list.Title += fmt.Sprintf(", %d marked", marked)
+	}
+
+	cmd := m.list.SetItems(visible)
+	for i, index := range m.shown {
+		if index == selected {
+			m.list.Select(i)
+			break
+		}
+	}
+
+	m.skipHeader(m.list.Index())
+	return cmd
+}
+
+// openArticle selects the article at the position in the list, counted from one without the
+// group headers, and shows it in the viewport
+func (m Model) openArticle(number int) (tea.Model, tea.Cmd) {
+	count := 0
+	for i, index := range m.shown {
+		if index < 0 {
+			continue
+		}
+
+		if count++; count == number {
+			m.list.Select(i)
+			m.viewportOpen = true
+			return m.updateViewport()
+		}
+	}
+
+	return m, func() tea.Msg {
+		return backend.FetchErrorMsg{
+			Err:         fmt.Errorf("there are only %d articles", count),
+			Description: "Error opening the article",
+		}
+	}
+}
+
+// updateFinder handles the keys typed in the search input of the article, the matches are
+// highlighted as the query is typed
+func (m Model) updateFinder(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.closeFinder()
+		return m, backend.SetEnableKeybind(true)
+
+	case "enter":
+		m.finder.typing = false
+		m.finder.input.Blur()
+		return m, backend.SetEnableKeybind(true)
+	}
+
+	cmd := m.finder.update(msg, m.viewport.YOffset)
+	m.showMatches()
+	return m, cmd
+}
+
+// showMatches shows the article with the highlighted matches, the selected match is scrolled
+// into view
+func (m *Model) showMatches() {
+	m.viewport.SetContent(m.finder.highlight())
+	line := m.finder.currentLine()
+	if line >= 0 && (line < m.viewport.YOffset || line >= m.viewport.YOffset+m.viewport.Height) {
+		m.viewport.SetYOffset(line - m.viewport.Height/3)
+	}
+}
+
+// closeFinder hides the search of the article and shows the article in colors again
+func (m *Model) closeFinder() {
+	m.finder.close()
+	m.viewport.Height = m.style.viewportHeight
+	if _, ok := m.list.SelectedItem().(backend.ArticleItem); !ok {
+		return
+	}
+
+	styled, _, err := m.renderArticle(m.articleMarkdown())
+	if err != nil {
+		return
+	}
+
+	offset := m.viewport.YOffset
+	m.viewport.SetContent(styled)
+	m.viewport.SetYOffset(offset)
+}
+
+// moveArticle selects the nearest article after or before the selected one, only the unread
+// articles are considered if unread is set. The article is shown if the viewport is open, so
+// that a feed can be read straight through
+func (m Model) moveArticle(step int, unread bool) (tea.Model, tea.Cmd) {
+	for i := m.list.Index() + step; i >= 0 && i < len(m.list.Items()); i += step {
+		if item, ok := m.list.Items()[i].(backend.ArticleItem); ok && (!unread || !item.IsRead()) {
+			m.list.Select(i)
+			return m.updateViewport()
+		}
+	}
+
+	return m, nil
+}
+
+// skipHeader moves the cursor from a group header to the nearest article in the direction in
+// which it was moving, the headers can't be selected
+func (m *Model) skipHeader(previous int) {
+	if _, ok := m.list.SelectedItem().(groupHeader); !ok {
+		return
+	}
+
+	if m.list.Index() < previous && m.list.Index() > 0 {
+		m.list.CursorUp()
+	} else {
+		m.list.CursorDown()
+	}
+}
+
+// index returns the index of the selected article in the list of all the articles, the backend
+// and the article contents use it
+func (m Model) index() int {
+	i := m.list.Index()
+	if i >= 0 && i < len(m.shown) {
+		return m.shown[i]
+	}
+
+	return i
+}
+
+// savePosition remembers the line of the open article at which the reading stopped, the position
+// is forgotten once the end of the article is reached. The positions in the comments, in the
+// translations and below the summaries aren't kept. The position is saved once the scrolling
+// stops, the one of the previous article right away
+func (m *Model) savePosition() tea.Cmd {
+	item, ok := m.list.SelectedItem().(backend.ArticleItem)
+	if !ok || m.index() != m.article || m.threadOpen() || m.translationShown() || m.summaryShown() {
+		return nil
+	}
+
+	line := m.viewport.YOffset
+	if m.viewport.AtBottom() {
+		line = 0
+	}
+
+	if line == item.Position() {
+		return nil
+	}
+
+	m.setItem(item.SetPosition(line))
+	var previous tea.Cmd
+	if saver := m.positions; saver.pending && saver.article != m.article {
+		previous = backend.SavePosition(m.title, saver.article, saver.line)
+	}
+
+	saver := m.positions
+	saver.article, saver.line, saver.pending = m.article, line, true
+	generation := saver.generation.Add(1)
+	title, article := m.title, m.article
+	return tea.Batch(previous, tea.Tick(positionDelay, func(time.Time) tea.Msg {
+		if saver.generation.Load() != generation {
+			return nil
+		}
+
+		return backend.SavePositionMsg{FeedName: title, Index: article, Line: line}
+	}))
+}
+
+// setItem replaces the selected article
+func (m *Model) setItem(item backend.ArticleItem) {
+	if index := m.index(); index >= 0 && index < len(m.items) {
+		m.items[index] = item
+	}
+
+	m.list.SetItem(m.list.Index(), item)
+}
+
+// marked returns the indexes of the marked articles in the list of all the articles
+func (m Model) marked() []int {
+	var indexes []int
+	for i, item := range m.items {
+		if item.(backend.ArticleItem).IsMarked() {
+			indexes = append(indexes, i)
+		}
+	}
+
+	return indexes
+}
+
+// targets returns the indexes of the articles which the bulk actions change, the marked
+// articles or the selected one if nothing is marked
+func (m Model) targets() []int {
+	if marked := m.marked(); len(marked) > 0 {
+		return marked
+	}
+
+	if _, ok := m.list.SelectedItem().(backend.ArticleItem); !ok {
+		return nil
+	}
+
+	return []int{m.index()}
+}
+
+// toggleMark marks or unmarks the selected article and moves to the next one
+func (m *Model) toggleMark() {
+	item, ok := m.list.SelectedItem().(backend.ArticleItem)
+	if !ok {
+		return
+	}
+
+	m.setItem(item.SetMarked(!item.IsMarked()))
+	m.anchor = m.list.Index()
+	m.list.CursorDown()
+	m.skipHeader(m.anchor)
+	m.showItems()
+}
+
+// markRange marks the articles between the last marked article and the selected one
+func (m *Model) markRange() {
+	if m.anchor < 0 || m.anchor >= len(m.shown) {
+		m.toggleMark()
+		return
+	}
+
+	from, to := m.anchor, m.list.Index()
+	if from > to {
+		from, to = to, from
+	}
+
+	for i := from; i <= to && i < len(m.shown); i++ {
+		if index := m.shown[i]; index >= 0 {
+			m.items[index] = m.items[index].(backend.ArticleItem).SetMarked(true)
+		}
+	}
+
+	m.anchor = m.list.Index()
+	m.showItems()
+}
+
+// clearMarks unmarks all the articles
+func (m *Model) clearMarks() tea.Cmd {
+	for i := range m.items {
+		m.items[i] = m.items[i].(backend.ArticleItem).SetMarked(false)
+	}
+
+	m.anchor = -1
+	return m.showItems()
+}
+
 // updateViewport is fired when the user presses enter, it updates the
 // viewport with the selected item
 func (m Model) updateViewport() (tab.Tab, tea.Cmd) {
@@ -281,34 +1214,57 @@ func (m Model) updateViewport() (tab.Tab, tea.Cmd) {
 		return m, nil
 	}
 
-	if m.list.SelectedItem() == nil {
+	item, ok := m.list.SelectedItem().(backend.ArticleItem)
+	if !ok {
 		return m, nil
 	}
 
-	rawText := m.articleContent[m.list.Index()]
-	styledText, err := m.colorTr.Render(rawText)
+	if m.thread != nil && m.thread.index != m.index() {
+		m.thread = nil
+	}
+
+	if m.translation != nil && m.translation.index != m.index() {
+		m.translation = nil
+	}
+
+	if m.summary != nil && m.summary.index != m.index() {
+		m.summary = nil
+	}
+
+	rawText, links := numberLinks(m.content())
+	m.links, m.markers = links, linkMarkers(rawText)
+	m.linkNumber = 0
+	m.requestedImages = make(map[string]bool)
+	styledText, loadImages, err := m.renderArticle(rawText)
 	if err != nil {
 		m.viewport.SetContent(fmt.Sprintf("We have encountered an error styling the content: %s", err))
 		return m, nil
 	}
 
-	noColorText, err := m.noColorTr.Render(rawText)
+	noColorText, err := m.renderPlain(rawText)
 	if err != nil {
 		m.viewport.SetContent(fmt.Sprintf("We have encountered an error styling the content: %s", err))
 		return m, nil
 	}
 
 	m.selector.newArticle(&rawText, &noColorText)
+	m.finder.newArticle(&noColorText)
+	m.viewport.Height = m.style.viewportHeight
 	m.viewport.SetContent(styledText)
 	m.viewport.SetYOffset(0)
 
-	// Mark this item as read and prepend a ✓
-	item := m.list.SelectedItem().(list.DefaultItem)
-	if !strings.HasPrefix(item.Title(), "✓ ") {
-		m.list.SetItem(m.list.Index(), simplelist.NewItem("✓ "+item.Title(), item.Description()))
+	// The reading continues where it stopped the last time
+	m.article = m.index()
+	if !m.threadOpen() && !m.translationShown() && !m.summaryShown() {
+		m.viewport.SetYOffset(item.Position())
+	}
+
+	// Mark this item as read
+	if !item.IsRead() {
+		m.setItem(item.SetRead(true))
 	}
 
-	return m, backend.MarkAsRead(m.title, m.list.Index())
+	return m, tea.Batch(backend.MarkAsRead(m.title, m.index()), loadImages)
 }
 
 // View the tab
@@ -321,21 +1277,110 @@ func (m Model) View() string {
 		return m.style.focusedList.Render(m.list.View())
 	}
 
+	// The article is centered without the list and the borders in the zen mode
+	if m.style.layout.Zen && !theme.ScreenReader {
+		return lipgloss.PlaceHorizontal(m.width, lipgloss.Center, m.viewportView())
+	}
+
+	// The screen readers get one pane at a time, the focused one
+	if theme.ScreenReader {
+		if m.viewportFocused {
+			return m.style.focusedViewport.Render(m.viewportView())
+		}
+
+		return m.style.focusedList.Render(m.list.View())
+	}
+
+	join := lipgloss.JoinHorizontal
+	if m.style.layout.Stacked {
+		join = lipgloss.JoinVertical
+	}
+
 	if m.viewportFocused {
-		return lipgloss.JoinHorizontal(
+		return join(
 			lipgloss.Left,
 			m.style.idleList.Render(m.list.View()),
-			m.style.focusedViewport.Render(m.viewport.View()),
+			m.style.focusedViewport.Render(m.viewportView()),
 		)
 	}
 
-	return lipgloss.JoinHorizontal(
+	return join(
 		lipgloss.Left,
 		m.style.focusedList.Render(m.list.View()),
-		m.style.idleViewport.Render(m.viewport.View()),
+		m.style.idleViewport.Render(m.viewportView()),
 	)
 }
 
+// viewportView renders the article, the search or the chosen link is shown below it. Only the
+// images which fit in the view entirely are drawn
+func (m Model) viewportView() string {
+	var status string
+	switch {
+	case m.finder.active:
+		status = m.finder.status()
+	case m.linkNumber > 0:
+		status = fmt.Sprintf("Link %d: press %s to open it, %s to copy it", m.linkNumber,
+			m.keymap.OpenInBrowser.Help().Key, m.keymap.CopyLink.Help().Key)
+	default:
+		return graphics.Visible(m.viewport.View())
+	}
+
+	// The status takes the last line of the article
+	vp := m.viewport
+	vp.Height = m.style.viewportHeight - 1
+	return graphics.Visible(vp.View()) + "\n" + truncate.String(status, uint(m.viewport.Width))
+}
+
+// Progress describes how much of the open article was read, like "line 12 of 80, 15%"
+func (m Model) Progress() string {
+	if !m.loaded || !m.viewportOpen || m.viewport.TotalLineCount() == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("line %d of %d, %d%%", m.viewport.YOffset+1, m.viewport.TotalLineCount(),
+		int(m.viewport.ScrollPercent()*100))
+}
+
+// Position describes the focused pane and the selected article or the line of the open article
+// for the screen readers
+func (m Model) Position() string {
+	switch {
+	case !m.loaded:
+		return "loading"
+	case m.viewportOpen && m.viewportFocused:
+		return fmt.Sprintf("article text, line %d of %d", m.viewport.YOffset+1, m.viewport.TotalLineCount())
+	case len(m.list.VisibleItems()) == 0:
+		return "article list, no articles"
+	}
+
+	return fmt.Sprintf("article list, article %d of %d", m.list.Index()+1, len(m.list.VisibleItems()))
+}
+
+// Filter describes the active filters, it's empty if all the articles are shown
+func (m Model) Filter() string {
+	var filters []string
+	if m.unreadOnly {
+		filters = append(filters, "unread")
+	}
+
+	if m.loaded && m.list.FilterState() != list.Unfiltered && m.list.FilterValue() != "" {
+		filters = append(filters, fmt.Sprintf("%q", m.list.FilterValue()))
+	}
+
+	return strings.Join(filters, ", ")
+}
+
+// UnreadOnly reports if only the unread articles are shown
+func (m Model) UnreadOnly() bool {
+	return m.unreadOnly
+}
+
+// SetSortOrder sets the order in which the articles are shown
+func (m Model) SetSortOrder(order string) Model {
+	m.sortOrder = order
+	return m
+}
+
 // DisableSaving disables the saving of the article
 func (m Model) DisableSaving() Model {
 	m.keymap.SaveArticle.SetEnabled(false)
@@ -348,12 +1393,41 @@ func (m Model) DisableDeleting() Model {
 	return m
 }
 
+// Article returns the title, the link and the markdown of the article open in the viewport, it's
+// the translation, the summary or the comments if they're shown
+func (m Model) Article() (title, link, markdown string, ok bool) {
+	item, isArticle := m.list.SelectedItem().(backend.ArticleItem)
+	if !m.loaded || !m.viewportOpen || !isArticle || m.index() != m.article {
+		return "", "", "", false
+	}
+
+	return item.Title(), m.cleanLink(item.Link()), m.content(), true
+}
+
+// Defer delays loading the articles until the tab is focused for the first time, it's used by the
+// tabs opened in the background
+func (m Model) Defer() Model {
+	m.deferred = true
+	return m
+}
+
+// ShowArchived shows only the articles put away in the state, it's used by the archive and the trash
+func (m Model) ShowArchived(state cache.ArchiveState) Model {
+	m.archive = state
+	return m
+}
+
 // ShortHelp returns the short help for the tab
 func (m Model) ShortHelp() []key.Binding {
 	return []key.Binding{
 		m.keymap.Open, m.keymap.ToggleFocus, m.keymap.RefreshArticles,
 		m.keymap.SaveArticle, m.keymap.DeleteFromSaved, m.keymap.CycleSelection,
-		m.keymap.MarkAsUnread,
+		m.keymap.ToggleRead, m.keymap.ToggleUnreadOnly, m.keymap.OpenInBrowser,
+		m.keymap.DownloadEpisode, m.keymap.PlayEpisode, m.keymap.ReadAloud, m.keymap.Translate, m.keymap.Summarize, m.keymap.MarkAllAsRead,
+		m.keymap.Mark, m.keymap.Visual, m.keymap.CycleSortOrder,
+		m.keymap.NextUnread, m.keymap.PrevUnread, m.keymap.CopyLink, m.keymap.CopyTitle,
+		m.keymap.ExportMarkdown, m.keymap.ExportHTML, m.keymap.Share, m.keymap.Annotate, m.keymap.Snooze, m.keymap.Archive, m.keymap.Trash, m.keymap.OpenInTab, m.keymap.Comments,
+		m.keymap.ToggleLayout, m.keymap.GrowList, m.keymap.ShrinkList,
 	}
 }
 
@@ -366,6 +1440,12 @@ func (m Model) FullHelp() [][]key.Binding {
 	}
 
 	return [][]key.Binding{m.ShortHelp(), {
+		m.keymap.NextArticle,
+		m.keymap.PrevArticle,
+		m.keymap.Find,
+		m.keymap.NextMatch,
+		m.keymap.PrevMatch,
+		m.keymap.FoldReplies,
 		m.viewport.KeyMap.PageDown,
 		m.viewport.KeyMap.PageUp,
 		m.viewport.KeyMap.HalfPageDown,
@@ -378,10 +1458,18 @@ func (m Model) FullHelp() [][]key.Binding {
 // showLoading shows the loading message or the error message
 func (m Model) showLoading() string {
 	if m.errShown {
+		wait := time.Until(m.retryAt).Round(time.Second)
+		if wait < 0 {
+			wait = 0
+		}
+
 		return lipgloss.JoinHorizontal(
 			lipgloss.Top,
 			m.style.errIcon,
-			m.style.loadingMsg.Render("Failed to load the tab"),
+			m.style.loadingMsg.Render(fmt.Sprintf(
+				"Failed to load the tab, retrying in %v, press %s to retry now",
+				wait, m.keymap.RefreshArticles.Help().Key,
+			)),
 		)
 	}
 
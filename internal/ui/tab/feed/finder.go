@@ -0,0 +1,155 @@
+package feed
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/TypicalAM/goread/internal/theme"
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// finder searches the text of the open article and highlights the matches, like the selector
+// it works on the article rendered without colors
+type finder struct {
+	input        textinput.Model
+	matchStyle   lipgloss.Style
+	currentStyle lipgloss.Style
+	article      *string
+	matches      [][]int
+	current      int
+	typing       bool
+	active       bool
+}
+
+// newFinder creates a new finder
+func newFinder(colors *theme.Colors) *finder {
+	input := textinput.New()
+	input.Prompt = "/"
+	input.PromptStyle = lipgloss.NewStyle().Foreground(colors.Color1).Bold(true)
+	input.Cursor.SetMode(cursor.CursorStatic)
+
+	return &finder{
+		input: input,
+		matchStyle: lipgloss.NewStyle().
+			Background(colors.TextDark).
+			Foreground(colors.BgDark).
+			Underline(theme.NoColor),
+		currentStyle: theme.Emphasize(lipgloss.NewStyle().
+			Background(colors.Color2).
+			Foreground(colors.BgDark).
+			Bold(true)),
+	}
+}
+
+// newArticle forgets the search of the previous article
+func (f *finder) newArticle(noColorText *string) {
+	f.article = noColorText
+	f.close()
+}
+
+// start shows the search input, the previous query is kept so that it can be changed
+func (f *finder) start() tea.Cmd {
+	f.typing = true
+	f.active = true
+	f.input.CursorEnd()
+	return f.input.Focus()
+}
+
+// close hides the search input and the highlights
+func (f *finder) close() {
+	f.typing = false
+	f.active = false
+	f.matches = nil
+	f.input.Blur()
+}
+
+// update handles the keys typed in the search input, the matches are found as the query changes.
+// The first match at or after the line is selected
+func (f *finder) update(msg tea.KeyMsg, line int) tea.Cmd {
+	var cmd tea.Cmd
+	query := f.input.Value()
+	f.input, cmd = f.input.Update(msg)
+	if f.input.Value() != query {
+		f.find(line)
+	}
+
+	return cmd
+}
+
+// find finds the matches of the query, the case is ignored. The matches are found in the article
+// itself, since the lowercase text can have a different length
+func (f *finder) find(line int) {
+	f.matches, f.current = nil, 0
+	query := f.input.Value()
+	if query == "" || f.article == nil {
+		return
+	}
+
+	f.matches = regexp.MustCompile("(?i)"+regexp.QuoteMeta(query)).FindAllStringIndex(*f.article, -1)
+
+	for i, match := range f.matches {
+		if f.line(match) >= line {
+			f.current = i
+			break
+		}
+	}
+}
+
+// cycle selects the next or the previous match, the search wraps around
+func (f *finder) cycle(step int) {
+	if len(f.matches) == 0 {
+		return
+	}
+
+	f.current = (f.current + step + len(f.matches)) % len(f.matches)
+}
+
+// line returns the line of the article on which the match starts
+func (f *finder) line(match []int) int {
+	return strings.Count((*f.article)[:match[0]], "\n")
+}
+
+// currentLine returns the line of the selected match, it's -1 if nothing matches
+func (f *finder) currentLine() int {
+	if len(f.matches) == 0 {
+		return -1
+	}
+
+	return f.line(f.matches[f.current])
+}
+
+// highlight returns the article with the matches highlighted
+func (f *finder) highlight() string {
+	var b strings.Builder
+	last := 0
+	for i, match := range f.matches {
+		style := f.matchStyle
+		if i == f.current {
+			style = f.currentStyle
+		}
+
+		b.WriteString((*f.article)[last:match[0]])
+		b.WriteString(style.Render((*f.article)[match[0]:match[1]]))
+		last = match[1]
+	}
+
+	b.WriteString((*f.article)[last:])
+	return b.String()
+}
+
+// status describes the search, it's shown below the article
+func (f *finder) status() string {
+	if f.typing {
+		return f.input.View()
+	}
+
+	if len(f.matches) == 0 {
+		return fmt.Sprintf("/%s: no matches", f.input.Value())
+	}
+
+	return fmt.Sprintf("/%s: %d of %d", f.input.Value(), f.current+1, len(f.matches))
+}
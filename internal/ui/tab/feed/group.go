@@ -0,0 +1,42 @@
+package feed
+
+import "time"
+
+// groupHeader is a section header in the article list, the articles under it were published in
+// the same period
+type groupHeader string
+
+// FilterValue returns nothing, the headers are hidden when filtering
+func (h groupHeader) FilterValue() string {
+	return ""
+}
+
+// groupedOrder reports if the articles are grouped by their date in the sort order, grouping
+// makes no sense when they aren't sorted by the date
+func groupedOrder(order string) bool {
+	return order == "" || order == "newest" || order == "oldest"
+}
+
+// dateGroup returns the name of the period in which the article was published
+func dateGroup(published, now time.Time) string {
+	if published.IsZero() {
+		return "Undated"
+	}
+
+	published = published.In(now.Location())
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch {
+	case !published.Before(today):
+		return "Today"
+	case !published.Before(today.AddDate(0, 0, -1)):
+		return "Yesterday"
+	case !published.Before(today.AddDate(0, 0, -6)):
+		return "This week"
+	case !published.Before(today.AddDate(0, 0, -29)):
+		return "This month"
+	case published.Year() == now.Year():
+		return published.Format("January")
+	default:
+		return published.Format("January 2006")
+	}
+}
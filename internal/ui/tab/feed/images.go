@@ -0,0 +1,172 @@
+package feed
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/TypicalAM/goread/internal/backend"
+	"github.com/TypicalAM/goread/internal/ui/graphics"
+	"github.com/TypicalAM/goread/internal/ui/tab"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ImageProtocol is the graphics protocol used to display the images of the articles
+var ImageProtocol graphics.Protocol
+
+// maxImageAttempts is how many times an image is downloaded before its alt text is shown for good
+const maxImageAttempts = 3
+
+// imageRetryDelay is how long the download of an image waits after it failed
+var imageRetryDelay = 5 * time.Second
+
+var (
+	imagePattern = regexp.MustCompile(`!\[([^\]]*)\]\((\S+?)(?:\s+"[^"]*")?\)`)
+	ansiPattern  = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+)
+
+// articleImage is an image in the article, it's replaced with a token before rendering
+type articleImage struct {
+	token string
+	alt   string
+	url   string
+}
+
+// replaceImages replaces the images in the markdown with tokens, so that they can be found after
+// the markdown is rendered
+func replaceImages(markdown string) (string, []articleImage) {
+	var images []articleImage
+	result := imagePattern.ReplaceAllStringFunc(markdown, func(match string) string {
+		groups := imagePattern.FindStringSubmatch(match)
+		token := fmt.Sprintf("goreadimage%dgoread", len(images))
+		images = append(images, articleImage{token, groups[1], groups[2]})
+		return token
+	})
+
+	return result, images
+}
+
+// renderArticle renders the markdown of the article, if the terminal supports it the images are
+// displayed inline, otherwise the alt text is shown. The links are clickable if the terminal supports
+// it and the returned command loads the missing images. The open comments are rendered as a thread instead
+func (m Model) renderArticle(markdown string) (string, tea.Cmd, error) {
+	if m.threadOpen() {
+		styled, err := m.renderThread(markdown, m.colors.MarkdownStyle, lipgloss.NewStyle().Foreground(m.colors.Color2))
+		return styled, nil, err
+	}
+
+	if !ImageProtocol.Inline() {
+		styled, err := m.colorTr.Render(markdown)
+		return m.hyperlinks(styled), nil, err
+	}
+
+	markdown, images := replaceImages(markdown)
+	styled, err := m.colorTr.Render(markdown)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var cmds []tea.Cmd
+	for _, img := range images {
+		if _, ok := m.images[img.url]; !ok && !m.requestedImages[img.url] {
+			m.requestedImages[img.url] = true
+			cmds = append(cmds, backend.LoadImage(img.url))
+		}
+	}
+
+	lines := strings.Split(styled, "\n")
+	for i, line := range lines {
+		if !strings.Contains(line, "goreadimage") {
+			continue
+		}
+
+		for _, img := range images {
+			if !strings.Contains(line, img.token) {
+				continue
+			}
+
+			// Images which are the only thing in their paragraph are displayed, others use the alt text
+			placeholder := m.images[img.url]
+			if placeholder != "" && strings.TrimSpace(ansiPattern.ReplaceAllString(line, "")) == img.token {
+				line = "  " + strings.ReplaceAll(placeholder, "\n", "\n  ")
+				break
+			}
+
+			line = strings.Replace(line, img.token, m.style.imageAlt.Render(imageAlt(img.alt)), 1)
+		}
+
+		lines[i] = line
+	}
+
+	return m.hyperlinks(strings.Join(lines, "\n")), tea.Batch(cmds...), nil
+}
+
+// loadImage prepares a loaded image and transmits it to the terminal
+func (m Model) loadImage(msg backend.ImageLoadedMsg) (tab.Tab, tea.Cmd) {
+	if msg.Err != nil {
+		log.Printf("Error loading image %s: %v\n", msg.URL, msg.Err)
+		m.failedImages[msg.URL]++
+		if m.failedImages[msg.URL] < maxImageAttempts {
+			load := backend.LoadImage(msg.URL)
+			return m, tea.Tick(imageRetryDelay, func(time.Time) tea.Msg { return load() })
+		}
+
+		m.images[msg.URL] = ""
+		return m, nil
+	}
+
+	maxRows := m.style.viewportHeight / 2
+	if maxRows > graphics.MaxRows {
+		maxRows = graphics.MaxRows
+	}
+
+	img, err := graphics.Decode(msg.Data, m.style.viewportWidth-8, maxRows)
+	if err != nil {
+		log.Printf("Error decoding image %s: %v\n", msg.URL, err)
+		m.images[msg.URL] = ""
+		return m, nil
+	}
+
+	// The iTerm and sixel images are drawn by the output along with the frames
+	var transmit tea.Cmd
+	if ImageProtocol == graphics.Kitty {
+		kittyImage := graphics.NewKittyImage(img)
+		m.images[msg.URL] = kittyImage.Placeholder()
+		transmit = func() tea.Msg {
+			if err := kittyImage.Transmit(graphics.Terminal); err != nil {
+				log.Printf("Error transmitting image %s: %v\n", msg.URL, err)
+			}
+
+			return nil
+		}
+	} else {
+		m.images[msg.URL] = graphics.NewInlineImage(img, ImageProtocol).Placeholder()
+	}
+
+	// Show the image if the article is still open
+	if !m.viewportOpen || m.list.SelectedItem() == nil || (m.viewportFocused && m.selector.active) || m.finder.active {
+		return m, transmit
+	}
+
+	styled, _, err := m.renderArticle(m.articleMarkdown())
+	if err != nil {
+		return m, transmit
+	}
+
+	offset := m.viewport.YOffset
+	m.viewport.SetContent(styled)
+	m.viewport.SetYOffset(offset)
+	return m, transmit
+}
+
+// imageAlt returns the text which is shown in place of an image
+func imageAlt(alt string) string {
+	if alt == "" {
+		return "[Image]"
+	}
+
+	return fmt.Sprintf("[Image: %s]", alt)
+}
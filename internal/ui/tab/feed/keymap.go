@@ -4,13 +4,46 @@ import "github.com/charmbracelet/bubbles/key"
 
 // Keymap contains the key bindings for this tab
 type Keymap struct {
-	Open            key.Binding
-	ToggleFocus     key.Binding
-	RefreshArticles key.Binding
-	SaveArticle     key.Binding
-	DeleteFromSaved key.Binding
-	CycleSelection  key.Binding
-	MarkAsUnread    key.Binding
+	Open             key.Binding
+	ToggleFocus      key.Binding
+	ToggleLayout     key.Binding
+	GrowList         key.Binding
+	ShrinkList       key.Binding
+	RefreshArticles  key.Binding
+	SaveArticle      key.Binding
+	DeleteFromSaved  key.Binding
+	CycleSelection   key.Binding
+	ToggleRead       key.Binding
+	ToggleUnreadOnly key.Binding
+	OpenInBrowser    key.Binding
+	DownloadEpisode  key.Binding
+	PlayEpisode      key.Binding
+	MarkAllAsRead    key.Binding
+	Mark             key.Binding
+	Visual           key.Binding
+	CycleSortOrder   key.Binding
+	NextUnread       key.Binding
+	PrevUnread       key.Binding
+	NextArticle      key.Binding
+	PrevArticle      key.Binding
+	Find             key.Binding
+	NextMatch        key.Binding
+	PrevMatch        key.Binding
+	CopyLink         key.Binding
+	CopyTitle        key.Binding
+	ExportMarkdown   key.Binding
+	ExportHTML       key.Binding
+	Share            key.Binding
+	Annotate         key.Binding
+	Comments         key.Binding
+	FoldReplies      key.Binding
+	ReadAloud        key.Binding
+	Translate        key.Binding
+	Summarize        key.Binding
+	Snooze           key.Binding
+	Archive          key.Binding
+	Trash            key.Binding
+	OpenInTab        key.Binding
 }
 
 // DefaultKeymap contains the default key bindings for this tab
@@ -23,6 +56,18 @@ var DefaultKeymap = Keymap{
 		key.WithKeys("left", "right", "h", "l"),
 		key.WithHelp("←/→", "Move left/right"),
 	),
+	ToggleLayout: key.NewBinding(
+		key.WithKeys("L"),
+		key.WithHelp("L", "Toggle stacked layout"),
+	),
+	GrowList: key.NewBinding(
+		key.WithKeys("+", "="),
+		key.WithHelp("+", "Grow the list"),
+	),
+	ShrinkList: key.NewBinding(
+		key.WithKeys("-"),
+		key.WithHelp("-", "Shrink the list"),
+	),
 	RefreshArticles: key.NewBinding(
 		key.WithKeys("r", "ctrl+r"),
 		key.WithHelp("r/ctrl+r", "Refresh"),
@@ -39,9 +84,129 @@ var DefaultKeymap = Keymap{
 		key.WithKeys("g"),
 		key.WithHelp("g", "Cycle selection"),
 	),
-	MarkAsUnread: key.NewBinding(
+	ToggleRead: key.NewBinding(
 		key.WithKeys("u"),
-		key.WithHelp("u", "Mark as unread"),
+		key.WithHelp("u", "Toggle read"),
+	),
+	ToggleUnreadOnly: key.NewBinding(
+		key.WithKeys("U"),
+		key.WithHelp("U", "Unread only"),
+	),
+	OpenInBrowser: key.NewBinding(
+		key.WithKeys("o"),
+		key.WithHelp("o", "Open in browser"),
+	),
+	DownloadEpisode: key.NewBinding(
+		key.WithKeys("D"),
+		key.WithHelp("D", "Download episode"),
+	),
+	PlayEpisode: key.NewBinding(
+		key.WithKeys("p"),
+		key.WithHelp("p", "Play episode"),
+	),
+	MarkAllAsRead: key.NewBinding(
+		key.WithKeys("A"),
+		key.WithHelp("A", "Mark all as read"),
+	),
+	Mark: key.NewBinding(
+		key.WithKeys(" "),
+		key.WithHelp("space", "Mark"),
+	),
+	Visual: key.NewBinding(
+		key.WithKeys("v"),
+		key.WithHelp("v", "Mark range"),
+	),
+	CycleSortOrder: key.NewBinding(
+		key.WithKeys("S"),
+		key.WithHelp("S", "Sort"),
+	),
+	NextUnread: key.NewBinding(
+		key.WithKeys("n"),
+		key.WithHelp("n", "Next unread"),
+	),
+	PrevUnread: key.NewBinding(
+		key.WithKeys("N"),
+		key.WithHelp("N", "Previous unread"),
+	),
+	NextArticle: key.NewBinding(
+		key.WithKeys("J"),
+		key.WithHelp("J", "Next article"),
+	),
+	PrevArticle: key.NewBinding(
+		key.WithKeys("K"),
+		key.WithHelp("K", "Previous article"),
+	),
+	Find: key.NewBinding(
+		key.WithKeys("/"),
+		key.WithHelp("/", "Find in article"),
+	),
+	NextMatch: key.NewBinding(
+		key.WithKeys("n"),
+		key.WithHelp("n", "Next match"),
+	),
+	PrevMatch: key.NewBinding(
+		key.WithKeys("N"),
+		key.WithHelp("N", "Previous match"),
+	),
+	CopyLink: key.NewBinding(
+		key.WithKeys("y"),
+		key.WithHelp("y", "Copy link"),
+	),
+	CopyTitle: key.NewBinding(
+		key.WithKeys("Y"),
+		key.WithHelp("Y", "Copy title and link"),
+	),
+	ExportMarkdown: key.NewBinding(
+		key.WithKeys("e"),
+		key.WithHelp("e", "Export as markdown"),
+	),
+	ExportHTML: key.NewBinding(
+		key.WithKeys("E"),
+		key.WithHelp("E", "Export as html"),
+	),
+	Share: key.NewBinding(
+		key.WithKeys("w"),
+		key.WithHelp("w", "Share to a read-later service"),
+	),
+	Annotate: key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("t", "Tags and note"),
+	),
+	Comments: key.NewBinding(
+		key.WithKeys("C"),
+		key.WithHelp("C", "Hacker News comments"),
+	),
+	FoldReplies: key.NewBinding(
+		key.WithKeys("F"),
+		key.WithHelp("F", "Fold replies"),
+	),
+	ReadAloud: key.NewBinding(
+		key.WithKeys("a"),
+		key.WithHelp("a", "Read aloud"),
+	),
+	Translate: key.NewBinding(
+		key.WithKeys("T"),
+		key.WithHelp("T", "Translate"),
+	),
+	Summarize: key.NewBinding(
+		key.WithKeys("i"),
+		key.WithHelp("i", "Summarize"),
+	),
+	Snooze: key.NewBinding(
+		key.WithKeys("Z"),
+		key.WithHelp("Z", "Snooze"),
+	),
+	Archive: key.NewBinding(
+		key.WithKeys("m"),
+		key.WithHelp("m", "Archive/restore"),
+	),
+	Trash: key.NewBinding(
+		key.WithKeys("#"),
+		key.WithHelp("#", "Trash/restore"),
+	),
+	OpenInTab: key.NewBinding(
+		key.WithKeys("O"),
+		key.WithHelp("O", "Keep in a tab"),
 	),
 }
 
@@ -49,9 +214,42 @@ var DefaultKeymap = Keymap{
 func (m *Keymap) SetEnabled(enabled bool) {
 	m.Open.SetEnabled(enabled)
 	m.ToggleFocus.SetEnabled(enabled)
+	m.ToggleLayout.SetEnabled(enabled)
+	m.GrowList.SetEnabled(enabled)
+	m.ShrinkList.SetEnabled(enabled)
 	m.RefreshArticles.SetEnabled(enabled)
 	m.SaveArticle.SetEnabled(enabled)
 	m.DeleteFromSaved.SetEnabled(enabled)
 	m.CycleSelection.SetEnabled(enabled)
-	m.MarkAsUnread.SetEnabled(enabled)
+	m.ToggleRead.SetEnabled(enabled)
+	m.ToggleUnreadOnly.SetEnabled(enabled)
+	m.OpenInBrowser.SetEnabled(enabled)
+	m.DownloadEpisode.SetEnabled(enabled)
+	m.PlayEpisode.SetEnabled(enabled)
+	m.MarkAllAsRead.SetEnabled(enabled)
+	m.Mark.SetEnabled(enabled)
+	m.Visual.SetEnabled(enabled)
+	m.CycleSortOrder.SetEnabled(enabled)
+	m.NextUnread.SetEnabled(enabled)
+	m.PrevUnread.SetEnabled(enabled)
+	m.NextArticle.SetEnabled(enabled)
+	m.PrevArticle.SetEnabled(enabled)
+	m.Find.SetEnabled(enabled)
+	m.NextMatch.SetEnabled(enabled)
+	m.PrevMatch.SetEnabled(enabled)
+	m.CopyLink.SetEnabled(enabled)
+	m.CopyTitle.SetEnabled(enabled)
+	m.ExportMarkdown.SetEnabled(enabled)
+	m.ExportHTML.SetEnabled(enabled)
+	m.Share.SetEnabled(enabled)
+	m.Annotate.SetEnabled(enabled)
+	m.Comments.SetEnabled(enabled)
+	m.FoldReplies.SetEnabled(enabled)
+	m.ReadAloud.SetEnabled(enabled)
+	m.Translate.SetEnabled(enabled)
+	m.Summarize.SetEnabled(enabled)
+	m.Snooze.SetEnabled(enabled)
+	m.Archive.SetEnabled(enabled)
+	m.Trash.SetEnabled(enabled)
+	m.OpenInTab.SetEnabled(enabled)
 }
@@ -0,0 +1,106 @@
+package feed
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/glamour"
+)
+
+// Layout describes how the article list and the article are placed in the tab
+type Layout struct {
+	// Stacked puts the list above the article instead of next to it
+	Stacked bool
+	// ListRatio is the percentage of the width, or of the height when stacked, taken by the list
+	ListRatio int
+	// Zen shows the open article alone on the whole tab
+	Zen bool
+}
+
+// DefaultLayout is the layout of the new feed tabs, it follows the changes made in the tabs
+var DefaultLayout = Layout{ListRatio: 25}
+
+// ZenWidth is the largest width of the article in the zen mode, the article is centered. The
+// article takes the whole width if it's zero
+var ZenWidth int
+
+const (
+	// minListRatio and maxListRatio keep both of the panes visible
+	minListRatio = 10
+	maxListRatio = 90
+
+	// listRatioStep is the change of the ratio with every key press
+	listRatioStep = 5
+
+	// minListHeight fits the title of the list and an article, a lower list grows past its border
+	minListHeight = 6
+)
+
+// resize changes the ratio of the list by the delta, the ratio stays within the limits
+func (l Layout) resize(delta int) Layout {
+	l.ListRatio += delta
+	if l.ListRatio < minListRatio {
+		l.ListRatio = minListRatio
+	}
+
+	if l.ListRatio > maxListRatio {
+		l.ListRatio = maxListRatio
+	}
+
+	return l
+}
+
+// paneSizes returns the sizes of the list and the article inside of their borders. In the screen
+// reader mode only one of them is shown at a time so both take the whole tab
+func (l Layout) paneSizes(width, height int, screenReader bool) (listWidth, listHeight, viewportWidth, viewportHeight int) {
+	if screenReader {
+		return width - 2, height, width - 2, height
+	}
+
+	// The article has no border in the zen mode, the list is shown alone until an article is opened
+	if l.Zen {
+		viewportWidth = width
+		if ZenWidth > 0 && ZenWidth < width {
+			viewportWidth = ZenWidth
+		}
+
+		return width - 2, height - 2, viewportWidth, height
+	}
+
+	ratio := l.resize(0).ListRatio
+	if !l.Stacked {
+		listWidth = width*ratio/100 - 2
+		return listWidth, height, width - listWidth - 4, height
+	}
+
+	// Both of the panes have a border, together they are as high as the panes next to each other
+	listHeight = height*ratio/100 - 1
+	if listHeight < minListHeight {
+		listHeight = minListHeight
+	}
+
+	return width - 2, listHeight, width - 2, height - listHeight - 2
+}
+
+// setLayout changes the layout of the tab, the new tabs are opened with it too. The open article
+// is rendered again to fit the new width, the reading position is kept
+func (m Model) setLayout(layout Layout) (tea.Model, tea.Cmd) {
+	DefaultLayout = layout
+	m.style.layout = layout
+	m = m.restyle().(Model)
+	if !m.loaded {
+		return m, nil
+	}
+
+	noColorTr, err := glamour.NewTermRenderer(
+		glamour.WithStyles(glamour.NoTTYStyleConfig),
+		glamour.WithWordWrap(m.style.viewportWidth-2),
+	)
+	if err == nil {
+		m.noColorTr = noColorTr
+	}
+
+	offset := m.viewport.YOffset
+	updated, cmd := m.updateViewport()
+	m = updated.(Model)
+	m.viewport.SetYOffset(offset)
+	return m, cmd
+}
@@ -0,0 +1,232 @@
+package feed
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/TypicalAM/goread/internal/backend"
+	"github.com/TypicalAM/goread/internal/backend/rss"
+	"github.com/TypicalAM/goread/internal/ui/hyperlink"
+)
+
+// referencesHeading is the heading of the list of the numbered links
+const referencesHeading = "## References"
+
+// linkPattern matches the markdown links and the images, only the links are numbered
+var linkPattern = regexp.MustCompile(`(!?)\[([^\]]*)\]\((\S+?)(?:\s+"[^"]*")?\)`)
+
+// markerPattern matches the numbers of the links in the rendered article
+var markerPattern = regexp.MustCompile(`\[(\d+)\]`)
+
+// sourceMarkerPattern matches the numbers of the links in the markdown, they are escaped there.
+// The other numbers in brackets are matched too, since they look the same once rendered
+var sourceMarkerPattern = regexp.MustCompile(`\\\[(\d+)\\\]|\[\d+\]`)
+
+// fencePattern matches the lines which open or close a fenced code block
+var fencePattern = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})")
+
+// codeSpan is a part of the markdown, it's code if it's in a code block or an inline code span
+type codeSpan struct {
+	text string
+	code bool
+}
+
+// splitCode splits the markdown into the code and the rest, so that nothing in the code is
+// taken for a link
+func splitCode(markdown string) []codeSpan {
+	var spans []codeSpan
+	var text strings.Builder
+	flush := func(code bool) {
+		if text.Len() > 0 {
+			spans = append(spans, codeSpan{text.String(), code})
+			text.Reset()
+		}
+	}
+
+	fence := ""
+	for _, line := range strings.SplitAfter(markdown, "\n") {
+		opening := fencePattern.FindStringSubmatch(line)
+		switch {
+		case fence == "" && opening != nil:
+			flush(false)
+			fence = opening[1]
+			text.WriteString(line)
+
+		case fence != "":
+			text.WriteString(line)
+			if opening != nil && opening[1][0] == fence[0] && len(opening[1]) >= len(fence) &&
+				strings.TrimSpace(line) == opening[1] {
+				flush(true)
+				fence = ""
+			}
+
+		default:
+			for line != "" {
+				start := strings.IndexByte(line, '`')
+				if start < 0 {
+					text.WriteString(line)
+					break
+				}
+
+				ticks := len(line[start:]) - len(strings.TrimLeft(line[start:], "`"))
+				end := strings.Index(line[start+ticks:], line[start:start+ticks])
+				if end < 0 {
+					text.WriteString(line[:start+ticks])
+					line = line[start+ticks:]
+					continue
+				}
+
+				text.WriteString(line[:start])
+				flush(false)
+				text.WriteString(line[start : start+2*ticks+end])
+				flush(true)
+				line = line[start+2*ticks+end:]
+			}
+		}
+	}
+
+	flush(fence != "")
+	return spans
+}
+
+// linkMarkers returns the number of the link of every number in brackets in the numbered markdown,
+// in the order they are rendered. The ones in the code aren't links, their number is zero
+func linkMarkers(numbered string) []int {
+	var markers []int
+	for _, span := range splitCode(numbered) {
+		if span.code {
+			for range markerPattern.FindAllString(span.text, -1) {
+				markers = append(markers, 0)
+			}
+
+			continue
+		}
+
+		for _, match := range sourceMarkerPattern.FindAllStringSubmatch(span.text, -1) {
+			number, _ := strconv.Atoi(match[1])
+			markers = append(markers, number)
+		}
+	}
+
+	return markers
+}
+
+// numberLinks replaces the links in the markdown with their text and a number, like in newsboat.
+// The numbered links are listed at the bottom of the article and returned, a link which appears
+// several times gets a single number
+func numberLinks(markdown string) (string, []string) {
+	var links []string
+	numbers := make(map[string]int)
+	number := func(match string) string {
+		groups := linkPattern.FindStringSubmatch(match)
+		text, url := groups[2], groups[3]
+		if groups[1] == "!" || strings.Contains(text, "![") || strings.HasPrefix(url, "#") {
+			return match
+		}
+
+		number, ok := numbers[url]
+		if !ok {
+			links = append(links, url)
+			number = len(links)
+			numbers[url] = number
+		}
+
+		return fmt.Sprintf("%s\\[%d\\]", text, number)
+	}
+
+	// The links in the code are left alone
+	var b strings.Builder
+	for _, span := range splitCode(markdown) {
+		if span.code {
+			b.WriteString(span.text)
+		} else {
+			b.WriteString(linkPattern.ReplaceAllStringFunc(span.text, number))
+		}
+	}
+
+	if len(links) == 0 {
+		return markdown, nil
+	}
+
+	numbered := b.String()
+	b.Reset()
+	b.WriteString(strings.TrimRight(numbered, "\n"))
+	b.WriteString("\n\n" + referencesHeading + "\n\n")
+	for i, link := range links {
+		fmt.Fprintf(&b, "%d. %s\n", i+1, link)
+	}
+
+	b.WriteString("\n\n")
+	return b.String(), links
+}
+
+// link returns the link with the number, counted from one
+func (m Model) link(number int) (string, error) {
+	if number < 1 || number > len(m.links) {
+		return "", fmt.Errorf("there is no link %d in the article", number)
+	}
+
+	return m.links[number-1], nil
+}
+
+// cleanLink cleans the link using the rules of the feed the selected article comes from, the tabs
+// which combine many feeds don't know it unless some rules need it
+func (m Model) cleanLink(link string) string {
+	feed := m.title
+	if item, ok := m.list.SelectedItem().(backend.ArticleItem); ok && item.Feed() != "" {
+		feed = item.Feed()
+	}
+
+	return rss.CleanLink(feed, link)
+}
+
+// articleMarkdown returns the markdown of the selected article with the numbered links
+func (m Model) articleMarkdown() string {
+	markdown, _ := numberLinks(m.content())
+	return markdown
+}
+
+// hyperlinks makes the numbers of the links and the urls in the references of the rendered article
+// clickable, the links are cleaned like the opened ones. The numbers are matched with the markers
+// in order, so the ones in the code stay as they are
+func (m Model) hyperlinks(styled string) string {
+	if !hyperlink.Enabled || len(m.links) == 0 {
+		return styled
+	}
+
+	found := 0
+	styled = markerPattern.ReplaceAllStringFunc(styled, func(marker string) string {
+		found++
+		number, err := strconv.Atoi(marker[1 : len(marker)-1])
+		if err != nil || number < 1 || number > len(m.links) || found > len(m.markers) || m.markers[found-1] != number {
+			return marker
+		}
+
+		return hyperlink.Wrap(marker, m.cleanLink(m.links[number-1]))
+	})
+
+	// The references are listed in order, so every url is searched for after the previous one
+	start := strings.LastIndex(styled, referencesHeading[len("## "):])
+	if start < 0 {
+		return styled
+	}
+
+	var b strings.Builder
+	b.WriteString(styled[:start])
+	pos := start
+	for _, link := range m.links {
+		i := strings.Index(styled[pos:], link)
+		if i < 0 {
+			continue
+		}
+
+		b.WriteString(styled[pos : pos+i])
+		b.WriteString(hyperlink.Wrap(link, m.cleanLink(link)))
+		pos += i + len(link)
+	}
+
+	b.WriteString(styled[pos:])
+	return b.String()
+}
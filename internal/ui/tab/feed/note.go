@@ -0,0 +1,101 @@
+package feed
+
+import (
+	"strings"
+
+	"github.com/TypicalAM/goread/internal/theme"
+	"github.com/TypicalAM/goread/internal/ui/popup"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ChosenNoteMsg is the message sent when the tags and the note of an article are chosen.
+type ChosenNoteMsg struct {
+	FeedName string
+	Index    int
+	Tags     []string
+	Note     string
+}
+
+// NotePopup is the popup where a user can tag an article and write a note about it.
+type NotePopup struct {
+	tagsInput textinput.Model
+	noteInput textinput.Model
+	style     popupStyle
+	overlay   popup.Overlay
+	feedName  string
+	index     int
+}
+
+// NewNotePopup creates a new popup window with the current tags and note of the article.
+func NewNotePopup(colors *theme.Colors, bgRaw string, width, height int, feedName string, index int, tags []string, note string) NotePopup {
+	overlay := popup.NewOverlay(bgRaw, width, height)
+	style := newPopupStyle(colors, width, height)
+	tagsInput := textinput.New()
+	tagsInput.CharLimit = 100
+	tagsInput.Width = width - 20
+	tagsInput.Prompt = "Tags: "
+	tagsInput.Placeholder = "research golang"
+	tagsInput.SetValue(strings.Join(tags, " "))
+	tagsInput.Focus()
+
+	noteInput := textinput.New()
+	noteInput.CharLimit = 500
+	noteInput.Width = width - 20
+	noteInput.Prompt = "Note: "
+	noteInput.SetValue(note)
+
+	return NotePopup{
+		overlay:   overlay,
+		style:     style,
+		tagsInput: tagsInput,
+		noteInput: noteInput,
+		feedName:  feedName,
+		index:     index,
+	}
+}
+
+// Init the popup window.
+func (p NotePopup) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Update the popup window.
+func (p NotePopup) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "down", "up", "tab", "shift+tab":
+			if p.tagsInput.Focused() {
+				p.tagsInput.Blur()
+				return p, p.noteInput.Focus()
+			}
+
+			p.noteInput.Blur()
+			return p, p.tagsInput.Focus()
+
+		case "enter":
+			chosen := ChosenNoteMsg{p.feedName, p.index, strings.Fields(p.tagsInput.Value()), p.noteInput.Value()}
+			return p, func() tea.Msg { return chosen }
+		}
+	}
+
+	var cmd tea.Cmd
+	if p.tagsInput.Focused() {
+		p.tagsInput, cmd = p.tagsInput.Update(msg)
+	} else {
+		p.noteInput, cmd = p.noteInput.Update(msg)
+	}
+
+	return p, cmd
+}
+
+// View renders the popup window.
+func (p NotePopup) View() string {
+	title := p.style.itemTitle.Render("Tags are separated by spaces")
+	tags := p.style.itemField.Render(p.tagsInput.View())
+	note := p.style.itemField.Render(p.noteInput.View())
+	item := p.style.item.Render(lipgloss.JoinVertical(lipgloss.Left, title, tags, note))
+	popup := lipgloss.JoinVertical(lipgloss.Left, p.style.heading.Render("Tag the article"), item)
+	return p.overlay.WrapView(p.style.general.Render(popup))
+}
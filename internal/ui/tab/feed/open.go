@@ -0,0 +1,72 @@
+package feed
+
+import (
+	"errors"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// DefaultBrowserCommand is the command used to open urls, "%u" is replaced with the url. If it's
+// empty the default browser of the system is used
+var DefaultBrowserCommand string
+
+// DefaultPlayerCommand is the command used to play the podcast episodes, "%u" is replaced with the
+// url of the episode and "%t" with the title of the article
+var DefaultPlayerCommand = "mpv %u"
+
+// OpenURL opens the url in the browser
+func OpenURL(url string) error {
+	if DefaultBrowserCommand != "" {
+		return startCommand(DefaultBrowserCommand, url, "", false)
+	}
+
+	switch runtime.GOOS {
+	case "linux":
+		return exec.Command("xdg-open", url).Start() //nolint:gosec
+	case "windows":
+		return exec.Command("rundll32", "url.dll,FileProtocolHandler", url).Start() //nolint:gosec
+	case "darwin":
+		return exec.Command("open", url).Start() //nolint:gosec
+	default:
+		return errors.New("unsupported platform")
+	}
+}
+
+// playEpisode streams the episode using the player, the url comes from the feed so the player
+// is told that it isn't an option
+func playEpisode(url, title string) error {
+	return startCommand(DefaultPlayerCommand, url, title, true)
+}
+
+// startCommand starts the command in the background, "%u" and "%t" in its arguments are replaced
+// with the url and the title. The url is appended if the command doesn't use it, with endOptions
+// a "--" goes before the url when it's an argument of its own
+func startCommand(command, url, title string, endOptions bool) error {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return errors.New("no command given")
+	}
+
+	// The placeholders are replaced at once, so a url or a title which contains one is kept as is
+	replacer := strings.NewReplacer("%u", url, "%t", title)
+	args, replaced := make([]string, 0, len(fields)), false
+	for _, field := range fields[1:] {
+		replaced = replaced || strings.Contains(field, "%u")
+		if endOptions && field == "%u" {
+			args = append(args, "--")
+		}
+
+		args = append(args, replacer.Replace(field))
+	}
+
+	if !replaced {
+		if endOptions {
+			args = append(args, "--")
+		}
+
+		args = append(args, url)
+	}
+
+	return exec.Command(fields[0], args...).Start() //nolint:gosec
+}
@@ -1,9 +1,6 @@
 package feed
 
 import (
-	"errors"
-	"os/exec"
-	"runtime"
 	"strings"
 
 	"github.com/TypicalAM/goread/internal/theme"
@@ -120,16 +117,7 @@ func (s *selector) cycle() string {
 	return b.String()
 }
 
-// open opens the URL in the browser
-func (s *selector) open() error {
-	switch runtime.GOOS {
-	case "linux":
-		return exec.Command("xdg-open", s.urls[s.selection]).Start() //nolint:gosec
-	case "windows":
-		return exec.Command("rundll32", "url.dll,FileProtocolHandler", s.urls[s.selection]).Start() //nolint:gosec
-	case "darwin":
-		return exec.Command("open", s.urls[s.selection]).Start() //nolint:gosec
-	default:
-		return errors.New("unsupported platform")
-	}
+// open opens the URL in the browser, it's cleaned using the link rules of the feed
+func (s *selector) open(clean func(string) string) error {
+	return OpenURL(clean(s.urls[s.selection]))
 }
@@ -0,0 +1,128 @@
+package feed
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/TypicalAM/goread/internal/backend"
+	"github.com/TypicalAM/goread/internal/theme"
+	"github.com/TypicalAM/goread/internal/ui/popup"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ChosenSnoozeMsg is the message sent when the time until which an article is snoozed is chosen.
+type ChosenSnoozeMsg struct {
+	FeedName string
+	Index    int
+	When     string
+}
+
+// SnoozePopup is the popup where a user can choose until when an article is hidden.
+type SnoozePopup struct {
+	customInput textinput.Model
+	style       popupStyle
+	overlay     popup.Overlay
+	feedName    string
+	index       int
+	focused     int
+}
+
+// NewSnoozePopup creates a new popup window with the usual snooze times and a custom one.
+func NewSnoozePopup(colors *theme.Colors, bgRaw string, width, height int, feedName string, index int) SnoozePopup {
+	customInput := textinput.New()
+	customInput.CharLimit = 30
+	customInput.Width = width - 20
+	customInput.Prompt = "Until: "
+	customInput.Placeholder = "3h, 2d or 2006-01-02 15:04"
+
+	return SnoozePopup{
+		overlay:     popup.NewOverlay(bgRaw, width, height),
+		style:       newPopupStyle(colors, width, height),
+		customInput: customInput,
+		feedName:    feedName,
+		index:       index,
+	}
+}
+
+// Init the popup window.
+func (p SnoozePopup) Init() tea.Cmd {
+	return nil
+}
+
+// Update the popup window.
+func (p SnoozePopup) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	custom := len(backend.SnoozeChoices)
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "down", "tab":
+			return p.focus((p.focused + 1) % (custom + 1))
+
+		case "up", "shift+tab":
+			return p.focus((p.focused + custom) % (custom + 1))
+
+		case "enter":
+			return p, p.choose(p.focused)
+		}
+
+		// The choices have their numbers as shortcuts, unless the custom time is being typed
+		if number, err := strconv.Atoi(msg.String()); err == nil && p.focused != custom && number >= 1 && number <= custom {
+			return p, p.choose(number - 1)
+		}
+	}
+
+	var cmd tea.Cmd
+	if p.customInput.Focused() {
+		p.customInput, cmd = p.customInput.Update(msg)
+	}
+
+	return p, cmd
+}
+
+// focus focuses the choice, the last one is the custom time
+func (p SnoozePopup) focus(choice int) (tea.Model, tea.Cmd) {
+	p.focused = choice
+	if p.focused == len(backend.SnoozeChoices) {
+		return p, p.customInput.Focus()
+	}
+
+	p.customInput.Blur()
+	return p, nil
+}
+
+// choose sends the chosen time
+func (p SnoozePopup) choose(choice int) tea.Cmd {
+	chosen := ChosenSnoozeMsg{FeedName: p.feedName, Index: p.index, When: p.customInput.Value()}
+	if choice < len(backend.SnoozeChoices) {
+		chosen.When = backend.SnoozeChoices[choice]
+	}
+
+	return func() tea.Msg { return chosen }
+}
+
+// View renders the popup window.
+func (p SnoozePopup) View() string {
+	now := time.Now()
+	lines := make([]string, 0, len(backend.SnoozeChoices)+1)
+	for i, choice := range backend.SnoozeChoices {
+		line := fmt.Sprintf("%d %s", i+1, choice)
+		if until, err := backend.ParseSnooze(choice, now); err == nil {
+			line += until.Format(" (Mon 15:04)")
+		}
+
+		style := p.style.itemField
+		if i == p.focused {
+			style = p.style.itemTitle.Copy().Bold(true)
+		}
+
+		lines = append(lines, style.Render(line))
+	}
+
+	lines = append(lines, p.style.itemField.Render(p.customInput.View()))
+	title := p.style.itemTitle.Render("Choose with the arrows or the numbers")
+	item := p.style.item.Render(lipgloss.JoinVertical(lipgloss.Left, append([]string{title, ""}, lines...)...))
+	popup := lipgloss.JoinVertical(lipgloss.Left, p.style.heading.Render("Snooze the article until"), item)
+	return p.overlay.WrapView(p.style.general.Render(popup))
+}
@@ -0,0 +1,97 @@
+package feed
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/TypicalAM/goread/internal/backend"
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// sortOrders are the orders in which the articles can be shown, they are saved in the urls file.
+// Without an order the articles are shown in the order of the feed
+var sortOrders = []string{"newest", "oldest", "title", "unread"}
+
+// sortLabels describe the sort orders in the title of the list
+var sortLabels = map[string]string{
+	"newest": "newest first",
+	"oldest": "oldest first",
+	"title":  "by title",
+	"unread": "unread first",
+}
+
+// nextSortOrder returns the order which comes after the given one
+func nextSortOrder(order string) string {
+	for i, candidate := range sortOrders {
+		if candidate == order {
+			return sortOrders[(i+1)%len(sortOrders)]
+		}
+	}
+
+	return sortOrders[0]
+}
+
+// sortIndexes sorts the indexes of the articles in the order, the articles which are equal keep
+// the order of the feed. The articles without a date come last when sorting by date
+func sortIndexes(indexes []int, items []list.Item, order string) {
+	article := func(i int) backend.ArticleItem {
+		return items[indexes[i]].(backend.ArticleItem)
+	}
+
+	var less func(a, b backend.ArticleItem) bool
+	switch order {
+	case "newest":
+		less = func(a, b backend.ArticleItem) bool {
+			return !a.Published().IsZero() && a.Published().After(b.Published())
+		}
+
+	case "oldest":
+		less = func(a, b backend.ArticleItem) bool {
+			return !a.Published().IsZero() && (b.Published().IsZero() || a.Published().Before(b.Published()))
+		}
+
+	case "title":
+		less = func(a, b backend.ArticleItem) bool {
+			return strings.ToLower(a.Title()) < strings.ToLower(b.Title())
+		}
+
+	case "unread":
+		less = func(a, b backend.ArticleItem) bool {
+			return !a.IsRead() && b.IsRead()
+		}
+
+	default:
+		return
+	}
+
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return less(article(i), article(j))
+	})
+}
+
+// sortAliases are the other names of the sort orders, "feed" keeps the order of the feed
+var sortAliases = map[string]string{
+	"date": "newest",
+	"feed": "",
+}
+
+// ParseSortOrder returns the sort order with the name, it's not ok if there is no such order
+func ParseSortOrder(name string) (string, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if order, ok := sortAliases[name]; ok {
+		return order, true
+	}
+
+	for _, order := range sortOrders {
+		if order == name {
+			return order, true
+		}
+	}
+
+	return "", false
+}
+
+// SortOrderNames returns the names accepted by ParseSortOrder
+func SortOrderNames() []string {
+	return append(append([]string(nil), sortOrders...), "date", "feed")
+}
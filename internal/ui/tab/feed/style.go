@@ -9,39 +9,51 @@ import (
 // style is the style of the feed tab.
 type style struct {
 	listItems       list.DefaultItemStyles
+	readListItems   list.DefaultItemStyles
+	hlListItems     list.DefaultItemStyles
+	groupHeader     lipgloss.Style
+	listTitle       lipgloss.Style
+	listTitleBar    lipgloss.Style
 	link            lipgloss.Style
+	imageAlt        lipgloss.Style
 	loadingMsg      lipgloss.Style
 	idleList        lipgloss.Style
 	focusedList     lipgloss.Style
 	idleViewport    lipgloss.Style
 	focusedViewport lipgloss.Style
 	errIcon         string
+	layout          Layout
 	width           int
 	height          int
 	listWidth       int
+	listHeight      int
 	viewportWidth   int
+	viewportHeight  int
 }
 
 // newStyle creates a new style for the feed tab.
-func newStyle(colors *theme.Colors, width, height int) style {
-	listWidth := width/4 - 2
-	viewportWidth := width - listWidth - 4
+func newStyle(colors *theme.Colors, layout Layout, width, height int) style {
+	listWidth, listHeight, viewportWidth, viewportHeight := layout.paneSizes(width, height, theme.ScreenReader)
 
 	link := lipgloss.NewStyle().
 		Background(colors.Color1).
 		Underline(true)
 
+	imageAlt := lipgloss.NewStyle().
+		Foreground(colors.TextDark).
+		Italic(true)
+
 	loadingMsg := lipgloss.NewStyle().
 		MarginLeft(3).
 		MarginTop(1)
 
 	errIconStyle := loadingMsg.Copy().
 		Foreground(colors.Color4).
-		SetString("")
+		SetString(theme.Icons.Error)
 
 	idleList := lipgloss.NewStyle().
 		Width(listWidth).
-		Height(height).
+		Height(listHeight).
 		Border(lipgloss.NormalBorder()).
 		BorderForeground(colors.TextDark)
 
@@ -50,19 +62,25 @@ func newStyle(colors *theme.Colors, width, height int) style {
 
 	idleViewport := lipgloss.NewStyle().
 		Width(viewportWidth).
-		Height(height).
+		Height(viewportHeight).
 		Border(lipgloss.NormalBorder()).
 		BorderForeground(colors.TextDark)
 
 	focusedViewport := idleViewport.Copy().
 		BorderForeground(colors.Color1)
 
+	// Without the colors the focused pane has a thicker border
+	if theme.NoColor {
+		focusedList = focusedList.Border(lipgloss.ThickBorder())
+		focusedViewport = focusedViewport.Border(lipgloss.ThickBorder())
+	}
+
 	// Create the styles for the list items
 	delegateStyles := list.NewDefaultItemStyles()
-	delegateStyles.SelectedTitle = delegateStyles.SelectedTitle.Copy().
+	delegateStyles.SelectedTitle = theme.Emphasize(delegateStyles.SelectedTitle.Copy().
 		BorderForeground(colors.Color3).
 		Foreground(colors.Color3).
-		Italic(true)
+		Italic(true))
 
 	delegateStyles.SelectedDesc = delegateStyles.SelectedDesc.Copy().
 		BorderForeground(colors.Color3).
@@ -74,12 +92,49 @@ func newStyle(colors *theme.Colors, width, height int) style {
 		Foreground(colors.TextDark).
 		Height(2)
 
+	// Read articles are dimmed
+	readDelegateStyles := delegateStyles
+	readDelegateStyles.NormalTitle = readDelegateStyles.NormalTitle.Copy().
+		Foreground(colors.TextDark).
+		Faint(true)
+
+	readDelegateStyles.NormalDesc = readDelegateStyles.NormalDesc.Copy().
+		Faint(true)
+
+	readDelegateStyles.SelectedTitle = readDelegateStyles.SelectedTitle.Copy().
+		Foreground(colors.TextDark)
+
+	// Highlighted articles stand out
+	hlDelegateStyles := delegateStyles
+	hlDelegateStyles.NormalTitle = hlDelegateStyles.NormalTitle.Copy().
+		Foreground(colors.Color2).
+		Bold(true)
+
+	hlDelegateStyles.SelectedTitle = hlDelegateStyles.SelectedTitle.Copy().
+		Bold(true)
+
+	groupHeader := lipgloss.NewStyle().
+		Foreground(colors.Color1).
+		Bold(true).
+		PaddingLeft(2)
+
+	listTitle := lipgloss.NewStyle().
+		Foreground(colors.Color3).
+		Italic(true)
+
+	listTitleBar := lipgloss.NewStyle().
+		Padding(0, 0, 1, 2)
+
 	return style{
+		layout:          layout,
 		width:           width,
 		height:          height,
 		listWidth:       listWidth,
+		listHeight:      listHeight,
 		viewportWidth:   viewportWidth,
+		viewportHeight:  viewportHeight,
 		link:            link,
+		imageAlt:        imageAlt,
 		loadingMsg:      loadingMsg,
 		errIcon:         errIconStyle.String(),
 		idleList:        idleList,
@@ -87,6 +142,11 @@ func newStyle(colors *theme.Colors, width, height int) style {
 		idleViewport:    idleViewport,
 		focusedViewport: focusedViewport,
 		listItems:       delegateStyles,
+		readListItems:   readDelegateStyles,
+		hlListItems:     hlDelegateStyles,
+		groupHeader:     groupHeader,
+		listTitle:       listTitle,
+		listTitleBar:    listTitleBar,
 	}
 }
 
@@ -94,11 +154,56 @@ func newStyle(colors *theme.Colors, width, height int) style {
 func (s style) setSize(width, height int) style {
 	s.width = width
 	s.height = height
-	s.listWidth = width/4 - 2
-	s.viewportWidth = width - s.listWidth - 4
-	s.idleList = s.idleList.Width(s.listWidth).Height(height)
-	s.focusedList = s.focusedList.Width(s.listWidth).Height(height)
-	s.idleViewport = s.idleViewport.Width(s.viewportWidth).Height(height)
-	s.focusedViewport = s.focusedViewport.Width(s.viewportWidth).Height(height)
+	s.listWidth, s.listHeight, s.viewportWidth, s.viewportHeight = s.layout.paneSizes(width, height, theme.ScreenReader)
+	s.idleList = s.idleList.Width(s.listWidth).Height(s.listHeight)
+	s.focusedList = s.focusedList.Width(s.listWidth).Height(s.listHeight)
+	s.idleViewport = s.idleViewport.Width(s.viewportWidth).Height(s.viewportHeight)
+	s.focusedViewport = s.focusedViewport.Width(s.viewportWidth).Height(s.viewportHeight)
 	return s
 }
+
+// popupStyle is the style of the popup windows of the feed tab.
+type popupStyle struct {
+	general   lipgloss.Style
+	heading   lipgloss.Style
+	item      lipgloss.Style
+	itemTitle lipgloss.Style
+	itemField lipgloss.Style
+}
+
+// newPopupStyle creates a new popup style.
+func newPopupStyle(colors *theme.Colors, width, height int) popupStyle {
+	general := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FFFFFF")).
+		Width(width - 2).
+		Height(height - 2).
+		Border(lipgloss.NormalBorder()).
+		BorderForeground(colors.Color1)
+
+	heading := lipgloss.NewStyle().
+		Margin(1, 0, 1, 0).
+		Width(width - 2).
+		Align(lipgloss.Center).
+		Italic(true)
+
+	item := lipgloss.NewStyle().
+		Margin(0, 4).
+		PaddingLeft(1).
+		Border(lipgloss.RoundedBorder(), false, false, false, true).
+		BorderForeground(colors.Color3).
+		Italic(true)
+
+	itemTitle := lipgloss.NewStyle().
+		Foreground(colors.Color3)
+
+	itemField := lipgloss.NewStyle().
+		Foreground(colors.Color2)
+
+	return popupStyle{
+		general:   general,
+		heading:   heading,
+		item:      item,
+		itemTitle: itemTitle,
+		itemField: itemField,
+	}
+}
@@ -0,0 +1,13 @@
+package feed
+
+// summary is the summary of an article, it's shown above the article until it's toggled off
+type summary struct {
+	index    int
+	markdown string
+	shown    bool
+}
+
+// summaryShown reports if the summary of the selected article is shown
+func (m Model) summaryShown() bool {
+	return m.summary != nil && m.summary.shown && m.summary.index == m.index()
+}
@@ -0,0 +1,14 @@
+package feed
+
+// translation is the translated text of an article, it's shown in place of the article until it's
+// toggled off
+type translation struct {
+	index    int
+	markdown string
+	shown    bool
+}
+
+// translationShown reports if the translation of the selected article is shown
+func (m Model) translationShown() bool {
+	return m.translation != nil && m.translation.shown && m.translation.index == m.index()
+}
@@ -0,0 +1,58 @@
+package health
+
+import (
+	"github.com/TypicalAM/goread/internal/backend"
+	"github.com/TypicalAM/goread/internal/theme"
+	"github.com/TypicalAM/goread/internal/ui/tab"
+	"github.com/TypicalAM/goread/internal/ui/tab/listtab"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Title is the title of the feed health tab
+const Title = "Feed health"
+
+// Model contains the state of this tab
+type Model struct {
+	listtab.Model
+}
+
+// New creates a new feed health tab, the fetcher returns the health of the feeds
+func New(colors *theme.Colors, width, height int, fetcher backend.Fetcher) Model {
+	return Model{listtab.New(listtab.Config{
+		Title:     Title,
+		ListTitle: "Feeds, the broken ones first",
+		Empty:     "There are no feeds yet",
+		Noun:      "feed",
+		Name:      "HEALTH",
+		Icon:      func() string { return theme.Icons.Health },
+		Color:     func(colors *theme.Colors) lipgloss.Color { return colors.Color5 },
+	}, colors, width, height, fetcher)}
+}
+
+// SetSize sets the dimensions of the tab
+func (m Model) SetSize(width, height int) tab.Tab {
+	m.Model = m.Resize(width, height)
+	return m
+}
+
+// Init initializes the tab
+func (m Model) Init() tea.Cmd {
+	return m.Fetch()
+}
+
+// Update updates the variables of the tab
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// The feeds might have been fetched since the tab was opened
+	if msg, ok := msg.(tab.RefreshMsg); ok {
+		if !msg.Active {
+			return m, nil
+		}
+
+		return m, m.Fetch()
+	}
+
+	var cmd tea.Cmd
+	m.Model, cmd = m.Model.Update(msg)
+	return m, cmd
+}
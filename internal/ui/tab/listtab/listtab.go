@@ -0,0 +1,155 @@
+package listtab
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/TypicalAM/goread/internal/backend"
+	"github.com/TypicalAM/goread/internal/theme"
+	"github.com/TypicalAM/goread/internal/ui/tab"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Config describes a tab which only shows a list fetched from the backend
+type Config struct {
+	// Title is the title of the tab, the fetcher gets it too
+	Title string
+	// ListTitle is shown above the list and Empty instead of it when it has no items
+	ListTitle string
+	Empty     string
+	// Noun names an item for the screen readers, like "feed"
+	Noun string
+	// Name, Icon and Color make up the style of the tab, the icon and the color are picked when
+	// the tab is drawn so that they follow the theme
+	Name  string
+	Icon  func() string
+	Color func(colors *theme.Colors) lipgloss.Color
+}
+
+// Model contains the state of a list tab, the tabs embed it and add their own messages
+type Model struct {
+	cfg    Config
+	colors *theme.Colors
+	reader backend.Fetcher
+	list   list.Model
+	style  style
+	width  int
+	height int
+	loaded bool
+}
+
+// New creates a new list tab, the fetcher returns its items
+func New(cfg Config, colors *theme.Colors, width, height int, fetcher backend.Fetcher) Model {
+	log.Println("Creating new list tab with title", cfg.Title)
+	st := newStyle(colors, cfg.Color(colors))
+	delegate := list.NewDefaultDelegate()
+	delegate.Styles = st.listItems
+
+	items := list.New(nil, delegate, width-2, height-1)
+	items.Title = cfg.ListTitle
+	items.Styles.Title = st.listTitle
+	items.Styles.TitleBar = st.listTitleBar
+	items.SetShowHelp(false)
+	items.SetShowStatusBar(false)
+	items.SetFilteringEnabled(false)
+	items.DisableQuitKeybindings()
+
+	return Model{
+		cfg:    cfg,
+		colors: colors,
+		reader: fetcher,
+		list:   items,
+		style:  st,
+		width:  width,
+		height: height,
+	}
+}
+
+// Title returns the title of the tab
+func (m Model) Title() string {
+	return m.cfg.Title
+}
+
+// Position describes the selected item for the screen readers
+func (m Model) Position() string {
+	if len(m.list.VisibleItems()) == 0 {
+		return fmt.Sprintf("no %ss", m.cfg.Noun)
+	}
+
+	return fmt.Sprintf("%s %d of %d", m.cfg.Noun, m.list.Index()+1, len(m.list.VisibleItems()))
+}
+
+// Style returns the style of the tab
+func (m Model) Style() tab.Style {
+	return tab.Style{
+		Color: m.cfg.Color(m.colors),
+		Icon:  m.cfg.Icon(),
+		Name:  m.cfg.Name,
+	}
+}
+
+// Resize returns the tab with the new dimensions, the tabs embedding it wrap it in SetSize
+func (m Model) Resize(width, height int) Model {
+	m.width = width
+	m.height = height
+	m.list.SetSize(width-2, height-1)
+	return m
+}
+
+// Fetch fetches the items of the list
+func (m Model) Fetch() tea.Cmd {
+	return m.reader(m.cfg.Title)
+}
+
+// Update handles the fetched items, the restyling and the keys of the list
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case backend.FetchSuccessMsg:
+		m.loaded = true
+		return m, m.list.SetItems(msg.Items)
+
+	case tab.RestyleMsg:
+		m.style = newStyle(m.colors, m.cfg.Color(m.colors))
+		delegate := list.NewDefaultDelegate()
+		delegate.Styles = m.style.listItems
+		m.list.SetDelegate(delegate)
+		m.list.Styles.Title = m.style.listTitle
+		m.list.Styles.TitleBar = m.style.listTitleBar
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.String() == "esc" {
+			return m, backend.StartQuitting()
+		}
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+// View returns the view of the tab
+func (m Model) View() string {
+	if !m.loaded {
+		return "Loading..."
+	}
+
+	if len(m.list.Items()) == 0 {
+		return m.style.list.Render(m.cfg.Empty)
+	}
+
+	return m.style.list.Render(m.list.View())
+}
+
+// ShortHelp returns the short help for this tab
+func (m Model) ShortHelp() []key.Binding {
+	return []key.Binding{m.list.KeyMap.CursorUp, m.list.KeyMap.CursorDown}
+}
+
+// FullHelp returns the full help for this tab
+func (m Model) FullHelp() [][]key.Binding {
+	return [][]key.Binding{m.ShortHelp()}
+}
@@ -0,0 +1,50 @@
+package listtab
+
+import (
+	"github.com/TypicalAM/goread/internal/theme"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// style is the style of a list tab.
+type style struct {
+	listItems    list.DefaultItemStyles
+	listTitle    lipgloss.Style
+	listTitleBar lipgloss.Style
+	list         lipgloss.Style
+}
+
+// newStyle creates a new style for a list tab in the color.
+func newStyle(colors *theme.Colors, color lipgloss.Color) style {
+	delegateStyles := list.NewDefaultItemStyles()
+	delegateStyles.SelectedTitle = theme.Emphasize(delegateStyles.SelectedTitle.Copy().
+		BorderForeground(color).
+		Foreground(color).
+		Italic(true))
+
+	delegateStyles.SelectedDesc = delegateStyles.SelectedDesc.Copy().
+		BorderForeground(color).
+		Foreground(colors.Color2).
+		Italic(true)
+
+	delegateStyles.NormalDesc = delegateStyles.NormalDesc.Copy().
+		Foreground(colors.TextDark)
+
+	listTitle := lipgloss.NewStyle().
+		Foreground(color).
+		Italic(true)
+
+	listTitleBar := lipgloss.NewStyle().
+		Padding(0, 0, 1, 2)
+
+	list := lipgloss.NewStyle().
+		MarginLeft(1).
+		MarginTop(1)
+
+	return style{
+		listItems:    delegateStyles,
+		listTitle:    listTitle,
+		listTitleBar: listTitleBar,
+		list:         list,
+	}
+}
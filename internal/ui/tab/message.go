@@ -12,8 +12,45 @@ func NewTab(sender Tab, title string) tea.Cmd {
 	}
 }
 
-// NewTabMsg is a tea.Msg that signals that a new tab should be created.
+// NewBackgroundTab returns a tea.Cmd which sends a message to the main model to create a new tab
+// without focusing it
+func NewBackgroundTab(sender Tab, title string) tea.Cmd {
+	return func() tea.Msg {
+		return NewTabMsg{
+			Sender:     sender,
+			Title:      title,
+			Background: true,
+		}
+	}
+}
+
+// NewTabMsg is a tea.Msg that signals that a new tab should be created. A tab created in the
+// background is added at the end of the tab bar and the focus stays where it was.
 type NewTabMsg struct {
-	Sender Tab
-	Title  string
+	Sender     Tab
+	Title      string
+	Background bool
 }
+
+// RefreshMsg is a tea.Msg that signals that the data was refreshed in the background. Tabs
+// which aren't active should remember that they are stale and reload once they become active.
+type RefreshMsg struct{ Active bool }
+
+// OpenItemMsg is a tea.Msg that signals that the item with the number should be opened, the
+// number is the one shown next to the item or the position of the article in the list
+type OpenItemMsg struct{ Number int }
+
+// FilterMsg is a tea.Msg that signals that the items should be filtered, "unread" shows only the
+// unread articles and "all" shows all of them
+type FilterMsg struct{ Filter string }
+
+// SortMsg is a tea.Msg that signals that the items should be shown in the sort order
+type SortMsg struct{ Order string }
+
+// ZenMsg is a tea.Msg that signals that the zen mode was toggled, the tab takes the whole screen
+// and the open article should be shown alone
+type ZenMsg struct{ Enabled bool }
+
+// RestyleMsg is a tea.Msg that signals that the colors changed, the tabs should rebuild their
+// styles from the colors
+type RestyleMsg struct{}
@@ -0,0 +1,83 @@
+package overview
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/TypicalAM/goread/internal/theme"
+	"github.com/TypicalAM/goread/internal/ui/popup"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ChosenAgeMsg is the message sent when the age of the articles which should be marked as read is chosen.
+type ChosenAgeMsg struct{ Days int }
+
+// AgePopup is the popup where a user can mark the old articles as read.
+type AgePopup struct {
+	daysInput textinput.Model
+	style     popupStyle
+	overlay   popup.Overlay
+	invalid   bool
+}
+
+// NewAgePopup creates a new popup window in which the user can enter the age of the articles in days.
+func NewAgePopup(colors *theme.Colors, bgRaw string, width, height int) AgePopup {
+	overlay := popup.NewOverlay(bgRaw, width, height)
+	style := newPopupStyle(colors, width, height)
+	daysInput := textinput.New()
+	daysInput.CharLimit = 5
+	daysInput.Width = width - 20
+	daysInput.Prompt = "Days: "
+	daysInput.Placeholder = "7"
+	daysInput.Focus()
+
+	return AgePopup{
+		overlay:   overlay,
+		style:     style,
+		daysInput: daysInput,
+	}
+}
+
+// Init the popup window.
+func (p AgePopup) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Update the popup window.
+func (p AgePopup) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok && msg.String() == "enter" {
+		days, err := strconv.Atoi(strings.TrimSpace(p.daysInput.Value()))
+		if err != nil || days < 0 {
+			p.invalid = true
+			return p, nil
+		}
+
+		return p, func() tea.Msg { return ChosenAgeMsg{days} }
+	}
+
+	var cmd tea.Cmd
+	p.invalid = false
+	p.daysInput, cmd = p.daysInput.Update(msg)
+	return p, cmd
+}
+
+// View renders the popup window.
+func (p AgePopup) View() string {
+	desc := "Articles of all the feeds published more than this many days ago"
+	if p.invalid {
+		desc = "Enter a whole number of days"
+	}
+
+	choice := p.style.selectedChoice.Render(lipgloss.JoinVertical(
+		lipgloss.Top,
+		p.style.selectedChoiceTitle.Render("Mark old articles as read"),
+		p.style.choiceDesc.Render(desc),
+		p.style.selectedChoiceDesc.Render(p.daysInput.View()),
+	))
+
+	toList := p.style.list.Render(choice)
+	popup := lipgloss.JoinVertical(lipgloss.Top, p.style.heading.Render("Mark as read"), toList)
+	return p.overlay.WrapView(p.style.general.Render(popup))
+}
@@ -7,6 +7,12 @@ type Keymap struct {
 	NewCategory    key.Binding
 	EditCategory   key.Binding
 	DeleteCategory key.Binding
+	ImportOPML     key.Binding
+	ExportOPML     key.Binding
+	Search         key.Binding
+	MarkOldAsRead  key.Binding
+	Expand         key.Binding
+	Collapse       key.Binding
 }
 
 // DefaultKeymap contains the default key bindings for this tab
@@ -23,6 +29,30 @@ var DefaultKeymap = Keymap{
 		key.WithKeys("d", "ctrl+d"),
 		key.WithHelp("d/ctrl+d", "Delete"),
 	),
+	ImportOPML: key.NewBinding(
+		key.WithKeys("i"),
+		key.WithHelp("i", "Import OPML"),
+	),
+	ExportOPML: key.NewBinding(
+		key.WithKeys("x"),
+		key.WithHelp("x", "Export OPML"),
+	),
+	Search: key.NewBinding(
+		key.WithKeys("/"),
+		key.WithHelp("/", "Search articles"),
+	),
+	MarkOldAsRead: key.NewBinding(
+		key.WithKeys("O"),
+		key.WithHelp("O", "Mark old as read"),
+	),
+	Expand: key.NewBinding(
+		key.WithKeys("right", "l"),
+		key.WithHelp("→/l", "Expand"),
+	),
+	Collapse: key.NewBinding(
+		key.WithKeys("left"),
+		key.WithHelp("←", "Collapse"),
+	),
 }
 
 // SetEnabled allows to disable/enable shortcuts
@@ -30,4 +60,10 @@ func (m *Keymap) SetEnabled(enabled bool) {
 	m.NewCategory.SetEnabled(enabled)
 	m.EditCategory.SetEnabled(enabled)
 	m.DeleteCategory.SetEnabled(enabled)
+	m.ImportOPML.SetEnabled(enabled)
+	m.ExportOPML.SetEnabled(enabled)
+	m.Search.SetEnabled(enabled)
+	m.MarkOldAsRead.SetEnabled(enabled)
+	m.Expand.SetEnabled(enabled)
+	m.Collapse.SetEnabled(enabled)
 }
@@ -0,0 +1,78 @@
+package overview
+
+import (
+	"github.com/TypicalAM/goread/internal/theme"
+	"github.com/TypicalAM/goread/internal/ui/popup"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ChosenOPMLMsg is the message sent when an OPML file path is chosen.
+type ChosenOPMLMsg struct {
+	Path   string
+	Export bool
+}
+
+// OPMLPopup is the popup where a user can choose which OPML file to import or export.
+type OPMLPopup struct {
+	pathInput textinput.Model
+	style     popupStyle
+	overlay   popup.Overlay
+	export    bool
+}
+
+// NewOPMLPopup creates a new popup window in which the user can choose an OPML file.
+func NewOPMLPopup(colors *theme.Colors, bgRaw string, width, height int, export bool) OPMLPopup {
+	overlay := popup.NewOverlay(bgRaw, width, height)
+	style := newPopupStyle(colors, width, height)
+	pathInput := textinput.New()
+	pathInput.CharLimit = 150
+	pathInput.Width = width - 20
+	pathInput.Prompt = "Path: "
+	pathInput.Placeholder = "feeds.opml"
+	pathInput.Focus()
+
+	return OPMLPopup{
+		overlay:   overlay,
+		style:     style,
+		pathInput: pathInput,
+		export:    export,
+	}
+}
+
+// Init the popup window.
+func (p OPMLPopup) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Update the popup window.
+func (p OPMLPopup) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok && msg.String() == "enter" {
+		path, export := p.pathInput.Value(), p.export
+		return p, func() tea.Msg { return ChosenOPMLMsg{path, export} }
+	}
+
+	var cmd tea.Cmd
+	p.pathInput, cmd = p.pathInput.Update(msg)
+	return p, cmd
+}
+
+// View renders the popup window.
+func (p OPMLPopup) View() string {
+	question, title, desc := "Import feeds from an OPML file", "Import", "Categories and feeds will be merged with yours"
+	if p.export {
+		question, title, desc = "Export feeds to an OPML file", "Export", "The file will be overwritten if it exists"
+	}
+
+	choice := p.style.selectedChoice.Render(lipgloss.JoinVertical(
+		lipgloss.Top,
+		p.style.selectedChoiceTitle.Render(title),
+		p.style.choiceDesc.Render(desc),
+		p.style.selectedChoiceDesc.Render(p.pathInput.View()),
+	))
+
+	toList := p.style.list.Render(choice)
+	popup := lipgloss.JoinVertical(lipgloss.Top, p.style.heading.Render(question), toList)
+	return p.overlay.WrapView(p.style.general.Render(popup))
+}
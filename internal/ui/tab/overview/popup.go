@@ -21,8 +21,7 @@ type ChosenCategoryMsg struct {
 type focusedField int
 
 const (
-	allField focusedField = iota
-	downloadedField
+	downloadedField focusedField = iota
 	nameField
 	descField
 )
@@ -49,7 +48,7 @@ func NewPopup(colors *theme.Colors, bgRaw string, width, height int, oldName, ol
 	descInput.CharLimit = 30
 	descInput.Width = width - 22
 	descInput.Prompt = "Description: "
-	focusedField := allField
+	focusedField := downloadedField
 
 	if oldName != "" || oldDesc != "" {
 		nameInput.SetValue(oldName)
@@ -81,8 +80,6 @@ func (p Popup) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		switch msg.String() {
 		case "down", "tab":
 			switch p.focused {
-			case allField:
-				p.focused = downloadedField
 			case downloadedField:
 				p.focused = nameField
 				cmds = append(cmds, p.nameInput.Focus())
@@ -91,17 +88,15 @@ func (p Popup) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				p.nameInput.Blur()
 				cmds = append(cmds, p.descInput.Focus())
 			case descField:
-				p.focused = allField
+				p.focused = downloadedField
 				p.descInput.Blur()
 			}
 
 		case "up":
 			switch p.focused {
-			case allField:
+			case downloadedField:
 				p.focused = descField
 				cmds = append(cmds, p.descInput.Focus())
-			case downloadedField:
-				p.focused = allField
 			case nameField:
 				p.focused = downloadedField
 				p.nameInput.Blur()
@@ -113,9 +108,6 @@ func (p Popup) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case "enter":
 			switch p.focused {
-			case allField:
-				return p, confirm(rss.AllFeedsName, "", "", false)
-
 			case downloadedField:
 				return p, confirm(rss.DownloadedFeedsName, "", "", false)
 
@@ -143,22 +135,20 @@ func (p Popup) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 // View renders the popup window.
 func (p Popup) View() string {
 	question := p.style.heading.Render("Choose a category")
-	renderedChoices := make([]string, 3)
+	renderedChoices := make([]string, 2)
 
-	titles := []string{rss.AllFeedsName, rss.DownloadedFeedsName, "New category"}
-	descs := []string{"All the feeds", "Saved Feeds", p.nameInput.View() + "\n" + p.descInput.View()}
+	titles := []string{rss.DownloadedFeedsName, "New category"}
+	descs := []string{"Saved Feeds", p.nameInput.View() + "\n" + p.descInput.View()}
 
 	var focused int
 	switch p.focused {
-	case allField:
-		focused = 0
 	case downloadedField:
-		focused = 1
+		focused = 0
 	case nameField, descField:
-		focused = 2
+		focused = 1
 	}
 
-	for i := 0; i < 3; i++ {
+	for i := range renderedChoices {
 		if i == focused {
 			renderedChoices[i] = p.style.selectedChoice.Render(lipgloss.JoinVertical(
 				lipgloss.Top,
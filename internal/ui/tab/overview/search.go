@@ -0,0 +1,68 @@
+package overview
+
+import (
+	"github.com/TypicalAM/goread/internal/theme"
+	"github.com/TypicalAM/goread/internal/ui/popup"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ChosenSearchMsg is the message sent when a search query is chosen.
+type ChosenSearchMsg struct{ Query string }
+
+// SearchPopup is the popup where a user can search through the cached articles.
+type SearchPopup struct {
+	queryInput textinput.Model
+	style      popupStyle
+	overlay    popup.Overlay
+}
+
+// NewSearchPopup creates a new popup window in which the user can enter a search query.
+func NewSearchPopup(colors *theme.Colors, bgRaw string, width, height int) SearchPopup {
+	overlay := popup.NewOverlay(bgRaw, width, height)
+	style := newPopupStyle(colors, width, height)
+	queryInput := textinput.New()
+	queryInput.CharLimit = 100
+	queryInput.Width = width - 20
+	queryInput.Prompt = "Query: "
+	queryInput.Placeholder = "linux kernel"
+	queryInput.Focus()
+
+	return SearchPopup{
+		overlay:    overlay,
+		style:      style,
+		queryInput: queryInput,
+	}
+}
+
+// Init the popup window.
+func (p SearchPopup) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Update the popup window.
+func (p SearchPopup) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok && msg.String() == "enter" {
+		query := p.queryInput.Value()
+		return p, func() tea.Msg { return ChosenSearchMsg{query} }
+	}
+
+	var cmd tea.Cmd
+	p.queryInput, cmd = p.queryInput.Update(msg)
+	return p, cmd
+}
+
+// View renders the popup window.
+func (p SearchPopup) View() string {
+	choice := p.style.selectedChoice.Render(lipgloss.JoinVertical(
+		lipgloss.Top,
+		p.style.selectedChoiceTitle.Render("Search"),
+		p.style.choiceDesc.Render("Titles and text of all the cached articles"),
+		p.style.selectedChoiceDesc.Render(p.queryInput.View()),
+	))
+
+	toList := p.style.list.Render(choice)
+	popup := lipgloss.JoinVertical(lipgloss.Top, p.style.heading.Render("Search the articles"), toList)
+	return p.overlay.WrapView(p.style.general.Render(popup))
+}
@@ -0,0 +1,149 @@
+package overview
+
+import (
+	"strings"
+
+	"github.com/TypicalAM/goread/internal/backend/rss"
+	"github.com/TypicalAM/goread/internal/theme"
+	"github.com/TypicalAM/goread/internal/ui/simplelist"
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// The categories without nested ones are aligned with the markers of the others, the markers are in
+// the icon set
+const (
+	leafMarker = "  "
+	indent     = "  "
+)
+
+// showItems shows the categories as a tree, the nested categories are shown only if the category
+// containing them is expanded. The selection stays on the same category or moves to the category
+// which hides it
+func (m *Model) showItems() {
+	var selected string
+	if !m.list.IsEmpty() {
+		selected = m.list.SelectedItem().FilterValue()
+	}
+
+	present := make(map[string]bool, len(m.categories))
+	for _, item := range m.categories {
+		present[item.FilterValue()] = true
+	}
+
+	// The categories whose parent is missing are shown at the top with their whole name
+	parentOf := func(name string) string {
+		if parent := rss.ParentName(name); present[parent] {
+			return parent
+		}
+
+		return ""
+	}
+
+	hasNested := make(map[string]bool)
+	for _, item := range m.categories {
+		hasNested[parentOf(item.FilterValue())] = true
+	}
+
+	var visible []list.Item
+	var walk func(parent string, depth int)
+	walk = func(parent string, depth int) {
+		for _, item := range m.categories {
+			name := item.FilterValue()
+			if parentOf(name) != parent {
+				continue
+			}
+
+			label := name
+			if parent != "" {
+				label = rss.BaseName(name)
+			}
+
+			switch {
+			case hasNested[name] && m.expanded[name]:
+				label = theme.Icons.Expanded + label
+			case hasNested[name]:
+				label = theme.Icons.Collapsed + label
+			case len(hasNested) > 1:
+				label = leafMarker + label
+			}
+
+			if item, ok := item.(simplelist.Item); ok {
+				visible = append(visible, item.SetLabel(strings.Repeat(indent, depth)+label))
+			} else {
+				visible = append(visible, item)
+			}
+
+			if m.expanded[name] {
+				walk(name, depth+1)
+			}
+		}
+	}
+
+	walk("", 0)
+	m.list.SetItems(visible)
+
+	for name := selected; name != ""; name = parentOf(name) {
+		if index := indexOf(visible, name); index >= 0 {
+			m.list.SetIndex(index)
+			return
+		}
+	}
+
+	if m.list.Index() >= len(visible) {
+		m.list.SetIndex(0)
+	}
+}
+
+// toggleExpanded expands or collapses the selected category, collapsing a category without
+// nested ones collapses the category containing it
+func (m *Model) toggleExpanded(expand bool) {
+	if m.list.IsEmpty() {
+		return
+	}
+
+	name := m.list.SelectedItem().FilterValue()
+	if !expand && !m.expanded[name] {
+		name = rss.ParentName(name)
+	}
+
+	if name == "" || (expand && !m.hasNested(name)) {
+		return
+	}
+
+	m.expanded[name] = expand
+	m.showItems()
+}
+
+// hasNested reports if there are categories nested in the category
+func (m Model) hasNested(name string) bool {
+	for _, item := range m.categories {
+		if rss.IsNestedIn(item.FilterValue(), name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// nestedCount returns the number of the categories nested in the category, at any depth
+func (m Model) nestedCount(name string) int {
+	var count int
+	for _, item := range m.categories {
+		if rss.IsNestedIn(item.FilterValue(), name) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// indexOf returns the index of the item with the name, it's -1 if the item isn't there
+func indexOf(items []list.Item, name string) int {
+	for i, item := range items {
+		if item.FilterValue() == name {
+			return i
+		}
+	}
+
+	return -1
+}
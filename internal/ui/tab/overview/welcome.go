@@ -1,27 +1,34 @@
 package overview
 
 import (
+	"fmt"
 	"log"
+	"strconv"
 
 	"github.com/TypicalAM/goread/internal/backend"
+	"github.com/TypicalAM/goread/internal/backend/rss"
 	"github.com/TypicalAM/goread/internal/theme"
 	"github.com/TypicalAM/goread/internal/ui/popup"
 	"github.com/TypicalAM/goread/internal/ui/simplelist"
 	"github.com/TypicalAM/goread/internal/ui/tab"
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 )
 
 // Model contains the state of this tab
 type Model struct {
-	colors  *theme.Colors
-	fetcher backend.Fetcher
-	title   string
-	keymap  Keymap
-	list    simplelist.Model
-	width   int
-	height  int
-	loaded  bool
+	colors     *theme.Colors
+	fetcher    backend.Fetcher
+	title      string
+	keymap     Keymap
+	list       simplelist.Model
+	categories []list.Item
+	expanded   map[string]bool
+	width      int
+	height     int
+	loaded     bool
 }
 
 // New creates a new welcome tab with sensible defaults
@@ -29,12 +36,13 @@ func New(colors *theme.Colors, width, height int, title string, fetcher backend.
 	log.Println("Creating new welcome tab with title", title)
 
 	return Model{
-		colors:  colors,
-		width:   width,
-		height:  height,
-		title:   title,
-		fetcher: fetcher,
-		keymap:  DefaultKeymap,
+		colors:   colors,
+		width:    width,
+		height:   height,
+		title:    title,
+		fetcher:  fetcher,
+		keymap:   DefaultKeymap,
+		expanded: make(map[string]bool),
 	}
 }
 
@@ -43,11 +51,16 @@ func (m Model) Title() string {
 	return m.title
 }
 
+// Position describes the selected item for the screen readers
+func (m Model) Position() string {
+	return m.list.Position()
+}
+
 // Style returns the style of the tab
 func (m Model) Style() tab.Style {
 	return tab.Style{
 		Color: m.colors.Color4,
-		Icon:  "﫢",
+		Icon:  theme.Icons.Welcome,
 		Name:  "WELCOME",
 	}
 }
@@ -78,22 +91,67 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		m.list = simplelist.New(m.colors, "Categories", m.height, true)
+		m.list.SetIcons(func(name string) string { return theme.CategoryDecorations[name].ListIcon(theme.Icons.CategoryItem) })
+		m.list.SetColors(func(name string) lipgloss.Color { return theme.CategoryDecorations[name].Color })
 		m.loaded = true
 	}
 
 	switch msg := msg.(type) {
 	case backend.FetchSuccessMsg:
-		m.list.SetItems(msg.Items)
+		m.categories = msg.Items
+		m.showItems()
+
+	case backend.UnreadCountMsg:
+		categories := make([]list.Item, len(m.categories))
+		for i, item := range m.categories {
+			categories[i] = item
+			unread := msg.Categories[item.FilterValue()]
+			if item.FilterValue() == rss.AllFeedsName {
+				unread = msg.Total
+			}
+
+			if item, ok := item.(simplelist.Item); ok {
+				categories[i] = item.SetBadge(backend.UnreadBadge(unread))
+			}
+		}
+
+		m.categories = categories
+		m.showItems()
+		return m, nil
 
 	case backend.SetEnableKeybindMsg:
 		m.keymap.SetEnabled(bool(msg))
 		return m, nil
 
+	case tab.RestyleMsg:
+		m.list.Restyle()
+		return m, nil
+
+	case tab.OpenItemMsg:
+		item, ok := m.list.GetItem(strconv.Itoa(msg.Number))
+		if !m.loaded || !ok {
+			return m, nil
+		}
+
+		m.list.SetIndex(msg.Number)
+		return m, tab.NewTab(m, item.FilterValue())
+
 	case popup.ChoiceResultMsg:
 		if !msg.Result {
 			return m, nil
 		}
 
+		if marked := m.markedNames(); len(marked) > 0 {
+			m.list.ClearMarks()
+			m.list.SetIndex(0)
+			cmds := make([]tea.Cmd, len(marked))
+			for i, name := range marked {
+				cmds[i] = backend.DeleteItem(m, name)
+			}
+
+			return m, tea.Sequence(cmds...)
+		}
+
 		delItemName := m.list.SelectedItem().FilterValue()
 		itemCount := len(m.list.Items())
 
@@ -122,21 +180,49 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 			return m, nil
 
+		case key.Matches(msg, m.keymap.Expand):
+			m.toggleExpanded(true)
+			return m, nil
+
+		case key.Matches(msg, m.keymap.Collapse):
+			m.toggleExpanded(false)
+			return m, nil
+
 		case key.Matches(msg, m.keymap.NewCategory):
 			return m, backend.NewItem(m)
 
 		case key.Matches(msg, m.keymap.EditCategory):
-			if !m.list.IsEmpty() {
+			if !m.list.IsEmpty() && !m.builtinSelected() {
 				item := m.list.SelectedItem().(simplelist.Item)
 				fields := []string{item.Title(), item.Description()}
 				return m, backend.EditItem(m, fields)
 			}
 
 		case key.Matches(msg, m.keymap.DeleteCategory):
-			if !m.list.IsEmpty() {
+			if marked := m.markedNames(); len(marked) > 0 {
+				return m, backend.MakeChoice(fmt.Sprintf("Delete %d categories?", len(marked)), true)
+			}
+
+			if !m.list.IsEmpty() && !m.builtinSelected() {
+				if nested := m.nestedCount(m.list.SelectedItem().FilterValue()); nested > 0 {
+					return m, backend.MakeChoice(fmt.Sprintf("Delete category and %d nested?", nested), true)
+				}
+
 				return m, backend.MakeChoice("Delete category?", true)
 			}
 
+		case key.Matches(msg, m.keymap.ImportOPML):
+			return m, backend.ManageOPML(false)
+
+		case key.Matches(msg, m.keymap.ExportOPML):
+			return m, backend.ManageOPML(true)
+
+		case key.Matches(msg, m.keymap.Search):
+			return m, backend.Search()
+
+		case key.Matches(msg, m.keymap.MarkOldAsRead):
+			return m, backend.MarkOldAsRead()
+
 		default:
 			// Check if we need to open a new category
 			if item, ok := m.list.GetItem(msg.String()); ok {
@@ -159,6 +245,40 @@ func (m Model) View() string {
 	return m.list.View()
 }
 
+// builtinSelected reports if the selected item is one of the built-in views, which can't be changed
+func (m Model) builtinSelected() bool {
+	return isBuiltin(m.list.SelectedItem().FilterValue())
+}
+
+// isBuiltin reports if the name is the name of the aggregated feed, the digest, the archive or the trash
+func isBuiltin(name string) bool {
+	return name == rss.AllFeedsName || name == rss.DigestName || name == rss.ArchiveName || name == rss.TrashName
+}
+
+// markedNames returns the names of the marked categories, the built-in ones can't be deleted so they are skipped.
+// The categories nested in the marked ones are deleted along with them so they are skipped too
+func (m Model) markedNames() []string {
+	marked := m.list.Marked()
+	var names []string
+	for _, item := range marked {
+		name := item.FilterValue()
+		if isBuiltin(name) {
+			continue
+		}
+
+		ancestorMarked := false
+		for parent := rss.ParentName(name); parent != ""; parent = rss.ParentName(parent) {
+			ancestorMarked = ancestorMarked || indexOf(marked, parent) >= 0
+		}
+
+		if !ancestorMarked {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
 // ShortHelp returns the short help for this tab
 func (m Model) ShortHelp() []key.Binding {
 	return []key.Binding{m.keymap.NewCategory, m.keymap.EditCategory, m.keymap.DeleteCategory}
@@ -166,5 +286,5 @@ func (m Model) ShortHelp() []key.Binding {
 
 // FullHelp returns the full help for this tab
 func (m Model) FullHelp() [][]key.Binding {
-	return [][]key.Binding{m.ShortHelp(), m.list.ShortHelp()}
+	return [][]key.Binding{m.ShortHelp(), {m.keymap.Expand, m.keymap.Collapse, m.keymap.ImportOPML, m.keymap.ExportOPML, m.keymap.Search, m.keymap.MarkOldAsRead}, m.list.ShortHelp(), m.list.MarkHelp()}
 }
@@ -1,6 +1,7 @@
 package tab
 
 import (
+	"github.com/TypicalAM/goread/internal/theme"
 	"github.com/charmbracelet/bubbles/help"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -13,6 +14,19 @@ type Style struct {
 	Name  string
 }
 
+// Decorate returns a copy of the style with the color and the icon of the decoration, if they're set
+func (s Style) Decorate(decoration theme.Decoration) Style {
+	if decoration.Color != "" {
+		s.Color = decoration.Color
+	}
+
+	if decoration.Icon != "" {
+		s.Icon = decoration.Icon
+	}
+
+	return s
+}
+
 // Tab is an interface outlining the methods that a tab should implement including bubbletea's model methods
 type Tab interface {
 	tea.Model
@@ -22,3 +36,41 @@ type Tab interface {
 	Style() Style
 	SetSize(width, height int) Tab
 }
+
+// Filterer is implemented by the tabs which can filter their items, the status bar shows the filter
+type Filterer interface {
+	// Filter describes the active filter, it's empty if nothing is filtered
+	Filter() string
+}
+
+// Progresser is implemented by the tabs which show a document, the status bar shows how much of
+// it was read
+type Progresser interface {
+	// Progress describes the position in the document, it's empty if no document is shown
+	Progress() string
+}
+
+// Positioner is implemented by the tabs which can tell where the cursor is, the status bar shows
+// the position in the screen reader mode
+type Positioner interface {
+	// Position describes the focused pane and the selected item, like "item 3 of 20"
+	Position() string
+}
+
+// Identifier is implemented by the tabs whose title doesn't tell them apart, like the article tabs
+// whose titles can repeat
+type Identifier interface {
+	// Identity is what the tab shows, like the link of the article
+	Identity() string
+}
+
+// Key returns the key which tells the tabs apart, two tabs with the same key show the same thing.
+// It's the type of the tab and its identity, the title is used for the tabs without one
+func Key(t Tab) string {
+	identity := t.Title()
+	if identifier, ok := t.(Identifier); ok {
+		identity = identifier.Identity()
+	}
+
+	return t.Style().Name + "\x00" + identity
+}
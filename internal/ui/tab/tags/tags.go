@@ -0,0 +1,177 @@
+package tags
+
+import (
+	"log"
+	"strconv"
+
+	"github.com/TypicalAM/goread/internal/backend"
+	"github.com/TypicalAM/goread/internal/theme"
+	"github.com/TypicalAM/goread/internal/ui/simplelist"
+	"github.com/TypicalAM/goread/internal/ui/tab"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Title is the title of the tags tab
+const Title = "Tags"
+
+// Model contains the state of this tab
+type Model struct {
+	colors *theme.Colors
+	reader backend.Fetcher
+	list   simplelist.Model
+	width  int
+	height int
+	loaded bool
+	stale  bool
+}
+
+// New creates a new tags tab, the fetcher returns the tags of the feeds
+func New(colors *theme.Colors, width, height int, fetcher backend.Fetcher) Model {
+	log.Println("Creating new tags tab")
+
+	return Model{
+		colors: colors,
+		width:  width,
+		height: height,
+		reader: fetcher,
+	}
+}
+
+// Title returns the title of the tab
+func (m Model) Title() string {
+	return Title
+}
+
+// Position describes the selected item for the screen readers
+func (m Model) Position() string {
+	return m.list.Position()
+}
+
+// Style returns the style of the tab
+func (m Model) Style() tab.Style {
+	return tab.Style{
+		Color: m.colors.Color2,
+		Icon:  theme.Icons.Tags,
+		Name:  "TAGS",
+	}
+}
+
+// SetSize sets the dimensions of the tab
+func (m Model) SetSize(width, height int) tab.Tab {
+	if !m.loaded {
+		return m
+	}
+
+	m.width = width
+	m.height = height
+	m.list.SetHeight(m.height)
+	return m
+}
+
+// Init initializes the tab
+func (m Model) Init() tea.Cmd {
+	return m.reader(Title)
+}
+
+// Update updates the variables of the tab
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case backend.FetchSuccessMsg:
+		if !m.loaded {
+			m.list = simplelist.New(m.colors, Title, m.height, true)
+			m.loaded = true
+		}
+
+		m.list.SetItems(msg.Items)
+		return m, nil
+
+	case backend.UnreadCountMsg:
+		if m.loaded {
+			for _, item := range m.list.Items() {
+				m.list.SetBadge(item.FilterValue(), backend.UnreadBadge(msg.Tags[item.FilterValue()]))
+			}
+		}
+
+		return m, nil
+
+	case tab.RestyleMsg:
+		if m.loaded {
+			m.list.Restyle()
+		}
+
+		return m, nil
+
+	case tab.RefreshMsg:
+		// The feeds might have been tagged since the tab was opened
+		if !msg.Active {
+			m.stale = true
+			return m, nil
+		}
+
+		if !m.stale {
+			return m, nil
+		}
+
+		m.stale = false
+		return m, m.reader(Title)
+
+	case tab.OpenItemMsg:
+		item, ok := m.list.GetItem(strconv.Itoa(msg.Number))
+		if !m.loaded || !ok {
+			return m, nil
+		}
+
+		m.list.SetIndex(msg.Number)
+		return m, tab.NewTab(m, item.FilterValue())
+
+	case tea.KeyMsg:
+		if !m.loaded {
+			return m, nil
+		}
+
+		switch {
+		case msg.String() == "esc":
+			return m, backend.StartQuitting()
+
+		case key.Matches(msg, m.list.Keymap.Open):
+			if !m.list.IsEmpty() {
+				return m, tab.NewTab(m, m.list.SelectedItem().FilterValue())
+			}
+
+			return m, nil
+
+		default:
+			if item, ok := m.list.GetItem(msg.String()); ok {
+				return m, tab.NewTab(m, item.FilterValue())
+			}
+		}
+	}
+
+	if !m.loaded {
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+// View returns the view for the tab
+func (m Model) View() string {
+	if !m.loaded {
+		return "Loading..."
+	}
+
+	return m.list.View()
+}
+
+// ShortHelp returns the short help for this tab
+func (m Model) ShortHelp() []key.Binding {
+	return m.list.ShortHelp()
+}
+
+// FullHelp returns the full help for this tab
+func (m Model) FullHelp() [][]key.Binding {
+	return [][]key.Binding{m.ShortHelp()}
+}